@@ -29,3 +29,10 @@ func (this *View) Index2(ctx *gin.Context) {
         "msg": "测试数据222",
     })
 }
+
+// 表单提交
+func (this *View) Submit(ctx *gin.Context) {
+    this.SuccessWithData(ctx, "提交成功", gin.H{
+        "msg": ctx.PostForm("msg"),
+    })
+}
@@ -7,6 +7,7 @@ import (
     "github.com/deatil/lakego-filesystem/filesystem"
 
     "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/facade"
@@ -125,9 +126,9 @@ func (this *ExampleServiceProvider) loadRoute() {
         example_route.AdminRoute(engine)
     })
 
-    // 常规 gin 路由，除 gin 自带外没有任何中间件
+    // 常规 gin 路由，使用 cookie 的路由需要 csrf 验证
     this.AddRoute(func(engine *gin.Engine) {
-        example_route.GinRoute(engine)
+        example_route.GinRoute(router.Groups(engine, "/", "lakego.csrf"))
     })
 }
 
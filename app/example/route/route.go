@@ -29,6 +29,9 @@ func GinRoute(engine gin.IRouter) {
     engine.GET("/example/view/index", viewController.Index)
     engine.GET("/example/view/index2", viewController.Index2)
 
+    // 表单提交，需要带上 csrf 令牌
+    engine.POST("/example/view/submit", viewController.Submit)
+
 }
 
 /**
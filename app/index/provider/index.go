@@ -3,6 +3,7 @@ package provider
 import (
     "github.com/gin-gonic/gin"
 
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
 
     // 路由
@@ -29,9 +30,9 @@ func (this *Index) Boot() {
  * 导入路由
  */
 func (this *Index) loadRoute() {
-    // 常规 gin 路由，除 gin 自带外没有任何中间件
+    // 常规 gin 路由，使用 cookie 的路由需要 csrf 验证
     this.AddRoute(func(engine *gin.Engine) {
-        index_route.Route(engine)
+        index_route.Route(router.Groups(engine, "/", "lakego.csrf"))
     })
 }
 
@@ -16,6 +16,9 @@ import (
     // 系统监控
     monitor "github.com/deatil/lakego-doak-monitor/monitor/bootstrap"
 
+    // 日志查看
+    log_viewer "github.com/deatil/lakego-doak-log-viewer/log-viewer/bootstrap"
+
     // 开发工具
     devtool "github.com/deatil/lakego-doak-devtool/devtool/bootstrap"
 
@@ -47,6 +50,7 @@ func init() {
     action_log.Boot()
     database.Boot()
     monitor.Boot()
+    log_viewer.Boot()
     devtool.Boot()
 
     // 其他模块
@@ -3,6 +3,8 @@ package provider
 import (
     "fmt"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/provider"
 
@@ -62,18 +64,48 @@ func (this *Demo) loadExtInfo() {
         },
         Version: "1.0.1",
         Adaptation: ">= 1.2.1",
-        Install: func() error {
+        Settings: []extension.Setting{
+            {
+                Name: "title",
+                Title: "标题",
+                Type: extension.SettingString,
+                Default: "扩展示例",
+                Rule: "required,max=50",
+                Messages: map[string]string{
+                    "required": "标题不能为空",
+                    "max": "标题字数超过了限制",
+                },
+            },
+            {
+                Name: "page_size",
+                Title: "每页数量",
+                Type: extension.SettingInt,
+                Default: 20,
+                Rule: "min=1,max=100",
+                Messages: map[string]string{
+                    "min": "每页数量最小为 1",
+                    "max": "每页数量最大为 100",
+                },
+            },
+            {
+                Name: "open",
+                Title: "开启",
+                Type: extension.SettingBool,
+                Default: true,
+            },
+        },
+        InstallTx: func(tx *gorm.DB) error {
             facade.Logger.Error("demo Install")
 
             rules := getRules(slug)
-            extension.NewRule().Create(rules, "0")
+            extension.NewRule().WithDB(tx).Create(rules, "0")
 
             return nil
         },
-        Uninstall: func() error {
+        UninstallTx: func(tx *gorm.DB) error {
             facade.Logger.Error("demo Uninstall")
 
-            extension.NewRule().Delete(slug)
+            extension.NewRule().WithDB(tx).Delete(slug)
 
             return nil
         },
@@ -82,22 +114,22 @@ func (this *Demo) loadExtInfo() {
 
             return nil
         },
-        Enable: func() error {
+        EnableTx: func(tx *gorm.DB) error {
             facade.Logger.Error("demo Enable")
 
-            extension.NewRule().Enable(slug)
+            extension.NewRule().WithDB(tx).Enable(slug)
 
             return nil
         },
-        Disable: func() error {
+        DisableTx: func(tx *gorm.DB) error {
             facade.Logger.Error("demo Disable")
 
-            extension.NewRule().Disable(slug)
+            extension.NewRule().WithDB(tx).Disable(slug)
 
             return nil
         },
         Start: func(i iapp.App) error {
-            fmt.Println("demo starting")
+            fmt.Println("demo starting", extension.Settings("lakego.demo").GetString("title"))
 
             facade.Logger.Error("demo starting")
 
@@ -1,6 +1,6 @@
 module github.com/deatil/lakego-admin
 
-go 1.20
+go 1.21
 
 replace (
 	app => ./app
@@ -32,6 +32,7 @@ replace (
 	github.com/deatil/lakego-doak-extension => ./pkg/lakego-app/doak-extension
 	github.com/deatil/lakego-doak-database => ./pkg/lakego-app/doak-database
 	github.com/deatil/lakego-doak-devtool => ./pkg/lakego-app/doak-devtool
+	github.com/deatil/lakego-doak-log-viewer => ./pkg/lakego-app/doak-log-viewer
 	github.com/deatil/lakego-doak-monitor => ./pkg/lakego-app/doak-monitor
 	github.com/deatil/lakego-doak-statics => ./pkg/lakego-app/doak-statics
 	github.com/deatil/lakego-doak-swagger => ./pkg/lakego-app/doak-swagger
@@ -46,6 +47,7 @@ require (
 	github.com/deatil/lakego-doak-action-log v0.0.3
 	github.com/deatil/lakego-doak-database v0.0.3
 	github.com/deatil/lakego-doak-devtool v0.0.3
+	github.com/deatil/lakego-doak-log-viewer v0.0.3
 	github.com/deatil/lakego-doak-monitor v0.0.3
 	github.com/deatil/lakego-doak-statics v0.0.3
 	github.com/deatil/lakego-doak-swagger v0.0.3
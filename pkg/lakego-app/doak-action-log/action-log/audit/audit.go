@@ -0,0 +1,124 @@
+package audit
+
+import (
+    "sort"
+    "sync"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/facade"
+)
+
+var registry = &Registry{
+    models: make(map[reflect.Type][]string),
+}
+
+// 注册审计模型，hidden 为不记录原始值的字段
+// audit.Register(&model.Admin{}, "password", "password_salt")
+func Register(model any, hidden ...string) {
+    registry.Register(model, hidden...)
+}
+
+// 移除审计模型
+func Remove(model any) {
+    registry.Remove(model)
+}
+
+// 注册 gorm 回调
+func Boot() error {
+    return RegisterCallbacks(facade.DB)
+}
+
+/**
+ * 审计模型
+ *
+ * @create 2024-5-8
+ * @author deatil
+ */
+type Registry struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 模型及隐藏字段
+    models map[reflect.Type][]string
+}
+
+// 注册
+func (this *Registry) Register(model any, hidden ...string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.models[modelType(model)] = hidden
+}
+
+// 移除
+func (this *Registry) Remove(model any) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.models, modelType(model))
+}
+
+// 获取
+func (this *Registry) Get(typ reflect.Type) ([]string, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    hidden, ok := this.models[typ]
+
+    return hidden, ok
+}
+
+// 模型类型
+func modelType(model any) reflect.Type {
+    typ := reflect.TypeOf(model)
+    for typ.Kind() == reflect.Ptr {
+        typ = typ.Elem()
+    }
+
+    return typ
+}
+
+// ===========
+
+// 字段变动
+type Change struct {
+    Field string `json:"field"`
+    Old   any    `json:"old"`
+    New   any    `json:"new"`
+}
+
+// 对比数据字段
+func Diff(old map[string]any, new map[string]any) []Change {
+    fields := make(map[string]bool)
+    for k := range old {
+        fields[k] = true
+    }
+    for k := range new {
+        fields[k] = true
+    }
+
+    keys := make([]string, 0, len(fields))
+    for k := range fields {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    changes := make([]Change, 0)
+    for _, k := range keys {
+        oldValue, oldOk := old[k]
+        newValue, newOk := new[k]
+
+        if oldOk && newOk && formatValue(oldValue) == formatValue(newValue) {
+            continue
+        }
+
+        changes = append(changes, Change{
+            Field: k,
+            Old:   oldValue,
+            New:   newValue,
+        })
+    }
+
+    return changes
+}
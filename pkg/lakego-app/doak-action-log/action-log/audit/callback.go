@@ -0,0 +1,386 @@
+package audit
+
+import (
+    "fmt"
+    "sync"
+    "context"
+    "reflect"
+    "encoding/json"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "gorm.io/gorm/schema"
+
+    "github.com/deatil/go-datebin/datebin"
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-action-log/action-log/model"
+)
+
+const (
+    // 操作类型
+    ActionCreate = "create"
+    ActionUpdate = "update"
+    ActionDelete = "delete"
+
+    // 修改前数据
+    instanceOldKey = "lakego-action-log:audit_old_values"
+
+    // 隐藏字段显示值
+    hiddenValue = "[hidden]"
+    hiddenChangedValue = "[hidden:changed]"
+)
+
+var registerOnce sync.Once
+
+// 注册 gorm 回调
+func RegisterCallbacks(db *gorm.DB) (err error) {
+    registerOnce.Do(func() {
+        cb := db.Callback()
+
+        if err = cb.Create().After("gorm:create").
+            Register("lakego:audit_after_create", afterCreate); err != nil {
+            return
+        }
+
+        if err = cb.Update().Before("gorm:update").
+            Register("lakego:audit_before_update", beforeChange); err != nil {
+            return
+        }
+        if err = cb.Update().After("gorm:update").
+            Register("lakego:audit_after_update", afterUpdate); err != nil {
+            return
+        }
+
+        if err = cb.Delete().Before("gorm:delete").
+            Register("lakego:audit_before_delete", beforeChange); err != nil {
+            return
+        }
+        err = cb.Delete().After("gorm:delete").
+            Register("lakego:audit_after_delete", afterDelete)
+    })
+
+    return
+}
+
+// 添加后
+func afterCreate(db *gorm.DB) {
+    hidden, ok := auditable(db)
+    if !ok || db.Error != nil {
+        return
+    }
+
+    stmt := db.Statement
+
+    rows := make([]map[string]any, 0)
+    eachValue(stmt.ReflectValue, func(rv reflect.Value) {
+        rows = append(rows, structToMap(stmt.Context, stmt.Schema, rv))
+    })
+
+    for _, row := range rows {
+        _, news := hideFields(nil, row, hidden)
+
+        writeLog(db, ActionCreate, primaryValue(stmt.Schema, row), nil, news)
+    }
+}
+
+// 更新及删除前记录原始数据
+func beforeChange(db *gorm.DB) {
+    if _, ok := auditable(db); !ok || db.Error != nil {
+        return
+    }
+
+    olds := queryRows(db)
+    if len(olds) > 0 {
+        db.InstanceSet(instanceOldKey, olds)
+    }
+}
+
+// 更新后
+func afterUpdate(db *gorm.DB) {
+    hidden, ok := auditable(db)
+    if !ok || db.Error != nil {
+        return
+    }
+
+    olds := instanceOlds(db)
+    if len(olds) == 0 {
+        return
+    }
+
+    stmt := db.Statement
+    pk := stmt.Schema.PrioritizedPrimaryField
+
+    ids := make([]any, 0, len(olds))
+    for _, old := range olds {
+        ids = append(ids, old[pk.DBName])
+    }
+
+    news := make([]map[string]any, 0)
+    newSession(db).
+        Where(clause.IN{
+            Column: clause.Column{Name: pk.DBName},
+            Values: ids,
+        }).
+        Find(&news)
+
+    newValues := make(map[string]map[string]any)
+    for _, row := range news {
+        newValues[formatValue(row[pk.DBName])] = row
+    }
+
+    for _, old := range olds {
+        id := formatValue(old[pk.DBName])
+
+        oldValues, changedValues := hideFields(old, newValues[id], hidden)
+        if len(Diff(oldValues, changedValues)) == 0 {
+            continue
+        }
+
+        writeLog(db, ActionUpdate, id, oldValues, changedValues)
+    }
+}
+
+// 删除后
+func afterDelete(db *gorm.DB) {
+    hidden, ok := auditable(db)
+    if !ok || db.Error != nil {
+        return
+    }
+
+    pk := db.Statement.Schema.PrioritizedPrimaryField
+
+    for _, old := range instanceOlds(db) {
+        oldValues, _ := hideFields(old, nil, hidden)
+
+        writeLog(db, ActionDelete, formatValue(old[pk.DBName]), oldValues, nil)
+    }
+}
+
+// 是否需要审计
+func auditable(db *gorm.DB) ([]string, bool) {
+    stmt := db.Statement
+    if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+        return nil, false
+    }
+
+    return registry.Get(stmt.Schema.ModelType)
+}
+
+// 新会话
+func newSession(db *gorm.DB) *gorm.DB {
+    return db.Session(&gorm.Session{
+        NewDB: true,
+        SkipHooks: true,
+    }).Table(db.Statement.Table)
+}
+
+// 根据当前条件查询数据
+func queryRows(db *gorm.DB) []map[string]any {
+    stmt := db.Statement
+    tx := newSession(db)
+
+    hasCondition := false
+    if c, ok := stmt.Clauses["WHERE"]; ok {
+        if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+            tx = tx.Clauses(where)
+            hasCondition = true
+        }
+    }
+
+    // 主键条件
+    pk := stmt.Schema.PrioritizedPrimaryField
+
+    ids := make([]any, 0)
+    eachValue(stmt.ReflectValue, func(rv reflect.Value) {
+        if value, zero := pk.ValueOf(stmt.Context, rv); !zero {
+            ids = append(ids, value)
+        }
+    })
+
+    if len(ids) > 0 {
+        tx = tx.Where(clause.IN{
+            Column: clause.Column{Name: pk.DBName},
+            Values: ids,
+        })
+        hasCondition = true
+    }
+
+    // 没有条件时不记录，防止全表查询
+    if !hasCondition {
+        return nil
+    }
+
+    rows := make([]map[string]any, 0)
+    tx.Find(&rows)
+
+    return rows
+}
+
+// 原始数据
+func instanceOlds(db *gorm.DB) []map[string]any {
+    if olds, ok := db.InstanceGet(instanceOldKey); ok {
+        if rows, ok := olds.([]map[string]any); ok {
+            return rows
+        }
+    }
+
+    return nil
+}
+
+// 遍历结构体数据
+func eachValue(rv reflect.Value, f func(reflect.Value)) {
+    rv = reflect.Indirect(rv)
+
+    switch rv.Kind() {
+        case reflect.Struct:
+            f(rv)
+        case reflect.Slice, reflect.Array:
+            for i := 0; i < rv.Len(); i++ {
+                if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+                    f(elem)
+                }
+            }
+    }
+}
+
+// 结构体转为 map
+func structToMap(ctx context.Context, s *schema.Schema, rv reflect.Value) map[string]any {
+    data := make(map[string]any)
+    for _, field := range s.Fields {
+        if field.DBName == "" {
+            continue
+        }
+
+        value, _ := field.ValueOf(ctx, rv)
+        data[field.DBName] = value
+    }
+
+    return data
+}
+
+// 主键值
+func primaryValue(s *schema.Schema, row map[string]any) string {
+    return formatValue(row[s.PrioritizedPrimaryField.DBName])
+}
+
+// 隐藏字段
+func hideFields(old map[string]any, new map[string]any, hidden []string) (map[string]any, map[string]any) {
+    old = copyMap(old)
+    new = copyMap(new)
+
+    for _, field := range hidden {
+        oldValue, oldOk := old[field]
+        newValue, newOk := new[field]
+
+        if oldOk {
+            old[field] = hiddenValue
+        }
+
+        if newOk {
+            if oldOk && formatValue(oldValue) != formatValue(newValue) {
+                new[field] = hiddenChangedValue
+            } else {
+                new[field] = hiddenValue
+            }
+        }
+    }
+
+    return old, new
+}
+
+func copyMap(data map[string]any) map[string]any {
+    if data == nil {
+        return nil
+    }
+
+    newData := make(map[string]any, len(data))
+    for k, v := range data {
+        newData[k] = v
+    }
+
+    return newData
+}
+
+// 格式化值用于比较
+func formatValue(value any) string {
+    switch v := value.(type) {
+        case nil:
+            return ""
+        case string:
+            return v
+        case []byte:
+            return string(v)
+    }
+
+    return fmt.Sprintf("%v", value)
+}
+
+// 操作者信息
+func actorFromContext(ctx context.Context) (adminId string, requestId string, ip string) {
+    if ctx == nil {
+        return
+    }
+
+    if c, ok := ctx.(*router.Context); ok {
+        if id, exists := c.Get("admin_id"); exists {
+            adminId = formatValue(id)
+        }
+
+        requestId = c.GetString("request_id")
+        if requestId == "" {
+            requestId = c.GetHeader("X-Request-Id")
+        }
+
+        if c.Request != nil {
+            ip = router.GetRequestIp(c)
+        }
+
+        return
+    }
+
+    if id := ctx.Value("admin_id"); id != nil {
+        adminId = formatValue(id)
+    }
+
+    if id := ctx.Value("request_id"); id != nil {
+        requestId = formatValue(id)
+    }
+
+    if value := ctx.Value("ip"); value != nil {
+        ip = formatValue(value)
+    }
+
+    return
+}
+
+// 记录日志
+func writeLog(db *gorm.DB, action string, recordId string, old map[string]any, new map[string]any) {
+    adminId, requestId, ip := actorFromContext(db.Statement.Context)
+
+    oldValues := ""
+    if old != nil {
+        data, _ := json.Marshal(old)
+        oldValues = string(data)
+    }
+
+    newValues := ""
+    if new != nil {
+        data, _ := json.Marshal(new)
+        newValues = string(data)
+    }
+
+    db.Session(&gorm.Session{
+        NewDB: true,
+    }).Create(&model.AuditLog{
+        Model: db.Statement.Table,
+        RecordId: recordId,
+        Action: action,
+        OldValues: oldValues,
+        NewValues: newValues,
+        AdminId: adminId,
+        RequestId: requestId,
+        Ip: ip,
+        Time: int(datebin.NowTimestamp()),
+    })
+}
@@ -1,6 +1,13 @@
 package controller
 
 import (
+    "fmt"
+    "strings"
+    "encoding/csv"
+    "encoding/json"
+
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
 
@@ -9,6 +16,7 @@ import (
     adminController "github.com/deatil/lakego-doak-admin/admin/controller"
 
     "github.com/deatil/lakego-doak-action-log/action-log/model"
+    "github.com/deatil/lakego-doak-action-log/action-log/writer"
 )
 
 /**
@@ -33,6 +41,7 @@ type ActionLog struct {
 // @Param end_time   query string false "结束时间"
 // @Param method     query string false "请求方法"
 // @Param status     query string false "状态"
+// @Param request_id query string false "请求 id"
 // @Param start      query string false "开始数据量"
 // @Param limit      query string false "每页数量"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
@@ -54,39 +63,8 @@ func (this *ActionLog) Index(ctx *router.Context) {
 
     logModel = logModel.Order(orders[0] + " " + orders[1])
 
-    // 搜索条件
-    searchword := ctx.DefaultQuery("searchword", "")
-    if searchword != "" {
-        searchword = "%" + searchword + "%"
-
-        logModel = logModel.Where(
-            model.NewDB().
-                Where("name LIKE ?", searchword).
-                Or("url LIKE ?", searchword),
-        )
-    }
-
-    // 时间条件
-    startTime := ctx.DefaultQuery("start_time", "")
-    if startTime != "" {
-        logModel = logModel.Where("time >= ?", this.FormatDate(startTime))
-    }
-
-    endTime := ctx.DefaultQuery("end_time", "")
-    if endTime != "" {
-        logModel = logModel.Where("time <= ?", this.FormatDate(endTime))
-    }
-
-    // 请求方式
-    method := ctx.DefaultQuery("method", "")
-    if method != "" {
-        logModel = logModel.Where("method = ?", method)
-    }
-
-    status := this.SwitchStatus(ctx.DefaultQuery("status", ""))
-    if status != -1 {
-        logModel = logModel.Where("status = ?", status)
-    }
+    // 筛选条件
+    logModel = this.filter(ctx, logModel)
 
     // 分页相关
     start := ctx.DefaultQuery("start", "0")
@@ -148,3 +126,167 @@ func (this *ActionLog) Clear(ctx *router.Context) {
 
     this.Success(ctx, "30天前日志清除成功")
 }
+
+// 导出操作日志
+// @Summary 导出操作日志
+// @Description 按筛选条件导出操作日志，支持 csv 和 jsonl 格式
+// @Tags 操作日志
+// @Accept  application/json
+// @Produce application/octet-stream
+// @Param format     query string false "导出格式，可选：csv | jsonl"
+// @Param searchword query string false "搜索关键字"
+// @Param start_time query string false "开始时间"
+// @Param end_time   query string false "结束时间"
+// @Param method     query string false "请求方法"
+// @Param status     query string false "状态"
+// @Param request_id query string false "请求 id"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /action-log/export [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.action-log.export"}
+func (this *ActionLog) Export(ctx *router.Context) {
+    format := ctx.DefaultQuery("format", "csv")
+    if format != "csv" && format != "jsonl" {
+        this.Error(ctx, "导出格式错误")
+        return
+    }
+
+    filename := fmt.Sprintf("action-log-%s.%s", datebin.Now().Format("YmdHis"), format)
+
+    ctx.Header("Content-Disposition", "attachment; filename=" + filename)
+    if format == "csv" {
+        ctx.Header("Content-Type", "text/csv; charset=utf-8")
+    } else {
+        ctx.Header("Content-Type", "application/x-ndjson; charset=utf-8")
+    }
+
+    var csvWriter *csv.Writer
+    var jsonEncoder *json.Encoder
+
+    if format == "csv" {
+        csvWriter = csv.NewWriter(ctx.Writer)
+        csvWriter.Write([]string{
+            "id", "name", "url", "method", "info", "header",
+            "useragent", "time", "ip", "status", "request_id",
+        })
+    } else {
+        jsonEncoder = json.NewEncoder(ctx.Writer)
+    }
+
+    // 按 time 及 id 分页，导出时有新日志写入也不会重复或遗漏
+    batch := 500
+    var last *model.ActionLog
+    for {
+        list := make([]model.ActionLog, 0)
+
+        query := this.filter(ctx, model.NewActionLog())
+        if last != nil {
+            query = query.Where("(time < ? OR (time = ? AND id < ?))", last.Time, last.Time, last.ID)
+        }
+
+        err := query.
+            Order("time DESC").
+            Order("id DESC").
+            Limit(batch).
+            Find(&list).
+            Error
+        if err != nil || len(list) == 0 {
+            break
+        }
+
+        last = &list[len(list)-1]
+
+        for _, item := range list {
+            if csvWriter != nil {
+                csvWriter.Write(csvRow(
+                    item.ID, item.Name, item.Url, item.Method,
+                    item.Info, item.Header, item.Useragent,
+                    datebin.FromTimestamp(int64(item.Time)).ToDatetimeString(),
+                    item.Ip, item.Status, item.RequestId,
+                ))
+            } else {
+                jsonEncoder.Encode(item)
+            }
+        }
+
+        if csvWriter != nil {
+            csvWriter.Flush()
+        }
+
+        ctx.Writer.Flush()
+
+        if len(list) < batch {
+            break
+        }
+    }
+}
+
+// csv 行，以 = + - @ 等开头的内容加 ' 前缀，避免表格软件作为公式执行
+func csvRow(cells ...string) []string {
+    for i, cell := range cells {
+        if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+            cells[i] = "'" + cell
+        }
+    }
+
+    return cells
+}
+
+// 筛选条件
+func (this *ActionLog) filter(ctx *router.Context, logModel *gorm.DB) *gorm.DB {
+    // 搜索条件
+    searchword := ctx.DefaultQuery("searchword", "")
+    if searchword != "" {
+        searchword = "%" + searchword + "%"
+
+        logModel = logModel.Where(
+            model.NewDB().
+                Where("name LIKE ?", searchword).
+                Or("url LIKE ?", searchword),
+        )
+    }
+
+    // 时间条件
+    startTime := ctx.DefaultQuery("start_time", "")
+    if startTime != "" {
+        logModel = logModel.Where("time >= ?", this.FormatDate(startTime))
+    }
+
+    endTime := ctx.DefaultQuery("end_time", "")
+    if endTime != "" {
+        logModel = logModel.Where("time <= ?", this.FormatDate(endTime))
+    }
+
+    // 请求方式
+    method := ctx.DefaultQuery("method", "")
+    if method != "" {
+        logModel = logModel.Where("method = ?", method)
+    }
+
+    status := this.SwitchStatus(ctx.DefaultQuery("status", ""))
+    if status != -1 {
+        logModel = logModel.Where("status = ?", status)
+    }
+
+    // 请求 id
+    requestId := ctx.DefaultQuery("request_id", "")
+    if requestId != "" {
+        logModel = logModel.Where("request_id = ?", requestId)
+    }
+
+    return logModel
+}
+
+// 写入队列状态
+// @Summary 写入队列状态
+// @Description 操作日志写入队列状态，包括排队、写入及丢弃数量
+// @Tags 操作日志
+// @Accept  application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /action-log/stats [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.action-log.stats"}
+func (this *ActionLog) Stats(ctx *router.Context) {
+    this.SuccessWithData(ctx, "获取成功", writer.Default().Stats())
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+    "encoding/json"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    adminController "github.com/deatil/lakego-doak-admin/admin/controller"
+
+    "github.com/deatil/lakego-doak-action-log/action-log/audit"
+    "github.com/deatil/lakego-doak-action-log/action-log/model"
+)
+
+/**
+ * 数据审计日志
+ *
+ * @create 2024-5-8
+ * @author deatil
+ */
+type AuditLog struct {
+    adminController.Base
+}
+
+// 审计日志列表
+// @Summary 审计日志列表
+// @Description 数据审计日志列表
+// @Tags 操作日志
+// @Accept  application/json
+// @Produce application/json
+// @Param model      query string false "数据表"
+// @Param record_id  query string false "数据ID"
+// @Param admin_id   query string false "操作账号ID"
+// @Param action     query string false "操作类型，可选：create | update | delete"
+// @Param request_id query string false "请求ID"
+// @Param order      query string false "排序，示例：time__DESC"
+// @Param start_time query string false "开始时间"
+// @Param end_time   query string false "结束时间"
+// @Param start      query string false "开始数据量"
+// @Param limit      query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /audit-log [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.audit-log.index"}
+func (this *AuditLog) Index(ctx *router.Context) {
+    // 模型
+    logModel := model.NewAuditLog()
+
+    // 排序
+    order := ctx.DefaultQuery("order", "time__DESC")
+    orders := this.FormatOrderBy(order)
+    if orders[0] == "" ||
+        (orders[0] != "id" &&
+        orders[0] != "time") {
+        orders[0] = "time"
+    }
+
+    logModel = logModel.Order(orders[0] + " " + orders[1])
+
+    // 筛选条件
+    filters := []string{"model", "record_id", "admin_id", "action", "request_id"}
+    for _, filter := range filters {
+        value := ctx.DefaultQuery(filter, "")
+        if value != "" {
+            logModel = logModel.Where(filter + " = ?", value)
+        }
+    }
+
+    // 时间条件
+    startTime := ctx.DefaultQuery("start_time", "")
+    if startTime != "" {
+        logModel = logModel.Where("time >= ?", this.FormatDate(startTime))
+    }
+
+    endTime := ctx.DefaultQuery("end_time", "")
+    if endTime != "" {
+        logModel = logModel.Where("time <= ?", this.FormatDate(endTime))
+    }
+
+    // 分页相关
+    start := ctx.DefaultQuery("start", "0")
+    limit := ctx.DefaultQuery("limit", "10")
+
+    newStart := goch.ToInt(start)
+    newLimit := goch.ToInt(limit)
+
+    logModel = logModel.
+        Offset(newStart).
+        Limit(newLimit)
+
+    list := make([]map[string]any, 0)
+
+    // 列表
+    logModel = logModel.
+        Select([]string{
+            "id", "model", "record_id",
+            "action", "admin_id", "request_id",
+            "ip", "time",
+        }).
+        Find(&list)
+
+    var total int64
+
+    // 总数
+    err := logModel.
+        Offset(-1).
+        Limit(-1).
+        Count(&total).
+        Error
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "start": start,
+        "limit": limit,
+        "total": total,
+        "list": list,
+    })
+}
+
+// 审计日志详情
+// @Summary 审计日志详情
+// @Description 审计日志详情及字段变动
+// @Tags 操作日志
+// @Accept  application/json
+// @Produce application/json
+// @Param id path string true "日志ID"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /audit-log/{id} [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.audit-log.detail"}
+func (this *AuditLog) Detail(ctx *router.Context) {
+    id := ctx.Param("id")
+    if id == "" {
+        this.Error(ctx, "日志ID不能为空")
+        return
+    }
+
+    var info model.AuditLog
+    err := model.NewAuditLog().
+        Where("id = ?", id).
+        First(&info).
+        Error
+    if err != nil {
+        this.Error(ctx, "日志信息不存在")
+        return
+    }
+
+    oldValues := make(map[string]any)
+    if info.OldValues != "" {
+        json.Unmarshal([]byte(info.OldValues), &oldValues)
+    }
+
+    newValues := make(map[string]any)
+    if info.NewValues != "" {
+        json.Unmarshal([]byte(info.NewValues), &newValues)
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "id": info.ID,
+        "model": info.Model,
+        "record_id": info.RecordId,
+        "action": info.Action,
+        "admin_id": info.AdminId,
+        "request_id": info.RequestId,
+        "ip": info.Ip,
+        "time": info.Time,
+        "old_values": oldValues,
+        "new_values": newValues,
+        "diffs": audit.Diff(oldValues, newValues),
+    })
+}
@@ -2,28 +2,29 @@ package actionlog
 
 import (
     "strconv"
+    "strings"
     "encoding/json"
+    gourl "net/url"
 
     "github.com/deatil/go-datebin/datebin"
-    "github.com/deatil/lakego-doak/lakego/gmq"
+    "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/http/request"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/url"
 
     "github.com/deatil/lakego-doak-action-log/action-log/model"
+    "github.com/deatil/lakego-doak-action-log/action-log/redact"
+    "github.com/deatil/lakego-doak-action-log/action-log/writer"
 )
 
-var MQ *gmq.GMQ
-
-func init() {
-    MQ = gmq.NewGMQ()
-
-    // 执行记录
-    MQ.Subscribe("action-log", func(value interface{}) {
-        f := value.(func())
-        f()
-    })
-
-    go MQ.Run()
+// 默认脱敏字段
+var defaultRedactFields = []string{
+    "password",
+    "oldpassword",
+    "newpassword",
+    "newpassword_confirm",
 }
 
 /**
@@ -36,25 +37,72 @@ func Handler() router.HandlerFunc {
     return func(ctx *router.Context) {
         ctx.Next()
 
-        // 协程使用 ctx
-        newCtx := ctx.Copy()
+        if !shouldRecord(ctx) {
+            return
+        }
 
-        go MQ.Publish("action-log", func() {
-            recordLog(newCtx)
-        })
+        // 写入队列，由工作协程批量入库
+        writer.Default().Write(newLog(ctx))
     }
 }
 
-func filterPassword(data map[string]any, key string) map[string]any {
-    if _, ok := data[key]; ok {
-        data[key] = ""
+// 是否需要记录
+func shouldRecord(ctx *router.Context) bool {
+    conf := config.New("actionlog")
+
+    method := strings.ToUpper(ctx.Request.Method)
+
+    methods := conf.GetStringSlice("record.methods")
+    if len(methods) > 0 {
+        for k, v := range methods {
+            methods[k] = strings.ToUpper(v)
+        }
+
+        if !array.InArray(method, methods) {
+            return false
+        }
     }
 
-    return data
+    // 只检测 url 中的 path 部分
+    urlPath := ctx.Request.URL.String()
+
+    u, _ := gourl.Parse(urlPath)
+    urlPath = u.Path
+
+    excepts := conf.GetStringSlice("record.excepts")
+    for _, ae := range excepts {
+        newStr := strings.SplitN(ae, ":", 2)
+        if len(newStr) != 2 {
+            continue
+        }
+
+        newUrl := newStr[0] + ":" + url.AdminUrl(newStr[1])
+        if url.MatchPath(ctx, newUrl, urlPath) {
+            return false
+        }
+    }
+
+    return true
+}
+
+// 脱敏
+func newRedact() *redact.Redact {
+    conf := config.New("actionlog")
+
+    fields := defaultRedactFields
+    if conf.IsSet("redact.fields") {
+        fields = conf.GetStringSlice("redact.fields")
+    }
+
+    return redact.New(
+        fields,
+        conf.GetStringSlice("redact.headers"),
+        conf.GetString("redact.replace"),
+    )
 }
 
-// 记录日志
-func recordLog(ctx *router.Context) {
+// 生成日志数据
+func newLog(ctx *router.Context) *model.ActionLog {
     path := ctx.Request.URL.Path
     raw := ctx.Request.URL.RawQuery
 
@@ -68,15 +116,21 @@ func recordLog(ctx *router.Context) {
         path = path + "?" + raw
     }
 
-    post = filterPassword(post, "password")
-    post = filterPassword(post, "oldpassword")
-    post = filterPassword(post, "newpassword")
-    post = filterPassword(post, "newpassword_confirm")
+    rd := newRedact()
+
+    post = rd.Data(post)
 
     // 请求数据
     info, _ := json.Marshal(&post)
     useragent := ctx.Request.Header.Get("User-Agent")
 
+    // 请求头
+    header := ""
+    if config.New("actionlog").GetBool("record.header") {
+        headerData, _ := json.Marshal(rd.Header(ctx.Request.Header))
+        header = string(headerData)
+    }
+
     // 请求 IP
     ip := router.GetRequestIp(ctx)
 
@@ -90,15 +144,16 @@ func recordLog(ctx *router.Context) {
         name = "操作账号[" + adminId.(string) + "]"
     }
 
-    // 记录数据
-    model.NewDB().Create(&model.ActionLog{
+    return &model.ActionLog{
         Name: name,
         Url: path,
         Method: method,
         Info: string(info),
+        Header: header,
         Useragent: useragent,
         Time: int(datebin.NowTimestamp()),
         Ip: ip,
         Status: status,
-    })
+        RequestId: router.GetRequestID(ctx),
+    }
 }
@@ -13,10 +13,12 @@ type ActionLog struct {
     Url       string `gorm:"column:url;type:text;" json:"url"`
     Method    string `gorm:"column:method;type:varchar(10);" json:"method"`
     Info      string `gorm:"column:info;type:text;" json:"info"`
+    Header    string `gorm:"column:header;type:text;" json:"header"`
     Useragent string `gorm:"column:useragent;type:text;" json:"useragent"`
     Time      int    `gorm:"column:time;type:int(10);" json:"time"`
     Ip        string `gorm:"column:ip;type:varchar(50);" json:"ip"`
     Status    string `gorm:"column:status;type:char(3);" json:"status"`
+    RequestId string `gorm:"column:request_id;type:varchar(128);" json:"request_id"`
 }
 
 /*
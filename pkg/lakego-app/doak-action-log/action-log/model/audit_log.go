@@ -0,0 +1,32 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade"
+)
+
+// 数据审计日志
+type AuditLog struct {
+    ID        string `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`
+    Model     string `gorm:"column:model;not null;type:varchar(100);" json:"model"`
+    RecordId  string `gorm:"column:record_id;type:varchar(100);" json:"record_id"`
+    Action    string `gorm:"column:action;type:varchar(10);" json:"action"`
+    OldValues string `gorm:"column:old_values;type:mediumtext;" json:"old_values"`
+    NewValues string `gorm:"column:new_values;type:mediumtext;" json:"new_values"`
+    AdminId   string `gorm:"column:admin_id;type:varchar(36);" json:"admin_id"`
+    RequestId string `gorm:"column:request_id;type:varchar(64);" json:"request_id"`
+    Ip        string `gorm:"column:ip;type:varchar(50);" json:"ip"`
+    Time      int    `gorm:"column:time;type:int(10);" json:"time"`
+}
+
+func (this *AuditLog) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+func NewAuditLog() *gorm.DB {
+    return facade.DB.Model(&AuditLog{})
+}
@@ -1,11 +1,22 @@
 package provider
 
 import (
+    "fmt"
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
 
+    admin_model "github.com/deatil/lakego-doak-admin/admin/model"
     admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
 
+    "github.com/deatil/lakego-doak-action-log/action-log/audit"
+    "github.com/deatil/lakego-doak-action-log/action-log/writer"
+    "github.com/deatil/lakego-doak-action-log/action-log/retention"
     log_router "github.com/deatil/lakego-doak-action-log/action-log/route"
     log_middleware "github.com/deatil/lakego-doak-action-log/action-log/middleware/actionlog"
 )
@@ -42,8 +53,41 @@ func (this *ActionLog) Register() {
 
 // 引导
 func (this *ActionLog) Boot() {
+    // 数据审计
+    this.loadAudit()
+
+    // 写入队列
+    this.loadWriter()
+
     // 路由
     this.loadRoute()
+
+    // 推送配置
+    this.publishConfig()
+}
+
+// 计划任务
+func (this *ActionLog) Schedule(s *schedule.Schedule) {
+    conf := config.New("actionlog")
+    if !conf.GetBool("retention.enable") {
+        return
+    }
+
+    spec := conf.GetString("retention.spec")
+    if spec == "" {
+        spec = "0 0 3 * * *"
+    }
+
+    // 清理过期日志
+    s.AddFunc(func() {
+        total, err := retention.Prune(retention.PolicyFromConfig())
+        if err != nil {
+            facade.Logger.Error("[action-log]" + err.Error())
+            return
+        }
+
+        facade.Logger.Info(fmt.Sprintf("[action-log]清理过期日志 %d 条", total))
+    }).Cron(spec).WithName("lakego-admin.action-log.retention")
 }
 
 /**
@@ -65,6 +109,38 @@ func (this *ActionLog) loadMiddleware() {
     }
 }
 
+/**
+ * 数据审计
+ */
+func (this *ActionLog) loadAudit() {
+    audit.Register(&admin_model.Admin{}, "password", "password_salt")
+    audit.Register(&admin_model.AuthGroup{})
+    audit.Register(&admin_model.AuthRule{})
+    audit.Register(&admin_model.Attachment{})
+
+    if err := audit.Boot(); err != nil {
+        facade.Logger.Error("[audit]" + err.Error())
+    }
+}
+
+/**
+ * 写入队列
+ */
+func (this *ActionLog) loadWriter() {
+    w := writer.Default().
+        OnError(func(err error) {
+            facade.Logger.Error("[action-log]" + err.Error())
+        }).
+        Start()
+
+    // 关闭时写入剩余日志
+    this.AddShutdown(func(ctx context.Context) {
+        if err := w.Close(ctx); err != nil {
+            facade.Logger.Error("[action-log]" + err.Error())
+        }
+    })
+}
+
 /**
  * 导入路由
  */
@@ -75,3 +151,17 @@ func (this *ActionLog) loadRoute() {
     })
 }
 
+/**
+ * 推送配置
+ */
+func (this *ActionLog) publishConfig() {
+    // 配置
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-action-log/resources/config/actionlog.yml")
+
+    // 推送文件
+    // > go run main.go lakego:publish --tag=action-log-config --force
+    toPath := pathTool.ConfigPath("/actionlog.yml")
+    this.Publishes(this, map[string]string{
+        path: toPath,
+    }, "action-log-config")
+}
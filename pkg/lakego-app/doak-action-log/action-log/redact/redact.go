@@ -0,0 +1,108 @@
+package redact
+
+import (
+    "strconv"
+    "strings"
+    "net/http"
+)
+
+/**
+ * 数据脱敏
+ *
+ * 字段支持使用 . 分隔的嵌套路径，* 匹配任意字段或数组索引
+ * 示例：password | user.password | list.*.token | *.password
+ *
+ * @create 2024-5-10
+ * @author deatil
+ */
+type Redact struct {
+    // 字段路径
+    fields [][]string
+
+    // 请求头
+    headers []string
+
+    // 替换值
+    replace string
+}
+
+// 构造函数
+func New(fields []string, headers []string, replace string) *Redact {
+    paths := make([][]string, 0, len(fields))
+    for _, field := range fields {
+        if field = strings.TrimSpace(field); field != "" {
+            paths = append(paths, strings.Split(field, "."))
+        }
+    }
+
+    newHeaders := make([]string, 0, len(headers))
+    for _, header := range headers {
+        newHeaders = append(newHeaders, http.CanonicalHeaderKey(header))
+    }
+
+    return &Redact{
+        fields:  paths,
+        headers: newHeaders,
+        replace: replace,
+    }
+}
+
+// 请求数据脱敏
+func (this *Redact) Data(data map[string]any) map[string]any {
+    for _, path := range this.fields {
+        this.redactPath(data, path)
+    }
+
+    return data
+}
+
+// 请求头脱敏
+func (this *Redact) Header(header http.Header) map[string]string {
+    data := make(map[string]string, len(header))
+    for name, values := range header {
+        data[name] = strings.Join(values, ", ")
+    }
+
+    for _, name := range this.headers {
+        if _, ok := data[name]; ok {
+            data[name] = this.replace
+        }
+    }
+
+    return data
+}
+
+func (this *Redact) redactPath(data any, path []string) {
+    if len(path) == 0 {
+        return
+    }
+
+    key, last := path[0], len(path) == 1
+
+    switch value := data.(type) {
+        case map[string]any:
+            for k, v := range value {
+                if key != "*" && key != k {
+                    continue
+                }
+
+                if last {
+                    value[k] = this.replace
+                } else {
+                    this.redactPath(v, path[1:])
+                }
+            }
+        case []any:
+            for i, v := range value {
+                if key != "*" && key != strconv.Itoa(i) {
+                    continue
+                }
+
+                if last {
+                    value[i] = this.replace
+                } else {
+                    this.redactPath(v, path[1:])
+                }
+            }
+    }
+}
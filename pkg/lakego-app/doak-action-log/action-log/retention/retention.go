@@ -0,0 +1,167 @@
+package retention
+
+import (
+    "bytes"
+    "errors"
+    "compress/gzip"
+    "encoding/json"
+
+    "github.com/deatil/go-datebin/datebin"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/storage"
+
+    "github.com/deatil/lakego-doak-action-log/action-log/model"
+)
+
+const (
+    // 删除
+    ModeDelete = "delete"
+
+    // 压缩归档后删除
+    ModeArchive = "archive"
+)
+
+// 保留策略
+type Policy struct {
+    // 保留天数
+    Days int
+
+    // 处理方式
+    Mode string
+
+    // 归档磁盘
+    Disk string
+
+    // 归档目录
+    Path string
+
+    // 每批数量
+    Batch int
+}
+
+// 配置中的保留策略
+func PolicyFromConfig() Policy {
+    conf := config.New("actionlog")
+
+    policy := Policy{
+        Days:  conf.GetInt("retention.days"),
+        Mode:  conf.GetString("retention.mode"),
+        Disk:  conf.GetString("retention.disk"),
+        Path:  conf.GetString("retention.path"),
+        Batch: conf.GetInt("retention.batch"),
+    }
+
+    if policy.Days <= 0 {
+        policy.Days = 30
+    }
+    if policy.Mode == "" {
+        policy.Mode = ModeDelete
+    }
+    if policy.Path == "" {
+        policy.Path = "action-log"
+    }
+    if policy.Batch <= 0 {
+        policy.Batch = 1000
+    }
+
+    return policy
+}
+
+/**
+ * 执行保留策略，返回处理的数据数量
+ *
+ * @create 2024-5-10
+ * @author deatil
+ */
+func Prune(policy Policy) (int64, error) {
+    before := int(datebin.Now().SubDays(uint(policy.Days)).Timestamp())
+
+    switch policy.Mode {
+        case ModeDelete:
+            result := model.NewActionLog().
+                Where("time <= ?", before).
+                Delete(&model.ActionLog{})
+
+            return result.RowsAffected, result.Error
+
+        case ModeArchive:
+            return archive(policy, before)
+    }
+
+    return 0, errors.New("retention mode [" + policy.Mode + "] is not supported")
+}
+
+// 压缩归档后删除
+func archive(policy Policy, before int) (int64, error) {
+    var total int64
+
+    disk := storage.New()
+    if policy.Disk != "" {
+        disk = storage.NewWithDisk(policy.Disk)
+    }
+
+    for {
+        list := make([]model.ActionLog, 0)
+        err := model.NewActionLog().
+            Where("time <= ?", before).
+            Order("time ASC").
+            Limit(policy.Batch).
+            Find(&list).
+            Error
+        if err != nil {
+            return total, err
+        }
+
+        if len(list) == 0 {
+            break
+        }
+
+        var buf bytes.Buffer
+
+        zw := gzip.NewWriter(&buf)
+        enc := json.NewEncoder(zw)
+
+        ids := make([]string, 0, len(list))
+        for _, item := range list {
+            if err := enc.Encode(item); err != nil {
+                return total, err
+            }
+
+            ids = append(ids, item.ID)
+        }
+
+        if err := zw.Close(); err != nil {
+            return total, err
+        }
+
+        // 文件名中的 ID 前缀，避免同一时间段的文件重名
+        prefix := ids[0]
+        if len(prefix) > 8 {
+            prefix = prefix[:8]
+        }
+
+        file := policy.Path + "/action-log-" +
+            datebin.FromTimestamp(int64(list[0].Time)).Format("YmdHis") + "-" +
+            datebin.FromTimestamp(int64(list[len(list)-1].Time)).Format("YmdHis") + "-" +
+            prefix + ".jsonl.gz"
+
+        if _, err := disk.Put(file, buf.String()); err != nil {
+            return total, err
+        }
+
+        result := model.NewActionLog().
+            Where("id IN ?", ids).
+            Delete(&model.ActionLog{})
+        if result.Error != nil {
+            return total, result.Error
+        }
+
+        total += result.RowsAffected
+
+        if len(list) < policy.Batch {
+            break
+        }
+    }
+
+    return total, nil
+}
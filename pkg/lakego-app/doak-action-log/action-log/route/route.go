@@ -1,7 +1,7 @@
 package route
 
 import (
-    "github.com/gin-gonic/gin"
+    "github.com/deatil/lakego-doak/lakego/router"
 
     "github.com/deatil/lakego-doak-action-log/action-log/controller"
 )
@@ -9,9 +9,36 @@ import (
 /**
  * 路由
  */
-func Route(engine gin.IRouter) {
+func Route(group router.IRouter) {
+    engine := router.WithMeta(group)
+
     // 操作日志
     actionLogController := new(controller.ActionLog)
-    engine.GET("/action-log", actionLogController.Index)
-    engine.DELETE("/action-log/clear", actionLogController.Clear)
+    engine.GET("/action-log", actionLogController.Index).
+        Slug("lakego-admin.action-log.index").
+        Title("操作日志列表").
+        Parent("操作日志")
+    engine.GET("/action-log/export", actionLogController.Export).
+        Slug("lakego-admin.action-log.export").
+        Title("导出操作日志").
+        Parent("操作日志")
+    engine.GET("/action-log/stats", actionLogController.Stats).
+        Slug("lakego-admin.action-log.stats").
+        Title("写入队列状态").
+        Parent("操作日志")
+    engine.DELETE("/action-log/clear", actionLogController.Clear).
+        Slug("lakego-admin.action-log.clear").
+        Title("清除 30 天前的日志数据").
+        Parent("操作日志")
+
+    // 审计日志
+    auditLogController := new(controller.AuditLog)
+    engine.GET("/audit-log", auditLogController.Index).
+        Slug("lakego-admin.audit-log.index").
+        Title("审计日志列表").
+        Parent("操作日志")
+    engine.GET("/audit-log/:id", auditLogController.Detail).
+        Slug("lakego-admin.audit-log.detail").
+        Title("审计日志详情").
+        Parent("操作日志")
 }
@@ -0,0 +1,311 @@
+package writer
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "context"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/lakego-doak-action-log/action-log/model"
+)
+
+// 已关闭
+var ErrClosed = errors.New("action-log writer is closed")
+
+// 配置
+type Options struct {
+    // 队列长度
+    QueueSize int
+
+    // 工作协程数量
+    Workers int
+
+    // 每批写入数量
+    BatchSize int
+
+    // 定时写入间隔
+    FlushInterval time.Duration
+
+    // 队列已满时等待时间，为 0 时直接丢弃
+    BlockTimeout time.Duration
+}
+
+// 配置中的设置
+func OptionsFromConfig() Options {
+    conf := config.New("actionlog")
+
+    opts := Options{
+        QueueSize:     conf.GetInt("writer.queue-size"),
+        Workers:       conf.GetInt("writer.workers"),
+        BatchSize:     conf.GetInt("writer.batch-size"),
+        FlushInterval: conf.GetDuration("writer.flush-interval"),
+        BlockTimeout:  conf.GetDuration("writer.block-timeout"),
+    }
+
+    return opts.withDefaults()
+}
+
+func (opts Options) withDefaults() Options {
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = 10000
+    }
+    if opts.Workers <= 0 {
+        opts.Workers = 2
+    }
+    if opts.BatchSize <= 0 {
+        opts.BatchSize = 100
+    }
+    if opts.FlushInterval <= 0 {
+        opts.FlushInterval = time.Second
+    }
+    if opts.BlockTimeout < 0 {
+        opts.BlockTimeout = 0
+    }
+
+    return opts
+}
+
+// 统计数据
+type Stats struct {
+    // 当前队列长度
+    Pending int `json:"pending"`
+
+    // 队列容量
+    Capacity int `json:"capacity"`
+
+    // 入队数量
+    Enqueued uint64 `json:"enqueued"`
+
+    // 写入数量
+    Written uint64 `json:"written"`
+
+    // 丢弃数量
+    Dropped uint64 `json:"dropped"`
+
+    // 写入失败数量
+    Failed uint64 `json:"failed"`
+
+    // 写入批次
+    Batches uint64 `json:"batches"`
+
+    // 等待入队次数
+    Blocked uint64 `json:"blocked"`
+}
+
+// 批量写入方法
+type StoreFunc = func([]*model.ActionLog) error
+
+// 默认写入数据库
+func DefaultStore(logs []*model.ActionLog) error {
+    return model.NewDB().CreateInBatches(logs, len(logs)).Error
+}
+
+/**
+ * 操作日志有界写入队列
+ *
+ * 队列满时等待 BlockTimeout，超时则丢弃并计数
+ *
+ * @create 2024-5-12
+ * @author deatil
+ */
+type Writer struct {
+    opts  Options
+    store StoreFunc
+
+    queue chan *model.ActionLog
+    done  chan struct{}
+    wg    sync.WaitGroup
+
+    // 关闭锁，防止关闭后写入
+    mu     sync.RWMutex
+    closed bool
+
+    enqueued uint64
+    written  uint64
+    dropped  uint64
+    failed   uint64
+    batches  uint64
+    blocked  uint64
+
+    // 错误回调
+    onError func(error)
+}
+
+// 构造函数
+func New(opts Options, store StoreFunc) *Writer {
+    opts = opts.withDefaults()
+
+    if store == nil {
+        store = DefaultStore
+    }
+
+    return &Writer{
+        opts:  opts,
+        store: store,
+        queue: make(chan *model.ActionLog, opts.QueueSize),
+        done:  make(chan struct{}),
+    }
+}
+
+// 设置错误回调
+func (this *Writer) OnError(f func(error)) *Writer {
+    this.onError = f
+    return this
+}
+
+// 启动工作协程
+func (this *Writer) Start() *Writer {
+    for i := 0; i < this.opts.Workers; i++ {
+        this.wg.Add(1)
+        go this.work()
+    }
+
+    return this
+}
+
+// 写入日志，返回是否成功入队
+func (this *Writer) Write(log *model.ActionLog) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.closed {
+        atomic.AddUint64(&this.dropped, 1)
+        return false
+    }
+
+    select {
+        case this.queue <- log:
+            atomic.AddUint64(&this.enqueued, 1)
+            return true
+        default:
+    }
+
+    if this.opts.BlockTimeout <= 0 {
+        atomic.AddUint64(&this.dropped, 1)
+        return false
+    }
+
+    atomic.AddUint64(&this.blocked, 1)
+
+    timer := time.NewTimer(this.opts.BlockTimeout)
+    defer timer.Stop()
+
+    select {
+        case this.queue <- log:
+            atomic.AddUint64(&this.enqueued, 1)
+            return true
+        case <-timer.C:
+            atomic.AddUint64(&this.dropped, 1)
+            return false
+    }
+}
+
+// 关闭并写入剩余数据，ctx 到期时返回错误
+func (this *Writer) Close(ctx context.Context) error {
+    this.mu.Lock()
+    if this.closed {
+        this.mu.Unlock()
+        return ErrClosed
+    }
+
+    this.closed = true
+    close(this.queue)
+    this.mu.Unlock()
+
+    go func() {
+        this.wg.Wait()
+        close(this.done)
+    }()
+
+    select {
+        case <-this.done:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 统计数据
+func (this *Writer) Stats() Stats {
+    return Stats{
+        Pending:  len(this.queue),
+        Capacity: cap(this.queue),
+        Enqueued: atomic.LoadUint64(&this.enqueued),
+        Written:  atomic.LoadUint64(&this.written),
+        Dropped:  atomic.LoadUint64(&this.dropped),
+        Failed:   atomic.LoadUint64(&this.failed),
+        Batches:  atomic.LoadUint64(&this.batches),
+        Blocked:  atomic.LoadUint64(&this.blocked),
+    }
+}
+
+// 工作协程
+func (this *Writer) work() {
+    defer this.wg.Done()
+
+    ticker := time.NewTicker(this.opts.FlushInterval)
+    defer ticker.Stop()
+
+    batch := make([]*model.ActionLog, 0, this.opts.BatchSize)
+
+    for {
+        select {
+            case log, ok := <-this.queue:
+                if !ok {
+                    this.flush(batch)
+                    return
+                }
+
+                batch = append(batch, log)
+                if len(batch) >= this.opts.BatchSize {
+                    this.flush(batch)
+                    batch = make([]*model.ActionLog, 0, this.opts.BatchSize)
+                }
+
+            case <-ticker.C:
+                if len(batch) > 0 {
+                    this.flush(batch)
+                    batch = make([]*model.ActionLog, 0, this.opts.BatchSize)
+                }
+        }
+    }
+}
+
+// 批量写入
+func (this *Writer) flush(batch []*model.ActionLog) {
+    if len(batch) == 0 {
+        return
+    }
+
+    atomic.AddUint64(&this.batches, 1)
+
+    if err := this.store(batch); err != nil {
+        atomic.AddUint64(&this.failed, uint64(len(batch)))
+
+        if this.onError != nil {
+            this.onError(err)
+        }
+
+        return
+    }
+
+    atomic.AddUint64(&this.written, uint64(len(batch)))
+}
+
+// 默认实例
+var (
+    defaultWriter *Writer
+    defaultOnce   sync.Once
+)
+
+// 默认实例，使用配置中的设置
+func Default() *Writer {
+    defaultOnce.Do(func() {
+        defaultWriter = New(OptionsFromConfig(), DefaultStore)
+    })
+
+    return defaultWriter
+}
@@ -2,7 +2,11 @@ package cmd
 
 import (
     "fmt"
+    "sort"
     "strings"
+    "context"
+
+    "gorm.io/gorm"
 
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-doak/lakego/router"
@@ -15,17 +19,22 @@ import (
 /**
  * 导入路由信息
  *
+ * 路由注册时通过 router.WithMeta 设置的 slug、标题、父级及描述会同步到权限菜单，
+ * 路由已不存在的权限菜单会被禁用
+ *
  * > ./main lakego-admin:import-route
  * > main.exe lakego-admin:import-route
  * > go run main.go lakego-admin:import-route
  *
+ * > go run main.go lakego-admin:import-route --dry-run
+ *
  * @create 2021-9-26
  * @author deatil
  */
 var ImportRouteCmd = &command.Command{
     Use: "lakego-admin:import-route",
     Short: "lakego-admin import route'info.",
-    Example: "{execfile} lakego-admin:import-route",
+    Example: "{execfile} lakego-admin:import-route [--dry-run]",
     SilenceUsage: true,
     PreRun: func(cmd *command.Command, args []string) {
 
@@ -35,57 +44,456 @@ var ImportRouteCmd = &command.Command{
     },
 }
 
+// 只显示差异
+var importRouteDryRun bool
+
+func init() {
+    pf := ImportRouteCmd.Flags()
+    pf.BoolVarP(&importRouteDryRun, "dry-run", "d", false, "只显示差异，不写入数据")
+}
+
+// 路由权限
+type importRouteRule struct {
+    Url         string
+    Method      string
+    Slug        string
+    Title       string
+    Parent      string
+    Description string
+    Listorder   int
+    HasMeta     bool
+}
+
+// 同步计划
+type importRoutePlan struct {
+    // 需要添加的父级菜单
+    Groups []string
+
+    // 需要添加的路由
+    Creates []importRouteRule
+
+    // 需要更新的路由
+    Updates []importRouteRule
+
+    // 需要禁用的路由
+    Disables []model.AuthRule
+
+    // 需要重新启用的路由
+    Enables []model.AuthRule
+}
+
+// 是否有变动
+func (this importRoutePlan) Empty() bool {
+    return len(this.Groups) == 0 &&
+        len(this.Creates) == 0 &&
+        len(this.Updates) == 0 &&
+        len(this.Disables) == 0 &&
+        len(this.Enables) == 0
+}
+
 // 导入路由信息
 func ImportRoute() {
+    rules := collectRouteRules()
+
+    var existRules []model.AuthRule
+    err := model.NewAuthRule().
+        Find(&existRules).
+        Error
+    if err != nil {
+        fmt.Println("获取权限菜单失败：", err.Error())
+        return
+    }
+
+    plan := buildImportRoutePlan(rules, existRules)
+    if plan.Empty() {
+        fmt.Println("权限路由已是最新，无需同步")
+        return
+    }
+
+    printImportRoutePlan(plan, existRules)
+
+    if importRouteDryRun {
+        return
+    }
+
+    // MyISAM 表不支持事务，导入失败时无法回滚
+    if engine := tableEngine(model.NewDB(), &model.AuthRule{}); engine != "" && !strings.EqualFold(engine, "InnoDB") {
+        fmt.Println(fmt.Sprintf("权限表引擎为 %s，不支持事务，请先运行 lakego-admin:upgrade", engine))
+        return
+    }
+
+    // 权限菜单修改事件在提交后触发
+    err = model.Transaction(context.Background(), func(tx *gorm.DB) error {
+        return applyImportRoutePlan(tx, plan, rules)
+    })
+    if err != nil {
+        fmt.Println("权限路由导入失败：", err.Error())
+        return
+    }
+
+    fmt.Println("权限路由导入成功")
+}
+
+// mysql 数据表引擎，其他数据库返回空
+func tableEngine(db *gorm.DB, value any) string {
+    if db.Dialector.Name() != "mysql" {
+        return ""
+    }
+
+    stmt := &gorm.Statement{DB: db}
+    if err := stmt.Parse(value); err != nil {
+        return ""
+    }
+
+    var engine string
+    db.Raw(
+        "SELECT ENGINE FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+        stmt.Schema.Table,
+    ).Scan(&engine)
+
+    return engine
+}
+
+// 收集路由及元数据
+func collectRouteRules() []importRouteRule {
     routes := router.NewRoute().GetRoutes()
 
     // 路由前缀
     group := config.New("admin").GetString("Route.Prefix")
 
+    metas := router.NewMeta()
+
+    rules := make([]importRouteRule, 0)
     for _, v := range routes {
         if !strings.HasPrefix(v.Path, "/" + group + "/") {
             continue
         }
 
-        /*
-        re, _ := regexp.Compile(`:[0-9a-zA-Z_\-\.\:]+`);
-        authUrl := re.ReplaceAllString(v.Path, "*");
-        authUrl = strings.TrimPrefix(authUrl, "/" + group)
-        */
+        rule := importRouteRule{
+            Url: strings.TrimPrefix(v.Path, "/" + group),
+            Method: strings.ToUpper(v.Method),
+            Listorder: 100,
+        }
 
-        v.Path = strings.TrimPrefix(v.Path, "/" + group)
+        rule.Title = rule.Url
+        rule.Slug = rule.Url
 
-        result := map[string]any{}
-        err := model.NewAuthRule().
-            Where("url = ?", v.Path).
-            First(&result).
-            Error
-        if err != nil || len(result) < 1 {
-            insertData := model.AuthRule{
-                Parentid: "0",
-                Title: v.Path,
-                Url: v.Path,
-                Method: strings.ToUpper(v.Method),
-                Slug: v.Path,
-                Description: "",
-                Listorder: 100,
-                Status: 1,
-                AddTime: int(datebin.NowTimestamp()),
-                AddIp: "127.0.0.1",
+        if meta, ok := metas.GetMeta(v.Method, v.Path); ok {
+            rule.HasMeta = true
+            rule.Parent = meta.Parent
+            rule.Description = meta.Description
+
+            if meta.Slug != "" {
+                rule.Slug = meta.Slug
+            }
+            if meta.Title != "" {
+                rule.Title = meta.Title
+            }
+            if meta.Sort != 0 {
+                rule.Listorder = meta.Sort
             }
+        }
+
+        rules = append(rules, rule)
+    }
+
+    sort.SliceStable(rules, func(i, j int) bool {
+        if rules[i].Url == rules[j].Url {
+            return rules[i].Method < rules[j].Method
+        }
 
-            model.NewDB().Create(&insertData)
+        return rules[i].Url < rules[j].Url
+    })
+
+    return rules
+}
+
+// 生成同步计划
+func buildImportRoutePlan(rules []importRouteRule, existRules []model.AuthRule) importRoutePlan {
+    plan := importRoutePlan{}
+
+    exists := make(map[string]model.AuthRule)
+    groups := make(map[string]model.AuthRule)
+    for _, v := range existRules {
+        if isImportRouteGroup(v) {
+            groups[v.Title] = v
         } else {
-            model.NewAuthRule().
-                Where("url = ?", v.Path).
-                Where("method = ?", strings.ToUpper(v.Method)).
-                Updates(map[string]any{
-                    "title": v.Path,
-                })
+            exists[v.Method + " " + v.Url] = v
         }
+    }
 
+    slugs := make(map[string]importRouteRule)
+    for _, v := range rules {
+        slugs[v.Slug] = v
     }
 
-    fmt.Println("权限路由导入成功")
+    newGroups := make(map[string]bool)
+
+    routeKeys := make(map[string]bool)
+    for _, v := range rules {
+        routeKeys[v.Method + " " + v.Url] = true
+
+        // 父级为菜单分组时需要先创建
+        if v.Parent != "" {
+            if _, ok := slugs[v.Parent]; !ok {
+                if _, ok := groups[v.Parent]; !ok && !newGroups[v.Parent] {
+                    newGroups[v.Parent] = true
+                    plan.Groups = append(plan.Groups, v.Parent)
+                }
+            }
+        }
+
+        exist, ok := exists[v.Method + " " + v.Url]
+        if !ok {
+            plan.Creates = append(plan.Creates, v)
+            continue
+        }
+
+        // 之前被禁用的路由重新添加
+        if exist.Status == 0 {
+            plan.Enables = append(plan.Enables, exist)
+        }
+
+        if !v.HasMeta {
+            continue
+        }
+
+        parentid := resolveImportRouteParent(v.Parent, slugs, exists, groups)
+        if exist.Title != v.Title ||
+            exist.Slug != v.Slug ||
+            exist.Description != v.Description ||
+            exist.Listorder != v.Listorder ||
+            (v.Parent != "" && exist.Parentid != parentid) {
+            plan.Updates = append(plan.Updates, v)
+        }
+    }
+
+    for _, v := range existRules {
+        if isImportRouteGroup(v) || v.Status != 1 {
+            continue
+        }
+
+        if !routeKeys[v.Method + " " + v.Url] {
+            plan.Disables = append(plan.Disables, v)
+        }
+    }
+
+    return plan
 }
 
+// 输出差异
+func printImportRoutePlan(plan importRoutePlan, existRules []model.AuthRule) {
+    exists := make(map[string]model.AuthRule)
+    for _, v := range existRules {
+        exists[v.Method + " " + v.Url] = v
+    }
+
+    for _, v := range plan.Groups {
+        fmt.Printf("+ [菜单] %s\n", v)
+    }
+
+    for _, v := range plan.Creates {
+        fmt.Printf("+ %s %s [%s] %s\n", v.Method, v.Url, v.Slug, v.Title)
+    }
+
+    for _, v := range plan.Updates {
+        exist := exists[v.Method + " " + v.Url]
+
+        fmt.Printf("~ %s %s\n", v.Method, v.Url)
+
+        printImportRouteField("title", exist.Title, v.Title)
+        printImportRouteField("slug", exist.Slug, v.Slug)
+        printImportRouteField("description", exist.Description, v.Description)
+        printImportRouteField("listorder", fmt.Sprintf("%d", exist.Listorder), fmt.Sprintf("%d", v.Listorder))
+
+        if v.Parent != "" {
+            fmt.Printf("    parent: %s\n", v.Parent)
+        }
+    }
+
+    for _, v := range plan.Enables {
+        fmt.Printf("* %s %s [%s] %s\n", v.Method, v.Url, v.Slug, v.Title)
+    }
+
+    for _, v := range plan.Disables {
+        fmt.Printf("- %s %s [%s] %s\n", v.Method, v.Url, v.Slug, v.Title)
+    }
+
+    fmt.Printf(
+        "\n添加菜单: %d, 添加: %d, 更新: %d, 启用: %d, 禁用: %d\n\n",
+        len(plan.Groups), len(plan.Creates), len(plan.Updates), len(plan.Enables), len(plan.Disables),
+    )
+}
+
+// 输出字段差异
+func printImportRouteField(name string, old string, new string) {
+    if old != new {
+        fmt.Printf("    %s: %q => %q\n", name, old, new)
+    }
+}
+
+// 执行同步
+func applyImportRoutePlan(tx *gorm.DB, plan importRoutePlan, rules []importRouteRule) error {
+    addTime := int(datebin.NowTimestamp())
+
+    for _, title := range plan.Groups {
+        group := model.AuthRule{
+            Parentid: "0",
+            Title: title,
+            Url: "#",
+            Method: "OPTIONS",
+            Slug: "#",
+            Description: "",
+            Listorder: 100,
+            Status: 1,
+            AddTime: addTime,
+            AddIp: "127.0.0.1",
+        }
+
+        if err := tx.Create(&group).Error; err != nil {
+            return err
+        }
+    }
+
+    for _, v := range plan.Creates {
+        insertData := model.AuthRule{
+            Parentid: "0",
+            Title: v.Title,
+            Url: v.Url,
+            Method: v.Method,
+            Slug: v.Slug,
+            Description: v.Description,
+            Listorder: v.Listorder,
+            Status: 1,
+            AddTime: addTime,
+            AddIp: "127.0.0.1",
+        }
+
+        if err := tx.Create(&insertData).Error; err != nil {
+            return err
+        }
+    }
+
+    // 重新读取数据用于设置父级
+    var existRules []model.AuthRule
+    err := tx.Model(&model.AuthRule{}).
+        Find(&existRules).
+        Error
+    if err != nil {
+        return err
+    }
+
+    exists := make(map[string]model.AuthRule)
+    groups := make(map[string]model.AuthRule)
+    for _, v := range existRules {
+        if isImportRouteGroup(v) {
+            groups[v.Title] = v
+        } else {
+            exists[v.Method + " " + v.Url] = v
+        }
+    }
+
+    slugs := make(map[string]importRouteRule)
+    for _, v := range rules {
+        slugs[v.Slug] = v
+    }
+
+    changed := make(map[string]bool)
+    for _, v := range plan.Creates {
+        changed[v.Method + " " + v.Url] = true
+    }
+    for _, v := range plan.Updates {
+        changed[v.Method + " " + v.Url] = true
+    }
+
+    for _, v := range rules {
+        if !changed[v.Method + " " + v.Url] {
+            continue
+        }
+
+        data := map[string]any{
+            "title": v.Title,
+            "slug": v.Slug,
+            "description": v.Description,
+            "listorder": v.Listorder,
+            "update_time": addTime,
+            "update_ip": "127.0.0.1",
+        }
+
+        if v.Parent != "" {
+            if parentid := resolveImportRouteParent(v.Parent, slugs, exists, groups); parentid != "" {
+                data["parentid"] = parentid
+            }
+        }
+
+        err := tx.Model(&model.AuthRule{}).
+            Where("url = ?", v.Url).
+            Where("method = ?", v.Method).
+            Updates(data).
+            Error
+        if err != nil {
+            return err
+        }
+    }
+
+    for _, v := range plan.Enables {
+        err := tx.Model(&model.AuthRule{}).
+            Where("id = ?", v.ID).
+            Updates(map[string]any{
+                "status": 1,
+                "update_time": addTime,
+                "update_ip": "127.0.0.1",
+            }).
+            Error
+        if err != nil {
+            return err
+        }
+    }
+
+    for _, v := range plan.Disables {
+        err := tx.Model(&model.AuthRule{}).
+            Where("id = ?", v.ID).
+            Updates(map[string]any{
+                "status": 0,
+                "update_time": addTime,
+                "update_ip": "127.0.0.1",
+            }).
+            Error
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 获取父级 ID
+func resolveImportRouteParent(
+    parent string,
+    slugs map[string]importRouteRule,
+    exists map[string]model.AuthRule,
+    groups map[string]model.AuthRule,
+) string {
+    if parent == "" {
+        return "0"
+    }
+
+    if rule, ok := slugs[parent]; ok {
+        if exist, ok := exists[rule.Method + " " + rule.Url]; ok {
+            return exist.ID
+        }
+
+        return ""
+    }
+
+    if group, ok := groups[parent]; ok {
+        return group.ID
+    }
+
+    return ""
+}
+
+// 是否为菜单分组
+func isImportRouteGroup(rule model.AuthRule) bool {
+    return rule.Url == "#" && rule.Method == "OPTIONS"
+}
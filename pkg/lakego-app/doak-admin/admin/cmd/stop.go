@@ -1,8 +1,10 @@
 package cmd
 
 import (
+    "os"
     "strconv"
     "strings"
+    "syscall"
 
     cmdTool "github.com/deatil/go-cmd/cmd"
     "github.com/deatil/lakego-filesystem/filesystem"
@@ -16,10 +18,12 @@ import (
 /**
  * 停止 admin 系统服务
  *
- * > ./main lakego-admin:stop [--pid=12345]
+ * > ./main lakego-admin:stop [--pid=12345] [--force]
  * > main.exe lakego-admin:stop [--pid=12345]
  * > go run main.go lakego-admin:stop [--pid=12345]
  *
+ * 默认发送 SIGTERM 平滑关闭，--force 时强制结束进程
+ *
  * @create 2022-2-13
  * @author deatil
  */
@@ -34,9 +38,13 @@ var StopCmd = &command.Command{
 // 自定义 Pid
 var stopPid string
 
+// 强制结束
+var stopForce bool
+
 func init() {
     pf := StopCmd.Flags()
     pf.StringVarP(&stopPid, "pid", "p", "", "要停止的pid")
+    pf.BoolVarP(&stopForce, "force", "f", false, "强制结束进程")
 }
 
 // 停止 admin 系统服务
@@ -61,22 +69,38 @@ func Stop() {
             return
         }
 
+        // 平滑关闭时只通知服务进程，服务进程为最后一个
+        if !stopForce {
+            pids = pids[len(pids)-1:]
+        }
+
         for _, pid := range pids {
             id, err2 := strconv.Atoi(pid)
             if err2 == nil {
-                _, err3 := cmdTool.New().Kill(id)
-                if err3 != nil {
-                    color.Redln(err3.Error())
-                }
+                stopProcess(id)
             }
         }
     } else {
         id, err2 := strconv.Atoi(stopPid)
         if err2 == nil {
-            _, err3 := cmdTool.New().Kill(id)
-            if err3 != nil {
-                color.Redln(err3.Error())
+            stopProcess(id)
+        }
+    }
+}
+
+// 停止进程，不支持信号时强制结束
+func stopProcess(pid int) {
+    if !stopForce {
+        proc, err := os.FindProcess(pid)
+        if err == nil {
+            if err = proc.Signal(syscall.SIGTERM); err == nil {
+                return
             }
         }
     }
+
+    _, err := cmdTool.New().Kill(pid)
+    if err != nil {
+        color.Redln(err.Error())
+    }
 }
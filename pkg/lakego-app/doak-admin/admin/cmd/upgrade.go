@@ -0,0 +1,105 @@
+package cmd
+
+import (
+    "os"
+    "fmt"
+    "errors"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-admin/admin/model"
+)
+
+/**
+ * 升级数据库，已安装的系统更新代码后执行
+ *
+ * > ./main lakego-admin:upgrade
+ * > main.exe lakego-admin:upgrade
+ * > go run main.go lakego-admin:upgrade
+ *
+ * @create 2024-6-3
+ * @author deatil
+ */
+var UpgradeCmd = &command.Command{
+    Use: "lakego-admin:upgrade",
+    Short: "Upgrade the lakego-admin database.",
+    Example: "{execfile} lakego-admin:upgrade",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        // 运行升级
+        runUpgrade()
+    },
+}
+
+// 运行升级
+func runUpgrade() {
+    fmt.Println("开始升级数据库...")
+
+    failed, err := execSqlFile("{root}/resources/database/lakego_admin_upgrade.sql")
+    if err != nil {
+        fmt.Println(err.Error())
+        os.Exit(1)
+    }
+
+    if failed > 0 {
+        fmt.Println(fmt.Sprintf("\n升级完成，%d 条语句执行失败。", failed))
+        os.Exit(1)
+    }
+
+    fmt.Println("\n数据库升级成功。")
+}
+
+// 执行 sql 文件，返回失败的语句数量
+func execSqlFile(file string) (int, error) {
+    sqlFile := path.FormatPath(file)
+
+    sqls, err := os.ReadFile(sqlFile)
+    if err != nil {
+        return 0, errors.New(fmt.Sprintf("数据库文件 [%s] 不存在！", file))
+    }
+
+    prefix := model.GetConfig("prefix").(string)
+
+    db := model.NewDB()
+
+    failed := 0
+    for _, sql := range strings.Split(string(sqls), ";") {
+        sql = trimSql(sql)
+        if len(sql) == 0 {
+            continue
+        }
+
+        // 替换前缀
+        sql = strings.ReplaceAll(sql, "pre__", prefix)
+
+        err := db.Exec(sql).Error
+        if err == nil {
+            fmt.Println(sql, "\t 执行成功！")
+        } else {
+            failed++
+            fmt.Println(sql, err, "\t 执行失败！")
+        }
+    }
+
+    return failed, nil
+}
+
+// 去掉注释及空白
+func trimSql(sql string) string {
+    lines := make([]string, 0)
+    for _, line := range strings.Split(sql, "\n") {
+        line = strings.TrimSpace(line)
+        if line == "" || strings.HasPrefix(line, "--") {
+            continue
+        }
+
+        lines = append(lines, line)
+    }
+
+    return strings.Join(lines, "\n")
+}
@@ -383,6 +383,7 @@ func (this *Admin) Create(ctx *router.Context) {
     }
 
     err2 := model.NewDB().
+        WithContext(ctx).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -475,6 +476,7 @@ func (this *Admin) Update(ctx *router.Context) {
     }
 
     err3 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -543,6 +545,7 @@ func (this *Admin) Delete(ctx *router.Context) {
 
     // 删除
     err2 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Delete(&model.Admin{
             ID: id,
@@ -607,6 +610,7 @@ func (this *Admin) UpdateAvatar(ctx *router.Context) {
     }
 
     err3 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -675,6 +679,7 @@ func (this *Admin) UpdatePasssword(ctx *router.Context) {
     pass, encrypt := auth_password.MakePassword(password)
 
     err3 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -739,6 +744,7 @@ func (this *Admin) Enable(ctx *router.Context) {
     }
 
     err2 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -802,6 +808,7 @@ func (this *Admin) Disable(ctx *router.Context) {
     }
 
     err2 := model.NewAdmin().
+        WithContext(ctx).
         Scopes(scope.AdminWithAccess(ctx, gadb)).
         Where("id = ?", id).
         Updates(map[string]any{
@@ -869,6 +876,7 @@ func (this *Admin) Logout(ctx *router.Context) {
     c.Put(utils.MD5(refreshToken), "no", int64(refreshTokenExpiresIn))
 
     model.NewAdmin().
+        WithContext(ctx).
         Where("id = ?", refreshAdminid).
         Updates(map[string]any{
             "refresh_time": int(datebin.NowTimestamp()),
@@ -199,6 +199,7 @@ func (this *Attachment) Delete(ctx *router.Context) {
 
     // 附件模型
     err2 := model.NewAttachment().
+        WithContext(ctx).
         Delete(&model.Attachment{
             ID: id,
         }).
@@ -252,6 +253,7 @@ func (this *Attachment) Enable(ctx *router.Context) {
     }
 
     err2 := model.NewAttachment().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -302,6 +304,7 @@ func (this *Attachment) Disable(ctx *router.Context) {
     }
 
     err2 := model.NewAttachment().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -295,6 +295,7 @@ func (this *AuthGroup) Create(ctx *router.Context) {
     }
 
     err2 := model.NewDB().
+        WithContext(ctx).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -361,6 +362,7 @@ func (this *AuthGroup) Update(ctx *router.Context) {
     }
 
     err3 := model.NewAuthGroup().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "parentid": post["parentid"].(string),
@@ -422,6 +424,7 @@ func (this *AuthGroup) Delete(ctx *router.Context) {
 
     // 删除
     err3 := model.NewAuthGroup().
+        WithContext(ctx).
         Delete(&model.AuthGroup{
             ID: id,
         }).
@@ -477,6 +480,7 @@ func (this *AuthGroup) Listorder(ctx *router.Context) {
     }
 
     err2 := model.NewAuthGroup().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "listorder": listorder,
@@ -529,6 +533,7 @@ func (this *AuthGroup) Enable(ctx *router.Context) {
     }
 
     err2 := model.NewAuthGroup().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -581,6 +586,7 @@ func (this *AuthGroup) Disable(ctx *router.Context) {
     }
 
     err2 := model.NewAuthGroup().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -289,6 +289,7 @@ func (this *AuthRule) Create(ctx *router.Context) {
     }
 
     err2 := model.NewDB().
+        WithContext(ctx).
         Create(&insertData).
         Error
     if err2 != nil {
@@ -361,6 +362,7 @@ func (this *AuthRule) Update(ctx *router.Context) {
     }
 
     err3 := model.NewAuthRule().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "parentid": post["parentid"].(string),
@@ -425,6 +427,7 @@ func (this *AuthRule) Delete(ctx *router.Context) {
 
     // 删除
     err3 := model.NewAuthRule().
+        WithContext(ctx).
         Delete(&model.AuthRule{
             ID: id,
         }).
@@ -480,6 +483,7 @@ func (this *AuthRule) Listorder(ctx *router.Context) {
     }
 
     err2 := model.NewAuthRule().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "listorder": listorder,
@@ -532,6 +536,7 @@ func (this *AuthRule) Enable(ctx *router.Context) {
     }
 
     err2 := model.NewAuthRule().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 1,
@@ -584,6 +589,7 @@ func (this *AuthRule) Disable(ctx *router.Context) {
     }
 
     err2 := model.NewAuthRule().
+        WithContext(ctx).
         Where("id = ?", id).
         Updates(map[string]any{
             "status": 0,
@@ -643,6 +649,7 @@ func (this *AuthRule) Clear(ctx *router.Context) {
 
         // 删除
         err3 := model.NewAuthRule().
+            WithContext(ctx).
             Delete(&model.AuthRule{
                 ID: id,
             }).
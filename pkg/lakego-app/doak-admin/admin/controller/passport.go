@@ -5,6 +5,8 @@ import (
 
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/captcha"
+    facade_captcha "github.com/deatil/lakego-doak/lakego/facade/captcha"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
     "github.com/deatil/lakego-doak-admin/admin/auth/auth"
@@ -26,26 +28,40 @@ type Passport struct {
 
 // 验证码
 // @Summary 登陆验证码
-// @Description 登陆验证码
+// @Description 登陆验证码，type 可选 image, arithmetic, audio, slider, click 等，为空时使用默认方式
 // @Tags 登陆相关
 // @Accept application/json
 // @Produce application/json
+// @Param type query string false "验证方式"
+// @Param name query string false "账号，用于判断是否需要验证码"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Header 200 {string} string "Lakego-Admin-Captcha-Id"
 // @Router /passport/captcha [get]
 // @x-lakego {"slug": "lakego-admin.passport.captcha"}
 func (this *Passport) Captcha(ctx *router.Context) {
-    id, b64s, err := facade.Captcha.Make()
+    challenge, err := facade_captcha.Challenges.Generate(ctx.Query("type"))
     if err != nil {
-        this.Error(ctx, "error", code.StatusError)
+        this.Error(ctx, "验证码生成失败", code.StatusError)
+        return
     }
 
     key := facade.Config("auth").GetString("passport.header-captcha-key")
 
-    this.SetHeader(ctx, key, id)
-    this.SuccessWithData(ctx, "获取成功", router.H{
-        "captcha": b64s,
-    })
+    data := router.H{
+        "type": challenge.Type,
+        "required": this.captchaRequired(ctx, ctx.Query("name")),
+    }
+    for k, v := range challenge.Data {
+        data[k] = v
+    }
+
+    // 兼容图片验证码
+    if image, ok := challenge.Data["image"]; ok {
+        data["captcha"] = image
+    }
+
+    this.SetHeader(ctx, key, challenge.Id)
+    this.SuccessWithData(ctx, "获取成功", data)
 }
 
 // 账号登陆
@@ -54,10 +70,10 @@ func (this *Passport) Captcha(ctx *router.Context) {
 // @Tags 登陆相关
 // @Accept application/json
 // @Produce application/json
-// @Param Lakego-Admin-Captcha-Id header string true "验证码字段"
+// @Param Lakego-Admin-Captcha-Id header string false "验证码字段"
 // @Param name formData string true "账号"
 // @Param password formData string true "密码"
-// @Param captcha formData string true "验证码"
+// @Param captcha formData string false "验证码，需要验证码时必填"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /passport/login [post]
 // @x-lakego {"slug": "lakego-admin.passport.login"}
@@ -74,16 +90,26 @@ func (this *Passport) Login(ctx *router.Context) {
 
     name := post["name"].(string)
     password := post["password"].(string)
-    captchaCode := post["captcha"].(string)
+    captchaCode, _ := post["captcha"].(string)
 
-    // 验证码检测
-    key := facade.Config("auth").GetString("passport.header-captcha-key")
-    captchaId := ctx.GetHeader(key)
+    // 登录失败记录
+    failKeys := captcha.AdaptiveKeys(router.GetRequestIp(ctx), name)
 
-    ok := facade.Captcha.Verify(captchaId, captchaCode, true)
-    if !ok {
-        this.Error(ctx, "验证码错误", code.LoginError)
-        return
+    // 验证码检测
+    if facade_captcha.Adaptive.Required(failKeys...) {
+        if validateErr := passport_validate.LoginCaptcha(post); validateErr != "" {
+            this.Error(ctx, validateErr, code.LoginError)
+            return
+        }
+
+        key := facade.Config("auth").GetString("passport.header-captcha-key")
+        captchaId := ctx.GetHeader(key)
+
+        ok, _ := facade_captcha.Challenges.Verify(router.TraceContext(ctx), captchaId, captchaCode, router.GetRequestIp(ctx))
+        if !ok {
+            this.loginError(ctx, "验证码错误", failKeys)
+            return
+        }
     }
 
     // 用户信息
@@ -93,17 +119,20 @@ func (this *Passport) Login(ctx *router.Context) {
         First(&admin).
         Error
     if err != nil {
-        this.Error(ctx, "账号或者密码错误", code.LoginError)
+        this.loginError(ctx, "账号或者密码错误", failKeys)
         return
     }
 
     // 验证密码
     checkStatus := auth_password.CheckPassword(admin["password"].(string), password, admin["password_salt"].(string))
     if !checkStatus {
-        this.Error(ctx, "账号或者密码错误", code.LoginError)
+        this.loginError(ctx, "账号或者密码错误", failKeys)
         return
     }
 
+    // 登录成功清除失败记录
+    facade_captcha.Adaptive.Reset(failKeys...)
+
     // 生成 token
     aud := auth.GetJwtAud(ctx)
     jwter := auth.NewWithAud(aud)
@@ -139,6 +168,7 @@ func (this *Passport) Login(ctx *router.Context) {
 
     // 更新登录时间
     model.NewAdmin().
+        WithContext(ctx).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "last_active": int(datebin.NowTimestamp()),
@@ -287,3 +317,17 @@ func (this *Passport) Logout(ctx *router.Context) {
     // 数据输出
     this.Success(ctx, "退出成功")
 }
+
+// 是否需要验证码
+func (this *Passport) captchaRequired(ctx *router.Context, name string) bool {
+    return facade_captcha.Adaptive.Required(captcha.AdaptiveKeys(router.GetRequestIp(ctx), name)...)
+}
+
+// 登录失败，返回是否需要验证码
+func (this *Passport) loginError(ctx *router.Context, msg string, failKeys []string) {
+    facade_captcha.Adaptive.Fail(failKeys...)
+
+    this.ErrorWithData(ctx, msg, code.LoginError, router.H{
+        "captcha_required": facade_captcha.Adaptive.Required(failKeys...),
+    })
+}
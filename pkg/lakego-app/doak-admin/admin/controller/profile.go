@@ -4,8 +4,10 @@ import (
     "github.com/deatil/go-event/event"
     
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
 
     "github.com/deatil/lakego-doak-admin/admin/model"
+    admin_event "github.com/deatil/lakego-doak-admin/admin/event"
     "github.com/deatil/lakego-doak-admin/admin/auth/admin"
     auth_password "github.com/deatil/lakego-doak-admin/admin/password"
     profile_validate "github.com/deatil/lakego-doak-admin/admin/validate/profile"
@@ -78,6 +80,7 @@ func (this *Profile) Update(ctx *router.Context) {
     adminid := adminInfo.(*admin.Admin).GetId()
 
     err := model.NewAdmin().
+        WithContext(ctx).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "nickname": post["nickname"].(string),
@@ -92,6 +95,9 @@ func (this *Profile) Update(ctx *router.Context) {
 
     // 事件
     event.Dispatch("profile.update-after", adminid)
+    this.emit(event.Emit(ctx, admin_event.ProfileUpdated{
+        AdminId: adminid,
+    }))
 
     this.Success(ctx, "修改信息成功")
 }
@@ -129,6 +135,7 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
     adminid := adminInfo.(*admin.Admin).GetId()
 
     err := model.NewAdmin().
+        WithContext(ctx).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "avatar": post["avatar"].(string),
@@ -141,6 +148,10 @@ func (this *Profile) UpdateAvatar(ctx *router.Context) {
 
     // 事件
     event.Dispatch("profile.update-avatar-after", adminid)
+    this.emit(event.Emit(ctx, admin_event.ProfileAvatarUpdated{
+        AdminId: adminid,
+        Avatar: post["avatar"].(string),
+    }))
 
     this.Success(ctx, "修改头像成功")
 }
@@ -203,6 +214,7 @@ func (this *Profile) UpdatePasssword(ctx *router.Context) {
     pass, encrypt := auth_password.MakePassword(newpassword)
 
     err := model.NewAdmin().
+        WithContext(ctx).
         Where("id = ?", adminid).
         Updates(map[string]any{
             "password": pass,
@@ -216,6 +228,9 @@ func (this *Profile) UpdatePasssword(ctx *router.Context) {
 
     // 事件
     event.Dispatch("profile.update-passsword-after", adminid)
+    this.emit(event.Emit(ctx, admin_event.ProfilePasswordUpdated{
+        AdminId: adminid,
+    }))
 
     this.Success(ctx, "密码修改成功")
 }
@@ -243,3 +258,10 @@ func (this *Profile) Rules(ctx *router.Context) {
         "list": rules,
     })
 }
+
+// 记录事件监听错误
+func (this *Profile) emit(err error) {
+    if err != nil {
+        facade.Logger.Error("[profile]" + err.Error())
+    }
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+    "context"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+    "github.com/deatil/lakego-doak/lakego/facade/queue"
+
+    scheduleSupport "github.com/deatil/lakego-doak-admin/admin/support/schedule"
+)
+
+/**
+ * 计划任务
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type Schedule struct {
+    Base
+}
+
+// 计划任务列表
+// @Summary 计划任务列表
+// @Description 计划任务列表，包括下次执行时间
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.index"}
+func (this *Schedule) Index(ctx *router.Context) {
+    s := scheduleSupport.Get()
+    if s == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    list := s.EntryInfos()
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "total": len(list),
+        "list": list,
+    })
+}
+
+// 计划任务执行记录
+// @Summary 计划任务执行记录
+// @Description 计划任务执行记录
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name  query string false "任务名称"
+// @Param start query string false "开始数据量"
+// @Param limit query string false "每页数量"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/history [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.history"}
+func (this *Schedule) History(ctx *router.Context) {
+    s := scheduleSupport.Get()
+    if s == nil || s.GetHistory() == nil {
+        this.Error(ctx, "执行记录未启用")
+        return
+    }
+
+    name := ctx.DefaultQuery("name", "")
+
+    // 分页相关
+    start := ctx.DefaultQuery("start", "0")
+    limit := ctx.DefaultQuery("limit", "10")
+
+    list, total, err := s.GetHistory().List(name, goch.ToInt(start), goch.ToInt(limit))
+    if err != nil {
+        this.Error(ctx, "获取失败")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "start": start,
+        "limit": limit,
+        "total": total,
+        "list": list,
+    })
+}
+
+// 计划任务暂停
+// @Summary 计划任务暂停
+// @Description 计划任务暂停，schedule:work 在每次执行前检查暂停状态
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name path string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/{name}/pause [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.pause"}
+func (this *Schedule) Pause(ctx *router.Context) {
+    s := scheduleSupport.Get()
+    if s == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    if err := s.Pause(ctx.Param("name")); err != nil {
+        if err == schedule.ErrEntryNotFound {
+            this.Error(ctx, "计划任务不存在")
+        } else {
+            this.Error(ctx, "计划任务暂停失败")
+        }
+
+        return
+    }
+
+    this.Success(ctx, "计划任务暂停成功")
+}
+
+// 计划任务恢复
+// @Summary 计划任务恢复
+// @Description 计划任务恢复
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name path string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/{name}/resume [patch]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.resume"}
+func (this *Schedule) Resume(ctx *router.Context) {
+    s := scheduleSupport.Get()
+    if s == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    name := ctx.Param("name")
+    if !s.IsPaused(name) {
+        this.Error(ctx, "计划任务未暂停")
+        return
+    }
+
+    if err := s.Resume(name); err != nil {
+        this.Error(ctx, "计划任务恢复失败")
+        return
+    }
+
+    this.Success(ctx, "计划任务恢复成功")
+}
+
+// 计划任务立即执行
+// @Summary 计划任务立即执行
+// @Description 计划任务立即执行，推送到队列由工作进程执行，不等待执行完成
+// @Tags 计划任务
+// @Accept  application/json
+// @Produce application/json
+// @Param name path string true "任务名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /schedule/{name}/trigger [post]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.schedule.trigger"}
+func (this *Schedule) Trigger(ctx *router.Context) {
+    s := scheduleSupport.Get()
+    if s == nil {
+        this.Error(ctx, "计划任务未启用")
+        return
+    }
+
+    name := ctx.Param("name")
+    if !s.HasEntry(name) {
+        this.Error(ctx, "计划任务不存在")
+        return
+    }
+
+    // 不在请求中执行，任务不随请求取消
+    _, err := queue.New().Push(context.Background(), &scheduleSupport.TriggerJob{Entry: name})
+    if err != nil {
+        this.Error(ctx, "计划任务触发失败")
+        return
+    }
+
+    this.Success(ctx, "计划任务已触发")
+}
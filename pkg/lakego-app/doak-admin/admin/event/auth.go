@@ -0,0 +1,22 @@
+package event
+
+/**
+ * 权限事件，权限菜单及分组数据修改后触发
+ *
+ * event.On(func(ctx context.Context, e admin_event.AuthRuleChanged) error {
+ *     return nil
+ * })
+ *
+ * @create 2024-5-31
+ * @author deatil
+ */
+
+// 权限菜单修改后
+type AuthRuleChanged struct {
+    RuleId string
+}
+
+// 权限分组及授权修改后
+type AuthGroupChanged struct {
+    GroupId string
+}
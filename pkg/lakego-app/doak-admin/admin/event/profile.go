@@ -0,0 +1,28 @@
+package event
+
+/**
+ * 个人信息事件
+ *
+ * event.On(func(ctx context.Context, e admin_event.ProfileUpdated) error {
+ *     return nil
+ * })
+ *
+ * @create 2024-5-14
+ * @author deatil
+ */
+
+// 修改信息后
+type ProfileUpdated struct {
+    AdminId string
+}
+
+// 修改头像后
+type ProfileAvatarUpdated struct {
+    AdminId string
+    Avatar  string
+}
+
+// 修改密码后
+type ProfilePasswordUpdated struct {
+    AdminId string
+}
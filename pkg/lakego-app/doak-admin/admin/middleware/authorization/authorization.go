@@ -4,6 +4,7 @@ import (
     "strings"
 
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
     "github.com/deatil/lakego-doak/lakego/facade/config"
 
     "github.com/deatil/lakego-doak-admin/admin/auth/auth"
@@ -96,6 +97,9 @@ func jwtCheck(ctx *router.Context) bool {
     ctx.Set("access_token", accessToken)
     ctx.Set("admin", adminer)
 
+    // 日志上下文
+    ctx.Request = ctx.Request.WithContext(logger.ContextWithAdminID(ctx.Request.Context(), userId))
+
     return true
 }
 
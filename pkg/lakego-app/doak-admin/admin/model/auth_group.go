@@ -1,9 +1,14 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
+    "github.com/deatil/go-event/event"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
+
+    admin_event "github.com/deatil/lakego-doak-admin/admin/event"
 )
 
 // 权限分组
@@ -32,6 +37,28 @@ func (this *AuthGroup) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
+// 保存后
+func (this *AuthGroup) AfterSave(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthGroupChanged{
+            GroupId: this.ID,
+        })
+    })
+
+    return nil
+}
+
+// 删除后
+func (this *AuthGroup) AfterDelete(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthGroupChanged{
+            GroupId: this.ID,
+        })
+    })
+
+    return nil
+}
+
 func NewAuthGroup() *gorm.DB {
     return NewDB().Model(&AuthGroup{})
 }
@@ -1,7 +1,12 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
+    "github.com/deatil/go-event/event"
+
+    admin_event "github.com/deatil/lakego-doak-admin/admin/event"
 )
 
 // 管理员管理分组
@@ -13,6 +18,28 @@ type AuthGroupAccess struct {
     Group AuthGroup `gorm:"foreignKey:ID;references:GroupId"`
 }
 
+// 保存后
+func (this *AuthGroupAccess) AfterSave(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthGroupChanged{
+            GroupId: this.GroupId,
+        })
+    })
+
+    return nil
+}
+
+// 删除后
+func (this *AuthGroupAccess) AfterDelete(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthGroupChanged{
+            GroupId: this.GroupId,
+        })
+    })
+
+    return nil
+}
+
 func NewAuthGroupAccess() *gorm.DB {
     return NewDB().Model(&AuthGroupAccess{})
 }
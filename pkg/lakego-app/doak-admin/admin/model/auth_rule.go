@@ -1,9 +1,14 @@
 package model
 
 import (
+    "context"
+
     "gorm.io/gorm"
+    "github.com/deatil/go-event/event"
 
     "github.com/deatil/lakego-doak/lakego/uuid"
+
+    admin_event "github.com/deatil/lakego-doak-admin/admin/event"
 )
 
 // 菜单权限
@@ -31,6 +36,28 @@ func (this *AuthRule) BeforeCreate(tx *gorm.DB) error {
     return nil
 }
 
+// 保存后
+func (this *AuthRule) AfterSave(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthRuleChanged{
+            RuleId: this.ID,
+        })
+    })
+
+    return nil
+}
+
+// 删除后
+func (this *AuthRule) AfterDelete(tx *gorm.DB) error {
+    emitAfterCommit(tx, func(ctx context.Context) {
+        event.Emit(ctx, admin_event.AuthRuleChanged{
+            RuleId: this.ID,
+        })
+    })
+
+    return nil
+}
+
 func NewAuthRule() *gorm.DB {
     return NewDB().Model(&AuthRule{})
 }
@@ -0,0 +1,67 @@
+package model
+
+import (
+    "sync"
+    "context"
+
+    "gorm.io/gorm"
+)
+
+// 事务中待触发的事件
+type pendingEvents struct {
+    mu     sync.Mutex
+    events []func(context.Context)
+}
+
+func (this *pendingEvents) add(fn func(context.Context)) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.events = append(this.events, fn)
+}
+
+func (this *pendingEvents) emit(ctx context.Context) {
+    this.mu.Lock()
+    events := this.events
+    this.events = nil
+    this.mu.Unlock()
+
+    for _, fn := range events {
+        fn(ctx)
+    }
+}
+
+type pendingEventsKey struct{}
+
+// 事务，事务中模型修改触发的事件在提交后触发，回滚时丢弃
+func Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+    // 嵌套时由最外层事务触发
+    if _, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents); ok {
+        return NewDB().WithContext(ctx).Transaction(fn)
+    }
+
+    pending := &pendingEvents{}
+
+    err := NewDB().
+        WithContext(context.WithValue(ctx, pendingEventsKey{}, pending)).
+        Transaction(fn)
+    if err != nil {
+        return err
+    }
+
+    pending.emit(ctx)
+
+    return nil
+}
+
+// 模型事件，在 Transaction 中时提交后触发，否则直接触发
+func emitAfterCommit(tx *gorm.DB, fn func(context.Context)) {
+    ctx := tx.Statement.Context
+
+    if pending, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents); ok {
+        pending.add(fn)
+        return
+    }
+
+    fn(ctx)
+}
@@ -3,18 +3,26 @@ package provider
 import (
     "os"
     "fmt"
+    "context"
 
+    "github.com/deatil/go-event/event"
     "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-filesystem/filesystem"
+    "github.com/deatil/lakego-doak/lakego/app"
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/facade/queue"
     "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/httpcache"
     pathTool "github.com/deatil/lakego-doak/lakego/path"
 
     "github.com/deatil/lakego-doak-admin/admin/support/url"
     "github.com/deatil/lakego-doak-admin/admin/support/time"
+    "github.com/deatil/lakego-doak-admin/admin/support/schedule"
     "github.com/deatil/lakego-doak-admin/admin/support/response"
     "github.com/deatil/lakego-doak-admin/admin/support/http/code"
+    admin_event "github.com/deatil/lakego-doak-admin/admin/event"
 
     // 中间件
     "github.com/deatil/lakego-doak-admin/admin/middleware/recovery"
@@ -51,12 +59,19 @@ var routeMiddlewares = map[string]router.HandlerFunc{
     "lakego-admin.admin-check": admincheck.Handler(),
 }
 
+// 响应缓存中间件，在 httpcache 配置中开启
+// 需要在全部权限检测之后使用，缓存命中时不再执行后续中间件
+const responseCacheMiddleware = "lakego.response-cache"
+
 // 中间件分组
 var middlewareGroups = map[string][]string{
     // 常规中间件
     "lakego-admin": {
         "lakego-admin.auth",
         "lakego-admin.permission",
+
+        // 请求限流，在 ratelimit 配置中开启
+        "lakego.ratelimit",
     },
 
     // 超级管理员检测
@@ -83,9 +98,21 @@ func (this *Admin) Boot() {
     // 脚本
     this.loadCommand()
 
+    // 计划任务管理
+    schedule.Set(this.GetApp().GetSchedule())
+
+    // 计划任务手动执行队列任务
+    queue.Register(&schedule.TriggerJob{})
+
     // 路由
     this.loadRoute()
 
+    // 事件
+    this.loadEvent()
+
+    // 接口文档
+    this.loadOpenAPI()
+
     // 推送配置
     this.publishConfig()
 
@@ -112,6 +139,9 @@ func (this *Admin) loadCommand() {
     // 安装
     this.AddCommand(cmd.InstallCmd)
 
+    // 升级数据库
+    this.AddCommand(cmd.UpgradeCmd)
+
     // 重设权限
     this.AddCommand(cmd.ResetPermissionCmd)
 
@@ -167,18 +197,37 @@ func (this *Admin) loadRoute() {
         admin := router.Groups(engine, conf.GetString("route.prefix"), conf.GetString("route.middleware"))
         {
             // 常规路由
-            adminRoute.Route(admin)
+            adminRoute.Route(router.Groups(admin, "", responseCacheMiddleware))
 
             // 需要管理员权限
             router.Use(admin, conf.GetString("route.admin-middleware"))
             {
-                adminRoute.AdminRoute(admin)
+                adminRoute.AdminRoute(router.Groups(admin, "", responseCacheMiddleware))
             }
         }
 
     })
 }
 
+/**
+ * 导入事件
+ */
+func (this *Admin) loadEvent() {
+    if !config.New("httpcache").GetBool("enable") {
+        return
+    }
+
+    // 权限菜单修改后清除响应缓存
+    event.On(func(ctx context.Context, e admin_event.AuthRuleChanged) error {
+        return flushResponseCache(ctx, "auth-rule")
+    })
+
+    // 权限分组及授权修改后清除响应缓存
+    event.On(func(ctx context.Context, e admin_event.AuthGroupChanged) error {
+        return flushResponseCache(ctx, "auth-group")
+    })
+}
+
 /**
  * 导入中间件
  */
@@ -223,5 +272,20 @@ func (this *Admin) putSock() {
 
     contents := fmt.Sprintf("%d,%d", os.Getppid(), os.Getpid())
 
+    // 平滑重启的进程，父进程为旧的服务进程
+    if app.Inherited() {
+        contents = fmt.Sprintf("%d", os.Getpid())
+    }
+
     filesystem.New().Put(file, contents)
 }
+
+// 清除响应缓存
+func flushResponseCache(ctx context.Context, tags ...string) error {
+    err := httpcache.Flush(ctx, tags...)
+    if err != nil {
+        logger.New().WithContext(ctx).Error("[httpcache]" + err.Error())
+    }
+
+    return err
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/openapi"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    "github.com/deatil/lakego-doak-admin/admin/support/except"
+    "github.com/deatil/lakego-doak-admin/admin/support/response"
+
+    adminValidate "github.com/deatil/lakego-doak-admin/admin/validate/admin"
+    profileValidate "github.com/deatil/lakego-doak-admin/admin/validate/profile"
+    passportValidate "github.com/deatil/lakego-doak-admin/admin/validate/passport"
+    authRuleValidate "github.com/deatil/lakego-doak-admin/admin/validate/authrule"
+    authGroupValidate "github.com/deatil/lakego-doak-admin/admin/validate/authgroup"
+)
+
+/**
+ * 接口文档设置
+ *
+ * 后台路由默认使用 Bearer 鉴权，登陆过滤的路由不需要鉴权，
+ * 请求数据使用验证器的规则生成
+ */
+func (this *Admin) loadOpenAPI() {
+    prefix := "/" + config.New("admin").GetString("route.prefix")
+
+    openapi.Default().
+        WithInfo(openapi.Info{
+            Title: "lakego-admin",
+            Description: "lakego-admin 后台接口",
+            Version: "1.0.0",
+        }).
+        AddSecurityScheme("Bearer", openapi.BearerScheme("JWT")).
+        WithSecurity(func(method string, path string) []string {
+            if !strings.HasPrefix(path, prefix + "/") {
+                return nil
+            }
+
+            if isAuthenticateExcept(prefix, method, path) {
+                return nil
+            }
+
+            return []string{"Bearer"}
+        }).
+        WithEnvelope(openapi.SchemaOf(response.JSONResult{}), "data")
+
+    // 请求数据
+    openapi.SetRequestRules("lakego-admin.passport.login", passportValidate.LoginRules, passportValidate.LoginMessages)
+    openapi.SetRequestRules("lakego-admin.profile.update", profileValidate.UpdateRules, profileValidate.UpdateMessages)
+    openapi.SetRequestRules("lakego-admin.profile.avatar", profileValidate.UpdateAvatarRules, profileValidate.UpdateAvatarMessages)
+    openapi.SetRequestRules("lakego-admin.profile.password", profileValidate.UpdatePassswordRules, profileValidate.UpdatePassswordMessages)
+    openapi.SetRequestRules("lakego-admin.admin.create", adminValidate.CreateRules, adminValidate.CreateMessages)
+    openapi.SetRequestRules("lakego-admin.admin.update", adminValidate.UpdateRules, adminValidate.UpdateMessages)
+    openapi.SetRequestRules("lakego-admin.admin.avatar", adminValidate.UpdateAvatarRules, adminValidate.UpdateAvatarMessages)
+    openapi.SetRequestRules("lakego-admin.auth-rule.create", authRuleValidate.CreateRules, authRuleValidate.CreateMessages)
+    openapi.SetRequestRules("lakego-admin.auth-rule.update", authRuleValidate.UpdateRules, authRuleValidate.UpdateMessages)
+    openapi.SetRequestRules("lakego-admin.auth-group.create", authGroupValidate.CreateRules, authGroupValidate.CreateMessages)
+    openapi.SetRequestRules("lakego-admin.auth-group.update", authGroupValidate.UpdateRules, authGroupValidate.UpdateMessages)
+}
+
+// 是否为登陆过滤路由，格式为 GET:passport/login，支持 * 结尾
+func isAuthenticateExcept(prefix string, method string, path string) bool {
+    excepts := config.New("auth").GetStringSlice("auth.authenticate-excepts")
+    excepts = append(excepts, except.GetAuthenticateExcepts()...)
+
+    for _, item := range excepts {
+        m, p, ok := strings.Cut(item, ":")
+        if !ok || !strings.EqualFold(m, method) {
+            continue
+        }
+
+        p = prefix + "/" + strings.TrimPrefix(p, "/")
+        if strings.HasSuffix(p, "*") {
+            if strings.HasPrefix(path, strings.TrimSuffix(p, "*")) {
+                return true
+            }
+        } else if p == path {
+            return true
+        }
+    }
+
+    return false
+}
@@ -9,88 +9,292 @@ import (
 /**
  * 后台路由
  */
-func Route(engine router.IRouter) {
+func Route(group router.IRouter) {
+    engine := router.WithMeta(group)
+
     // 登陆
     passportController := new(controller.Passport)
-    engine.GET("/passport/captcha", passportController.Captcha)
-    engine.POST("/passport/login", passportController.Login)
-    engine.PUT("/passport/refresh-token", passportController.RefreshToken)
-    engine.DELETE("/passport/logout", passportController.Logout)
+    engine.GET("/passport/captcha", passportController.Captcha).
+        Slug("lakego-admin.passport.captcha").
+        Title("登陆验证码").
+        Parent("登陆相关").
+        Security()
+    engine.POST("/passport/login", passportController.Login).
+        Slug("lakego-admin.passport.login").
+        Title("账号登陆").
+        Parent("登陆相关").
+        Security()
+    engine.PUT("/passport/refresh-token", passportController.RefreshToken).
+        Slug("lakego-admin.passport.refresh-token").
+        Title("刷新 token").
+        Parent("登陆相关").
+        Security()
+    engine.DELETE("/passport/logout", passportController.Logout).
+        Slug("lakego-admin.passport.logout").
+        Title("当前账号退出").
+        Parent("登陆相关")
 
     // 个人信息
     profileController := new(controller.Profile)
-    engine.GET("/profile", profileController.Index)
-    engine.PUT("/profile", profileController.Update)
-    engine.PATCH("/profile/avatar", profileController.UpdateAvatar)
-    engine.PATCH("/profile/password", profileController.UpdatePasssword)
-    engine.GET("/profile/rules", profileController.Rules)
+    engine.GET("/profile", profileController.Index).
+        Slug("lakego-admin.profile.index").
+        Title("个人信息详情").
+        Parent("个人信息")
+    engine.PUT("/profile", profileController.Update).
+        Slug("lakego-admin.profile.update").
+        Title("修改个人信息详情").
+        Parent("个人信息")
+    engine.PATCH("/profile/avatar", profileController.UpdateAvatar).
+        Slug("lakego-admin.profile.avatar").
+        Title("修改个人头像").
+        Parent("个人信息")
+    engine.PATCH("/profile/password", profileController.UpdatePasssword).
+        Slug("lakego-admin.profile.password").
+        Title("修改密码").
+        Parent("个人信息")
+    engine.GET("/profile/rules", profileController.Rules).
+        Slug("lakego-admin.profile.rules").
+        Title("个人权限列表").
+        Parent("个人信息")
 
     // 上传
     uploadController := new(controller.Upload)
-    engine.POST("/upload/file", uploadController.File)
+    engine.POST("/upload/file", uploadController.File).
+        Slug("lakego-admin.upload.file").
+        Title("上传文件").
+        Parent("上传")
 
     // 附件
     attachmentController := new(controller.Attachment)
-    engine.GET("/attachment", attachmentController.Index)
-    engine.GET("/attachment/:id", attachmentController.Detail)
-    engine.PATCH("/attachment/:id/enable", attachmentController.Enable)
-    engine.PATCH("/attachment/:id/disable", attachmentController.Disable)
-    engine.DELETE("/attachment/:id", attachmentController.Delete)
-    engine.GET("/attachment/downcode/:id", attachmentController.DownloadCode)
-    engine.GET("/attachment/download/:code", attachmentController.Download)
+    engine.GET("/attachment", attachmentController.Index).
+        Slug("lakego-admin.attachment.index").
+        Title("附件列表").
+        Sort(151).
+        Parent("附件")
+    engine.GET("/attachment/:id", attachmentController.Detail).
+        Slug("lakego-admin.attachment.detail").
+        Title("附件详情").
+        Sort(152).
+        Parent("附件")
+    engine.PATCH("/attachment/:id/enable", attachmentController.Enable).
+        Slug("lakego-admin.attachment.enable").
+        Title("附件启用").
+        Sort(154).
+        Parent("附件")
+    engine.PATCH("/attachment/:id/disable", attachmentController.Disable).
+        Slug("lakego-admin.attachment.disable").
+        Title("附件禁用").
+        Sort(155).
+        Parent("附件")
+    engine.DELETE("/attachment/:id", attachmentController.Delete).
+        Slug("lakego-admin.attachment.delete").
+        Title("附件删除").
+        Sort(153).
+        Parent("附件")
+    engine.GET("/attachment/downcode/:id", attachmentController.DownloadCode).
+        Slug("lakego-admin.attachment.downcode").
+        Title("附件下载码").
+        Sort(156).
+        Parent("附件")
+    engine.GET("/attachment/download/:code", attachmentController.Download).
+        Slug("lakego-admin.attachment.download").
+        Title("附件下载").
+        Sort(157).
+        Parent("附件").
+        Security()
 
     // 管理员
     adminController := new(controller.Admin)
-    engine.GET("/admin", adminController.Index)
-    engine.GET("/admin/groups", adminController.Groups)
-    engine.GET("/admin/:id", adminController.Detail)
-    engine.GET("/admin/:id/rules", adminController.Rules)
-    engine.POST("/admin", adminController.Create)
-    engine.PUT("/admin/:id", adminController.Update)
-    engine.DELETE("/admin/:id", adminController.Delete)
-    engine.PATCH("/admin/:id/enable", adminController.Enable)
-    engine.PATCH("/admin/:id/disable", adminController.Disable)
-    engine.PATCH("/admin/:id/avatar", adminController.UpdateAvatar)
-    engine.PATCH("/admin/:id/password", adminController.UpdatePasssword)
-    engine.PATCH("/admin/:id/access", adminController.Access)
-    engine.DELETE("/admin/logout/:refreshToken", adminController.Logout)
-    engine.PUT("/admin/reset-permission", adminController.ResetPermission)
+    engine.GET("/admin", adminController.Index).
+        Slug("lakego-admin.admin.index").
+        Title("账号列表").
+        Parent("管理员")
+    engine.GET("/admin/groups", adminController.Groups).
+        Slug("lakego-admin.admin.groups").
+        Title("添加账号所需分组").
+        Parent("管理员")
+    engine.GET("/admin/:id", adminController.Detail).
+        Slug("lakego-admin.admin.detail").
+        Title("账号详情").
+        Parent("管理员")
+    engine.GET("/admin/:id/rules", adminController.Rules).
+        Slug("lakego-admin.admin.rules").
+        Title("账号权限").
+        Parent("管理员")
+    engine.POST("/admin", adminController.Create).
+        Slug("lakego-admin.admin.create").
+        Title("添加账号").
+        Parent("管理员")
+    engine.PUT("/admin/:id", adminController.Update).
+        Slug("lakego-admin.admin.update").
+        Title("更新账号").
+        Parent("管理员")
+    engine.DELETE("/admin/:id", adminController.Delete).
+        Slug("lakego-admin.admin.delete").
+        Title("删除账号").
+        Parent("管理员")
+    engine.PATCH("/admin/:id/enable", adminController.Enable).
+        Slug("lakego-admin.admin.enable").
+        Title("账号启用").
+        Parent("管理员")
+    engine.PATCH("/admin/:id/disable", adminController.Disable).
+        Slug("lakego-admin.admin.disable").
+        Title("账号禁用").
+        Parent("管理员")
+    engine.PATCH("/admin/:id/avatar", adminController.UpdateAvatar).
+        Slug("lakego-admin.admin.avatar").
+        Title("修改账号头像").
+        Parent("管理员")
+    engine.PATCH("/admin/:id/password", adminController.UpdatePasssword).
+        Slug("lakego-admin.admin.password").
+        Title("修改账号密码").
+        Parent("管理员")
+    engine.PATCH("/admin/:id/access", adminController.Access).
+        Slug("lakego-admin.admin.access").
+        Title("账号授权").
+        Parent("管理员")
+    engine.DELETE("/admin/logout/:refreshToken", adminController.Logout).
+        Slug("lakego-admin.admin.logout").
+        Title("账号退出").
+        Parent("管理员")
+    engine.PUT("/admin/reset-permission", adminController.ResetPermission).
+        Slug("lakego-admin.admin.reset-permission").
+        Title("账号权限同步").
+        Sort(200).
+        Parent("管理员")
 
     // 系统信息
     systemController := new(controller.System)
-    engine.GET("/system/info", systemController.Info)
-    engine.GET("/system/rules", systemController.Rules)
+    engine.GET("/system/info", systemController.Info).
+        Slug("lakego-admin.system.info").
+        Title("系统信息").
+        Parent("系统")
+    engine.GET("/system/rules", systemController.Rules).
+        Slug("lakego-admin.system.rules").
+        Title("权限 slug 列表").
+        Parent("系统")
 }
 
 /**
  * 后台管理员路由
  */
-func AdminRoute(engine router.IRouter) {
+func AdminRoute(group router.IRouter) {
+    engine := router.WithMeta(group)
+
     // 权限菜单
     authRuleController := new(controller.AuthRule)
-    engine.GET("/auth/rule", authRuleController.Index)
-    engine.GET("/auth/rule/tree", authRuleController.IndexTree)
-    engine.GET("/auth/rule/children", authRuleController.IndexChildren)
-    engine.GET("/auth/rule/:id", authRuleController.Detail)
-    engine.POST("/auth/rule", authRuleController.Create)
-    engine.PUT("/auth/rule/:id", authRuleController.Update)
-    engine.DELETE("/auth/rule/clear", authRuleController.Clear)
-    engine.DELETE("/auth/rule/:id", authRuleController.Delete)
-    engine.PATCH("/auth/rule/:id/sort", authRuleController.Listorder)
-    engine.PATCH("/auth/rule/:id/enable", authRuleController.Enable)
-    engine.PATCH("/auth/rule/:id/disable", authRuleController.Disable)
+    engine.GET("/auth/rule", authRuleController.Index).
+        Slug("lakego-admin.auth-rule.index").
+        Title("权限菜单列表").
+        Parent("权限菜单")
+    engine.GET("/auth/rule/tree", authRuleController.IndexTree).
+        Slug("lakego-admin.auth-rule.tree").
+        Title("权限菜单树结构").
+        Parent("权限菜单")
+    engine.GET("/auth/rule/children", authRuleController.IndexChildren).
+        Slug("lakego-admin.auth-rule.children").
+        Title("权限菜单子列表").
+        Parent("权限菜单")
+    engine.GET("/auth/rule/:id", authRuleController.Detail).
+        Slug("lakego-admin.auth-rule.detail").
+        Title("权限菜单详情").
+        Parent("权限菜单")
+    engine.POST("/auth/rule", authRuleController.Create).
+        Slug("lakego-admin.auth-rule.create").
+        Title("权限菜单添加").
+        Parent("权限菜单")
+    engine.PUT("/auth/rule/:id", authRuleController.Update).
+        Slug("lakego-admin.auth-rule.update").
+        Title("权限菜单更新").
+        Parent("权限菜单")
+    engine.DELETE("/auth/rule/clear", authRuleController.Clear).
+        Slug("lakego-admin.auth-rule.clear").
+        Title("清空特定ID权限").
+        Parent("权限菜单")
+    engine.DELETE("/auth/rule/:id", authRuleController.Delete).
+        Slug("lakego-admin.auth-rule.delete").
+        Title("权限菜单删除").
+        Parent("权限菜单")
+    engine.PATCH("/auth/rule/:id/sort", authRuleController.Listorder).
+        Slug("lakego-admin.auth-rule.sort").
+        Title("权限菜单排序").
+        Parent("权限菜单")
+    engine.PATCH("/auth/rule/:id/enable", authRuleController.Enable).
+        Slug("lakego-admin.auth-rule.enable").
+        Title("权限菜单启用").
+        Parent("权限菜单")
+    engine.PATCH("/auth/rule/:id/disable", authRuleController.Disable).
+        Slug("lakego-admin.auth-rule.disable").
+        Title("权限菜单禁用").
+        Parent("权限菜单")
 
     // 权限分组
     authGroupController := new(controller.AuthGroup)
-    engine.GET("/auth/group", authGroupController.Index)
-    engine.GET("/auth/group/tree", authGroupController.IndexTree)
-    engine.GET("/auth/group/children", authGroupController.IndexChildren)
-    engine.GET("/auth/group/:id", authGroupController.Detail)
-    engine.POST("/auth/group", authGroupController.Create)
-    engine.PUT("/auth/group/:id", authGroupController.Update)
-    engine.DELETE("/auth/group/:id", authGroupController.Delete)
-    engine.PATCH("/auth/group/:id/sort", authGroupController.Listorder)
-    engine.PATCH("/auth/group/:id/enable", authGroupController.Enable)
-    engine.PATCH("/auth/group/:id/disable", authGroupController.Disable)
-    engine.PATCH("/auth/group/:id/access", authGroupController.Access)
+    engine.GET("/auth/group", authGroupController.Index).
+        Slug("lakego-admin.auth-group.index").
+        Title("权限分组列表").
+        Parent("权限分组")
+    engine.GET("/auth/group/tree", authGroupController.IndexTree).
+        Slug("lakego-admin.auth-group.tree").
+        Title("权限分组树结构").
+        Parent("权限分组")
+    engine.GET("/auth/group/children", authGroupController.IndexChildren).
+        Slug("lakego-admin.auth-group.children").
+        Title("权限分组子列表").
+        Parent("权限分组")
+    engine.GET("/auth/group/:id", authGroupController.Detail).
+        Slug("lakego-admin.auth-group.detail").
+        Title("权限分组详情").
+        Parent("权限分组")
+    engine.POST("/auth/group", authGroupController.Create).
+        Slug("lakego-admin.auth-group.create").
+        Title("权限分组添加").
+        Parent("权限分组")
+    engine.PUT("/auth/group/:id", authGroupController.Update).
+        Slug("lakego-admin.auth-group.update").
+        Title("权限分组更新").
+        Parent("权限分组")
+    engine.DELETE("/auth/group/:id", authGroupController.Delete).
+        Slug("lakego-admin.auth-group.delete").
+        Title("权限分组删除").
+        Parent("权限分组")
+    engine.PATCH("/auth/group/:id/sort", authGroupController.Listorder).
+        Slug("lakego-admin.auth-group.sort").
+        Title("权限分组排序").
+        Parent("权限分组")
+    engine.PATCH("/auth/group/:id/enable", authGroupController.Enable).
+        Slug("lakego-admin.auth-group.enable").
+        Title("权限分组启用").
+        Parent("权限分组")
+    engine.PATCH("/auth/group/:id/disable", authGroupController.Disable).
+        Slug("lakego-admin.auth-group.disable").
+        Title("权限分组禁用").
+        Parent("权限分组")
+    engine.PATCH("/auth/group/:id/access", authGroupController.Access).
+        Slug("lakego-admin.auth-group.access").
+        Title("权限分组授权").
+        Parent("权限分组")
+
+    // 计划任务
+    scheduleController := new(controller.Schedule)
+    engine.GET("/schedule", scheduleController.Index).
+        Slug("lakego-admin.schedule.index").
+        Title("计划任务列表").
+        Parent("计划任务")
+    engine.GET("/schedule/history", scheduleController.History).
+        Slug("lakego-admin.schedule.history").
+        Title("计划任务执行记录").
+        Parent("计划任务")
+    engine.PATCH("/schedule/:name/pause", scheduleController.Pause).
+        Slug("lakego-admin.schedule.pause").
+        Title("计划任务暂停").
+        Parent("计划任务")
+    engine.PATCH("/schedule/:name/resume", scheduleController.Resume).
+        Slug("lakego-admin.schedule.resume").
+        Title("计划任务恢复").
+        Parent("计划任务")
+    engine.POST("/schedule/:name/trigger", scheduleController.Trigger).
+        Slug("lakego-admin.schedule.trigger").
+        Title("计划任务立即执行").
+        Parent("计划任务")
 }
@@ -0,0 +1,34 @@
+package schedule
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/schedule"
+)
+
+/**
+ * 手动执行计划任务，由队列工作进程执行
+ *
+ * @create 2024-6-3
+ * @author deatil
+ */
+type TriggerJob struct {
+    // 任务名称
+    Entry string `json:"entry"`
+}
+
+// 任务名称
+func (this *TriggerJob) Name() string {
+    return "lakego-admin.schedule.trigger"
+}
+
+// 执行，执行结果记录在计划任务执行记录中
+func (this *TriggerJob) Handle(ctx context.Context) error {
+    s := Get()
+    if s == nil {
+        return schedule.ErrEntryNotFound
+    }
+
+    _, err := s.RunEntry(ctx, this.Entry)
+    return err
+}
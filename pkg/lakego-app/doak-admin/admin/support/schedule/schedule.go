@@ -0,0 +1,30 @@
+package schedule
+
+import (
+    "sync"
+
+    "github.com/deatil/lakego-doak/lakego/schedule"
+)
+
+var (
+    mu sync.RWMutex
+
+    // 当前计划任务
+    current *schedule.Schedule
+)
+
+// 设置计划任务
+func Set(s *schedule.Schedule) {
+    mu.Lock()
+    defer mu.Unlock()
+
+    current = s
+}
+
+// 获取计划任务
+func Get() *schedule.Schedule {
+    mu.RLock()
+    defer mu.RUnlock()
+
+    return current
+}
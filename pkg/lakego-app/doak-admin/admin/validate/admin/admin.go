@@ -4,38 +4,38 @@ import (
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
-// 创建验证
-func Create(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "group_id": "required,len=36",
-        "name": "required,min=2,max=20",
-        "nickname": "required,min=2,max=150",
-        "email": "required,email,min=5,max=100",
-        "introduce": "required,max=500",
-        "status": "required",
-    }
+// 创建验证规则
+var CreateRules = map[string]any{
+    "group_id": "required,len=36",
+    "name": "required,min=2,max=20",
+    "nickname": "required,min=2,max=150",
+    "email": "required,email,min=5,max=100",
+    "introduce": "required,max=500",
+    "status": "required",
+}
 
-    // 错误提示
-    messages := map[string]string{
-        "group_id.required": "账号分组不能为空",
-        "group_id.len": "账号分组字符需要32个",
-        "name.required": "账号不能为空",
-        "name.min": "账号最小字符需要2个",
-        "name.max": "账号最大字符需要20个",
-        "nickname.required": "昵称不能为空",
-        "nickname.min": "昵称最小字符需要2个",
-        "nickname.max": "昵称最大字符需要150个",
-        "email.required": "邮箱不能为空",
-        "email.email": "邮箱格式错误",
-        "email.min": "邮箱最小字符需要5个",
-        "email.max": "邮箱最大字符需要100个",
-        "introduce.required": "简介不能为空",
-        "introduce.max": "简介字数最大字符需要500个",
-        "status.required": "状态选项不能为空",
-    }
+// 创建验证提示
+var CreateMessages = map[string]string{
+    "group_id.required": "账号分组不能为空",
+    "group_id.len": "账号分组字符需要32个",
+    "name.required": "账号不能为空",
+    "name.min": "账号最小字符需要2个",
+    "name.max": "账号最大字符需要20个",
+    "nickname.required": "昵称不能为空",
+    "nickname.min": "昵称最小字符需要2个",
+    "nickname.max": "昵称最大字符需要150个",
+    "email.required": "邮箱不能为空",
+    "email.email": "邮箱格式错误",
+    "email.min": "邮箱最小字符需要5个",
+    "email.max": "邮箱最大字符需要100个",
+    "introduce.required": "简介不能为空",
+    "introduce.max": "简介字数最大字符需要500个",
+    "status.required": "状态选项不能为空",
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 创建验证
+func Create(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, CreateRules, CreateMessages)
     if ok {
         return ""
     }
@@ -43,35 +43,35 @@ func Create(data map[string]any) string {
     return err
 }
 
-// 编辑验证
-func Update(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "name": "required,min=2,max=20",
-        "nickname": "required,min=2,max=150",
-        "email": "required,email,min=5,max=100",
-        "introduce": "required,max=500",
-        "status": "required",
-    }
+// 编辑验证规则
+var UpdateRules = map[string]any{
+    "name": "required,min=2,max=20",
+    "nickname": "required,min=2,max=150",
+    "email": "required,email,min=5,max=100",
+    "introduce": "required,max=500",
+    "status": "required",
+}
 
-    // 错误提示
-    messages := map[string]string{
-        "name.required": "账号不能为空",
-        "name.min": "账号最小字符需要2个",
-        "name.max": "账号最大字符需要20个",
-        "nickname.required": "昵称不能为空",
-        "nickname.min": "昵称最小字符需要2个",
-        "nickname.max": "昵称最大字符需要150个",
-        "email.required": "邮箱不能为空",
-        "email.email": "邮箱格式错误",
-        "email.min": "邮箱最小字符需要5个",
-        "email.max": "邮箱最大字符需要100个",
-        "introduce.required": "简介不能为空",
-        "introduce.max": "简介字数最大字符需要500个",
-        "status.required": "状态选项不能为空",
-    }
+// 编辑验证提示
+var UpdateMessages = map[string]string{
+    "name.required": "账号不能为空",
+    "name.min": "账号最小字符需要2个",
+    "name.max": "账号最大字符需要20个",
+    "nickname.required": "昵称不能为空",
+    "nickname.min": "昵称最小字符需要2个",
+    "nickname.max": "昵称最大字符需要150个",
+    "email.required": "邮箱不能为空",
+    "email.email": "邮箱格式错误",
+    "email.min": "邮箱最小字符需要5个",
+    "email.max": "邮箱最大字符需要100个",
+    "introduce.required": "简介不能为空",
+    "introduce.max": "简介字数最大字符需要500个",
+    "status.required": "状态选项不能为空",
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 编辑验证
+func Update(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, UpdateRules, UpdateMessages)
     if ok {
         return ""
     }
@@ -79,20 +79,20 @@ func Update(data map[string]any) string {
     return err
 }
 
-// 修改头像
-func UpdateAvatar(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "avatar": "required,len=36",
-    }
+// 修改头像规则
+var UpdateAvatarRules = map[string]any{
+    "avatar": "required,len=36",
+}
 
-    // 错误提示
-    messages := map[string]string{
-        "avatar.required": "头像数据不能为空",
-        "avatar.len": "头像数据错误",
-    }
+// 修改头像提示
+var UpdateAvatarMessages = map[string]string{
+    "avatar.required": "头像数据不能为空",
+    "avatar.len": "头像数据错误",
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 修改头像
+func UpdateAvatar(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, UpdateAvatarRules, UpdateAvatarMessages)
     if ok {
         return ""
     }
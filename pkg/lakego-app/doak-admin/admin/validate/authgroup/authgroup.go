@@ -4,24 +4,24 @@ import (
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
-// 创建验证
-func Create(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "parentid": "required",
-        "title": "required,max=50",
-        "status": "required",
-    }
+// 创建验证规则
+var CreateRules = map[string]any{
+    "parentid": "required",
+    "title": "required,max=50",
+    "status": "required",
+}
 
-    // 错误提示
-    messages := map[string]string{
-        "parentid.required": "父级分类不能为空",
-        "title.required": "名称不能为空",
-        "title.max": "名称最大字符需要50个",
-        "status.required": "状态选项不能为空",
-    }
+// 创建验证提示
+var CreateMessages = map[string]string{
+    "parentid.required": "父级分类不能为空",
+    "title.required": "名称不能为空",
+    "title.max": "名称最大字符需要50个",
+    "status.required": "状态选项不能为空",
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 创建验证
+func Create(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, CreateRules, CreateMessages)
     if ok {
         return ""
     }
@@ -29,24 +29,24 @@ func Create(data map[string]any) string {
     return err
 }
 
-// 编辑验证
-func Update(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "parentid": "required",
-        "title": "required,max=50",
-        "status": "required",
-    }
+// 编辑验证规则
+var UpdateRules = map[string]any{
+    "parentid": "required",
+    "title": "required,max=50",
+    "status": "required",
+}
 
-    // 错误提示
-    messages := map[string]string{
-        "parentid.required": "父级分类不能为空",
-        "title.required": "名称不能为空",
-        "title.max": "名称最大字符需要50个",
-        "status.required": "状态选项不能为空",
-    }
+// 编辑验证提示
+var UpdateMessages = map[string]string{
+    "parentid.required": "父级分类不能为空",
+    "title.required": "名称不能为空",
+    "title.max": "名称最大字符需要50个",
+    "status.required": "状态选项不能为空",
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 编辑验证
+func Update(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, UpdateRules, UpdateMessages)
     if ok {
         return ""
     }
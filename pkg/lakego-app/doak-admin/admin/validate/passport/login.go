@@ -4,6 +4,29 @@ import (
     "github.com/deatil/lakego-doak/lakego/validate"
 )
 
+// 登陆验证规则
+var LoginRules = map[string]any{
+    "name": "required",
+    "password": "required,len=32",
+}
+
+// 登陆验证提示
+var LoginMessages = map[string]string{
+    "name.required": "name 字段必填",
+    "password.required": "password 字段必填",
+    "password.len": "password 字段为32位长度",
+}
+
+// 需要验证码时的验证规则
+var LoginCaptchaRules = map[string]any{
+    "captcha": "required",
+}
+
+// 需要验证码时的验证提示
+var LoginCaptchaMessages = map[string]string{
+    "captcha.required": "captcha 字段必填",
+}
+
 /*
 user := map[string]any{
     "name": "Arshiya Kiani",
@@ -13,27 +36,20 @@ user := map[string]any{
 Login(user)
 */
 func Login(data map[string]any) string {
-    // 规则
-    rules := map[string]any{
-        "name": "required",
-        "password": "required,len=32",
-        "captcha": "required,len=4",
+    ok, err := validate.ValidateMapError(data, LoginRules, LoginMessages)
+    if ok {
+        return ""
     }
 
-    // 错误提示
-    messages := map[string]string{
-        "name.required": "name 字段必填",
-        "password.required": "password 字段必填",
-        "password.len": "password 字段为32位长度",
-        "captcha.required": "captcha 字段必填",
-        "captcha.len": ":field 字段为4位长度",
-    }
+    return err
+}
 
-    ok, err := validate.ValidateMapError(data, rules, messages)
+// 验证码字段
+func LoginCaptcha(data map[string]any) string {
+    ok, err := validate.ValidateMapError(data, LoginCaptchaRules, LoginCaptchaMessages)
     if ok {
         return ""
     }
 
     return err
 }
-
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-devtool/devtool/stubs"
+)
+
+/**
+ * 生成脚本
+ *
+ * > ./main lakego-devtool:make:command --name=[name] [--use=use] [--app=admin] [--force]
+ * > go run main.go lakego-devtool:make:command --name=SyncBook
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+var MakeCommandCmd = &command.Command{
+    Use: "lakego-devtool:make:command",
+    Short: "lakego-devtool make command.",
+    Example: "{execfile} lakego-devtool:make:command --name=[name]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeCommand()
+    },
+}
+
+var commandName string
+var commandUse string
+var commandApp string
+var commandForce bool
+
+func init() {
+    pf := MakeCommandCmd.Flags()
+    pf.StringVarP(&commandName, "name", "n", "", "名称")
+    pf.StringVarP(&commandUse, "use", "", "", "脚本命令，默认为 app-[app]:[name]")
+    pf.StringVarP(&commandApp, "app", "", "admin", "模块")
+    pf.BoolVarP(&commandForce, "force", "f", false, "是否覆盖")
+
+    command.MarkFlagRequired(pf, "name")
+}
+
+// 生成脚本
+func MakeCommand() {
+    if commandName == "" {
+        color.Red("脚本名称不能为空！\n")
+        return
+    }
+
+    use := commandUse
+    if use == "" {
+        use = "app-" + commandApp + ":" + str.Kebab(commandName)
+    }
+
+    data := map[string]string{
+        "name": str.Camel(commandName),
+        "lowerName": str.LowerCamel(commandName),
+        "use": use,
+    }
+
+    file := commandApp + "/cmd/" + str.Snake(commandName) + ".go"
+
+    err := stubs.New().MakeFile("command", path.AppPath(file), data, commandForce)
+    if err != nil {
+        color.Red("生成脚本失败！原因为：" + err.Error() + "\n")
+        return
+    }
+
+    color.Green("生成脚本成功：" + file + "\n")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-devtool/devtool/crud"
+    "github.com/deatil/lakego-doak-devtool/devtool/stubs"
+)
+
+/**
+ * 生成增删改查
+ *
+ * 根据字段或者已有数据表生成模型、仓库、验证器、控制器及路由，
+ * 路由带有权限信息，生成后运行 lakego-admin:import-route 导入权限
+ *
+ * > ./main lakego-devtool:make:crud --name=[name] --fields=[fields] [--title=title] [--app=admin] [--force]
+ * > ./main lakego-devtool:make:crud --name=[name] --table=[table] [--title=title] [--app=admin] [--force]
+ *
+ * > go run main.go lakego-devtool:make:crud --name=HotBook --title=热门书籍 --fields="title:string(50):required|max=50:标题;price:decimal(10,2)::价格;content:text::内容"
+ * > go run main.go lakego-devtool:make:crud --name=HotBook --table=hot_book
+ *
+ * 字段格式为 名称:类型[:验证规则][:说明]，多个字段用分号分隔，多个验证规则用竖线分隔，
+ * 类型可选 string | char | text | int | bigint | tinyint | decimal | float | bool
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+var MakeCrudCmd = &command.Command{
+    Use: "lakego-devtool:make:crud",
+    Short: "lakego-devtool make crud.",
+    Example: "{execfile} lakego-devtool:make:crud --name=[name] --fields=[fields]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeCrud()
+    },
+}
+
+var crudName string
+var crudTitle string
+var crudFields string
+var crudTable string
+var crudApp string
+var crudForce bool
+
+func init() {
+    pf := MakeCrudCmd.Flags()
+    pf.StringVarP(&crudName, "name", "n", "", "资源名称")
+    pf.StringVarP(&crudTitle, "title", "", "", "资源说明")
+    pf.StringVarP(&crudFields, "fields", "", "", "字段")
+    pf.StringVarP(&crudTable, "table", "", "", "从数据表读取字段")
+    pf.StringVarP(&crudApp, "app", "", "admin", "模块")
+    pf.BoolVarP(&crudForce, "force", "f", false, "是否覆盖")
+
+    command.MarkFlagRequired(pf, "name")
+}
+
+// 生成增删改查
+func MakeCrud() {
+    if crudName == "" {
+        color.Red("资源名称不能为空！\n")
+        return
+    }
+
+    if crudFields == "" && crudTable == "" {
+        color.Red("字段及数据表需要设置其中一个！\n")
+        return
+    }
+
+    var fields []crud.Field
+    var err error
+
+    if crudTable != "" {
+        table := crudTable
+        if !facade.DB.Migrator().HasTable(table) {
+            table = facade.DB.NamingStrategy.TableName(str.Camel(crudTable))
+        }
+
+        fields, err = crud.FieldsFromTable(facade.DB, table)
+    } else {
+        fields, err = crud.ParseFields(crudFields)
+    }
+
+    if err != nil {
+        color.Red("读取字段失败！原因为：" + err.Error() + "\n")
+        return
+    }
+
+    c := crud.New(crudName, crudTitle, crudApp, fields)
+
+    files := c.Files()
+
+    // 覆盖前检测，防止生成一半
+    st := stubs.New()
+    if !crudForce {
+        for _, file := range files {
+            dst := path.AppPath(file.Path)
+            if st.Exists(dst) {
+                color.Red("[" + dst + "] 文件已经存在，可使用 --force 覆盖！\n")
+                return
+            }
+        }
+    }
+
+    for _, file := range files {
+        err := st.MakeFile(file.Stub, path.AppPath(file.Path), c.Data(), crudForce)
+        if err != nil {
+            color.Red("生成文件失败！原因为：" + err.Error() + "\n")
+            return
+        }
+
+        color.Green("生成文件：" + file.Path + "\n")
+    }
+
+    // 注册路由
+    added, err := crud.RegisterRoute(path.AppPath(crudApp + "/route/route.go"), crudName)
+    if err != nil {
+        color.Red("注册路由失败，请手动添加 " + str.Camel(crudName) + "Route(engine)！原因为：" + err.Error() + "\n")
+    } else if added {
+        color.Green("注册路由：" + crudApp + "/route/route.go\n")
+    }
+
+    color.Green("生成增删改查成功！\n")
+    color.Green("请运行 lakego-admin:import-route 导入权限\n")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-devtool/devtool/stubs"
+)
+
+/**
+ * 生成扩展
+ *
+ * > ./main lakego-devtool:make:extension --name=[name] [--vendor=lakego] [--title=title] [--force]
+ * > go run main.go lakego-devtool:make:extension --name=book --title=书籍管理
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+var MakeExtensionCmd = &command.Command{
+    Use: "lakego-devtool:make:extension",
+    Short: "lakego-devtool make extension.",
+    Example: "{execfile} lakego-devtool:make:extension --name=[name]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeExtension()
+    },
+}
+
+var extensionName string
+var extensionVendor string
+var extensionTitle string
+var extensionForce bool
+
+func init() {
+    pf := MakeExtensionCmd.Flags()
+    pf.StringVarP(&extensionName, "name", "n", "", "名称")
+    pf.StringVarP(&extensionVendor, "vendor", "", "lakego", "作者")
+    pf.StringVarP(&extensionTitle, "title", "", "", "扩展说明")
+    pf.BoolVarP(&extensionForce, "force", "f", false, "是否覆盖")
+
+    command.MarkFlagRequired(pf, "name")
+}
+
+// 生成扩展
+func MakeExtension() {
+    if extensionName == "" {
+        color.Red("扩展名称不能为空！\n")
+        return
+    }
+
+    pkg := strings.ToLower(str.Camel(extensionName))
+    vendor := strings.ToLower(extensionVendor)
+
+    title := extensionTitle
+    if title == "" {
+        title = str.Camel(extensionName)
+    }
+
+    data := map[string]string{
+        "name": str.Camel(extensionName),
+        "pkg": pkg,
+        "path": str.Kebab(extensionName),
+        "vendor": vendor,
+        "title": title,
+    }
+
+    dir := "extension/" + vendor + "/" + pkg
+    files := map[string]string{
+        "extension/bootstrap": dir + "/" + pkg + "/bootstrap/bootstrap.go",
+        "extension/provider": dir + "/" + pkg + "/provider/" + pkg + ".go",
+        "extension/readme": dir + "/README.md",
+    }
+
+    st := stubs.New()
+    for stub, file := range files {
+        err := st.MakeFile(stub, path.RootPath(file), data, extensionForce)
+        if err != nil {
+            color.Red("生成扩展失败！原因为：" + err.Error() + "\n")
+            return
+        }
+    }
+
+    color.Green("生成扩展成功：" + dir + "\n")
+    color.Green("请在 bootstrap/provider.go 中导入 extension/" + vendor + "/" + pkg + "/" + pkg + "/bootstrap 并调用 Boot()\n")
+}
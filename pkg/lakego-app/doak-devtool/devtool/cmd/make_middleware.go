@@ -0,0 +1,72 @@
+package cmd
+
+import (
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-devtool/devtool/stubs"
+)
+
+/**
+ * 生成中间件
+ *
+ * > ./main lakego-devtool:make:middleware --name=[name] [--app=admin] [--force]
+ * > go run main.go lakego-devtool:make:middleware --name=CheckSign
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+var MakeMiddlewareCmd = &command.Command{
+    Use: "lakego-devtool:make:middleware",
+    Short: "lakego-devtool make middleware.",
+    Example: "{execfile} lakego-devtool:make:middleware --name=[name]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeMiddleware()
+    },
+}
+
+var middlewareName string
+var middlewareApp string
+var middlewareForce bool
+
+func init() {
+    pf := MakeMiddlewareCmd.Flags()
+    pf.StringVarP(&middlewareName, "name", "n", "", "名称")
+    pf.StringVarP(&middlewareApp, "app", "", "admin", "模块")
+    pf.BoolVarP(&middlewareForce, "force", "f", false, "是否覆盖")
+
+    command.MarkFlagRequired(pf, "name")
+}
+
+// 生成中间件
+func MakeMiddleware() {
+    if middlewareName == "" {
+        color.Red("中间件名称不能为空！\n")
+        return
+    }
+
+    pkg := strings.ToLower(str.Camel(middlewareName))
+
+    data := map[string]string{
+        "name": str.Camel(middlewareName),
+        "pkg": pkg,
+    }
+
+    file := middlewareApp + "/middleware/" + pkg + "/" + pkg + ".go"
+
+    err := stubs.New().MakeFile("middleware", path.AppPath(file), data, middlewareForce)
+    if err != nil {
+        color.Red("生成中间件失败！原因为：" + err.Error() + "\n")
+        return
+    }
+
+    color.Green("生成中间件成功：" + file + "\n")
+}
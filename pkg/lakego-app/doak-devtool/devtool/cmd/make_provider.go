@@ -0,0 +1,67 @@
+package cmd
+
+import (
+    "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+
+    "github.com/deatil/lakego-doak-devtool/devtool/stubs"
+)
+
+/**
+ * 生成服务提供者
+ *
+ * > ./main lakego-devtool:make:provider --name=[name] [--app=admin] [--force]
+ * > go run main.go lakego-devtool:make:provider --name=HotBook
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+var MakeProviderCmd = &command.Command{
+    Use: "lakego-devtool:make:provider",
+    Short: "lakego-devtool make provider.",
+    Example: "{execfile} lakego-devtool:make:provider --name=[name]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        MakeProvider()
+    },
+}
+
+var providerName string
+var providerApp string
+var providerForce bool
+
+func init() {
+    pf := MakeProviderCmd.Flags()
+    pf.StringVarP(&providerName, "name", "n", "", "名称")
+    pf.StringVarP(&providerApp, "app", "", "admin", "模块")
+    pf.BoolVarP(&providerForce, "force", "f", false, "是否覆盖")
+
+    command.MarkFlagRequired(pf, "name")
+}
+
+// 生成服务提供者
+func MakeProvider() {
+    if providerName == "" {
+        color.Red("服务提供者名称不能为空！\n")
+        return
+    }
+
+    data := map[string]string{
+        "name": str.Camel(providerName),
+    }
+
+    file := providerApp + "/provider/" + str.Snake(providerName) + ".go"
+
+    err := stubs.New().MakeFile("provider", path.AppPath(file), data, providerForce)
+    if err != nil {
+        color.Red("生成服务提供者失败！原因为：" + err.Error() + "\n")
+        return
+    }
+
+    color.Green("生成服务提供者成功：" + file + "\n")
+}
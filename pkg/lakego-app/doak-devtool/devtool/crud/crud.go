@@ -0,0 +1,348 @@
+package crud
+
+import (
+    "os"
+    "fmt"
+    "regexp"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/str"
+)
+
+// 生成的文件
+type File struct {
+    // 模板名称
+    Stub string
+
+    // 相对 app 文件夹的路径
+    Path string
+}
+
+/**
+ * 增删改查生成器
+ *
+ * @create 2024-5-20
+ * @author deatil
+ */
+type Crud struct {
+    // 资源名称，比如 HotBook
+    Name string
+
+    // 资源说明，比如 热门书籍
+    Title string
+
+    // 模块，默认为 admin
+    App string
+
+    // 字段
+    Fields []Field
+}
+
+// 构造函数
+func New(name string, title string, app string, fields []Field) Crud {
+    if title == "" {
+        title = str.Camel(name)
+    }
+    if app == "" {
+        app = "admin"
+    }
+
+    return Crud{
+        Name:   name,
+        Title:  title,
+        App:    app,
+        Fields: fields,
+    }
+}
+
+// 包名
+func (this Crud) Package() string {
+    return strings.ToLower(str.Camel(this.Name))
+}
+
+// 权限标识前缀
+func (this Crud) SlugPrefix() string {
+    return "app-" + this.App + "." + str.Kebab(this.Name)
+}
+
+// 需要生成的文件
+func (this Crud) Files() []File {
+    snake := str.Snake(this.Name)
+    pkg := this.Package()
+
+    return []File{
+        {Stub: "crud/model", Path: this.App + "/model/" + snake + ".go"},
+        {Stub: "crud/repository", Path: this.App + "/repository/" + pkg + "/" + pkg + ".go"},
+        {Stub: "crud/validate", Path: this.App + "/validate/" + pkg + "/" + pkg + ".go"},
+        {Stub: "crud/controller", Path: this.App + "/controller/" + snake + ".go"},
+        {Stub: "crud/route", Path: this.App + "/route/" + snake + ".go"},
+    }
+}
+
+// 模板数据
+func (this Crud) Data() map[string]string {
+    return map[string]string{
+        "name":             str.Camel(this.Name),
+        "lowerName":        str.LowerCamel(this.Name),
+        "pkg":              this.Package(),
+        "path":             str.Kebab(this.Name),
+        "title":            this.Title,
+        "appImport":        "app/" + this.App,
+        "slugPrefix":       this.SlugPrefix(),
+        "modelFields":      this.modelFields(),
+        "validateRules":    this.validateRules(),
+        "validateMessages": this.validateMessages(),
+        "createFields":     this.createFields(),
+        "updateFields":     this.updateFields(),
+        "createParams":     this.swaggerParams(false),
+        "updateParams":     this.swaggerParams(true),
+        "searchBlock":      this.searchBlock(),
+    }
+}
+
+// 模型字段
+func (this Crud) modelFields() string {
+    type column struct {
+        name, typ, tag string
+    }
+
+    columns := []column{
+        {"ID", "string", `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`},
+    }
+    for _, f := range this.Fields {
+        notNull := ""
+        if f.Required() {
+            notNull = "not null;"
+        }
+
+        columns = append(columns, column{
+            f.GoName(),
+            f.GoType(),
+            fmt.Sprintf(`gorm:"column:%s;%stype:%s;" json:"%s"`, f.Name, notNull, f.DBType(), f.Name),
+        })
+    }
+    columns = append(columns, []column{
+        {"Listorder", "int", `gorm:"column:listorder;type:int(10);" json:"listorder"`},
+        {"Status", "int", `gorm:"column:status;not null;type:tinyint(1);" json:"status"`},
+        {"UpdateTime", "int", `gorm:"column:update_time;type:int(10);" json:"update_time"`},
+        {"UpdateIp", "string", `gorm:"column:update_ip;type:varchar(50);" json:"update_ip"`},
+        {"AddTime", "int", `gorm:"column:add_time;type:int(10);" json:"add_time"`},
+        {"AddIp", "string", `gorm:"column:add_ip;type:varchar(50);" json:"add_ip"`},
+    }...)
+
+    nameWidth, typWidth := 0, 0
+    for _, c := range columns {
+        nameWidth = maxInt(nameWidth, len(c.name))
+        typWidth = maxInt(typWidth, len(c.typ))
+    }
+
+    lines := make([]string, 0, len(columns))
+    for _, c := range columns {
+        lines = append(lines, fmt.Sprintf("    %-*s %-*s %s", nameWidth, c.name, typWidth, c.typ, "`" + c.tag + "`"))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 验证规则
+func (this Crud) validateRules() string {
+    lines := make([]string, 0)
+    for _, f := range this.Fields {
+        if len(f.Rules) == 0 {
+            continue
+        }
+
+        lines = append(lines, fmt.Sprintf(`    "%s": "%s",`, f.Name, strings.Join(f.Rules, ",")))
+    }
+
+    lines = append(lines, `    "status": "required",`)
+
+    return strings.Join(lines, "\n")
+}
+
+// 验证提示
+func (this Crud) validateMessages() string {
+    lines := make([]string, 0)
+    for _, f := range this.Fields {
+        for _, rule := range f.Rules {
+            lines = append(lines, fmt.Sprintf(`    "%s.%s": "%s",`, f.Name, ruleName(rule), ruleMessage(f, rule)))
+        }
+    }
+
+    lines = append(lines, `    "status.required": "状态选项不能为空",`)
+
+    return strings.Join(lines, "\n")
+}
+
+// 添加数据
+func (this Crud) createFields() string {
+    lines := make([]string, 0, len(this.Fields))
+    for _, f := range this.Fields {
+        lines = append(lines, fmt.Sprintf(`        %s: %s(post["%s"]),`, f.GoName(), f.CastFunc(), f.Name))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 更新数据
+func (this Crud) updateFields() string {
+    lines := make([]string, 0, len(this.Fields))
+    for _, f := range this.Fields {
+        lines = append(lines, fmt.Sprintf(`        "%s": %s(post["%s"]),`, f.Name, f.CastFunc(), f.Name))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 文档参数
+func (this Crud) swaggerParams(withId bool) string {
+    type param struct {
+        name, in, required, desc string
+    }
+
+    params := make([]param, 0)
+    if withId {
+        params = append(params, param{"id", "path", "true", "ID"})
+    }
+    for _, f := range this.Fields {
+        params = append(params, param{f.Name, "formData", fmt.Sprint(f.Required()), f.Title()})
+    }
+    params = append(params, []param{
+        {"listorder", "formData", "true", "排序"},
+        {"status", "formData", "true", "状态"},
+    }...)
+
+    nameWidth, inWidth := 0, 0
+    for _, p := range params {
+        nameWidth = maxInt(nameWidth, len(p.name))
+        inWidth = maxInt(inWidth, len(p.in))
+    }
+
+    lines := make([]string, 0, len(params))
+    for _, p := range params {
+        lines = append(lines, fmt.Sprintf(`// @Param %-*s %-*s string %s "%s"`, nameWidth, p.name, inWidth, p.in, p.required, p.desc))
+    }
+
+    return strings.Join(lines, "\n")
+}
+
+// 搜索条件，使用字符字段模糊搜索
+func (this Crud) searchBlock() string {
+    names := make([]string, 0)
+    for _, f := range this.Fields {
+        if f.IsString() && f.Type != TypeText {
+            names = append(names, f.Name)
+        }
+    }
+
+    if len(names) == 0 {
+        return ""
+    }
+
+    lowerName := str.LowerCamel(this.Name)
+
+    var sb strings.Builder
+    sb.WriteString("\n    // 搜索条件\n")
+    sb.WriteString("    searchword := ctx.DefaultQuery(\"searchword\", \"\")\n")
+    sb.WriteString("    if searchword != \"\" {\n")
+    sb.WriteString("        searchword = \"%\" + searchword + \"%\"\n\n")
+    sb.WriteString("        " + lowerName + "Model = " + lowerName + "Model.Where(\n")
+    sb.WriteString("            model.New" + str.Camel(this.Name) + "().\n")
+    for i, name := range names {
+        method := "Or"
+        if i == 0 {
+            method = "Where"
+        }
+
+        end := "."
+        if i == len(names) - 1 {
+            end = ","
+        }
+
+        sb.WriteString(fmt.Sprintf("                %s(\"%s LIKE ?\", searchword)%s\n", method, name, end))
+    }
+    sb.WriteString("        )\n")
+    sb.WriteString("    }\n")
+
+    return sb.String()
+}
+
+// 规则名称，比如 max=50 为 max
+func ruleName(rule string) string {
+    if i := strings.Index(rule, "="); i > -1 {
+        return rule[:i]
+    }
+
+    return rule
+}
+
+// 规则提示，数字字段的 min 及 max 为大小限制
+func ruleMessage(f Field, rule string) string {
+    title := f.Title()
+
+    param := ""
+    if i := strings.Index(rule, "="); i > -1 {
+        param = rule[i+1:]
+    }
+
+    switch ruleName(rule) {
+        case "required":
+            return title + "不能为空"
+        case "max":
+            if !f.IsString() {
+                return title + "最大值为" + param
+            }
+
+            return title + "最大字符需要" + param + "个"
+        case "min":
+            if !f.IsString() {
+                return title + "最小值为" + param
+            }
+
+            return title + "最少字符需要" + param + "个"
+        case "len":
+            return title + "字符长度需要为" + param + "个"
+    }
+
+    return title + "格式错误"
+}
+
+// 匹配路由函数
+var routeFuncRe = regexp.MustCompile(`(?s)func Route\(engine [^)]*\) \{\n(.*?)\n\}`)
+
+/**
+ * 在路由文件的 Route 函数中添加路由调用，已存在时跳过
+ *
+ * 返回 true 表示已添加
+ */
+func RegisterRoute(file string, name string) (bool, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return false, err
+    }
+
+    content := string(data)
+    call := str.Camel(name) + "Route(engine)"
+    if strings.Contains(content, call) {
+        return false, nil
+    }
+
+    loc := routeFuncRe.FindStringSubmatchIndex(content)
+    if loc == nil {
+        return false, fmt.Errorf("[%s] 没有找到 Route 函数", file)
+    }
+
+    // 在函数结尾前插入
+    insert := "\n\n    // " + str.Camel(name) + " 路由\n    " + call
+    content = content[:loc[3]] + insert + content[loc[3]:]
+
+    return true, os.WriteFile(file, []byte(content), 0644)
+}
+
+func maxInt(a, b int) int {
+    if a > b {
+        return a
+    }
+
+    return b
+}
@@ -0,0 +1,318 @@
+package crud
+
+import (
+    "fmt"
+    "regexp"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/str"
+)
+
+// 默认字段，生成时自动添加
+var defaultColumns = []string{
+    "id",
+    "listorder",
+    "status",
+    "update_time",
+    "update_ip",
+    "add_time",
+    "add_ip",
+}
+
+// 是否为默认字段
+func IsDefaultColumn(name string) bool {
+    for _, v := range defaultColumns {
+        if v == name {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 字段类型
+const (
+    TypeString  = "string"
+    TypeChar    = "char"
+    TypeText    = "text"
+    TypeInt     = "int"
+    TypeBigint  = "bigint"
+    TypeTinyint = "tinyint"
+    TypeDecimal = "decimal"
+    TypeFloat   = "float"
+    TypeBool    = "bool"
+)
+
+// 字段
+type Field struct {
+    // 字段名称
+    Name string
+
+    // 类型
+    Type string
+
+    // 长度，比如 varchar(50) 的 50 及 decimal(10,2) 的 10,2
+    Size string
+
+    // 验证规则
+    Rules []string
+
+    // 字段说明
+    Label string
+}
+
+// 结构体字段名称
+func (this Field) GoName() string {
+    return str.Camel(this.Name)
+}
+
+// go 类型
+func (this Field) GoType() string {
+    switch this.Type {
+        case TypeInt, TypeTinyint, TypeBool:
+            return "int"
+        case TypeBigint:
+            return "int64"
+        case TypeDecimal, TypeFloat:
+            return "float64"
+    }
+
+    return "string"
+}
+
+// 数据库类型
+func (this Field) DBType() string {
+    switch this.Type {
+        case TypeString:
+            return "varchar(" + this.sizeOr("255") + ")"
+        case TypeChar:
+            return "char(" + this.sizeOr("36") + ")"
+        case TypeText:
+            return "text"
+        case TypeInt:
+            return "int(" + this.sizeOr("10") + ")"
+        case TypeBigint:
+            return "bigint(" + this.sizeOr("20") + ")"
+        case TypeTinyint, TypeBool:
+            return "tinyint(" + this.sizeOr("1") + ")"
+        case TypeDecimal:
+            return "decimal(" + this.sizeOr("10,2") + ")"
+        case TypeFloat:
+            return "float"
+    }
+
+    return "varchar(255)"
+}
+
+// 数据转换函数
+func (this Field) CastFunc() string {
+    switch this.GoType() {
+        case "int":
+            return "goch.ToInt"
+        case "int64":
+            return "goch.ToInt64"
+        case "float64":
+            return "goch.ToFloat64"
+    }
+
+    return "goch.ToString"
+}
+
+// 是否为字符类型
+func (this Field) IsString() bool {
+    return this.GoType() == "string"
+}
+
+// 显示名称
+func (this Field) Title() string {
+    if this.Label != "" {
+        return this.Label
+    }
+
+    return this.Name
+}
+
+// 是否必填
+func (this Field) Required() bool {
+    for _, rule := range this.Rules {
+        if rule == "required" {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (this Field) sizeOr(def string) string {
+    if this.Size != "" {
+        return this.Size
+    }
+
+    return def
+}
+
+var (
+    fieldNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+    fieldTypeRe = regexp.MustCompile(`^([a-z]+)(?:\(([0-9,]+)\))?$`)
+)
+
+// 支持的类型
+var fieldTypes = map[string]bool{
+    TypeString:  true,
+    TypeChar:    true,
+    TypeText:    true,
+    TypeInt:     true,
+    TypeBigint:  true,
+    TypeTinyint: true,
+    TypeDecimal: true,
+    TypeFloat:   true,
+    TypeBool:    true,
+}
+
+/**
+ * 解析字段
+ *
+ * 格式为 名称:类型[:验证规则][:说明]，多个字段用分号分隔，多个验证规则用竖线分隔
+ * 比如 title:string(50):required|max=50:标题;price:decimal(10,2);content:text::内容
+ */
+func ParseFields(spec string) ([]Field, error) {
+    fields := make([]Field, 0)
+    exists := make(map[string]bool)
+
+    for _, item := range strings.Split(spec, ";") {
+        item = strings.TrimSpace(item)
+        if item == "" {
+            continue
+        }
+
+        parts := strings.SplitN(item, ":", 4)
+        if len(parts) < 2 {
+            return nil, fmt.Errorf("字段 [%s] 格式错误，格式为 名称:类型[:验证规则][:说明]", item)
+        }
+
+        field := Field{
+            Name: strings.TrimSpace(parts[0]),
+        }
+
+        if !fieldNameRe.MatchString(field.Name) {
+            return nil, fmt.Errorf("字段名称 [%s] 只能为小写字母、数字及下划线", field.Name)
+        }
+        if IsDefaultColumn(field.Name) {
+            return nil, fmt.Errorf("字段 [%s] 为默认字段，不需要设置", field.Name)
+        }
+        if exists[field.Name] {
+            return nil, fmt.Errorf("字段 [%s] 重复", field.Name)
+        }
+
+        matches := fieldTypeRe.FindStringSubmatch(strings.TrimSpace(parts[1]))
+        if matches == nil || !fieldTypes[matches[1]] {
+            return nil, fmt.Errorf("字段 [%s] 类型 [%s] 不支持", field.Name, parts[1])
+        }
+
+        field.Type = matches[1]
+        field.Size = matches[2]
+
+        if len(parts) > 2 && parts[2] != "" {
+            for _, rule := range strings.Split(parts[2], "|") {
+                if rule = strings.TrimSpace(rule); rule != "" {
+                    field.Rules = append(field.Rules, rule)
+                }
+            }
+        }
+
+        if len(parts) > 3 {
+            field.Label = strings.TrimSpace(parts[3])
+        }
+
+        exists[field.Name] = true
+        fields = append(fields, field)
+    }
+
+    if len(fields) == 0 {
+        return nil, fmt.Errorf("字段不能为空")
+    }
+
+    return fields, nil
+}
+
+// 从数据表读取字段，跳过默认字段
+func FieldsFromTable(db *gorm.DB, table string) ([]Field, error) {
+    if !db.Migrator().HasTable(table) {
+        return nil, fmt.Errorf("数据表 [%s] 不存在", table)
+    }
+
+    columns, err := db.Migrator().ColumnTypes(table)
+    if err != nil {
+        return nil, err
+    }
+
+    fields := make([]Field, 0)
+    for _, column := range columns {
+        name := column.Name()
+        if IsDefaultColumn(name) {
+            continue
+        }
+
+        field := Field{
+            Name: name,
+        }
+
+        columnType, _ := column.ColumnType()
+        field.Type, field.Size = columnToType(column.DatabaseTypeName(), columnType)
+
+        if comment, ok := column.Comment(); ok {
+            field.Label = comment
+        }
+
+        if nullable, ok := column.Nullable(); ok && !nullable {
+            if _, ok := column.DefaultValue(); !ok {
+                field.Rules = append(field.Rules, "required")
+            }
+        }
+
+        if length, ok := column.Length(); ok && field.IsString() && field.Type != TypeText && length > 0 {
+            field.Rules = append(field.Rules, fmt.Sprintf("max=%d", length))
+        }
+
+        fields = append(fields, field)
+    }
+
+    if len(fields) == 0 {
+        return nil, fmt.Errorf("数据表 [%s] 没有可用字段", table)
+    }
+
+    return fields, nil
+}
+
+var columnSizeRe = regexp.MustCompile(`\(([0-9,]+)\)`)
+
+// 数据库类型转换
+func columnToType(typeName string, columnType string) (string, string) {
+    size := ""
+    if matches := columnSizeRe.FindStringSubmatch(columnType); matches != nil {
+        size = matches[1]
+    }
+
+    switch strings.ToLower(typeName) {
+        case "varchar":
+            return TypeString, size
+        case "char":
+            return TypeChar, size
+        case "text", "tinytext", "mediumtext", "longtext", "json":
+            return TypeText, ""
+        case "tinyint":
+            return TypeTinyint, size
+        case "smallint", "mediumint", "int", "integer":
+            return TypeInt, size
+        case "bigint":
+            return TypeBigint, size
+        case "decimal", "numeric":
+            return TypeDecimal, size
+        case "float", "double", "real":
+            return TypeFloat, ""
+    }
+
+    return TypeString, ""
+}
@@ -29,4 +29,11 @@ func (this *Devtool) Boot() {
 func (this *Devtool) loadCommand() {
     // 脚手架
     this.AddCommand(cmd.AppAdminCmd)
+
+    // 生成文件
+    this.AddCommand(cmd.MakeCrudCmd)
+    this.AddCommand(cmd.MakeProviderCmd)
+    this.AddCommand(cmd.MakeMiddlewareCmd)
+    this.AddCommand(cmd.MakeCommandCmd)
+    this.AddCommand(cmd.MakeExtensionCmd)
 }
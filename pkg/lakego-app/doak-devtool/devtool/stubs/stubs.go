@@ -61,6 +61,20 @@ func (this Stubs) MakeModel(name string, data map[string]string, force bool) err
     return this.CopyFile(srcData, dstFile, data, force)
 }
 
+// 使用模板生成文件，name 为模板名称，比如 crud/model
+func (this Stubs) MakeFile(name string, dst string, data map[string]string, force bool) error {
+    if _, ok := data["datetime"]; !ok {
+        data["datetime"] = datebin.Now().ToDatetimeString()
+    }
+
+    srcData, err := this.readStubFile(name)
+    if err != nil {
+        return err
+    }
+
+    return this.CopyFile(srcData, dst, data, force)
+}
+
 // 复制文件
 func (this Stubs) CopyFile(srcData string, dst string, data map[string]string, force bool) error {
     if this.Exists(dst) && !force {
@@ -1,12 +1,20 @@
 package cmd
 
 import (
+    "os"
     "fmt"
+    "bufio"
     "errors"
+    "strings"
+    "path/filepath"
 
+    "github.com/deatil/lakego-doak/lakego/path"
     "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
 
+    "github.com/deatil/lakego-doak-extension/extension/pack"
     "github.com/deatil/lakego-doak-extension/extension/service"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
 )
 
 /**
@@ -19,10 +27,14 @@ import (
  * > go run main.go lakego-admin:extension --action=local
  * > go run main.go lakego-admin:extension --action=inatll --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=uninstall --name=lakego.demo
+ * > go run main.go lakego-admin:extension --action=uninstall --name=lakego.demo --cascade
  * > go run main.go lakego-admin:extension --action=upgrade --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=enable --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=disable --name=lakego.demo
  * > go run main.go lakego-admin:extension --action=sort --name=lakego.demo --sort=105
+ * > go run main.go lakego-admin:extension --action=pack --name=lakego.demo --source=./demo-package --rule=lakego-admin.ext.demo
+ * > go run main.go lakego-admin:extension --action=import --file=./runtime/extension/lakego.demo-1.0.1.zip
+ * > go run main.go lakego-admin:extension --action=keygen --alg=ed25519 --output=./resources/extension/keys
  *
  * @create 2023-7-3
  * @author deatil
@@ -43,12 +55,24 @@ var ExtensionCmd = &command.Command{
 var action string
 var name string
 var sort int
+var cascade bool
+var source string
+var rules []string
+var file string
+var output string
+var alg string
 
 func init() {
     pf := ExtensionCmd.Flags()
     pf.StringVarP(&action, "action", "a", "", "操作类型")
     pf.StringVarP(&name, "name", "n", "", "扩展名称")
     pf.IntVarP(&sort, "sort", "s", 100, "扩展排序值")
+    pf.BoolVarP(&cascade, "cascade", "c", false, "同时禁用或者卸载依赖当前扩展的扩展")
+    pf.StringVarP(&source, "source", "", "", "扩展包数据目录")
+    pf.StringSliceVarP(&rules, "rule", "", nil, "扩展包导出的权限规则 slug")
+    pf.StringVarP(&file, "file", "f", "", "扩展包文件")
+    pf.StringVarP(&output, "output", "o", "", "输出文件或者目录")
+    pf.StringVarP(&alg, "alg", "", "ed25519", "签名算法，ed25519 或者 sm2")
 
     command.MarkFlagRequired(pf, "action")
 }
@@ -60,9 +84,9 @@ func ExtensionCtl() {
         return
     }
 
-    switch name {
+    switch action {
         case "inatll", "uninstall", "upgrade",
-            "enable", "disable", "sort":
+            "enable", "disable", "sort", "pack":
             if name == "" {
                 fmt.Println("扩展名称不能为空")
                 return
@@ -71,7 +95,7 @@ func ExtensionCtl() {
 
     err := errors.New("操作类型不存在")
 
-    newExtension := service.NewExtension()
+    newExtension := service.NewExtension().WithCascade(cascade)
 
     switch action {
         case "local":
@@ -91,6 +115,12 @@ func ExtensionCtl() {
             }
         case "uninstall":
             err = newExtension.Uninstall(name)
+            if confirmed, asked := confirmCascade(err); confirmed {
+                err = newExtension.WithCascade(true).Uninstall(name)
+            } else if asked {
+                fmt.Println("操作已取消")
+                return
+            }
             if err == nil {
                 fmt.Println("卸载扩展成功")
                 return
@@ -109,6 +139,12 @@ func ExtensionCtl() {
             }
         case "disable":
             err = newExtension.Disable(name)
+            if confirmed, asked := confirmCascade(err); confirmed {
+                err = newExtension.WithCascade(true).Disable(name)
+            } else if asked {
+                fmt.Println("操作已取消")
+                return
+            }
             if err == nil {
                 fmt.Println("禁用扩展成功")
                 return
@@ -119,8 +155,110 @@ func ExtensionCtl() {
                 fmt.Println("更新扩展排序成功")
                 return
             }
+        case "pack":
+            var out string
+            out, err = packExtension()
+            if err == nil {
+                fmt.Println("扩展打包成功: " + out)
+                return
+            }
+        case "import":
+            var m *pack.Manifest
+            m, err = importExtension()
+            if err == nil {
+                fmt.Println(fmt.Sprintf("导入扩展包成功: %s(%s)", m.Name, m.Version))
+                return
+            }
+        case "keygen":
+            err = generateKey()
+            if err == nil {
+                return
+            }
+    }
+
+    fmt.Println(err.Error())
+}
+
+
+// 存在依赖当前扩展的扩展时确认是否同时处理，返回是否确认及是否询问过
+func confirmCascade(err error) (bool, bool) {
+    var depErr *service.DependentsError
+    if !errors.As(err, &depErr) {
+        return false, false
     }
 
     fmt.Println(err.Error())
+    fmt.Printf("是否同时%s扩展[%s]? [y/N]: ", depErr.Action, strings.Join(depErr.Dependents, ", "))
+
+    answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+    answer = strings.ToLower(strings.TrimSpace(answer))
+
+    return answer == "y" || answer == "yes", true
 }
 
+// 打包扩展
+func packExtension() (string, error) {
+    info := extension.GetManager().GetExtension(name)
+    if info.Name == "" {
+        return "", errors.New("扩展不存在")
+    }
+
+    signer, err := pack.SignerFromConfig()
+    if err != nil {
+        return "", err
+    }
+
+    out := output
+    if out == "" {
+        dir := path.FormatPath(config.New("extension").GetString("package.output"))
+        out = filepath.Join(dir, fmt.Sprintf("%s-%s.zip", info.Name, info.Version))
+    }
+
+    err = pack.PackFile(info, pack.Options{
+        Source: source,
+        Rules:  rules,
+        Signer: signer,
+    }, out)
+
+    return out, err
+}
+
+// 导入扩展包
+func importExtension() (*pack.Manifest, error) {
+    if file == "" {
+        return nil, errors.New("扩展包文件不能为空")
+    }
+
+    return pack.ImporterFromConfig().Import(file)
+}
+
+// 生成签名密钥
+func generateKey() error {
+    if output == "" {
+        return errors.New("密钥保存目录不能为空")
+    }
+
+    pri, pub, err := pack.GenerateKey(alg)
+    if err != nil {
+        return err
+    }
+
+    if err := os.MkdirAll(output, 0755); err != nil {
+        return err
+    }
+
+    priFile := filepath.Join(output, alg + ".key")
+    pubFile := filepath.Join(output, alg + ".pub")
+
+    if err := os.WriteFile(priFile, pri, 0600); err != nil {
+        return err
+    }
+    if err := os.WriteFile(pubFile, pub, 0644); err != nil {
+        return err
+    }
+
+    fmt.Println("私钥: " + priFile)
+    fmt.Println("公钥: " + pubFile)
+
+    return nil
+}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+    "errors"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/lakego-doak/lakego/router"
 
     admin_controller "github.com/deatil/lakego-doak-admin/admin/controller"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
 
     "github.com/deatil/lakego-doak-extension/extension/model"
     "github.com/deatil/lakego-doak-extension/extension/service"
@@ -170,6 +173,7 @@ func (this *Extension) Inatll(ctx *router.Context) {
 // @Accept  application/json
 // @Produce application/json
 // @Param name query string true "扩展名称"
+// @Param cascade query bool false "同时卸载依赖当前扩展的扩展"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /extension/{name}/uninstall [delete]
 // @Security Bearer
@@ -181,8 +185,20 @@ func (this *Extension) Uninstall(ctx *router.Context) {
         return
     }
 
-    err := service.NewExtension().Uninstall(name)
+    cascade := ctx.DefaultQuery("cascade", "") == "true"
+
+    err := service.NewExtensionWithCtx(ctx).
+        WithCascade(cascade).
+        Uninstall(name)
     if err != nil {
+        var depErr *service.DependentsError
+        if errors.As(err, &depErr) {
+            this.ErrorWithData(ctx, err.Error(), code.StatusError, router.H{
+                "dependents": depErr.Dependents,
+            })
+            return
+        }
+
         this.Error(ctx, err.Error())
         return
     }
@@ -289,6 +305,7 @@ func (this *Extension) Enable(ctx *router.Context) {
 // @Accept  application/json
 // @Produce application/json
 // @Param name query string true "扩展名称"
+// @Param cascade query bool false "同时禁用依赖当前扩展的扩展"
 // @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
 // @Router /extension/{name}/disable [patch]
 // @Security Bearer
@@ -300,8 +317,20 @@ func (this *Extension) Disable(ctx *router.Context) {
         return
     }
 
-    err := service.NewExtension().Disable(name)
+    cascade := ctx.DefaultQuery("cascade", "") == "true"
+
+    err := service.NewExtensionWithCtx(ctx).
+        WithCascade(cascade).
+        Disable(name)
     if err != nil {
+        var depErr *service.DependentsError
+        if errors.As(err, &depErr) {
+            this.ErrorWithData(ctx, err.Error(), code.StatusError, router.H{
+                "dependents": depErr.Dependents,
+            })
+            return
+        }
+
         this.Error(ctx, err.Error())
         return
     }
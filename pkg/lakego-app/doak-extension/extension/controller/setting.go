@@ -0,0 +1,101 @@
+package controller
+
+import (
+    "errors"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    admin_controller "github.com/deatil/lakego-doak-admin/admin/controller"
+    "github.com/deatil/lakego-doak-admin/admin/support/http/code"
+
+    "github.com/deatil/lakego-doak-extension/extension/service"
+)
+
+/**
+ * 扩展设置
+ *
+ * @create 2024-5-24
+ * @author deatil
+ */
+type Setting struct {
+    admin_controller.Base
+}
+
+// 扩展设置
+// @Summary 扩展设置
+// @Description 扩展设置字段及当前设置
+// @Tags 扩展
+// @Accept  application/json
+// @Produce application/json
+// @Param name path string true "扩展名称"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /extension/{name}/setting [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.extension.setting"}
+func (this *Setting) Detail(ctx *router.Context) {
+    name := ctx.Param("name")
+    if name == "" {
+        this.Error(ctx, "扩展不能为空")
+        return
+    }
+
+    settingService := service.NewSettingWithCtx(ctx)
+
+    fields, err := settingService.Fields(name)
+    if err != nil {
+        this.Error(ctx, err.Error())
+        return
+    }
+
+    values, _ := settingService.Get(name)
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "fields": fields,
+        "values": values,
+    })
+}
+
+// 更新扩展设置
+// @Summary 更新扩展设置
+// @Description 更新扩展设置，验证失败时返回字段错误
+// @Tags 扩展
+// @Accept  application/json
+// @Produce application/json
+// @Param name path string true "扩展名称"
+// @Param data body object true "设置数据"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /extension/{name}/setting [put]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.extension.setting-update"}
+func (this *Setting) Update(ctx *router.Context) {
+    name := ctx.Param("name")
+    if name == "" {
+        this.Error(ctx, "扩展不能为空")
+        return
+    }
+
+    // 接收数据
+    post := make(map[string]any)
+    if err := this.ShouldBindJSON(ctx, &post); err != nil {
+        this.Error(ctx, "提交数据错误")
+        return
+    }
+
+    values, err := service.NewSettingWithCtx(ctx).Update(name, post)
+    if err != nil {
+        var settingErr *service.SettingError
+        if errors.As(err, &settingErr) {
+            this.ErrorWithData(ctx, err.Error(), code.StatusError, router.H{
+                "errors": settingErr.Errors,
+            })
+            return
+        }
+
+        this.Error(ctx, err.Error())
+        return
+    }
+
+    this.SuccessWithData(ctx, "更新设置成功", router.H{
+        "values": values,
+    })
+}
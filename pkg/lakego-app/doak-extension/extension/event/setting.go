@@ -0,0 +1,28 @@
+package event
+
+/**
+ * 扩展设置事件
+ *
+ * event.On(func(ctx context.Context, e ext_event.SettingUpdated) error {
+ *     if e.Name == "lakego.demo" {
+ *         // 重新加载
+ *     }
+ *
+ *     return nil
+ * })
+ *
+ * @create 2024-5-24
+ * @author deatil
+ */
+
+// 修改设置后
+type SettingUpdated struct {
+    // 扩展名称
+    Name string
+
+    // 修改前设置
+    Old map[string]any
+
+    // 修改后设置
+    New map[string]any
+}
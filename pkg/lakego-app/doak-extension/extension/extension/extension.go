@@ -3,6 +3,8 @@ package extension
 import (
     "encoding/json"
 
+    "gorm.io/gorm"
+
     iapp "github.com/deatil/lakego-doak/lakego/app/interfaces"
 )
 
@@ -48,21 +50,39 @@ type Extension struct {
     // map[string]string{'lakego.log-viewer' => '1.0.*'}
     Require map[string]string `json:"require"`
 
-    // 安装后
+    // 设置字段[选填]
+    Settings []Setting `json:"settings"`
+
+    // 安装后，不使用事务，写入数据库时使用 InstallTx
     Install func() error `json:"-"`
 
-    // 卸载后
+    // 卸载后，不使用事务，写入数据库时使用 UninstallTx
     Uninstall func() error `json:"-"`
 
-    // 更新后
+    // 更新后，不使用事务，写入数据库时使用 UpgradeTx
     Upgrade func() error `json:"-"`
 
-    // 启用后
+    // 启用后，不使用事务，写入数据库时使用 EnableTx
     Enable func() error `json:"-"`
 
-    // 禁用后
+    // 禁用后，不使用事务，写入数据库时使用 DisableTx
     Disable func() error `json:"-"`
 
+    // 安装后，和扩展记录在同一事务中执行，返回错误时一起回滚
+    InstallTx func(tx *gorm.DB) error `json:"-"`
+
+    // 卸载后，和扩展记录在同一事务中执行
+    UninstallTx func(tx *gorm.DB) error `json:"-"`
+
+    // 更新后，和扩展记录在同一事务中执行
+    UpgradeTx func(tx *gorm.DB) error `json:"-"`
+
+    // 启用后，和扩展记录在同一事务中执行
+    EnableTx func(tx *gorm.DB) error `json:"-"`
+
+    // 禁用后，和扩展记录在同一事务中执行
+    DisableTx func(tx *gorm.DB) error `json:"-"`
+
     // 安装启用后运行
     Start func(iapp.App) error `json:"-"`
 }
@@ -0,0 +1,249 @@
+package extension
+
+import (
+    "sort"
+    "strings"
+)
+
+// 循环依赖错误
+type CycleError struct {
+    // 循环路径，首尾相同
+    Path []string
+}
+
+func (this *CycleError) Error() string {
+    return "扩展存在循环依赖：" + strings.Join(this.Path, " -> ")
+}
+
+// 根据扩展生成依赖图
+func NewGraph(exts []Extension) *Graph {
+    g := &Graph{
+        requires: make(map[string][]string),
+    }
+
+    for _, ext := range exts {
+        g.Add(ext.Name, ext.Require)
+    }
+
+    return g
+}
+
+/**
+ * 扩展依赖图
+ *
+ * @create 2024-5-22
+ * @author deatil
+ */
+type Graph struct {
+    // 扩展 => 依赖的扩展
+    requires map[string][]string
+}
+
+// 添加扩展
+func (this *Graph) Add(name string, require map[string]string) *Graph {
+    deps := make([]string, 0, len(require))
+    for dep := range require {
+        deps = append(deps, dep)
+    }
+
+    sort.Strings(deps)
+
+    this.requires[name] = deps
+
+    return this
+}
+
+// 依赖的扩展
+func (this *Graph) Requires(name string) []string {
+    return this.requires[name]
+}
+
+// 直接依赖当前扩展的扩展
+func (this *Graph) Dependents(name string) []string {
+    list := make([]string, 0)
+    for _, ext := range this.names() {
+        for _, dep := range this.requires[ext] {
+            if dep == name {
+                list = append(list, ext)
+                break
+            }
+        }
+    }
+
+    return list
+}
+
+// 全部依赖当前扩展的扩展，依赖方在前，可直接按顺序禁用或者卸载
+// 存在循环依赖时返回 CycleError
+func (this *Graph) AllDependents(name string) ([]string, error) {
+    found := make(map[string]bool)
+    cycle := false
+
+    var walk func(string)
+    walk = func(n string) {
+        for _, dep := range this.Dependents(n) {
+            if dep == name {
+                cycle = true
+                continue
+            }
+
+            if !found[dep] {
+                found[dep] = true
+                walk(dep)
+            }
+        }
+    }
+
+    walk(name)
+
+    names := make([]string, 0, len(found))
+    for n := range found {
+        names = append(names, n)
+    }
+
+    // 依赖方又被当前扩展依赖时，和当前扩展一起检测循环
+    if cycle {
+        if _, err := this.Sort(append(names, name)); err != nil {
+            return nil, err
+        }
+    }
+
+    sorted, err := this.Sort(names)
+    if err != nil {
+        return nil, err
+    }
+
+    // 依赖方在前
+    for i, j := 0, len(sorted) - 1; i < j; i, j = i + 1, j - 1 {
+        sorted[i], sorted[j] = sorted[j], sorted[i]
+    }
+
+    return sorted, nil
+}
+
+/**
+ * 拓扑排序，被依赖的扩展在前
+ *
+ * 只处理 names 中扩展之间的依赖，存在循环依赖时返回可排序部分及 CycleError
+ */
+func (this *Graph) Sort(names []string) ([]string, error) {
+    in := make(map[string]bool, len(names))
+    for _, n := range names {
+        in[n] = true
+    }
+
+    // 剩余依赖数量
+    degree := make(map[string]int, len(names))
+    for n := range in {
+        for _, dep := range this.requires[n] {
+            if in[dep] && dep != n {
+                degree[n]++
+            }
+        }
+    }
+
+    ready := make([]string, 0)
+    for n := range in {
+        if degree[n] == 0 {
+            ready = append(ready, n)
+        }
+    }
+
+    sorted := make([]string, 0, len(in))
+    for len(ready) > 0 {
+        sort.Strings(ready)
+
+        n := ready[0]
+        ready = ready[1:]
+        sorted = append(sorted, n)
+
+        for _, dependent := range this.Dependents(n) {
+            if !in[dependent] || dependent == n {
+                continue
+            }
+
+            degree[dependent]--
+            if degree[dependent] == 0 {
+                ready = append(ready, dependent)
+            }
+        }
+    }
+
+    if len(sorted) < len(in) {
+        rest := make([]string, 0)
+        for n := range in {
+            if degree[n] > 0 {
+                rest = append(rest, n)
+            }
+        }
+
+        return sorted, this.cycleIn(rest)
+    }
+
+    for n := range in {
+        for _, dep := range this.requires[n] {
+            if dep == n {
+                return sorted, &CycleError{Path: []string{n, n}}
+            }
+        }
+    }
+
+    return sorted, nil
+}
+
+// 检测循环依赖
+func (this *Graph) DetectCycle() error {
+    _, err := this.Sort(this.names())
+
+    return err
+}
+
+// 查找循环路径
+func (this *Graph) cycleIn(names []string) *CycleError {
+    sort.Strings(names)
+
+    in := make(map[string]bool, len(names))
+    for _, n := range names {
+        in[n] = true
+    }
+
+    // 沿依赖查找，剩余节点都有未处理的依赖，必定可以找到循环
+    start := names[0]
+    path := []string{start}
+    index := map[string]int{start: 0}
+
+    n := start
+    for {
+        next := ""
+        for _, dep := range this.requires[n] {
+            if in[dep] {
+                next = dep
+                break
+            }
+        }
+
+        if next == "" {
+            return &CycleError{Path: path}
+        }
+
+        if i, ok := index[next]; ok {
+            return &CycleError{Path: append(path[i:], next)}
+        }
+
+        index[next] = len(path)
+        path = append(path, next)
+        n = next
+    }
+}
+
+// 全部扩展名称
+func (this *Graph) names() []string {
+    names := make([]string, 0, len(this.requires))
+    for n := range this.requires {
+        names = append(names, n)
+    }
+
+    sort.Strings(names)
+
+    return names
+}
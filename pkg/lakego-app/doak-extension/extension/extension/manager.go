@@ -1,6 +1,7 @@
 package extension
 
 import (
+    "fmt"
     "sync"
     "errors"
     "encoding/json"
@@ -8,6 +9,7 @@ import (
     "github.com/deatil/go-event/event"
 
     "github.com/deatil/lakego-doak/lakego/str"
+    "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/router"
     iapp "github.com/deatil/lakego-doak/lakego/app/interfaces"
     admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
@@ -138,18 +140,60 @@ func (this *Manager) Routes(fn func(*router.RouterGroup)) {
     })
 }
 
-// 加载扩展
+// 加载扩展，按依赖顺序执行，依赖未启用或者存在循环依赖的扩展不会加载
 func (this *Manager) BootExtension(ia iapp.App) {
     exts := model.GetActiveExtensions()
 
+    active := make(map[string]bool, len(exts))
+    names := make([]string, 0, len(exts))
     for _, ext := range exts {
-        info := this.GetExtension(ext["name"].(string))
-        if info.Name != "" && info.Start != nil {
-            info.Start(ia)
+        name := ext["name"].(string)
+
+        active[name] = true
+        names = append(names, name)
+    }
+
+    sorted, err := this.Graph().Sort(names)
+    if err != nil {
+        facade.Logger.Error("[extension] " + err.Error())
+    }
+
+    started := make(map[string]bool, len(sorted))
+    for _, name := range sorted {
+        info := this.GetExtension(name)
+        if info.Name == "" {
+            continue
+        }
+
+        ready := true
+        for dep := range info.Require {
+            if !active[dep] || !started[dep] {
+                facade.Logger.Error(fmt.Sprintf("[extension] 扩展[%s]依赖的扩展[%s]没有启用", name, dep))
+                ready = false
+                break
+            }
+        }
+
+        if !ready {
+            continue
+        }
+
+        if info.Start != nil {
+            if err := info.Start(ia); err != nil {
+                facade.Logger.Error(fmt.Sprintf("[extension] 扩展[%s]启动失败：%s", name, err.Error()))
+                continue
+            }
         }
+
+        started[name] = true
     }
 }
 
+// 已添加扩展的依赖图
+func (this *Manager) Graph() *Graph {
+    return NewGraph(this.GetExtensions())
+}
+
 // 扩展配置信息
 func (this *Manager) GetExtension(name string) Extension {
     info, err := this.GetExtend(name)
@@ -162,6 +206,9 @@ func (this *Manager) GetExtension(name string) Extension {
 
 // 全部添加的扩展
 func (this *Manager) GetExtensions() []Extension {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
     exts := make([]Extension, 0)
 
     for _, ext := range this.extensions {
@@ -3,6 +3,8 @@ package extension
 import (
     "strings"
 
+    "gorm.io/gorm"
+
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-tree/tree"
     "github.com/deatil/go-datebin/datebin"
@@ -13,7 +15,13 @@ import (
 )
 
 // 规则
-type Rule struct {}
+type Rule struct {
+    // 数据库，为空时使用默认连接
+    db *gorm.DB
+
+    // 第一个数据库错误
+    err error
+}
 
 // 初始化
 func NewRule() *Rule {
@@ -22,6 +30,25 @@ func NewRule() *Rule {
     return r
 }
 
+// 设置数据库，扩展方法中传入事务使规则和扩展记录一起提交或者回滚
+func (this *Rule) WithDB(db *gorm.DB) *Rule {
+    this.db = db
+
+    return this
+}
+
+// 数据库错误，事务中执行时用于回滚
+func (this *Rule) Err() error {
+    return this.err
+}
+
+// 记录错误
+func (this *Rule) setErr(err error) {
+    if err != nil && this.err == nil {
+        this.err = err
+    }
+}
+
 // 创建
 func (this *Rule) Create(data map[string]any, parentId string) bool {
     if len(data) == 0 {
@@ -31,7 +58,7 @@ func (this *Rule) Create(data map[string]any, parentId string) bool {
     lastOrder := 0
 
     var info model.AuthRule
-    err := model.NewAuthRule().
+    err := this.newAuthRule().
         Order("listorder DESC").
         First(&info).
         Error
@@ -54,9 +81,11 @@ func (this *Rule) Create(data map[string]any, parentId string) bool {
         AddIp:       "0.0.0.0",
     }
 
-    err2 := model.NewDB().
+    err2 := this.newDB().
         Create(&insertData).
         Error
+    this.setErr(err2)
+
     if err2 == nil {
         children := res.Value("children").ToSlice()
         for _, child := range children {
@@ -76,9 +105,11 @@ func (this *Rule) Delete(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    err := this.newAuthRule().
         Where("id IN ?", ids).
-        Delete(&model.AuthRule{})
+        Delete(&model.AuthRule{}).
+        Error
+    this.setErr(err)
 
     return true
 }
@@ -90,11 +121,13 @@ func (this *Rule) Enable(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    err := this.newAuthRule().
         Where("id IN ?", ids).
         Updates(map[string]any{
             "status": 1,
-        })
+        }).
+        Error
+    this.setErr(err)
 
     return true
 }
@@ -106,11 +139,13 @@ func (this *Rule) Disable(slug string) bool {
         return false
     }
 
-    model.NewAuthRule().
+    err := this.newAuthRule().
         Where("id IN ?", ids).
         Updates(map[string]any{
             "status": 0,
-        })
+        }).
+        Error
+    this.setErr(err)
 
     return true
 }
@@ -127,14 +162,14 @@ func (this *Rule) Export(slug string) []map[string]any {
     var info model.AuthRule
 
     // 模型
-    err := model.NewAuthRule().
+    err := this.newAuthRule().
         Where("slug = ?", slug).
         First(&info).
         Error
     if err == nil {
         rules := make([]map[string]any, 0)
 
-        model.NewAuthRule().
+        this.newAuthRule().
             Where("id IN ?", ids).
             Order("listorder ASC").
             Find(&rules)
@@ -152,15 +187,19 @@ func (this *Rule) GetAuthRuleIdsBySlug(slug string) []string {
     ids := make([]string, 0)
 
     rules := make([]map[string]any, 0)
-    model.NewAuthRule().
+    err := this.newAuthRule().
         Where("slug = ?", slug).
-        Find(&rules)
+        Find(&rules).
+        Error
+    this.setErr(err)
 
     ruleList := make([]map[string]any, 0)
-    model.NewAuthRule().
+    err = this.newAuthRule().
         Order("listorder ASC").
         Select("id", "parentid", "slug").
-        Find(&ruleList)
+        Find(&ruleList).
+        Error
+    this.setErr(err)
 
     for _, rule := range rules {
         ruleId := rule["id"].(string)
@@ -175,3 +214,17 @@ func (this *Rule) GetAuthRuleIdsBySlug(slug string) []string {
 
     return ids
 }
+
+// 数据库
+func (this *Rule) newDB() *gorm.DB {
+    if this.db != nil {
+        return this.db
+    }
+
+    return model.NewDB()
+}
+
+// 规则模型
+func (this *Rule) newAuthRule() *gorm.DB {
+    return this.newDB().Model(&model.AuthRule{})
+}
@@ -0,0 +1,287 @@
+package extension
+
+import (
+    "sync"
+    "errors"
+    "encoding/json"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak-extension/extension/model"
+)
+
+// 设置字段类型
+const (
+    SettingString = "string"
+    SettingText   = "text"
+    SettingInt    = "int"
+    SettingFloat  = "float"
+    SettingBool   = "bool"
+    SettingSelect = "select"
+    SettingJSON   = "json"
+)
+
+// 选项
+type SettingOption struct {
+    // 显示名称
+    Label string `json:"label"`
+
+    // 选项值
+    Value any `json:"value"`
+}
+
+/**
+ * 扩展设置字段
+ *
+ * Rule 为 lakego/validate 验证规则，Messages 键名为验证标签，比如 required
+ *
+ * @create 2024-5-24
+ * @author deatil
+ */
+type Setting struct {
+    // 字段名称
+    Name string `json:"name"`
+
+    // 显示名称
+    Title string `json:"title"`
+
+    // 类型，默认为 string
+    Type string `json:"type"`
+
+    // 默认值
+    Default any `json:"default"`
+
+    // 验证规则
+    Rule string `json:"rule"`
+
+    // 验证提示
+    Messages map[string]string `json:"messages"`
+
+    // 选项，select 类型使用
+    Options []SettingOption `json:"options"`
+
+    // 描述
+    Description string `json:"description"`
+}
+
+// 转换为字段类型
+func (this Setting) Convert(value any) (any, error) {
+    if value == nil {
+        return nil, nil
+    }
+
+    switch this.Type {
+        case SettingInt:
+            return goch.ToInt64E(value)
+        case SettingFloat:
+            return goch.ToFloat64E(value)
+        case SettingBool:
+            return goch.ToBoolE(value)
+        case SettingJSON:
+            if s, ok := value.(string); ok {
+                var data any
+                if err := json.Unmarshal([]byte(s), &data); err != nil {
+                    return nil, err
+                }
+
+                return data, nil
+            }
+
+            return value, nil
+        case SettingSelect:
+            for _, opt := range this.Options {
+                if goch.ToString(opt.Value) == goch.ToString(value) {
+                    return opt.Value, nil
+                }
+            }
+
+            return nil, errors.New("选项不存在")
+        default:
+            return goch.ToStringE(value)
+    }
+}
+
+// 设置验证规则及提示
+func SettingRules(settings []Setting) (map[string]any, map[string]string) {
+    rules := make(map[string]any)
+    messages := make(map[string]string)
+
+    for _, s := range settings {
+        if s.Rule == "" {
+            continue
+        }
+
+        rules[s.Name] = s.Rule
+        for tag, msg := range s.Messages {
+            messages[s.Name + "." + tag] = msg
+        }
+    }
+
+    return rules, messages
+}
+
+// 已加载的设置
+var settingCache = struct {
+    mu   sync.RWMutex
+    data map[string]*SettingValues
+}{
+    data: make(map[string]*SettingValues),
+}
+
+// 扩展设置，未保存的字段使用默认值
+func Settings(name string) *SettingValues {
+    settingCache.mu.RLock()
+    values, ok := settingCache.data[name]
+    settingCache.mu.RUnlock()
+
+    if ok {
+        return values
+    }
+
+    values = LoadSettings(name)
+
+    settingCache.mu.Lock()
+    settingCache.data[name] = values
+    settingCache.mu.Unlock()
+
+    return values
+}
+
+// 清除已加载的设置
+func ForgetSettings(name string) {
+    settingCache.mu.Lock()
+    defer settingCache.mu.Unlock()
+
+    delete(settingCache.data, name)
+}
+
+// 从数据库读取设置
+func LoadSettings(name string) *SettingValues {
+    info := GetManager().GetExtension(name)
+
+    saved := make(map[string]string)
+    for _, row := range model.GetExtensionSettings(name) {
+        saved[row.Key] = row.Value
+    }
+
+    data := make(map[string]any, len(info.Settings))
+    for _, s := range info.Settings {
+        value := s.Default
+
+        if raw, ok := saved[s.Name]; ok {
+            var v any
+            if err := json.Unmarshal([]byte(raw), &v); err == nil {
+                if cv, err := s.Convert(v); err == nil {
+                    value = cv
+                }
+            }
+        }
+
+        data[s.Name] = value
+    }
+
+    return &SettingValues{
+        data: data,
+    }
+}
+
+/**
+ * 扩展设置值
+ *
+ * @create 2024-5-24
+ * @author deatil
+ */
+type SettingValues struct {
+    data map[string]any
+}
+
+// 获取原始值
+func (this *SettingValues) Get(key string, def ...any) any {
+    if v, ok := this.data[key]; ok && v != nil {
+        return v
+    }
+
+    if len(def) > 0 {
+        return def[0]
+    }
+
+    return nil
+}
+
+// 是否存在
+func (this *SettingValues) Has(key string) bool {
+    _, ok := this.data[key]
+    return ok
+}
+
+// 字符
+func (this *SettingValues) GetString(key string, def ...string) string {
+    if v := this.Get(key); v != nil {
+        return goch.ToString(v)
+    }
+
+    if len(def) > 0 {
+        return def[0]
+    }
+
+    return ""
+}
+
+// 整数
+func (this *SettingValues) GetInt(key string, def ...int) int {
+    if v := this.Get(key); v != nil {
+        return goch.ToInt(v)
+    }
+
+    if len(def) > 0 {
+        return def[0]
+    }
+
+    return 0
+}
+
+// 浮点数
+func (this *SettingValues) GetFloat64(key string, def ...float64) float64 {
+    if v := this.Get(key); v != nil {
+        return goch.ToFloat64(v)
+    }
+
+    if len(def) > 0 {
+        return def[0]
+    }
+
+    return 0
+}
+
+// 布尔值
+func (this *SettingValues) GetBool(key string, def ...bool) bool {
+    if v := this.Get(key); v != nil {
+        return goch.ToBool(v)
+    }
+
+    if len(def) > 0 {
+        return def[0]
+    }
+
+    return false
+}
+
+// 解析 json 类型到结构体
+func (this *SettingValues) Unmarshal(key string, dst any) error {
+    data, err := json.Marshal(this.Get(key))
+    if err != nil {
+        return err
+    }
+
+    return json.Unmarshal(data, dst)
+}
+
+// 全部设置
+func (this *SettingValues) All() map[string]any {
+    data := make(map[string]any, len(this.data))
+    for k, v := range this.data {
+        data[k] = v
+    }
+
+    return data
+}
@@ -94,3 +94,14 @@ func IsEnableExtension(name string) bool {
 
     return true
 }
+
+// 获取已安装扩展
+func GetInstalledExtensions() []Extension {
+    list := make([]Extension, 0)
+
+    NewExtension().
+        Order("listorder DESC").
+        Find(&list)
+
+    return list
+}
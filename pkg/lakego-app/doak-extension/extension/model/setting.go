@@ -0,0 +1,41 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade"
+)
+
+// 扩展设置
+type ExtensionSetting struct {
+    ID         string `gorm:"column:id;type:char(36);not null;primaryKey;" json:"id"`
+    Name       string `gorm:"column:name;not null;type:varchar(160);" json:"name"`
+    Key        string `gorm:"column:key;not null;type:varchar(100);" json:"key"`
+    Value      string `gorm:"column:value;type:text;" json:"value"`
+    UpdateTime int    `gorm:"column:update_time;size:10;" json:"update_time"`
+    UpdateIp   string `gorm:"column:update_ip;size:50;" json:"update_ip"`
+    AddTime    int    `gorm:"column:add_time;size:10;" json:"add_time"`
+    AddIp      string `gorm:"column:add_ip;size:50;" json:"add_ip"`
+}
+
+func (this *ExtensionSetting) BeforeCreate(tx *gorm.DB) error {
+    this.ID = uuid.ToUUIDString()
+
+    return nil
+}
+
+func NewExtensionSetting() *gorm.DB {
+    return facade.DB.Model(&ExtensionSetting{})
+}
+
+// 获取扩展设置
+func GetExtensionSettings(name string) []ExtensionSetting {
+    list := make([]ExtensionSetting, 0)
+
+    NewExtensionSetting().
+        Where("name = ?", name).
+        Find(&list)
+
+    return list
+}
@@ -0,0 +1,70 @@
+package pack
+
+import (
+    "os"
+    "errors"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 配置中的签名私钥
+func SignerFromConfig() (*Signer, error) {
+    conf := config.New("extension")
+
+    file := conf.GetString("package.sign.key")
+    if file == "" {
+        return nil, errors.New("扩展包签名私钥没有设置")
+    }
+
+    key, err := os.ReadFile(path.FormatPath(file))
+    if err != nil {
+        return nil, errors.New("扩展包签名私钥读取失败：" + err.Error())
+    }
+
+    return NewSigner(
+        conf.GetString("package.sign.alg"),
+        conf.GetString("package.sign.name"),
+        key,
+        conf.GetString("package.sign.password"),
+    )
+}
+
+// 配置中信任的公钥，读取失败的公钥会被跳过
+func KeyringFromConfig() Keyring {
+    items, ok := config.New("extension").Get("package.trusted-keys").([]any)
+    if !ok {
+        return nil
+    }
+
+    keyring := make(Keyring, 0, len(items))
+    for _, item := range items {
+        data := array.ArrayFrom(item)
+
+        key, err := os.ReadFile(path.FormatPath(data.Value("key").ToString()))
+        if err != nil {
+            continue
+        }
+
+        keyring = append(keyring, TrustedKey{
+            Name: data.Value("name").ToString(),
+            Alg:  data.Value("alg").ToString(),
+            Key:  key,
+        })
+    }
+
+    return keyring
+}
+
+// 使用配置生成导入
+func ImporterFromConfig() *Importer {
+    conf := config.New("extension")
+
+    return &Importer{
+        Keyring:    KeyringFromConfig(),
+        AssetsPath: path.FormatPath(conf.GetString("package.assets")),
+        ConfigPath: path.ConfigPath(""),
+        MaxSize:    conf.GetInt64("package.max-size") * 1024 * 1024,
+    }
+}
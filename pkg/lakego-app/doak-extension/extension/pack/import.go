@@ -0,0 +1,320 @@
+package pack
+
+import (
+    "os"
+    "fmt"
+    "errors"
+    "context"
+    "strings"
+    "path/filepath"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/go-goch/goch"
+
+    admin_model "github.com/deatil/lakego-doak-admin/admin/model"
+
+    "github.com/deatil/lakego-doak-extension/extension/model"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
+)
+
+/**
+ * 扩展包导入
+ *
+ * 签名及文件摘要验证通过后才会写入数据，
+ * 数据库文件及权限规则在事务中执行，静态资源及配置文件在事务提交后从临时目录移入，
+ * 配置文件已存在时不覆盖
+ *
+ * @create 2024-5-23
+ * @author deatil
+ */
+type Importer struct {
+    // 信任的公钥
+    Keyring Keyring
+
+    // 静态资源目录，资源保存在 {AssetsPath}/{扩展名称} 下
+    AssetsPath string
+
+    // 配置目录
+    ConfigPath string
+
+    // 解压后最大大小
+    MaxSize int64
+}
+
+// 导入扩展包文件
+func (this *Importer) Import(file string) (*Manifest, error) {
+    pkg, err := Open(file, this.MaxSize)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := pkg.Verify(this.Keyring); err != nil {
+        return nil, err
+    }
+
+    return pkg.Manifest, this.Apply(pkg)
+}
+
+// 写入扩展包数据
+func (this *Importer) Apply(pkg *Package) error {
+    if !pkg.Verified() {
+        return errors.New("扩展包没有验证签名")
+    }
+
+    m := pkg.Manifest
+
+    info := extension.GetManager().GetExtension(m.Name)
+    if info.Name == "" {
+        return errors.New(fmt.Sprintf("扩展[%s]没有编译到程序中", m.Name))
+    }
+
+    if info.Version != m.Version {
+        return errors.New(fmt.Sprintf("扩展包版本[%s]和程序中扩展版本[%s]不一致", m.Version, info.Version))
+    }
+
+    // 静态资源及配置先写入临时目录，数据库提交后再移动到正式目录
+    staged := &stagedFiles{}
+    defer staged.clean()
+
+    if err := this.stageAssets(pkg, staged); err != nil {
+        return err
+    }
+
+    if err := this.stageConfigs(pkg, staged); err != nil {
+        return err
+    }
+
+    // 数据库文件及权限规则在同一事务中执行
+    err := admin_model.Transaction(context.Background(), func(tx *gorm.DB) error {
+        if err := this.applySql(tx, pkg); err != nil {
+            return err
+        }
+
+        return this.applyRules(tx, pkg)
+    })
+    if err != nil {
+        return err
+    }
+
+    return staged.commit()
+}
+
+// 执行数据库文件
+func (this *Importer) applySql(tx *gorm.DB, pkg *Package) error {
+    files := pkg.Manifest.FilesIn(SqlDir)
+    if len(files) == 0 {
+        return nil
+    }
+
+    prefix := goch.ToString(admin_model.GetConfig("prefix"))
+
+    for _, f := range files {
+        sqls := strings.Split(string(pkg.File(f.Path)), ";")
+        for _, sql := range sqls {
+            sql = strings.TrimSpace(sql)
+            if sql == "" {
+                continue
+            }
+
+            sql = strings.ReplaceAll(sql, "pre__", prefix)
+
+            if err := tx.Exec(sql).Error; err != nil {
+                return errors.New(fmt.Sprintf("数据库文件[%s]执行失败：%s", f.Path, err.Error()))
+            }
+        }
+    }
+
+    return nil
+}
+
+// 静态资源写入临时目录，提交时替换 {AssetsPath}/{扩展名称}
+func (this *Importer) stageAssets(pkg *Package, staged *stagedFiles) error {
+    files := pkg.Manifest.FilesIn(AssetsDir)
+    if len(files) == 0 {
+        return nil
+    }
+
+    dir, err := staged.tempDir(this.AssetsPath)
+    if err != nil {
+        return errors.New(fmt.Sprintf("静态资源临时目录创建失败：%s", err.Error()))
+    }
+
+    for _, f := range files {
+        file := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(f.Path, AssetsDir)))
+
+        if err := writeFile(file, pkg.File(f.Path)); err != nil {
+            return errors.New(fmt.Sprintf("静态资源[%s]写入失败：%s", f.Path, err.Error()))
+        }
+    }
+
+    staged.replaceDir(dir, filepath.Join(this.AssetsPath, pkg.Manifest.Name))
+
+    return nil
+}
+
+// 默认配置写入临时目录，已存在的配置跳过
+func (this *Importer) stageConfigs(pkg *Package, staged *stagedFiles) error {
+    files := pkg.Manifest.FilesIn(ConfigDir)
+    if len(files) == 0 {
+        return nil
+    }
+
+    dir, err := staged.tempDir(this.ConfigPath)
+    if err != nil {
+        return errors.New(fmt.Sprintf("配置临时目录创建失败：%s", err.Error()))
+    }
+
+    for _, f := range files {
+        name := filepath.FromSlash(strings.TrimPrefix(f.Path, ConfigDir))
+
+        file := filepath.Join(this.ConfigPath, name)
+        if _, err := os.Stat(file); err == nil {
+            continue
+        }
+
+        tmp := filepath.Join(dir, name)
+        if err := writeFile(tmp, pkg.File(f.Path)); err != nil {
+            return errors.New(fmt.Sprintf("配置文件[%s]写入失败：%s", f.Path, err.Error()))
+        }
+
+        staged.moveFile(tmp, file)
+    }
+
+    return nil
+}
+
+// 导入权限规则，已存在的规则会被替换
+func (this *Importer) applyRules(tx *gorm.DB, pkg *Package) error {
+    enabled := model.IsEnableExtension(pkg.Manifest.Name)
+
+    rule := extension.NewRule().WithDB(tx)
+    for _, data := range pkg.Manifest.Rules {
+        slug := goch.ToString(data["slug"])
+        if slug == "" {
+            continue
+        }
+
+        rule.Delete(slug)
+        rule.Create(normalizeRule(data), "0")
+
+        if enabled {
+            rule.Enable(slug)
+        }
+
+        if err := rule.Err(); err != nil {
+            return errors.New(fmt.Sprintf("权限规则[%s]导入失败：%s", slug, err.Error()))
+        }
+    }
+
+    return nil
+}
+
+// 临时写入的文件，提交时移动到正式目录
+type stagedFiles struct {
+    // 临时目录
+    dirs []string
+
+    // 替换的目录，临时目录 => 正式目录
+    replaces [][2]string
+
+    // 移动的文件，临时文件 => 正式文件
+    moves [][2]string
+}
+
+// 在 base 下创建临时目录，和正式目录在同一文件系统中
+func (this *stagedFiles) tempDir(base string) (string, error) {
+    if err := os.MkdirAll(base, 0755); err != nil {
+        return "", err
+    }
+
+    dir, err := os.MkdirTemp(base, ".extension-pack-")
+    if err != nil {
+        return "", err
+    }
+
+    this.dirs = append(this.dirs, dir)
+
+    return dir, nil
+}
+
+func (this *stagedFiles) replaceDir(from string, to string) {
+    this.replaces = append(this.replaces, [2]string{from, to})
+}
+
+func (this *stagedFiles) moveFile(from string, to string) {
+    this.moves = append(this.moves, [2]string{from, to})
+}
+
+// 移动到正式目录，替换目录时先备份，失败时恢复
+func (this *stagedFiles) commit() error {
+    for _, r := range this.replaces {
+        from, to := r[0], r[1]
+
+        backup := from + ".old"
+        if _, err := os.Stat(to); err == nil {
+            if err := os.Rename(to, backup); err != nil {
+                return errors.New(fmt.Sprintf("静态资源[%s]替换失败：%s", to, err.Error()))
+            }
+        }
+
+        if err := os.Rename(from, to); err != nil {
+            os.Rename(backup, to)
+
+            return errors.New(fmt.Sprintf("静态资源[%s]替换失败：%s", to, err.Error()))
+        }
+
+        os.RemoveAll(backup)
+    }
+
+    for _, m := range this.moves {
+        from, to := m[0], m[1]
+
+        if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+            return errors.New(fmt.Sprintf("配置文件[%s]写入失败：%s", to, err.Error()))
+        }
+
+        if err := os.Rename(from, to); err != nil {
+            return errors.New(fmt.Sprintf("配置文件[%s]写入失败：%s", to, err.Error()))
+        }
+    }
+
+    return nil
+}
+
+// 删除临时目录
+func (this *stagedFiles) clean() {
+    for _, dir := range this.dirs {
+        os.RemoveAll(dir)
+        os.RemoveAll(dir + ".old")
+    }
+}
+
+// json 解析后的子规则转换为 Rule.Create 使用的格式
+func normalizeRule(data map[string]any) map[string]any {
+    rule := make(map[string]any, len(data))
+    for k, v := range data {
+        rule[k] = v
+    }
+
+    if children, ok := data["children"].([]any); ok {
+        list := make([]map[string]any, 0, len(children))
+        for _, child := range children {
+            if c, ok := child.(map[string]any); ok {
+                list = append(list, normalizeRule(c))
+            }
+        }
+
+        rule["children"] = list
+    }
+
+    return rule
+}
+
+func writeFile(file string, data []byte) error {
+    if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+        return err
+    }
+
+    return os.WriteFile(file, data, 0644)
+}
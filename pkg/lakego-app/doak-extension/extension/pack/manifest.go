@@ -0,0 +1,126 @@
+package pack
+
+import (
+    "sort"
+    "strings"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+)
+
+// 扩展包内文件
+const (
+    // 清单
+    ManifestFile = "extension.json"
+
+    // 清单签名
+    SignatureFile = "extension.sig"
+
+    // 静态资源目录
+    AssetsDir = "assets/"
+
+    // 数据库文件目录，按文件名顺序执行
+    SqlDir = "sql/"
+
+    // 默认配置目录
+    ConfigDir = "config/"
+)
+
+// 文件信息
+type File struct {
+    // 包内路径
+    Path string `json:"path"`
+
+    // 大小
+    Size int64 `json:"size"`
+
+    // sha256 摘要
+    Sha256 string `json:"sha256"`
+}
+
+/**
+ * 扩展包清单
+ *
+ * 签名只针对清单，清单中记录包内其他文件的摘要
+ *
+ * @create 2024-5-23
+ * @author deatil
+ */
+type Manifest struct {
+    // 扩展名称
+    Name string `json:"name"`
+
+    // 扩展标题
+    Title string `json:"title"`
+
+    // 版本号，需要和编译的扩展一致
+    Version string `json:"version"`
+
+    // 适配系统版本
+    Adaptation string `json:"adaptation"`
+
+    // 依赖扩展
+    Require map[string]string `json:"require"`
+
+    // 包内文件
+    Files []File `json:"files"`
+
+    // 权限规则，格式同 extension.Rule.Create
+    Rules []map[string]any `json:"rules"`
+
+    // 打包时间
+    CreateTime int64 `json:"create_time"`
+}
+
+// 添加文件
+func (this *Manifest) AddFile(name string, data []byte) {
+    sum := sha256.Sum256(data)
+
+    this.Files = append(this.Files, File{
+        Path:   name,
+        Size:   int64(len(data)),
+        Sha256: hex.EncodeToString(sum[:]),
+    })
+
+    sort.Slice(this.Files, func(i, j int) bool {
+        return this.Files[i].Path < this.Files[j].Path
+    })
+}
+
+// 获取文件信息
+func (this *Manifest) GetFile(name string) (File, bool) {
+    for _, f := range this.Files {
+        if f.Path == name {
+            return f, true
+        }
+    }
+
+    return File{}, false
+}
+
+// 指定目录下的文件，按路径排序
+func (this *Manifest) FilesIn(dir string) []File {
+    files := make([]File, 0)
+    for _, f := range this.Files {
+        if strings.HasPrefix(f.Path, dir) {
+            files = append(files, f)
+        }
+    }
+
+    return files
+}
+
+// 生成 json
+func (this *Manifest) ToJSON() ([]byte, error) {
+    return json.MarshalIndent(this, "", "  ")
+}
+
+// 解析清单
+func ParseManifest(data []byte) (*Manifest, error) {
+    m := &Manifest{}
+    if err := json.Unmarshal(data, m); err != nil {
+        return nil, err
+    }
+
+    return m, nil
+}
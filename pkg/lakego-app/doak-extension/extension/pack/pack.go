@@ -0,0 +1,229 @@
+package pack
+
+import (
+    "os"
+    "io"
+    "fmt"
+    "bytes"
+    "errors"
+    "strings"
+    "archive/zip"
+    "path/filepath"
+
+    "github.com/deatil/go-datebin/datebin"
+
+    admin_model "github.com/deatil/lakego-doak-admin/admin/model"
+
+    "github.com/deatil/lakego-doak-extension/extension/extension"
+)
+
+// 打包设置
+type Options struct {
+    // 数据目录，包含 assets、sql 及 config 目录
+    Source string
+
+    // 需要导出的权限规则 slug
+    Rules []string
+
+    // 签名
+    Signer *Signer
+}
+
+/**
+ * 生成扩展包
+ *
+ * 扩展代码需要编译在程序中，扩展包只包含扩展数据
+ *
+ * @create 2024-5-23
+ * @author deatil
+ */
+func Pack(ext extension.Extension, opts Options) ([]byte, error) {
+    if opts.Signer == nil {
+        return nil, errors.New("扩展包签名私钥不能为空")
+    }
+
+    manifest := &Manifest{
+        Name:       ext.Name,
+        Title:      ext.Title,
+        Version:    ext.Version,
+        Adaptation: ext.Adaptation,
+        Require:    ext.Require,
+        Files:      make([]File, 0),
+        Rules:      make([]map[string]any, 0),
+        CreateTime: datebin.NowTimestamp(),
+    }
+
+    files := make(map[string][]byte)
+    if opts.Source != "" {
+        for _, dir := range []string{AssetsDir, SqlDir, ConfigDir} {
+            err := readDir(opts.Source, dir, files)
+            if err != nil {
+                return nil, err
+            }
+        }
+    }
+
+    for name, data := range files {
+        manifest.AddFile(name, data)
+    }
+
+    for _, slug := range opts.Rules {
+        rule := ExportRule(slug)
+        if rule == nil {
+            return nil, errors.New(fmt.Sprintf("权限规则[%s]不存在", slug))
+        }
+
+        manifest.Rules = append(manifest.Rules, rule)
+    }
+
+    manifestData, err := manifest.ToJSON()
+    if err != nil {
+        return nil, err
+    }
+
+    sig, err := opts.Signer.Sign(manifestData)
+    if err != nil {
+        return nil, errors.New("扩展包签名失败：" + err.Error())
+    }
+
+    sigData, _ := jsonIndent(sig)
+
+    buf := new(bytes.Buffer)
+    zw := zip.NewWriter(buf)
+
+    write := func(name string, data []byte) error {
+        w, err := zw.Create(name)
+        if err != nil {
+            return err
+        }
+
+        _, err = w.Write(data)
+        return err
+    }
+
+    if err := write(ManifestFile, manifestData); err != nil {
+        return nil, err
+    }
+    if err := write(SignatureFile, sigData); err != nil {
+        return nil, err
+    }
+
+    for _, f := range manifest.Files {
+        if err := write(f.Path, files[f.Path]); err != nil {
+            return nil, err
+        }
+    }
+
+    if err := zw.Close(); err != nil {
+        return nil, err
+    }
+
+    return buf.Bytes(), nil
+}
+
+// 生成扩展包文件
+func PackFile(ext extension.Extension, opts Options, output string) error {
+    data, err := Pack(ext, opts)
+    if err != nil {
+        return err
+    }
+
+    if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+        return err
+    }
+
+    return os.WriteFile(output, data, 0644)
+}
+
+// 导出权限规则，包含 slug 对应的规则
+func ExportRule(slug string) map[string]any {
+    var info admin_model.AuthRule
+
+    err := admin_model.NewAuthRule().
+        Where("slug = ?", slug).
+        First(&info).
+        Error
+    if err != nil {
+        return nil
+    }
+
+    return map[string]any{
+        "title":       info.Title,
+        "url":         info.Url,
+        "method":      info.Method,
+        "slug":        info.Slug,
+        "description": info.Description,
+        "children":    formatRules(extension.NewRule().Export(slug)),
+    }
+}
+
+// 只保留创建规则需要的字段
+func formatRules(rules []map[string]any) []map[string]any {
+    list := make([]map[string]any, 0, len(rules))
+    for _, rule := range rules {
+        item := make(map[string]any)
+        for _, key := range []string{"title", "url", "method", "slug", "description"} {
+            item[key] = rule[key]
+        }
+
+        if children, ok := rule["children"].([]map[string]any); ok && len(children) > 0 {
+            item["children"] = formatRules(children)
+        }
+
+        list = append(list, item)
+    }
+
+    return list
+}
+
+// 读取目录文件
+func readDir(root string, dir string, files map[string][]byte) error {
+    base := filepath.Join(root, dir)
+    if _, err := os.Stat(base); os.IsNotExist(err) {
+        return nil
+    }
+
+    return filepath.Walk(base, func(file string, info os.FileInfo, err error) error {
+        if err != nil || info.IsDir() {
+            return err
+        }
+
+        rel, err := filepath.Rel(root, file)
+        if err != nil {
+            return err
+        }
+
+        f, err := os.Open(file)
+        if err != nil {
+            return err
+        }
+        defer f.Close()
+
+        data, err := io.ReadAll(f)
+        if err != nil {
+            return err
+        }
+
+        files[filepath.ToSlash(rel)] = data
+
+        return nil
+    })
+}
+
+// 检测包内路径
+func checkName(name string) bool {
+    if name == "" ||
+        strings.HasPrefix(name, "/") ||
+        strings.Contains(name, "\\") ||
+        strings.Contains(name, ":") {
+        return false
+    }
+
+    for _, part := range strings.Split(name, "/") {
+        if part == ".." {
+            return false
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,170 @@
+package pack
+
+import (
+    "os"
+    "io"
+    "fmt"
+    "bytes"
+    "errors"
+    "strings"
+    "archive/zip"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+)
+
+/**
+ * 扩展包
+ *
+ * 读取后需要先调用 Verify 验证签名及文件摘要
+ *
+ * @create 2024-5-23
+ * @author deatil
+ */
+type Package struct {
+    // 清单
+    Manifest *Manifest
+
+    // 签名
+    Signature *Signature
+
+    // 清单原始数据
+    manifestData []byte
+
+    // 包内文件
+    files map[string][]byte
+
+    // 是否已验证
+    verified bool
+}
+
+// 读取扩展包文件，maxSize 为解压后最大大小，0 为不限制
+func Open(file string, maxSize int64) (*Package, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+
+    return Read(data, maxSize)
+}
+
+// 读取扩展包
+func Read(data []byte, maxSize int64) (*Package, error) {
+    zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+    if err != nil {
+        return nil, errors.New("扩展包格式错误")
+    }
+
+    pkg := &Package{
+        files: make(map[string][]byte),
+    }
+
+    var total int64
+    for _, f := range zr.File {
+        if f.FileInfo().IsDir() {
+            continue
+        }
+
+        if !checkName(f.Name) {
+            return nil, errors.New(fmt.Sprintf("扩展包文件[%s]路径错误", f.Name))
+        }
+
+        if _, ok := pkg.files[f.Name]; ok {
+            return nil, errors.New(fmt.Sprintf("扩展包文件[%s]重复", f.Name))
+        }
+
+        rc, err := f.Open()
+        if err != nil {
+            return nil, err
+        }
+
+        var r io.Reader = rc
+        if maxSize > 0 {
+            r = io.LimitReader(rc, maxSize - total + 1)
+        }
+
+        content, err := io.ReadAll(r)
+        rc.Close()
+        if err != nil {
+            return nil, err
+        }
+
+        total += int64(len(content))
+        if maxSize > 0 && total > maxSize {
+            return nil, errors.New("扩展包大小超过限制")
+        }
+
+        pkg.files[f.Name] = content
+    }
+
+    manifestData, ok := pkg.files[ManifestFile]
+    if !ok {
+        return nil, errors.New("扩展包清单不存在")
+    }
+
+    pkg.Manifest, err = ParseManifest(manifestData)
+    if err != nil {
+        return nil, errors.New("扩展包清单格式错误")
+    }
+
+    pkg.manifestData = manifestData
+
+    sigData, ok := pkg.files[SignatureFile]
+    if !ok {
+        return nil, ErrSignatureEmpty
+    }
+
+    pkg.Signature, err = ParseSignature(sigData)
+    if err != nil {
+        return nil, errors.New("扩展包签名格式错误")
+    }
+
+    return pkg, nil
+}
+
+// 验证签名及文件摘要
+func (this *Package) Verify(keyring Keyring) error {
+    if err := keyring.Verify(this.manifestData, this.Signature); err != nil {
+        return err
+    }
+
+    for name := range this.files {
+        if name == ManifestFile || name == SignatureFile {
+            continue
+        }
+
+        if _, ok := this.Manifest.GetFile(name); !ok {
+            return errors.New(fmt.Sprintf("扩展包文件[%s]不在清单中", name))
+        }
+    }
+
+    for _, f := range this.Manifest.Files {
+        data, ok := this.files[f.Path]
+        if !ok {
+            return errors.New(fmt.Sprintf("扩展包文件[%s]不存在", f.Path))
+        }
+
+        sum := sha256.Sum256(data)
+        if int64(len(data)) != f.Size || !strings.EqualFold(hex.EncodeToString(sum[:]), f.Sha256) {
+            return errors.New(fmt.Sprintf("扩展包文件[%s]摘要错误", f.Path))
+        }
+    }
+
+    this.verified = true
+
+    return nil
+}
+
+// 是否已验证
+func (this *Package) Verified() bool {
+    return this.verified
+}
+
+// 包内文件数据
+func (this *Package) File(name string) []byte {
+    return this.files[name]
+}
+
+func jsonIndent(v any) ([]byte, error) {
+    return json.MarshalIndent(v, "", "  ")
+}
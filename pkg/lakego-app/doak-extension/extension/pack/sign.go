@@ -0,0 +1,200 @@
+package pack
+
+import (
+    "errors"
+    "strings"
+    "encoding/json"
+
+    "github.com/deatil/go-cryptobin/cryptobin/sm2"
+    "github.com/deatil/go-cryptobin/cryptobin/eddsa"
+)
+
+// 签名算法
+const (
+    AlgEd25519 = "ed25519"
+    AlgSM2     = "sm2"
+)
+
+var (
+    ErrAlgNotSupport  = errors.New("签名算法不支持")
+    ErrSignatureEmpty = errors.New("扩展包签名不存在")
+    ErrUntrusted      = errors.New("扩展包签名验证失败，签名公钥不受信任或者扩展包被修改")
+)
+
+// 签名信息，保存在 extension.sig 文件
+type Signature struct {
+    // 签名算法
+    Alg string `json:"alg"`
+
+    // 签名公钥名称
+    KeyName string `json:"key"`
+
+    // base64 编码的签名
+    Value string `json:"signature"`
+}
+
+// 解析签名信息
+func ParseSignature(data []byte) (*Signature, error) {
+    sig := &Signature{}
+    if err := json.Unmarshal(data, sig); err != nil {
+        return nil, err
+    }
+
+    if sig.Value == "" {
+        return nil, ErrSignatureEmpty
+    }
+
+    return sig, nil
+}
+
+// 签名
+type Signer struct {
+    Alg     string
+    KeyName string
+
+    // pem 格式私钥
+    key      []byte
+    password string
+}
+
+// 构造函数
+func NewSigner(alg string, keyName string, key []byte, password string) (*Signer, error) {
+    alg = strings.ToLower(alg)
+    if alg != AlgEd25519 && alg != AlgSM2 {
+        return nil, ErrAlgNotSupport
+    }
+
+    return &Signer{
+        Alg:      alg,
+        KeyName:  keyName,
+        key:      key,
+        password: password,
+    }, nil
+}
+
+// 签名数据
+func (this *Signer) Sign(data []byte) (*Signature, error) {
+    var value string
+    var err error
+
+    switch this.Alg {
+        case AlgEd25519:
+            obj := eddsa.New()
+            if this.password != "" {
+                obj = obj.FromPrivateKeyWithPassword(this.key, this.password)
+            } else {
+                obj = obj.FromPrivateKey(this.key)
+            }
+
+            obj = obj.FromBytes(data).Sign()
+            value, err = obj.ToBase64String(), obj.Error()
+        case AlgSM2:
+            obj := sm2.New()
+            if this.password != "" {
+                obj = obj.FromPrivateKeyWithPassword(this.key, this.password)
+            } else {
+                obj = obj.FromPrivateKey(this.key)
+            }
+
+            obj = obj.FromBytes(data).Sign()
+            value, err = obj.ToBase64String(), obj.Error()
+    }
+
+    if err != nil {
+        return nil, err
+    }
+
+    return &Signature{
+        Alg:     this.Alg,
+        KeyName: this.KeyName,
+        Value:   value,
+    }, nil
+}
+
+// 信任的公钥
+type TrustedKey struct {
+    // 名称
+    Name string
+
+    // 签名算法
+    Alg string
+
+    // pem 格式公钥
+    Key []byte
+}
+
+// 验证签名
+func (this TrustedKey) Verify(data []byte, sig *Signature) bool {
+    if !strings.EqualFold(this.Alg, sig.Alg) {
+        return false
+    }
+
+    switch strings.ToLower(this.Alg) {
+        case AlgEd25519:
+            obj := eddsa.New().
+                FromPublicKey(this.Key).
+                FromBase64String(sig.Value).
+                Verify(data)
+
+            return obj.Error() == nil && obj.ToVerify()
+        case AlgSM2:
+            obj := sm2.New().
+                FromPublicKey(this.Key).
+                FromBase64String(sig.Value).
+                Verify(data)
+
+            return obj.Error() == nil && obj.ToVerify()
+    }
+
+    return false
+}
+
+// 信任的公钥列表
+type Keyring []TrustedKey
+
+// 验证签名，签名带公钥名称时只使用同名公钥
+func (this Keyring) Verify(data []byte, sig *Signature) error {
+    if sig == nil || sig.Value == "" {
+        return ErrSignatureEmpty
+    }
+
+    for _, key := range this {
+        if sig.KeyName != "" && key.Name != sig.KeyName {
+            continue
+        }
+
+        if key.Verify(data, sig) {
+            return nil
+        }
+    }
+
+    return ErrUntrusted
+}
+
+// 生成密钥对，返回 pem 格式私钥及公钥
+func GenerateKey(alg string) ([]byte, []byte, error) {
+    switch strings.ToLower(alg) {
+        case AlgEd25519:
+            obj := eddsa.New().GenerateKey()
+
+            pri := obj.CreatePrivateKey()
+            pub := obj.CreatePublicKey()
+            if err := pri.Error(); err != nil {
+                return nil, nil, err
+            }
+
+            return pri.ToKeyBytes(), pub.ToKeyBytes(), pub.Error()
+        case AlgSM2:
+            obj := sm2.New().GenerateKey()
+
+            pri := obj.CreatePrivateKey()
+            pub := obj.CreatePublicKey()
+            if err := pri.Error(); err != nil {
+                return nil, nil, err
+            }
+
+            return pri.ToKeyBytes(), pub.ToKeyBytes(), pub.Error()
+    }
+
+    return nil, nil, ErrAlgNotSupport
+}
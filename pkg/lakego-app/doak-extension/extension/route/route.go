@@ -18,4 +18,9 @@ func Route(engine gin.IRouter) {
     engine.PATCH("/extension/:name/sort", extController.Listorder)
     engine.PATCH("/extension/:name/enable", extController.Enable)
     engine.PATCH("/extension/:name/disable", extController.Disable)
+
+    // 扩展设置
+    settingController := new(controller.Setting)
+    engine.GET("/extension/:name/setting", settingController.Detail)
+    engine.PUT("/extension/:name/setting", settingController.Update)
 }
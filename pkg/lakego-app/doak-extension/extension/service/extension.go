@@ -3,6 +3,10 @@ package service
 import (
     "fmt"
     "errors"
+    "strings"
+    "context"
+
+    "gorm.io/gorm"
 
     "github.com/deatil/go-goch/goch"
     "github.com/deatil/go-datebin/datebin"
@@ -17,14 +21,41 @@ import (
     "github.com/deatil/lakego-doak-extension/extension/extension"
 )
 
+// 被依赖错误
+type DependentsError struct {
+    // 扩展
+    Name string
+
+    // 操作，比如 禁用
+    Action string
+
+    // 依赖当前扩展的扩展
+    Dependents []string
+}
+
+func (this *DependentsError) Error() string {
+    return fmt.Sprintf(
+        "扩展[%s]被扩展[%s]依赖，请先%s依赖的扩展或者同时%s",
+        this.Name,
+        strings.Join(this.Dependents, ", "),
+        this.Action,
+        this.Action,
+    )
+}
+
 /**
  * 扩展
  *
+ * 安装、卸载、更新、启用及禁用在事务中执行，扩展方法返回错误时回滚
+ *
  * @create 2023-7-3
  * @author deatil
  */
 type Extension struct {
     Ctx *router.Context
+
+    // 禁用及卸载时同时处理依赖当前扩展的扩展
+    Cascade bool
 }
 
 // 构造函数
@@ -34,7 +65,7 @@ func NewExtension() *Extension {
 
 // 构造函数
 func NewExtensionWithCtx(ctx *router.Context) *Extension {
-    return &Extension{ctx}
+    return &Extension{Ctx: ctx}
 }
 
 // 设置上下文
@@ -44,6 +75,13 @@ func (this *Extension) WithCtx(ctx *router.Context) *Extension {
     return this
 }
 
+// 设置是否同时处理依赖的扩展
+func (this *Extension) WithCascade(cascade bool) *Extension {
+    this.Cascade = cascade
+
+    return this
+}
+
 // 本地扩展
 func (this *Extension) Local() []map[string]any {
     exts := extension.GetManager().GetExtensions()
@@ -106,6 +144,10 @@ func (this *Extension) Inatll(name string) error {
         return errors.New(fmt.Sprintf("扩展[%s]适配系统版本[%s]错误", info.Adaptation, adminVersion))
     }
 
+    if err := CheckExtensionCycle(name); err != nil {
+        return err
+    }
+
     if ok, err := CheckExtensionRequire(info.Require); !ok {
         return err
     }
@@ -114,38 +156,30 @@ func (this *Extension) Inatll(name string) error {
         return errors.New("扩展已经安装")
     }
 
-    ip := "0.0.0.0"
-    if this.Ctx != nil {
-        ip = router.GetRequestIp(this.Ctx)
-    }
-
-    insertData := model.Extension{
-        Name: name,
-        Title: info.Title,
-        Version: info.Version,
-        Adaptation: info.Adaptation,
-        Info: string(info.ToJSON()),
-        Listorder: 100,
-        Status: 0,
-        UpdateTime: int(datebin.NowTimestamp()),
-        UpdateIp: ip,
-        AddTime: int(datebin.NowTimestamp()),
-        AddIp: ip,
-    }
-
-    err = model.NewDB().
-        Create(&insertData).
-        Error
-    if err != nil {
-        return errors.New("安装扩展失败")
-    }
+    ip := this.requestIp()
+
+    return this.transaction(func(tx *gorm.DB) error {
+        insertData := model.Extension{
+            Name: name,
+            Title: info.Title,
+            Version: info.Version,
+            Adaptation: info.Adaptation,
+            Info: string(info.ToJSON()),
+            Listorder: 100,
+            Status: 0,
+            UpdateTime: int(datebin.NowTimestamp()),
+            UpdateIp: ip,
+            AddTime: int(datebin.NowTimestamp()),
+            AddIp: ip,
+        }
 
-    // 执行方法
-    if info.Install != nil {
-        info.Install()
-    }
+        err := tx.Create(&insertData).Error
+        if err != nil {
+            return errors.New("安装扩展失败")
+        }
 
-    return nil
+        return callHook(tx, name, "安装", info.InstallTx, info.Install)
+    })
 }
 
 // 卸载扩展
@@ -154,28 +188,43 @@ func (this *Extension) Uninstall(name string) error {
         return errors.New("扩展不能为空")
     }
 
+    if !model.IsInstallExtension(name) {
+        return errors.New("扩展没有被安装")
+    }
+
     if model.IsEnableExtension(name) {
-        return errors.New("扩展没有被安装或者请先禁用扩展")
+        return errors.New("请先禁用扩展")
     }
 
-    // 删除
-    err := model.NewExtension().
-        Where("name = ?", name).
-        Delete(&model.Extension{}).
-        Error
+    dependents, err := InstalledDependents(name)
     if err != nil {
-        return errors.New("卸载扩展失败")
+        return err
     }
 
-    info := extension.GetManager().GetExtension(name)
-    if info.Name != "" {
-        // 执行方法
-        if info.Uninstall != nil {
-            info.Uninstall()
+    if len(dependents) > 0 && !this.Cascade {
+        return &DependentsError{
+            Name: name,
+            Action: "卸载",
+            Dependents: dependents,
         }
     }
 
-    return nil
+    return this.transaction(func(tx *gorm.DB) error {
+        // 依赖方在前
+        for _, dep := range dependents {
+            if model.IsEnableExtension(dep) {
+                if err := this.disable(tx, dep); err != nil {
+                    return err
+                }
+            }
+
+            if err := this.uninstall(tx, dep); err != nil {
+                return err
+            }
+        }
+
+        return this.uninstall(tx, name)
+    })
 }
 
 // 更新扩展
@@ -217,39 +266,37 @@ func (this *Extension) Upgrade(name string) error {
         return errors.New(fmt.Sprintf("扩展[%s]升级到版本[%s]错误", installInfo.Version, info.Version))
     }
 
-    if ok, err := CheckExtensionRequire(info.Require); !ok {
+    if err := CheckExtensionCycle(name); err != nil {
         return err
     }
 
-    ip := "0.0.0.0"
-    if this.Ctx != nil {
-        ip = router.GetRequestIp(this.Ctx)
-    }
-
-    err = model.NewExtension().
-        Where("name = ?", name).
-        Updates(map[string]any{
-            "title": info.Title,
-            "version": info.Version,
-            "adaptation": info.Adaptation,
-            "info": string(info.ToJSON()),
-            "update_time": int(datebin.NowTimestamp()),
-            "update_ip": ip,
-        }).
-        Error
-    if err != nil {
-        return errors.New("更新扩展失败")
+    if ok, err := CheckExtensionRequire(info.Require); !ok {
+        return err
     }
 
-    // 执行方法
-    if info.Upgrade != nil {
-        info.Upgrade()
-    }
+    ip := this.requestIp()
+
+    return this.transaction(func(tx *gorm.DB) error {
+        err := tx.Model(&model.Extension{}).
+            Where("name = ?", name).
+            Updates(map[string]any{
+                "title": info.Title,
+                "version": info.Version,
+                "adaptation": info.Adaptation,
+                "info": string(info.ToJSON()),
+                "update_time": int(datebin.NowTimestamp()),
+                "update_ip": ip,
+            }).
+            Error
+        if err != nil {
+            return errors.New("更新扩展失败")
+        }
 
-    return nil
+        return callHook(tx, name, "更新", info.UpgradeTx, info.Upgrade)
+    })
 }
 
-// 启用扩展
+// 启用扩展，依赖的扩展需要已经启用
 func (this *Extension) Enable(name string) error {
     if name == "" {
         return errors.New("扩展不能为空")
@@ -260,26 +307,37 @@ func (this *Extension) Enable(name string) error {
         return errors.New("扩展不存在")
     }
 
+    if !model.IsInstallExtension(name) {
+        return errors.New("扩展没有被安装")
+    }
+
     if model.IsEnableExtension(name) {
         return errors.New("扩展已经启用")
     }
 
-    err := model.NewExtension().
-        Where("name = ?", name).
-        Updates(map[string]any{
-            "status": 1,
-        }).
-        Error
-    if err != nil {
-        return errors.New("启用扩展失败")
+    if err := CheckExtensionCycle(name); err != nil {
+        return err
     }
 
-    // 执行方法
-    if info.Enable != nil {
-        info.Enable()
+    for dep := range info.Require {
+        if !model.IsEnableExtension(dep) {
+            return errors.New(fmt.Sprintf("依赖扩展[%s]需要先启用", dep))
+        }
     }
 
-    return nil
+    return this.transaction(func(tx *gorm.DB) error {
+        err := tx.Model(&model.Extension{}).
+            Where("name = ?", name).
+            Updates(map[string]any{
+                "status": 1,
+            }).
+            Error
+        if err != nil {
+            return errors.New("启用扩展失败")
+        }
+
+        return callHook(tx, name, "启用", info.EnableTx, info.Enable)
+    })
 }
 
 // 禁用扩展
@@ -297,19 +355,107 @@ func (this *Extension) Disable(name string) error {
         return errors.New("扩展已经禁用")
     }
 
-    err := model.NewExtension().
+    installedDependents, err := InstalledDependents(name)
+    if err != nil {
+        return err
+    }
+
+    dependents := make([]string, 0)
+    for _, dep := range installedDependents {
+        if model.IsEnableExtension(dep) {
+            dependents = append(dependents, dep)
+        }
+    }
+
+    if len(dependents) > 0 && !this.Cascade {
+        return &DependentsError{
+            Name: name,
+            Action: "禁用",
+            Dependents: dependents,
+        }
+    }
+
+    return this.transaction(func(tx *gorm.DB) error {
+        // 依赖方在前
+        for _, dep := range dependents {
+            if err := this.disable(tx, dep); err != nil {
+                return err
+            }
+        }
+
+        return this.disable(tx, name)
+    })
+}
+
+// 禁用单个扩展
+func (this *Extension) disable(tx *gorm.DB, name string) error {
+    err := tx.Model(&model.Extension{}).
         Where("name = ?", name).
         Updates(map[string]any{
             "status": 0,
         }).
         Error
     if err != nil {
-        return errors.New("禁用扩展失败")
+        return errors.New(fmt.Sprintf("禁用扩展[%s]失败", name))
     }
 
-    // 执行方法
-    if info.Disable != nil {
-        info.Disable()
+    info := extension.GetManager().GetExtension(name)
+
+    return callHook(tx, name, "禁用", info.DisableTx, info.Disable)
+}
+
+// 卸载单个扩展
+func (this *Extension) uninstall(tx *gorm.DB, name string) error {
+    err := tx.Where("name = ?", name).
+        Delete(&model.Extension{}).
+        Error
+    if err != nil {
+        return errors.New(fmt.Sprintf("卸载扩展[%s]失败", name))
+    }
+
+    // 删除扩展设置
+    err = tx.Where("name = ?", name).
+        Delete(&model.ExtensionSetting{}).
+        Error
+    if err != nil {
+        return errors.New(fmt.Sprintf("删除扩展[%s]设置失败", name))
+    }
+
+    extension.ForgetSettings(name)
+
+    info := extension.GetManager().GetExtension(name)
+
+    return callHook(tx, name, "卸载", info.UninstallTx, info.Uninstall)
+}
+
+// 事务执行，权限菜单修改事件在提交后触发
+func (this *Extension) transaction(fn func(tx *gorm.DB) error) error {
+    return admin_model.Transaction(context.Background(), fn)
+}
+
+// 请求 ip
+func (this *Extension) requestIp() string {
+    if this.Ctx != nil {
+        return router.GetRequestIp(this.Ctx)
+    }
+
+    return "0.0.0.0"
+}
+
+// 执行扩展方法，先执行使用事务的方法
+func callHook(tx *gorm.DB, name string, action string, hookTx func(*gorm.DB) error, hook func() error) error {
+    var err error
+
+    if hookTx != nil {
+        err = hookTx(tx)
+    }
+
+    if err == nil && hook != nil {
+        err = hook()
+    }
+
+    if err != nil {
+        return errors.New(fmt.Sprintf("扩展[%s]%s方法执行失败：%s", name, action, err.Error()))
     }
 
     return nil
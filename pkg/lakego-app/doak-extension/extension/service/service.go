@@ -3,9 +3,11 @@ package service
 import (
     "fmt"
     "errors"
+    "encoding/json"
 
     "github.com/deatil/lakego-doak-extension/extension/model"
     "github.com/deatil/lakego-doak-extension/extension/version"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
 )
 
 // 检测依赖
@@ -15,31 +17,83 @@ func CheckExtensionRequire(requires map[string]string) (bool, error) {
     }
 
     exts := make([]string, 0)
-    for _, require := range requires {
-        exts = append(exts, require)
+    for name := range requires {
+        exts = append(exts, name)
     }
 
     requireExts := make([]map[string]any, 0)
 
     model.NewExtension().
-        Where("name IN", exts).
+        Where("name IN ?", exts).
         Order("listorder DESC").
         Find(&requireExts)
-    if len(requireExts) == 0 {
-        return false, errors.New("需要的依赖扩展需要安装")
-    }
 
+    installed := make(map[string]string, len(requireExts))
     for _, requireExt := range requireExts {
-        extName := requireExt["name"].(string)
-        extVersion := requireExt["version"].(string)
+        installed[requireExt["name"].(string)] = requireExt["version"].(string)
+    }
 
-        if ver, ok := requires[extName]; ok {
-            err := version.VersionCheck(extVersion, ver)
-            if err != nil {
-                return false, errors.New(fmt.Sprintf("依赖扩展[%s]所需安装版本[%s]错误", ver, extVersion))
-            }
+    for _, name := range exts {
+        extVersion, ok := installed[name]
+        if !ok {
+            return false, errors.New(fmt.Sprintf("依赖扩展[%s]需要安装", name))
+        }
+
+        ver := requires[name]
+        err := version.VersionCheck(extVersion, ver)
+        if err != nil {
+            return false, errors.New(fmt.Sprintf("依赖扩展[%s]所需安装版本[%s]错误", name, ver))
         }
     }
 
     return true, nil
 }
+
+// 检测扩展及其依赖是否存在循环依赖
+func CheckExtensionCycle(name string) error {
+    graph := extension.GetManager().Graph()
+
+    names := make([]string, 0)
+    found := map[string]bool{name: true}
+
+    var walk func(string)
+    walk = func(n string) {
+        names = append(names, n)
+
+        for _, dep := range graph.Requires(n) {
+            if !found[dep] {
+                found[dep] = true
+                walk(dep)
+            }
+        }
+    }
+
+    walk(name)
+
+    _, err := graph.Sort(names)
+
+    return err
+}
+
+// 已安装扩展中依赖当前扩展的扩展，依赖方在前，存在循环依赖时返回错误
+func InstalledDependents(name string) ([]string, error) {
+    return InstalledGraph().AllDependents(name)
+}
+
+// 已安装扩展的依赖图，优先使用已添加扩展的信息
+func InstalledGraph() *extension.Graph {
+    extManager := extension.GetManager()
+
+    exts := make([]extension.Extension, 0)
+    for _, installed := range model.GetInstalledExtensions() {
+        info := extManager.GetExtension(installed.Name)
+        if info.Name == "" {
+            info.Name = installed.Name
+            json.Unmarshal([]byte(installed.Info), &info)
+        }
+
+        exts = append(exts, info)
+    }
+
+    return extension.NewGraph(exts)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+    "fmt"
+    "sort"
+    "errors"
+    "strings"
+    "context"
+    "encoding/json"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/deatil/go-event/event"
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/validate"
+
+    "github.com/deatil/lakego-doak-extension/extension/model"
+    "github.com/deatil/lakego-doak-extension/extension/extension"
+    ext_event "github.com/deatil/lakego-doak-extension/extension/event"
+)
+
+// 设置验证错误，键名为字段名称
+type SettingError struct {
+    Errors map[string]string
+}
+
+func (this *SettingError) Error() string {
+    names := make([]string, 0, len(this.Errors))
+    for name := range this.Errors {
+        names = append(names, name)
+    }
+
+    if len(names) == 0 {
+        return "设置数据错误"
+    }
+
+    sort.Strings(names)
+
+    return this.Errors[names[0]]
+}
+
+/**
+ * 扩展设置
+ *
+ * @create 2024-5-24
+ * @author deatil
+ */
+type Setting struct {
+    Ctx *router.Context
+}
+
+// 构造函数
+func NewSetting() *Setting {
+    return &Setting{}
+}
+
+// 构造函数
+func NewSettingWithCtx(ctx *router.Context) *Setting {
+    return &Setting{Ctx: ctx}
+}
+
+// 设置字段
+func (this *Setting) Fields(name string) ([]extension.Setting, error) {
+    info := extension.GetManager().GetExtension(name)
+    if info.Name == "" {
+        return nil, errors.New("扩展不存在")
+    }
+
+    return info.Settings, nil
+}
+
+// 当前设置
+func (this *Setting) Get(name string) (map[string]any, error) {
+    if _, err := this.Fields(name); err != nil {
+        return nil, err
+    }
+
+    return extension.Settings(name).All(), nil
+}
+
+// 更新设置，只处理设置字段中存在的数据，未提交的字段保持原值
+func (this *Setting) Update(name string, post map[string]any) (map[string]any, error) {
+    fields, err := this.Fields(name)
+    if err != nil {
+        return nil, err
+    }
+
+    if len(fields) == 0 {
+        return nil, errors.New("扩展没有设置字段")
+    }
+
+    if !model.IsInstallExtension(name) {
+        return nil, errors.New("扩展没有被安装")
+    }
+
+    old := extension.LoadSettings(name).All()
+
+    data := make(map[string]any, len(fields))
+    errs := make(map[string]string)
+    for _, f := range fields {
+        raw, ok := post[f.Name]
+        if !ok {
+            data[f.Name] = old[f.Name]
+            continue
+        }
+
+        value, err := f.Convert(raw)
+        if err != nil {
+            errs[f.Name] = fmt.Sprintf("%s格式错误", fieldTitle(f))
+            continue
+        }
+
+        data[f.Name] = value
+    }
+
+    if len(errs) > 0 {
+        return nil, &SettingError{Errors: errs}
+    }
+
+    rules, messages := extension.SettingRules(fields)
+    if ok, verrs := validate.ValidateMap(data, rules, messages); !ok {
+        for key, msg := range verrs.All() {
+            field := key
+            if i := strings.LastIndex(key, "."); i > 0 {
+                field = key[:i]
+            }
+
+            if _, exists := errs[field]; !exists {
+                errs[field] = msg
+            }
+        }
+
+        return nil, &SettingError{Errors: errs}
+    }
+
+    ip := "0.0.0.0"
+    if this.Ctx != nil {
+        ip = router.GetRequestIp(this.Ctx)
+    }
+
+    err = model.NewDB().Transaction(func(tx *gorm.DB) error {
+        for _, f := range fields {
+            value, err := json.Marshal(data[f.Name])
+            if err != nil {
+                return err
+            }
+
+            now := int(datebin.NowTimestamp())
+
+            // 按 name 及 key 唯一索引写入，已存在时只更新设置值
+            err = tx.Clauses(clause.OnConflict{
+                Columns:   []clause.Column{{Name: "name"}, {Name: "key"}},
+                DoUpdates: clause.AssignmentColumns([]string{"value", "update_time", "update_ip"}),
+            }).Create(&model.ExtensionSetting{
+                Name: name,
+                Key: f.Name,
+                Value: string(value),
+                UpdateTime: now,
+                UpdateIp: ip,
+                AddTime: now,
+                AddIp: ip,
+            }).Error
+            if err != nil {
+                return err
+            }
+        }
+
+        return nil
+    })
+    if err != nil {
+        return nil, errors.New("更新设置失败")
+    }
+
+    extension.ForgetSettings(name)
+
+    ctx := context.Background()
+    if this.Ctx != nil {
+        ctx = router.TraceContext(this.Ctx)
+    }
+
+    err = event.Emit(ctx, ext_event.SettingUpdated{
+        Name: name,
+        Old: old,
+        New: data,
+    })
+    if err != nil {
+        facade.Logger.Error("[extension] " + err.Error())
+    }
+
+    return data, nil
+}
+
+// 字段显示名称
+func fieldTitle(f extension.Setting) string {
+    if f.Title != "" {
+        return f.Title
+    }
+
+    return f.Name
+}
@@ -0,0 +1,9 @@
+module github.com/deatil/lakego-doak-log-viewer
+
+go 1.18
+
+require (
+	github.com/deatil/go-goch v0.0.3
+	github.com/deatil/lakego-doak v0.0.3
+	github.com/deatil/lakego-doak-admin v0.0.3
+)
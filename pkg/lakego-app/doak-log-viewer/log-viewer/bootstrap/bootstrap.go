@@ -0,0 +1,14 @@
+package bootstrap
+
+import (
+    "github.com/deatil/lakego-doak/lakego/kernel"
+
+    "github.com/deatil/lakego-doak-log-viewer/log-viewer/provider"
+)
+
+// 添加服务提供者
+func Boot() {
+    kernel.AddProvider(func() any {
+        return &provider.LogViewer{}
+    })
+}
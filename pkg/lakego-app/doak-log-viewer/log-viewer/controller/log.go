@@ -0,0 +1,267 @@
+package controller
+
+import (
+    "time"
+    "regexp"
+    "strings"
+    "context"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+
+    adminController "github.com/deatil/lakego-doak-admin/admin/controller"
+
+    "github.com/deatil/lakego-doak-log-viewer/log-viewer/viewer"
+)
+
+/**
+ * 日志查看
+ *
+ * @create 2024-5-25
+ * @author deatil
+ */
+type Log struct {
+    adminController.Base
+}
+
+// 日志文件列表
+// @Summary 日志文件列表
+// @Description 日志文件列表
+// @Tags 日志查看
+// @Accept application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /log-viewer/files [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.log-viewer.files"}
+func (this *Log) Files(ctx *router.Context) {
+    files, err := newViewer().Files()
+    if err != nil {
+        this.Error(ctx, "获取日志文件失败")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "list": files,
+    })
+}
+
+// 查看日志
+// @Summary 查看日志
+// @Description 从文件末尾向前分页读取日志，使用返回的 next 作为 before 获取下一页
+// @Tags 日志查看
+// @Accept application/json
+// @Produce application/json
+// @Param name       path  string true  "日志文件"
+// @Param before     query string false "读取位置"
+// @Param limit      query string false "每页数量"
+// @Param level      query string false "等级，多个用逗号分隔"
+// @Param start_time query string false "开始时间"
+// @Param end_time   query string false "结束时间"
+// @Param keyword    query string false "关键字"
+// @Param regexp     query string false "正则表达式"
+// @Param request_id query string false "请求 id"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /log-viewer/files/{name} [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.log-viewer.read"}
+func (this *Log) Read(ctx *router.Context) {
+    filter, err := this.filter(ctx)
+    if err != "" {
+        this.Error(ctx, err)
+        return
+    }
+
+    conf := config.New("logviewer")
+
+    limit := goch.ToInt(ctx.DefaultQuery("limit", ""))
+    if limit <= 0 {
+        limit = conf.GetInt("limit")
+    }
+    if maxLimit := conf.GetInt("max-limit"); maxLimit > 0 && limit > maxLimit {
+        limit = maxLimit
+    }
+    if limit <= 0 {
+        limit = 50
+    }
+
+    before := goch.ToInt64(ctx.DefaultQuery("before", ""))
+
+    page, e := newViewer().Read(ctx.Param("name"), before, limit, filter)
+    if e != nil {
+        this.Error(ctx, e.Error())
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", page)
+}
+
+// 实时日志
+// @Summary 实时日志
+// @Description 使用 server-sent events 推送新增日志，事件：open、log
+// @Tags 日志查看
+// @Accept application/json
+// @Produce text/event-stream
+// @Param name       path  string true  "日志文件"
+// @Param offset     query string false "开始位置，默认为文件末尾"
+// @Param level      query string false "等级，多个用逗号分隔"
+// @Param keyword    query string false "关键字"
+// @Param regexp     query string false "正则表达式"
+// @Param request_id query string false "请求 id"
+// @Success 200 {string} string "event stream"
+// @Router /log-viewer/files/{name}/tail [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.log-viewer.tail"}
+func (this *Log) Tail(ctx *router.Context) {
+    filter, errMsg := this.filter(ctx)
+    if errMsg != "" {
+        this.Error(ctx, errMsg)
+        return
+    }
+
+    name := ctx.Param("name")
+
+    v := newViewer()
+    if _, err := v.Path(name); err != nil {
+        this.Error(ctx, err.Error())
+        return
+    }
+
+    conf := config.New("logviewer")
+
+    timeout := conf.GetDuration("tail.timeout")
+    if timeout <= 0 {
+        timeout = 30 * time.Minute
+    }
+
+    heartbeat := conf.GetDuration("tail.heartbeat")
+    if heartbeat <= 0 {
+        heartbeat = 15 * time.Second
+    }
+
+    offset := int64(-1)
+    if s := ctx.DefaultQuery("offset", ""); s != "" {
+        offset = goch.ToInt64(s)
+    }
+
+    reqCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+    defer cancel()
+
+    ctx.Header("Content-Type", "text/event-stream")
+    ctx.Header("Cache-Control", "no-cache")
+    ctx.Header("Connection", "keep-alive")
+    ctx.Header("X-Accel-Buffering", "no")
+
+    ctx.SSEvent("open", router.H{
+        "name": name,
+    })
+    ctx.Writer.Flush()
+
+    lastSend := time.Now()
+
+    v.Tail(reqCtx, name, viewer.TailOptions{
+        Offset:   offset,
+        Interval: conf.GetDuration("tail.interval"),
+        Filter:   filter,
+    }, func(entries []viewer.Entry) error {
+        if len(entries) == 0 {
+            if time.Since(lastSend) < heartbeat {
+                return nil
+            }
+
+            // 心跳
+            if _, err := ctx.Writer.WriteString(": ping\n\n"); err != nil {
+                return err
+            }
+        } else {
+            ctx.SSEvent("log", entries)
+        }
+
+        ctx.Writer.Flush()
+        lastSend = time.Now()
+
+        return reqCtx.Err()
+    })
+}
+
+// 下载日志
+// @Summary 下载日志
+// @Description 下载 gzip 压缩的日志文件
+// @Tags 日志查看
+// @Accept application/json
+// @Produce application/gzip
+// @Param name path string true "日志文件"
+// @Success 200 {string} string "gzip 文件"
+// @Router /log-viewer/files/{name}/download [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.log-viewer.download"}
+func (this *Log) Download(ctx *router.Context) {
+    name := ctx.Param("name")
+
+    v := newViewer()
+    if _, err := v.Path(name); err != nil {
+        this.Error(ctx, err.Error())
+        return
+    }
+
+    ctx.Header("Content-Type", "application/gzip")
+    ctx.Header("Content-Disposition", `attachment; filename="` + name + `.gz"`)
+
+    if err := v.Compress(name, ctx.Writer); err != nil {
+        ctx.Abort()
+    }
+}
+
+// 过滤条件
+func (this *Log) filter(ctx *router.Context) (viewer.Filter, string) {
+    filter := viewer.Filter{
+        Keyword:   ctx.DefaultQuery("keyword", ""),
+        RequestId: ctx.DefaultQuery("request_id", ""),
+    }
+
+    if level := ctx.DefaultQuery("level", ""); level != "" {
+        filter.Levels = strings.Split(level, ",")
+    }
+
+    if startTime := ctx.DefaultQuery("start_time", ""); startTime != "" {
+        filter.Start = time.Unix(this.FormatDate(startTime), 0)
+    }
+
+    if endTime := ctx.DefaultQuery("end_time", ""); endTime != "" {
+        filter.End = time.Unix(this.FormatDate(endTime), 0)
+    }
+
+    if expr := ctx.DefaultQuery("regexp", ""); expr != "" {
+        if len(expr) > 200 {
+            return filter, "正则表达式过长"
+        }
+
+        re, err := regexp.Compile(expr)
+        if err != nil {
+            return filter, "正则表达式错误"
+        }
+
+        filter.Regexp = re
+    }
+
+    return filter, ""
+}
+
+// 日志查看
+func newViewer() *viewer.Viewer {
+    conf := config.New("logviewer")
+
+    v := viewer.New(
+        path.FormatPath(conf.GetString("path")),
+        conf.GetStringSlice("exts"),
+    )
+
+    if maxScan := conf.GetInt64("max-scan"); maxScan > 0 {
+        v.MaxScan = maxScan * 1024 * 1024
+    }
+
+    return v
+}
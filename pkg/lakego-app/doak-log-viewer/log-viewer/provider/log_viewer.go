@@ -0,0 +1,55 @@
+package provider
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/provider"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
+
+    admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
+
+    viewer_router "github.com/deatil/lakego-doak-log-viewer/log-viewer/route"
+)
+
+/**
+ * 服务提供者
+ *
+ * @create 2024-5-25
+ * @author deatil
+ */
+type LogViewer struct {
+    provider.ServiceProvider
+}
+
+// 引导
+func (this *LogViewer) Boot() {
+    // 路由
+    this.loadRoute()
+
+    // 推送配置
+    this.publishConfig()
+}
+
+/**
+ * 导入路由
+ */
+func (this *LogViewer) loadRoute() {
+    // 后台路由
+    admin_route.AddRoute(func(engine *router.RouterGroup) {
+        viewer_router.Route(engine)
+    })
+}
+
+/**
+ * 推送配置
+ */
+func (this *LogViewer) publishConfig() {
+    // 配置
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-log-viewer/resources/config/logviewer.yml")
+
+    // 推送文件
+    // > go run main.go lakego:publish --tag=logviewer-config --force
+    toPath := pathTool.ConfigPath("/logviewer.yml")
+    this.Publishes(this, map[string]string{
+        path: toPath,
+    }, "logviewer-config")
+}
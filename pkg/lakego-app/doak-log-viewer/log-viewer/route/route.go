@@ -0,0 +1,33 @@
+package route
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+
+    "github.com/deatil/lakego-doak-log-viewer/log-viewer/controller"
+)
+
+/**
+ * 路由
+ */
+func Route(group router.IRouter) {
+    engine := router.WithMeta(group)
+
+    // 日志查看
+    logController := new(controller.Log)
+    engine.GET("/log-viewer/files", logController.Files).
+        Slug("lakego-admin.log-viewer.files").
+        Title("日志文件列表").
+        Parent("日志查看")
+    engine.GET("/log-viewer/files/:name", logController.Read).
+        Slug("lakego-admin.log-viewer.read").
+        Title("查看日志").
+        Parent("日志查看")
+    engine.GET("/log-viewer/files/:name/tail", logController.Tail).
+        Slug("lakego-admin.log-viewer.tail").
+        Title("实时日志").
+        Parent("日志查看")
+    engine.GET("/log-viewer/files/:name/download", logController.Download).
+        Slug("lakego-admin.log-viewer.download").
+        Title("下载日志").
+        Parent("日志查看")
+}
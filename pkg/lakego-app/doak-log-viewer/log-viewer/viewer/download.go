@@ -0,0 +1,35 @@
+package viewer
+
+import (
+    "os"
+    "io"
+    "compress/gzip"
+)
+
+// 压缩输出日志文件
+func (this *Viewer) Compress(name string, w io.Writer) error {
+    file, err := this.Path(name)
+    if err != nil {
+        return err
+    }
+
+    f, err := os.Open(file)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    gw, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+    if err != nil {
+        return err
+    }
+
+    gw.Name = name
+
+    if _, err := io.Copy(gw, f); err != nil {
+        gw.Close()
+        return err
+    }
+
+    return gw.Close()
+}
@@ -0,0 +1,247 @@
+package viewer
+
+import (
+    "time"
+    "strings"
+    "encoding/json"
+)
+
+// 日志时间格式
+var timeLayouts = []string{
+    time.RFC3339Nano,
+    time.RFC3339,
+    "2006-01-02 15:04:05.000",
+    "2006-01-02 15:04:05",
+    "2006-01-02T15:04:05",
+}
+
+/**
+ * 日志记录
+ *
+ * 支持 normal、text(logfmt) 及 json 格式
+ *
+ * @create 2024-5-25
+ * @author deatil
+ */
+type Entry struct {
+    // 所在位置
+    Offset int64 `json:"offset"`
+
+    // 时间
+    Time time.Time `json:"time"`
+
+    // 等级
+    Level string `json:"level"`
+
+    // 内容
+    Message string `json:"message"`
+
+    // 额外数据
+    Fields map[string]any `json:"fields"`
+
+    // 原始数据
+    Raw string `json:"raw"`
+}
+
+// 请求 id
+func (this Entry) RequestId() string {
+    if v, ok := this.Fields["request_id"].(string); ok {
+        return v
+    }
+
+    return ""
+}
+
+// 解析日志行
+func ParseLine(line string) Entry {
+    entry := Entry{
+        Raw:    line,
+        Fields: make(map[string]any),
+    }
+
+    trimmed := strings.TrimSpace(line)
+
+    switch {
+        case strings.HasPrefix(trimmed, "{"):
+            parseJSON(trimmed, &entry)
+        case strings.HasPrefix(trimmed, "["):
+            parseNormal(trimmed, &entry)
+        default:
+            parseLogfmt(trimmed, &entry)
+    }
+
+    entry.Level = normalizeLevel(entry.Level)
+
+    return entry
+}
+
+// json 格式
+func parseJSON(line string, entry *Entry) {
+    data := make(map[string]any)
+    if err := json.Unmarshal([]byte(line), &data); err != nil {
+        entry.Message = line
+        return
+    }
+
+    for k, v := range data {
+        switch k {
+            case "level":
+                entry.Level, _ = v.(string)
+            case "msg", "message":
+                entry.Message, _ = v.(string)
+            case "time":
+                s, _ := v.(string)
+                entry.Time = parseTime(s)
+            default:
+                entry.Fields[k] = v
+        }
+    }
+}
+
+// 正常格式，[时间] [等级] 内容 {额外数据}
+func parseNormal(line string, entry *Entry) {
+    rest := line
+
+    ts, rest, ok := cutBracket(rest)
+    if !ok {
+        entry.Message = line
+        return
+    }
+    entry.Time = parseTime(ts)
+
+    level, rest, ok := cutBracket(strings.TrimSpace(rest))
+    if ok {
+        entry.Level = level
+    }
+
+    rest = strings.TrimSpace(rest)
+
+    // 额外数据在最后
+    if strings.HasSuffix(rest, "}") {
+        if i := strings.LastIndex(rest, " {"); i >= 0 {
+            data := make(map[string]any)
+            if err := json.Unmarshal([]byte(rest[i+1:]), &data); err == nil {
+                entry.Fields = data
+                rest = rest[:i]
+            }
+        }
+    }
+
+    entry.Message = rest
+}
+
+// logfmt 格式，key=value key="value"
+func parseLogfmt(line string, entry *Entry) {
+    pairs := splitLogfmt(line)
+    if len(pairs) == 0 {
+        entry.Message = line
+        return
+    }
+
+    for _, kv := range pairs {
+        switch kv[0] {
+            case "level":
+                entry.Level = kv[1]
+            case "msg", "message":
+                entry.Message = kv[1]
+            case "time":
+                entry.Time = parseTime(kv[1])
+            default:
+                entry.Fields[kv[0]] = kv[1]
+        }
+    }
+
+    if entry.Level == "" && entry.Message == "" {
+        entry.Message = line
+    }
+}
+
+func splitLogfmt(line string) [][2]string {
+    pairs := make([][2]string, 0)
+
+    i := 0
+    for i < len(line) {
+        for i < len(line) && line[i] == ' ' {
+            i++
+        }
+
+        start := i
+        for i < len(line) && line[i] != '=' && line[i] != ' ' {
+            i++
+        }
+
+        if i >= len(line) || line[i] != '=' {
+            // 非 logfmt 格式
+            if start < len(line) {
+                return nil
+            }
+
+            break
+        }
+
+        key := line[start:i]
+        i++
+
+        var value string
+        if i < len(line) && line[i] == '"' {
+            i++
+
+            var b strings.Builder
+            for i < len(line) && line[i] != '"' {
+                if line[i] == '\\' && i+1 < len(line) {
+                    i++
+                }
+
+                b.WriteByte(line[i])
+                i++
+            }
+
+            i++
+            value = b.String()
+        } else {
+            start := i
+            for i < len(line) && line[i] != ' ' {
+                i++
+            }
+
+            value = line[start:i]
+        }
+
+        pairs = append(pairs, [2]string{key, value})
+    }
+
+    return pairs
+}
+
+func cutBracket(s string) (string, string, bool) {
+    if !strings.HasPrefix(s, "[") {
+        return "", s, false
+    }
+
+    end := strings.Index(s, "]")
+    if end < 0 {
+        return "", s, false
+    }
+
+    return s[1:end], s[end+1:], true
+}
+
+func parseTime(s string) time.Time {
+    for _, layout := range timeLayouts {
+        if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+            return t
+        }
+    }
+
+    return time.Time{}
+}
+
+// 统一等级名称
+func normalizeLevel(level string) string {
+    level = strings.ToLower(strings.TrimSpace(level))
+    if level == "warn" {
+        return "warning"
+    }
+
+    return level
+}
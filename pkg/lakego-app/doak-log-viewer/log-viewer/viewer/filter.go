@@ -0,0 +1,91 @@
+package viewer
+
+import (
+    "time"
+    "regexp"
+    "strings"
+)
+
+/**
+ * 日志过滤条件
+ *
+ * @create 2024-5-25
+ * @author deatil
+ */
+type Filter struct {
+    // 等级
+    Levels []string
+
+    // 时间范围
+    Start time.Time
+    End   time.Time
+
+    // 包含文本，不区分大小写
+    Keyword string
+
+    // 正则
+    Regexp *regexp.Regexp
+
+    // 请求 id
+    RequestId string
+}
+
+// 是否有过滤条件
+func (this Filter) Empty() bool {
+    return len(this.Levels) == 0 &&
+        this.Start.IsZero() &&
+        this.End.IsZero() &&
+        this.Keyword == "" &&
+        this.Regexp == nil &&
+        this.RequestId == ""
+}
+
+// 是否匹配
+func (this Filter) Match(entry Entry) bool {
+    if len(this.Levels) > 0 {
+        found := false
+        for _, level := range this.Levels {
+            if normalizeLevel(level) == entry.Level {
+                found = true
+                break
+            }
+        }
+
+        if !found {
+            return false
+        }
+    }
+
+    if !entry.Time.IsZero() {
+        if !this.Start.IsZero() && entry.Time.Before(this.Start) {
+            return false
+        }
+        if !this.End.IsZero() && entry.Time.After(this.End) {
+            return false
+        }
+    } else if !this.Start.IsZero() || !this.End.IsZero() {
+        return false
+    }
+
+    if this.Keyword != "" &&
+        !strings.Contains(strings.ToLower(entry.Raw), strings.ToLower(this.Keyword)) {
+        return false
+    }
+
+    if this.Regexp != nil && !this.Regexp.MatchString(entry.Raw) {
+        return false
+    }
+
+    if this.RequestId != "" && entry.RequestId() != this.RequestId {
+        return false
+    }
+
+    return true
+}
+
+// 向前读取时，记录早于开始时间后可以停止
+func (this Filter) before(entry Entry) bool {
+    return !this.Start.IsZero() &&
+        !entry.Time.IsZero() &&
+        entry.Time.Before(this.Start)
+}
@@ -0,0 +1,166 @@
+package viewer
+
+import (
+    "os"
+    "io"
+    "time"
+    "bytes"
+    "context"
+)
+
+// 实时日志设置
+type TailOptions struct {
+    // 开始位置，小于 0 时从文件末尾开始
+    Offset int64
+
+    // 检测间隔
+    Interval time.Duration
+
+    // 过滤条件
+    Filter Filter
+}
+
+/**
+ * 实时读取新增日志，直到 ctx 结束
+ *
+ * 每次检测都会调用 fn，没有新日志时 entries 为空，
+ * 每次最多读取 MaxScan 大小，剩余部分在下次检测时读取，
+ * 文件被截断时从头开始读取
+ */
+func (this *Viewer) Tail(ctx context.Context, name string, opts TailOptions, fn func([]Entry) error) error {
+    file, err := this.Path(name)
+    if err != nil {
+        return err
+    }
+
+    interval := opts.Interval
+    if interval <= 0 {
+        interval = time.Second
+    }
+
+    offset := opts.Offset
+    if offset < 0 {
+        info, err := os.Stat(file)
+        if err != nil {
+            return err
+        }
+
+        offset = info.Size()
+    }
+
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    var carry []byte
+
+    for {
+        entries, next, rest, err := this.readFrom(file, offset, carry, opts.Filter)
+        if err != nil {
+            return err
+        }
+
+        offset, carry = next, rest
+
+        if err := fn(entries); err != nil {
+            return err
+        }
+
+        select {
+            case <-ctx.Done():
+                return nil
+            case <-ticker.C:
+        }
+    }
+}
+
+// 单次检测最多读取大小
+func (this *Viewer) tailLimit() int64 {
+    if this.MaxScan > 0 {
+        return this.MaxScan
+    }
+
+    if this.ChunkSize > 0 {
+        return this.ChunkSize
+    }
+
+    return 64 * 1024
+}
+
+// 读取 offset 后的完整行，超过读取大小的行分段返回
+func (this *Viewer) readFrom(file string, offset int64, carry []byte, filter Filter) ([]Entry, int64, []byte, error) {
+    f, err := os.Open(file)
+    if err != nil {
+        return nil, offset, carry, err
+    }
+    defer f.Close()
+
+    info, err := f.Stat()
+    if err != nil {
+        return nil, offset, carry, err
+    }
+
+    // 文件被截断
+    if info.Size() < offset {
+        offset, carry = 0, nil
+    }
+
+    if info.Size() == offset {
+        return nil, offset, carry, nil
+    }
+
+    if _, err := f.Seek(offset, io.SeekStart); err != nil {
+        return nil, offset, carry, err
+    }
+
+    limit := this.tailLimit()
+
+    size := info.Size() - offset
+    if size > limit {
+        size = limit
+    }
+
+    data, err := io.ReadAll(io.LimitReader(f, size))
+    if err != nil {
+        return nil, offset, carry, err
+    }
+
+    lineOffset := offset - int64(len(carry))
+    offset += int64(len(data))
+
+    data = append(carry, data...)
+
+    entries := make([]Entry, 0)
+    addEntry := func(line []byte) {
+        line = bytes.TrimRight(line, "\r")
+        if len(bytes.TrimSpace(line)) == 0 {
+            return
+        }
+
+        entry := ParseLine(string(line))
+        entry.Offset = lineOffset
+
+        if filter.Match(entry) {
+            entries = append(entries, entry)
+        }
+    }
+
+    for {
+        i := bytes.IndexByte(data, '\n')
+        if i < 0 {
+            break
+        }
+
+        addEntry(data[:i])
+
+        lineOffset += int64(i) + 1
+        data = data[i+1:]
+    }
+
+    // 未完成的行超过读取大小时直接返回，避免一直累积
+    if int64(len(data)) >= limit {
+        addEntry(data)
+        data = nil
+    }
+
+    return entries, offset, append([]byte(nil), data...), nil
+}
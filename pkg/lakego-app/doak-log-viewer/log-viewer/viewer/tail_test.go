@@ -0,0 +1,100 @@
+package viewer
+
+import (
+    "os"
+    "time"
+    "context"
+    "strings"
+    "testing"
+    "path/filepath"
+)
+
+func Test_Tail(t *testing.T) {
+    dir := t.TempDir()
+    file := filepath.Join(dir, "app.log")
+    writeLog(t, dir, "app.log", 2)
+
+    v := New(dir, []string{".log"})
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2 * time.Second)
+    defer cancel()
+
+    got := make([]Entry, 0)
+    calls := 0
+
+    err := v.Tail(ctx, "app.log", TailOptions{
+        Offset:   -1,
+        Interval: 5 * time.Millisecond,
+    }, func(entries []Entry) error {
+        calls++
+        got = append(got, entries...)
+
+        switch calls {
+            case 1:
+                // 从末尾开始，写入未完成的行
+                f, _ := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+                f.WriteString("level=info msg=\"new 1\"\nlevel=info msg=\"ne")
+                f.Close()
+            case 2:
+                f, _ := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+                f.WriteString("w 2\"\n")
+                f.Close()
+            case 3:
+                cancel()
+        }
+
+        return nil
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if len(got) != 2 || got[0].Message != "new 1" || got[1].Message != "new 2" {
+        t.Fatalf("got %v", got)
+    }
+
+    info, _ := os.Stat(file)
+    if got[1].Offset != info.Size() - int64(len("level=info msg=\"new 2\"\n")) {
+        t.Errorf("offset got %d", got[1].Offset)
+    }
+}
+
+func Test_TailLimit(t *testing.T) {
+    dir := t.TempDir()
+    writeLog(t, dir, "app.log", 10)
+
+    file := filepath.Join(dir, "app.log")
+    info, _ := os.Stat(file)
+
+    v := New(dir, []string{".log"})
+    v.MaxScan = 100
+
+    // 每次最多读取 MaxScan，剩余部分下次读取
+    total := 0
+    offset := int64(0)
+    var carry []byte
+    for i := 0; i < 20 && offset < info.Size(); i++ {
+        entries, next, rest, err := v.readFrom(file, offset, carry, Filter{})
+        if err != nil {
+            t.Fatal(err)
+        }
+        if next - offset > v.MaxScan {
+            t.Fatalf("read %d bytes", next - offset)
+        }
+
+        total += len(entries)
+        offset, carry = next, rest
+    }
+
+    if total != 10 || len(carry) != 0 {
+        t.Errorf("got %d entries, carry %q", total, carry)
+    }
+
+    // 超过读取大小的行分段返回
+    os.WriteFile(file, []byte("level=info msg=\"" + strings.Repeat("a", 150) + "\"\n"), 0644)
+
+    entries, _, rest, _ := v.readFrom(file, 0, nil, Filter{})
+    if len(entries) != 1 || len(rest) != 0 {
+        t.Errorf("long line got %d entries, carry %d", len(entries), len(rest))
+    }
+}
@@ -0,0 +1,255 @@
+package viewer
+
+import (
+    "os"
+    "io"
+    "sort"
+    "bytes"
+    "errors"
+    "strings"
+    "path/filepath"
+)
+
+var (
+    ErrFileNotExists = errors.New("日志文件不存在")
+)
+
+// 日志文件信息
+type File struct {
+    Name       string `json:"name"`
+    Size       int64  `json:"size"`
+    UpdateTime int64  `json:"update_time"`
+}
+
+// 分页数据
+type Page struct {
+    // 日志，新的在前
+    Entries []Entry `json:"entries"`
+
+    // 下一页位置，作为 before 参数使用
+    Next int64 `json:"next"`
+
+    // 是否还有数据
+    HasMore bool `json:"has_more"`
+
+    // 文件大小，可作为实时日志的开始位置
+    Size int64 `json:"size"`
+}
+
+// 构造函数
+func New(dir string, exts []string) *Viewer {
+    return &Viewer{
+        Dir:       dir,
+        Exts:      exts,
+        ChunkSize: 64 * 1024,
+        MaxScan:   32 * 1024 * 1024,
+    }
+}
+
+/**
+ * 日志查看
+ *
+ * 只能访问日志目录下的文件
+ *
+ * @create 2024-5-25
+ * @author deatil
+ */
+type Viewer struct {
+    // 日志目录
+    Dir string
+
+    // 允许的文件后缀
+    Exts []string
+
+    // 每次读取大小
+    ChunkSize int64
+
+    // 单次请求最多扫描大小
+    MaxScan int64
+}
+
+// 日志文件列表，新的在前
+func (this *Viewer) Files() ([]File, error) {
+    items, err := os.ReadDir(this.Dir)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return []File{}, nil
+        }
+
+        return nil, err
+    }
+
+    files := make([]File, 0)
+    for _, item := range items {
+        if item.IsDir() || !this.allowed(item.Name()) {
+            continue
+        }
+
+        info, err := item.Info()
+        if err != nil {
+            continue
+        }
+
+        files = append(files, File{
+            Name:       item.Name(),
+            Size:       info.Size(),
+            UpdateTime: info.ModTime().Unix(),
+        })
+    }
+
+    sort.Slice(files, func(i, j int) bool {
+        return files[i].UpdateTime > files[j].UpdateTime
+    })
+
+    return files, nil
+}
+
+// 日志文件路径
+func (this *Viewer) Path(name string) (string, error) {
+    if name == "" ||
+        name != filepath.Base(name) ||
+        strings.HasPrefix(name, ".") ||
+        !this.allowed(name) {
+        return "", ErrFileNotExists
+    }
+
+    file := filepath.Join(this.Dir, name)
+
+    info, err := os.Stat(file)
+    if err != nil || !info.Mode().IsRegular() {
+        return "", ErrFileNotExists
+    }
+
+    return file, nil
+}
+
+// 从 before 位置向前读取 limit 条匹配的日志，before 小于等于 0 时从文件末尾开始
+func (this *Viewer) Read(name string, before int64, limit int, filter Filter) (Page, error) {
+    file, err := this.Path(name)
+    if err != nil {
+        return Page{}, err
+    }
+
+    f, err := os.Open(file)
+    if err != nil {
+        return Page{}, err
+    }
+    defer f.Close()
+
+    info, err := f.Stat()
+    if err != nil {
+        return Page{}, err
+    }
+
+    size := info.Size()
+    if before <= 0 || before > size {
+        before = size
+    }
+
+    page := Page{
+        Entries: make([]Entry, 0),
+        Size:    size,
+    }
+
+    chunkSize := this.ChunkSize
+    if chunkSize <= 0 {
+        chunkSize = 64 * 1024
+    }
+
+    // 未处理的行首部分
+    var carry []byte
+
+    end := before
+    pos := before
+    scanned := int64(0)
+    stop := false
+
+    for pos > 0 && !stop {
+        start := pos - chunkSize
+        if start < 0 {
+            start = 0
+        }
+
+        buf := make([]byte, pos - start)
+        if _, err := f.ReadAt(buf, start); err != nil && err != io.EOF {
+            return page, err
+        }
+
+        scanned += pos - start
+        pos = start
+
+        data := append(buf, carry...)
+
+        // 从后向前处理完整的行
+        for {
+            i := bytes.LastIndexByte(data, '\n')
+            if i < 0 {
+                break
+            }
+
+            line := data[i+1:]
+            lineOffset := pos + int64(i) + 1
+
+            if len(bytes.TrimSpace(line)) > 0 && lineOffset < end {
+                entry := ParseLine(string(bytes.TrimRight(line, "\r\n")))
+                entry.Offset = lineOffset
+
+                if filter.before(entry) {
+                    end, stop = 0, true
+                    break
+                }
+
+                end = lineOffset
+
+                if filter.Match(entry) {
+                    page.Entries = append(page.Entries, entry)
+                    if len(page.Entries) >= limit {
+                        stop = true
+                        break
+                    }
+                }
+            }
+
+            data = data[:i]
+        }
+
+        carry = append([]byte(nil), data...)
+
+        if this.MaxScan > 0 && scanned >= this.MaxScan {
+            break
+        }
+    }
+
+    // 文件第一行
+    if !stop && pos == 0 && len(bytes.TrimSpace(carry)) > 0 {
+        entry := ParseLine(string(bytes.TrimRight(carry, "\r\n")))
+        entry.Offset = 0
+
+        end = 0
+        if filter.Match(entry) {
+            page.Entries = append(page.Entries, entry)
+        }
+    } else if !stop && pos == 0 {
+        end = 0
+    }
+
+    page.Next = end
+    page.HasMore = end > 0
+
+    return page, nil
+}
+
+// 是否允许的文件
+func (this *Viewer) allowed(name string) bool {
+    if len(this.Exts) == 0 {
+        return true
+    }
+
+    for _, ext := range this.Exts {
+        if strings.HasSuffix(name, ext) {
+            return true
+        }
+    }
+
+    return false
+}
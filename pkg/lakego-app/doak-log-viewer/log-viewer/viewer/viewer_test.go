@@ -0,0 +1,94 @@
+package viewer
+
+import (
+    "os"
+    "fmt"
+    "strings"
+    "testing"
+    "path/filepath"
+)
+
+// 创建测试日志
+func writeLog(t *testing.T, dir string, name string, n int) {
+    var b strings.Builder
+    for i := 1; i <= n; i++ {
+        level := "info"
+        if i % 2 == 0 {
+            level = "error"
+        }
+
+        fmt.Fprintf(&b, "time=2024-05-25T10:00:%02dZ level=%s msg=\"line %d\"\n", i, level, i)
+    }
+
+    if err := os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0644); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func Test_ReadPaging(t *testing.T) {
+    dir := t.TempDir()
+    writeLog(t, dir, "app.log", 10)
+
+    v := New(dir, []string{".log"})
+
+    // 读取块小于单行，需要跨块拼接
+    v.ChunkSize = 16
+
+    got := make([]string, 0)
+
+    before := int64(0)
+    for i := 0; i < 10; i++ {
+        page, err := v.Read("app.log", before, 3, Filter{})
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        for _, entry := range page.Entries {
+            got = append(got, entry.Message)
+        }
+
+        if !page.HasMore {
+            break
+        }
+
+        before = page.Next
+    }
+
+    want := []string{"line 10", "line 9", "line 8", "line 7", "line 6", "line 5", "line 4", "line 3", "line 2", "line 1"}
+    if strings.Join(got, ",") != strings.Join(want, ",") {
+        t.Errorf("got %v", got)
+    }
+}
+
+func Test_ReadFilter(t *testing.T) {
+    dir := t.TempDir()
+    writeLog(t, dir, "app.log", 10)
+
+    v := New(dir, []string{".log"})
+
+    page, err := v.Read("app.log", 0, 2, Filter{Levels: []string{"error"}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(page.Entries) != 2 || page.Entries[0].Message != "line 10" || page.Entries[1].Message != "line 8" {
+        t.Fatalf("got %v", page.Entries)
+    }
+
+    page, _ = v.Read("app.log", page.Next, 10, Filter{Levels: []string{"error"}})
+    if len(page.Entries) != 3 || page.HasMore {
+        t.Errorf("second page got %d, has more %v", len(page.Entries), page.HasMore)
+    }
+}
+
+func Test_Path(t *testing.T) {
+    dir := t.TempDir()
+    writeLog(t, dir, "app.log", 1)
+
+    v := New(dir, []string{".log"})
+
+    for _, name := range []string{"", "../app.log", ".app.log", "app.txt", "none.log"} {
+        if _, err := v.Path(name); err != ErrFileNotExists {
+            t.Errorf("Path(%q) got %v", name, err)
+        }
+    }
+}
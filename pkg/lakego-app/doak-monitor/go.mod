@@ -11,6 +11,7 @@ require (
 )
 
 require (
+	github.com/deatil/go-event v0.0.3
 	github.com/deatil/go-hash v0.0.3
 	github.com/deatil/go-goch v0.0.3
 	github.com/deatil/lakego-doak v0.0.3
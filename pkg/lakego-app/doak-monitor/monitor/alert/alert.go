@@ -0,0 +1,224 @@
+package alert
+
+import (
+    "sync"
+    "time"
+
+    "github.com/deatil/lakego-doak-monitor/monitor/sampler"
+)
+
+// 比较方式
+const (
+    OpGreater = ">"
+    OpLess    = "<"
+)
+
+// 告警规则
+type Rule struct {
+    // 名称
+    Name string `json:"name"`
+
+    // 指标名称
+    Metric string `json:"metric"`
+
+    // 比较方式
+    Op string `json:"op"`
+
+    // 阈值
+    Threshold float64 `json:"threshold"`
+
+    // 持续时间，超过阈值持续该时间后告警
+    For time.Duration `json:"for"`
+}
+
+// 是否超过阈值
+func (this Rule) Match(v float64) bool {
+    if this.Op == OpLess {
+        return v < this.Threshold
+    }
+
+    return v > this.Threshold
+}
+
+// 告警触发事件
+type Triggered struct {
+    // 规则
+    Rule Rule `json:"rule"`
+
+    // 当前值
+    Value float64 `json:"value"`
+
+    // 开始超过阈值的时间
+    Since time.Time `json:"since"`
+
+    // 触发时间
+    At time.Time `json:"at"`
+}
+
+// 告警恢复事件
+type Resolved struct {
+    // 规则
+    Rule Rule `json:"rule"`
+
+    // 当前值
+    Value float64 `json:"value"`
+
+    // 告警触发时间
+    Since time.Time `json:"since"`
+
+    // 恢复时间
+    At time.Time `json:"at"`
+}
+
+// 规则状态
+type State struct {
+    // 规则
+    Rule Rule `json:"rule"`
+
+    // 当前值
+    Value float64 `json:"value"`
+
+    // 开始超过阈值的时间
+    Pending time.Time `json:"pending"`
+
+    // 是否告警中
+    Firing bool `json:"firing"`
+
+    // 告警触发时间
+    FiredAt time.Time `json:"fired_at"`
+}
+
+// 构造函数
+func NewEvaluator(rules []Rule) *Evaluator {
+    states := make([]*State, 0, len(rules))
+    for _, rule := range rules {
+        if !sampler.IsMetric(rule.Metric) {
+            continue
+        }
+
+        states = append(states, &State{Rule: rule})
+    }
+
+    return &Evaluator{
+        states: states,
+    }
+}
+
+/**
+ * 阈值告警，超过阈值持续一段时间后触发，恢复后发送恢复事件
+ *
+ * @create 2024-5-18
+ * @author deatil
+ */
+type Evaluator struct {
+    mu sync.RWMutex
+
+    // 规则状态
+    states []*State
+
+    // 触发回调
+    onTriggered []func(Triggered)
+
+    // 恢复回调
+    onResolved []func(Resolved)
+}
+
+// 触发回调
+func (this *Evaluator) OnTriggered(f func(Triggered)) *Evaluator {
+    this.onTriggered = append(this.onTriggered, f)
+
+    return this
+}
+
+// 恢复回调
+func (this *Evaluator) OnResolved(f func(Resolved)) *Evaluator {
+    this.onResolved = append(this.onResolved, f)
+
+    return this
+}
+
+// 检测采样数据
+func (this *Evaluator) Evaluate(s sampler.Sample) {
+    now := time.Unix(s.Time, 0)
+
+    triggered := make([]Triggered, 0)
+    resolved := make([]Resolved, 0)
+
+    this.mu.Lock()
+    for _, state := range this.states {
+        v, _ := s.Value(state.Rule.Metric)
+        state.Value = v
+
+        if !state.Rule.Match(v) {
+            if state.Firing {
+                resolved = append(resolved, Resolved{
+                    Rule:  state.Rule,
+                    Value: v,
+                    Since: state.FiredAt,
+                    At:    now,
+                })
+            }
+
+            state.Pending = time.Time{}
+            state.Firing = false
+            state.FiredAt = time.Time{}
+
+            continue
+        }
+
+        if state.Pending.IsZero() {
+            state.Pending = now
+        }
+
+        if !state.Firing && now.Sub(state.Pending) >= state.Rule.For {
+            state.Firing = true
+            state.FiredAt = now
+
+            triggered = append(triggered, Triggered{
+                Rule:  state.Rule,
+                Value: v,
+                Since: state.Pending,
+                At:    now,
+            })
+        }
+    }
+    this.mu.Unlock()
+
+    for _, t := range triggered {
+        for _, f := range this.onTriggered {
+            f(t)
+        }
+    }
+
+    for _, r := range resolved {
+        for _, f := range this.onResolved {
+            f(r)
+        }
+    }
+}
+
+// 规则状态列表
+func (this *Evaluator) States() []State {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    list := make([]State, 0, len(this.states))
+    for _, state := range this.states {
+        list = append(list, *state)
+    }
+
+    return list
+}
+
+// 默认告警
+var defaultEvaluator *Evaluator
+
+// 设置默认告警
+func SetDefault(e *Evaluator) {
+    defaultEvaluator = e
+}
+
+// 默认告警，未开启时为 nil
+func Default() *Evaluator {
+    return defaultEvaluator
+}
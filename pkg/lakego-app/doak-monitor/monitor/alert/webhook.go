@@ -0,0 +1,65 @@
+package alert
+
+import (
+    "fmt"
+    "time"
+    "bytes"
+    "context"
+    "net/http"
+    "encoding/json"
+)
+
+// 构造函数
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+    if timeout <= 0 {
+        timeout = 5 * time.Second
+    }
+
+    return &Webhook{
+        url: url,
+        client: &http.Client{
+            Timeout: timeout,
+        },
+    }
+}
+
+/**
+ * 告警 webhook，以 json 格式 POST 告警数据
+ *
+ * @create 2024-5-18
+ * @author deatil
+ */
+type Webhook struct {
+    url    string
+    client *http.Client
+}
+
+// 发送
+func (this *Webhook) Send(ctx context.Context, typ string, data any) error {
+    body, err := json.Marshal(map[string]any{
+        "type": typ,
+        "data": data,
+    })
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.url, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+
+    resp, err := this.client.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+    }
+
+    return nil
+}
@@ -4,6 +4,7 @@ import (
     "os"
     "fmt"
     "time"
+    "strings"
     "runtime"
     "strconv"
 
@@ -17,8 +18,12 @@ import (
     "github.com/deatil/go-datebin/datebin"
 
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
 
     adminController "github.com/deatil/lakego-doak-admin/admin/controller"
+
+    "github.com/deatil/lakego-doak-monitor/monitor/alert"
+    "github.com/deatil/lakego-doak-monitor/monitor/sampler"
 )
 
 /**
@@ -48,9 +53,14 @@ func (this *Monitor) Index(ctx *router.Context) {
     var cpuAvg5 float64 = 0    // CPU负载5
     var cpuAvg15 float64 = 0   // 当前空闲率
 
-    cpuInfo, err := cpu.Percent(time.Duration(time.Second), false)
-    if err == nil {
-        cpuUsed, _ = strconv.ParseFloat(fmt.Sprintf("%.2f", cpuInfo[0]), 64)
+    // 优先使用后台采样数据，避免阻塞请求
+    if last, ok := lastSample(); ok {
+        cpuUsed = last.CPU
+    } else {
+        cpuInfo, err := cpu.Percent(0, false)
+        if err == nil && len(cpuInfo) > 0 {
+            cpuUsed, _ = strconv.ParseFloat(fmt.Sprintf("%.2f", cpuInfo[0]), 64)
+        }
     }
 
     loadInfo, err := load.Avg()
@@ -152,3 +162,117 @@ func (this *Monitor) Index(ctx *router.Context) {
     })
 }
 
+
+// 监控序列数据
+// @Summary 监控序列数据
+// @Description 监控序列数据，数据点过多时按时间分桶取平均值
+// @Tags 系统监控
+// @Accept application/json
+// @Produce application/json
+// @Param metrics    query string false "指标，多个用逗号分隔，默认 cpu,mem_usage"
+// @Param start_time query string false "开始时间，默认一小时前"
+// @Param end_time   query string false "结束时间，默认当前时间"
+// @Param points     query string false "最多数据点"
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /monitor/series [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.monitor.series"}
+func (this *Monitor) Series(ctx *router.Context) {
+    s := sampler.Default()
+    if s == nil {
+        this.Error(ctx, "后台采样未开启")
+        return
+    }
+
+    conf := config.New("monitor")
+
+    metrics := make([]string, 0)
+    for _, metric := range strings.Split(ctx.DefaultQuery("metrics", "cpu,mem_usage"), ",") {
+        metric = strings.TrimSpace(metric)
+        if metric == "" {
+            continue
+        }
+
+        if !sampler.IsMetric(metric) {
+            this.Error(ctx, "指标 " + metric + " 不存在")
+            return
+        }
+
+        metrics = append(metrics, metric)
+    }
+
+    end := time.Now().Unix()
+    if endTime := ctx.DefaultQuery("end_time", ""); endTime != "" {
+        end = this.FormatDate(endTime)
+    }
+
+    rangeTime := conf.GetDuration("series.range")
+    if rangeTime <= 0 {
+        rangeTime = time.Hour
+    }
+
+    start := end - int64(rangeTime.Seconds())
+    if startTime := ctx.DefaultQuery("start_time", ""); startTime != "" {
+        start = this.FormatDate(startTime)
+    }
+
+    if start > end {
+        this.Error(ctx, "开始时间不能大于结束时间")
+        return
+    }
+
+    points := goch.ToInt(ctx.DefaultQuery("points", ""))
+    if maxPoints := conf.GetInt("series.points"); points <= 0 || (maxPoints > 0 && points > maxPoints) {
+        points = maxPoints
+    }
+
+    samples, err := s.Range(start, end)
+    if err != nil {
+        this.Error(ctx, "获取数据失败")
+        return
+    }
+
+    series := make(map[string][]sampler.Point, len(metrics))
+    for _, metric := range metrics {
+        series[metric] = sampler.Series(samples, metric, points)
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "start":  start,
+        "end":    end,
+        "total":  len(samples),
+        "series": series,
+    })
+}
+
+// 监控告警状态
+// @Summary 监控告警状态
+// @Description 监控告警规则及状态
+// @Tags 系统监控
+// @Accept application/json
+// @Produce application/json
+// @Success 200 {string} json "{"success": true, "code": 0, "message": "string", "data": ""}"
+// @Router /monitor/alerts [get]
+// @Security Bearer
+// @x-lakego {"slug": "lakego-admin.monitor.alerts"}
+func (this *Monitor) Alerts(ctx *router.Context) {
+    e := alert.Default()
+    if e == nil {
+        this.Error(ctx, "后台采样未开启")
+        return
+    }
+
+    this.SuccessWithData(ctx, "获取成功", router.H{
+        "list": e.States(),
+    })
+}
+
+// 最近一次采样数据
+func lastSample() (sampler.Sample, bool) {
+    s := sampler.Default()
+    if s == nil {
+        return sampler.Sample{}, false
+    }
+
+    return s.Ring().Last()
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/facade"
+
+    "github.com/deatil/lakego-doak-monitor/monitor/sampler"
+)
+
+// 采样数据
+type MonitorSample struct {
+    ID          uint64  `gorm:"column:id;type:bigint(20);not null;primaryKey;autoIncrement;" json:"id"`
+    Time        int64   `gorm:"column:time;type:int(10);not null;index;" json:"time"`
+    CPU         float64 `gorm:"column:cpu;type:decimal(6,2);" json:"cpu"`
+    Load1       float64 `gorm:"column:load1;type:decimal(8,2);" json:"load1"`
+    Load5       float64 `gorm:"column:load5;type:decimal(8,2);" json:"load5"`
+    Load15      float64 `gorm:"column:load15;type:decimal(8,2);" json:"load15"`
+    MemUsage    float64 `gorm:"column:mem_usage;type:decimal(6,2);" json:"mem_usage"`
+    MemUsed     float64 `gorm:"column:mem_used;type:bigint(20);" json:"mem_used"`
+    GoHeap      float64 `gorm:"column:go_heap;type:bigint(20);" json:"go_heap"`
+    Goroutines  float64 `gorm:"column:goroutines;type:int(10);" json:"goroutines"`
+    DiskUsage   float64 `gorm:"column:disk_usage;type:decimal(6,2);" json:"disk_usage"`
+    NetSent     float64 `gorm:"column:net_sent;type:bigint(20);" json:"net_sent"`
+    NetRecv     float64 `gorm:"column:net_recv;type:bigint(20);" json:"net_recv"`
+    NetSentRate float64 `gorm:"column:net_sent_rate;type:decimal(16,2);" json:"net_sent_rate"`
+    NetRecvRate float64 `gorm:"column:net_recv_rate;type:decimal(16,2);" json:"net_recv_rate"`
+}
+
+func NewMonitorSample() *gorm.DB {
+    return facade.DB.Model(&MonitorSample{})
+}
+
+// 数据库存储
+func NewSampleStore() *SampleStore {
+    return &SampleStore{}
+}
+
+/**
+ * 采样数据数据库存储
+ *
+ * @create 2024-5-18
+ * @author deatil
+ */
+type SampleStore struct {}
+
+// 保存
+func (this *SampleStore) Save(s sampler.Sample) error {
+    return NewMonitorSample().Create(&MonitorSample{
+        Time:        s.Time,
+        CPU:         s.CPU,
+        Load1:       s.Load1,
+        Load5:       s.Load5,
+        Load15:      s.Load15,
+        MemUsage:    s.MemUsage,
+        MemUsed:     s.MemUsed,
+        GoHeap:      s.GoHeap,
+        Goroutines:  s.Goroutines,
+        DiskUsage:   s.DiskUsage,
+        NetSent:     s.NetSent,
+        NetRecv:     s.NetRecv,
+        NetSentRate: s.NetSentRate,
+        NetRecvRate: s.NetRecvRate,
+    }).Error
+}
+
+// 时间范围内的数据
+func (this *SampleStore) Range(start, end int64) ([]sampler.Sample, error) {
+    tx := NewMonitorSample().Where("time >= ?", start)
+    if end > 0 {
+        tx = tx.Where("time <= ?", end)
+    }
+
+    list := make([]MonitorSample, 0)
+    if err := tx.Order("time ASC").Find(&list).Error; err != nil {
+        return nil, err
+    }
+
+    samples := make([]sampler.Sample, 0, len(list))
+    for _, item := range list {
+        samples = append(samples, sampler.Sample{
+            Time:        item.Time,
+            CPU:         item.CPU,
+            Load1:       item.Load1,
+            Load5:       item.Load5,
+            Load15:      item.Load15,
+            MemUsage:    item.MemUsage,
+            MemUsed:     item.MemUsed,
+            GoHeap:      item.GoHeap,
+            Goroutines:  item.Goroutines,
+            DiskUsage:   item.DiskUsage,
+            NetSent:     item.NetSent,
+            NetRecv:     item.NetRecv,
+            NetSentRate: item.NetSentRate,
+            NetRecvRate: item.NetRecvRate,
+        })
+    }
+
+    return samples, nil
+}
+
+// 删除指定时间前的数据
+func (this *SampleStore) Prune(before int64) (int64, error) {
+    result := NewMonitorSample().
+        Where("time < ?", before).
+        Delete(&MonitorSample{})
+
+    return result.RowsAffected, result.Error
+}
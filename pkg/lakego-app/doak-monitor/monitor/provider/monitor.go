@@ -1,11 +1,24 @@
 package provider
 
 import (
+    "fmt"
+    "time"
+    "context"
+
+    "github.com/deatil/go-event/event"
+    "github.com/deatil/lakego-doak/lakego/array"
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/schedule"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    pathTool "github.com/deatil/lakego-doak/lakego/path"
 
     admin_route "github.com/deatil/lakego-doak-admin/admin/support/route"
 
+    "github.com/deatil/lakego-doak-monitor/monitor/alert"
+    "github.com/deatil/lakego-doak-monitor/monitor/model"
+    "github.com/deatil/lakego-doak-monitor/monitor/sampler"
     monitor_router "github.com/deatil/lakego-doak-monitor/monitor/route"
 )
 
@@ -21,8 +34,103 @@ type Monitor struct {
 
 // 引导
 func (this *Monitor) Boot() {
+    // 后台采样
+    this.loadSampler()
+
     // 路由
     this.loadRoute()
+
+    // 推送配置
+    this.publishConfig()
+}
+
+// 计划任务
+func (this *Monitor) Schedule(s *schedule.Schedule) {
+    conf := config.New("monitor")
+    if !conf.GetBool("persist.enable") {
+        return
+    }
+
+    spec := conf.GetString("persist.prune-spec")
+    if spec == "" {
+        spec = "0 30 3 * * *"
+    }
+
+    retention := conf.GetDuration("persist.retention")
+    if retention <= 0 {
+        retention = 7 * 24 * time.Hour
+    }
+
+    // 清理过期采样数据
+    s.AddFunc(func() {
+        before := time.Now().Add(-retention).Unix()
+
+        total, err := model.NewSampleStore().Prune(before)
+        if err != nil {
+            facade.Logger.Error("[monitor]" + err.Error())
+            return
+        }
+
+        facade.Logger.Info(fmt.Sprintf("[monitor]清理过期采样数据 %d 条", total))
+    }).Cron(spec).WithName("lakego-admin.monitor.prune")
+}
+
+/**
+ * 后台采样，只在服务进程中运行
+ */
+func (this *Monitor) loadSampler() {
+    if this.GetApp().RunningInConsole() {
+        return
+    }
+
+    conf := config.New("monitor")
+    if !conf.GetBool("sampler.enable") {
+        return
+    }
+
+    s := sampler.New(sampler.Options{
+        Interval: conf.GetDuration("sampler.interval"),
+        Size:     conf.GetInt("sampler.size"),
+        DiskPath: conf.GetString("sampler.disk-path"),
+    })
+    s.OnError(func(err error) {
+        facade.Logger.Error("[monitor]" + err.Error())
+    })
+
+    if conf.GetBool("persist.enable") {
+        s.WithStore(model.NewSampleStore())
+    }
+
+    // 阈值告警
+    evaluator := alert.NewEvaluator(rulesFromConfig(conf.Get("alert.rules")))
+
+    var webhook *alert.Webhook
+    if url := conf.GetString("alert.webhook"); url != "" {
+        webhook = alert.NewWebhook(url, conf.GetDuration("alert.webhook-timeout"))
+    }
+
+    evaluator.OnTriggered(func(t alert.Triggered) {
+        facade.Logger.Warn(fmt.Sprintf("[monitor]告警 %s: %s %s %v, 当前值 %v", t.Rule.Name, t.Rule.Metric, t.Rule.Op, t.Rule.Threshold, t.Value))
+
+        notify(webhook, "triggered", t)
+    })
+    evaluator.OnResolved(func(r alert.Resolved) {
+        facade.Logger.Info(fmt.Sprintf("[monitor]告警恢复 %s: 当前值 %v", r.Rule.Name, r.Value))
+
+        notify(webhook, "resolved", r)
+    })
+
+    s.OnSample(evaluator.Evaluate)
+
+    sampler.SetDefault(s)
+    alert.SetDefault(evaluator)
+
+    s.Start()
+
+    // 关闭时停止采样
+    this.AddShutdown(func(ctx context.Context) {
+        s.Stop(ctx)
+    })
 }
 
 /**
@@ -35,3 +143,62 @@ func (this *Monitor) loadRoute() {
     })
 }
 
+/**
+ * 推送配置
+ */
+func (this *Monitor) publishConfig() {
+    // 配置
+    path := pathTool.FormatPath("{root}/pkg/lakego-app/doak-monitor/resources/config/monitor.yml")
+
+    // 推送文件
+    // > go run main.go lakego:publish --tag=monitor-config --force
+    toPath := pathTool.ConfigPath("/monitor.yml")
+    this.Publishes(this, map[string]string{
+        path: toPath,
+    }, "monitor-config")
+}
+
+// 发送告警事件及 webhook
+func notify[T any](webhook *alert.Webhook, typ string, data T) {
+    if err := event.Emit(context.Background(), data); err != nil {
+        facade.Logger.Error("[monitor]" + err.Error())
+    }
+
+    if webhook == nil {
+        return
+    }
+
+    go func() {
+        if err := webhook.Send(context.Background(), typ, data); err != nil {
+            facade.Logger.Error("[monitor]" + err.Error())
+        }
+    }()
+}
+
+// 解析告警规则配置
+func rulesFromConfig(items any) []alert.Rule {
+    list, ok := items.([]any)
+    if !ok {
+        return nil
+    }
+
+    rules := make([]alert.Rule, 0, len(list))
+    for _, item := range list {
+        data := array.ArrayFrom(item)
+
+        rule := alert.Rule{
+            Name:      data.Value("name").ToString(),
+            Metric:    data.Value("metric").ToString(),
+            Op:        data.Value("op").ToString(),
+            Threshold: data.Value("threshold").ToFloat64(),
+            For:       data.Value("for").ToDuration(),
+        }
+        if rule.Name == "" {
+            rule.Name = rule.Metric
+        }
+
+        rules = append(rules, rule)
+    }
+
+    return rules
+}
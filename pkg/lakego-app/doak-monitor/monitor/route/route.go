@@ -1,7 +1,7 @@
 package route
 
 import (
-    "github.com/gin-gonic/gin"
+    "github.com/deatil/lakego-doak/lakego/router"
 
     "github.com/deatil/lakego-doak-monitor/monitor/controller"
 )
@@ -9,8 +9,21 @@ import (
 /**
  * 路由
  */
-func Route(engine gin.IRouter) {
+func Route(group router.IRouter) {
+    engine := router.WithMeta(group)
+
     // 系统监控
     monitorController := new(controller.Monitor)
-    engine.GET("/monitor", monitorController.Index)
+    engine.GET("/monitor", monitorController.Index).
+        Slug("lakego-admin.monitor.index").
+        Title("服务监控详情").
+        Parent("系统监控")
+    engine.GET("/monitor/series", monitorController.Series).
+        Slug("lakego-admin.monitor.series").
+        Title("监控序列数据").
+        Parent("系统监控")
+    engine.GET("/monitor/alerts", monitorController.Alerts).
+        Slug("lakego-admin.monitor.alerts").
+        Title("监控告警状态").
+        Parent("系统监控")
 }
@@ -0,0 +1,74 @@
+package sampler
+
+// 序列数据点
+type Point struct {
+    // 时间，秒
+    Time int64 `json:"time"`
+
+    // 平均值
+    Value float64 `json:"value"`
+
+    // 最大值
+    Max float64 `json:"max"`
+}
+
+// 获取指标序列，数据超过 points 时按时间分桶取平均值
+func Series(samples []Sample, metric string, points int) []Point {
+    list := make([]Point, 0, len(samples))
+    if len(samples) == 0 {
+        return list
+    }
+
+    if points <= 0 || len(samples) <= points {
+        for _, s := range samples {
+            v, _ := s.Value(metric)
+            list = append(list, Point{Time: s.Time, Value: v, Max: v})
+        }
+
+        return list
+    }
+
+    start := samples[0].Time
+    end := samples[len(samples)-1].Time
+
+    // 向上取整，保证分桶数量不超过 points
+    step := (end - start + int64(points)) / int64(points)
+
+    var (
+        bucket int64 = -1
+        sum    float64
+        max    float64
+        count  int
+    )
+
+    flush := func() {
+        if count > 0 {
+            list = append(list, Point{
+                Time:  start + bucket*step,
+                Value: sum / float64(count),
+                Max:   max,
+            })
+        }
+    }
+
+    for _, s := range samples {
+        b := (s.Time - start) / step
+        if b != bucket {
+            flush()
+
+            bucket, sum, max, count = b, 0, 0, 0
+        }
+
+        v, _ := s.Value(metric)
+
+        sum += v
+        if count == 0 || v > max {
+            max = v
+        }
+        count++
+    }
+
+    flush()
+
+    return list
+}
@@ -0,0 +1,108 @@
+package sampler
+
+import (
+    "sync"
+)
+
+// 构造函数
+func NewRing(size int) *Ring {
+    if size <= 0 {
+        size = 2880
+    }
+
+    return &Ring{
+        items: make([]Sample, size),
+    }
+}
+
+/**
+ * 环形缓冲，保存最近的采样数据
+ *
+ * @create 2024-5-18
+ * @author deatil
+ */
+type Ring struct {
+    mu sync.RWMutex
+
+    items []Sample
+    next  int
+    full  bool
+}
+
+// 添加
+func (this *Ring) Add(s Sample) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.items[this.next] = s
+    this.next = (this.next + 1) % len(this.items)
+    if this.next == 0 {
+        this.full = true
+    }
+}
+
+// 数量
+func (this *Ring) Len() int {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.full {
+        return len(this.items)
+    }
+
+    return this.next
+}
+
+// 最新数据
+func (this *Ring) Last() (Sample, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if !this.full && this.next == 0 {
+        return Sample{}, false
+    }
+
+    i := (this.next - 1 + len(this.items)) % len(this.items)
+
+    return this.items[i], true
+}
+
+// 最早数据的时间
+func (this *Ring) Oldest() int64 {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.full {
+        return this.items[this.next].Time
+    }
+    if this.next > 0 {
+        return this.items[0].Time
+    }
+
+    return 0
+}
+
+// 时间范围内的数据，按时间正序
+func (this *Ring) Range(start, end int64) []Sample {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    list := make([]Sample, 0)
+
+    each := func(s Sample) {
+        if s.Time >= start && (end <= 0 || s.Time <= end) {
+            list = append(list, s)
+        }
+    }
+
+    if this.full {
+        for _, s := range this.items[this.next:] {
+            each(s)
+        }
+    }
+    for _, s := range this.items[:this.next] {
+        each(s)
+    }
+
+    return list
+}
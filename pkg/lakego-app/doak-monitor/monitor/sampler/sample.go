@@ -0,0 +1,104 @@
+package sampler
+
+// 指标名称
+const (
+    MetricCPU         = "cpu"
+    MetricLoad1       = "load1"
+    MetricLoad5       = "load5"
+    MetricLoad15      = "load15"
+    MetricMemUsage    = "mem_usage"
+    MetricMemUsed     = "mem_used"
+    MetricGoHeap      = "go_heap"
+    MetricGoroutines  = "goroutines"
+    MetricDiskUsage   = "disk_usage"
+    MetricNetSentRate = "net_sent_rate"
+    MetricNetRecvRate = "net_recv_rate"
+)
+
+// 全部指标名称
+var Metrics = []string{
+    MetricCPU,
+    MetricLoad1,
+    MetricLoad5,
+    MetricLoad15,
+    MetricMemUsage,
+    MetricMemUsed,
+    MetricGoHeap,
+    MetricGoroutines,
+    MetricDiskUsage,
+    MetricNetSentRate,
+    MetricNetRecvRate,
+}
+
+// 采样数据
+type Sample struct {
+    // 采样时间，秒
+    Time int64 `json:"time"`
+
+    // CPU 使用率
+    CPU float64 `json:"cpu"`
+
+    // 系统负载
+    Load1  float64 `json:"load1"`
+    Load5  float64 `json:"load5"`
+    Load15 float64 `json:"load15"`
+
+    // 内存使用率
+    MemUsage float64 `json:"mem_usage"`
+
+    // 已用内存
+    MemUsed float64 `json:"mem_used"`
+
+    // go 堆内存
+    GoHeap float64 `json:"go_heap"`
+
+    // 协程数量
+    Goroutines float64 `json:"goroutines"`
+
+    // 磁盘使用率
+    DiskUsage float64 `json:"disk_usage"`
+
+    // 网络累计发送及接收字节
+    NetSent float64 `json:"net_sent"`
+    NetRecv float64 `json:"net_recv"`
+
+    // 网络发送及接收速率，字节每秒
+    NetSentRate float64 `json:"net_sent_rate"`
+    NetRecvRate float64 `json:"net_recv_rate"`
+}
+
+// 获取指标值
+func (this Sample) Value(metric string) (float64, bool) {
+    switch metric {
+        case MetricCPU:
+            return this.CPU, true
+        case MetricLoad1:
+            return this.Load1, true
+        case MetricLoad5:
+            return this.Load5, true
+        case MetricLoad15:
+            return this.Load15, true
+        case MetricMemUsage:
+            return this.MemUsage, true
+        case MetricMemUsed:
+            return this.MemUsed, true
+        case MetricGoHeap:
+            return this.GoHeap, true
+        case MetricGoroutines:
+            return this.Goroutines, true
+        case MetricDiskUsage:
+            return this.DiskUsage, true
+        case MetricNetSentRate:
+            return this.NetSentRate, true
+        case MetricNetRecvRate:
+            return this.NetRecvRate, true
+    }
+
+    return 0, false
+}
+
+// 是否为支持的指标
+func IsMetric(metric string) bool {
+    _, ok := Sample{}.Value(metric)
+    return ok
+}
@@ -0,0 +1,296 @@
+package sampler
+
+import (
+    "sync"
+    "time"
+    "context"
+    "runtime"
+
+    "github.com/shirou/gopsutil/cpu"
+    "github.com/shirou/gopsutil/disk"
+    "github.com/shirou/gopsutil/load"
+    "github.com/shirou/gopsutil/mem"
+    "github.com/shirou/gopsutil/net"
+)
+
+// 持久化存储
+type Store interface {
+    // 保存采样数据
+    Save(s Sample) error
+
+    // 时间范围内的数据，按时间正序
+    Range(start, end int64) ([]Sample, error)
+}
+
+// 配置
+type Options struct {
+    // 采样间隔
+    Interval time.Duration
+
+    // 内存保留数量
+    Size int
+
+    // 磁盘路径
+    DiskPath string
+}
+
+// 构造函数
+func New(opts Options) *Sampler {
+    if opts.Interval <= 0 {
+        opts.Interval = 30 * time.Second
+    }
+    if opts.DiskPath == "" {
+        opts.DiskPath = "/"
+    }
+
+    return &Sampler{
+        opts: opts,
+        ring: NewRing(opts.Size),
+    }
+}
+
+/**
+ * 后台采样
+ *
+ * @create 2024-5-18
+ * @author deatil
+ */
+type Sampler struct {
+    mu sync.RWMutex
+
+    // 配置
+    opts Options
+
+    // 最近数据
+    ring *Ring
+
+    // 持久化存储
+    store Store
+
+    // 采样回调
+    handlers []func(Sample)
+
+    // 错误回调
+    onError func(error)
+
+    // 上次网络计数，手动采样和后台采样可能同时执行
+    netMu       sync.Mutex
+    lastNetSent float64
+    lastNetRecv float64
+    lastNetTime time.Time
+
+    cancel context.CancelFunc
+    done   chan struct{}
+}
+
+// 设置持久化存储
+func (this *Sampler) WithStore(store Store) *Sampler {
+    this.store = store
+
+    return this
+}
+
+// 添加采样回调
+func (this *Sampler) OnSample(f func(Sample)) *Sampler {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.handlers = append(this.handlers, f)
+
+    return this
+}
+
+// 错误回调
+func (this *Sampler) OnError(f func(error)) *Sampler {
+    this.onError = f
+
+    return this
+}
+
+// 最近数据
+func (this *Sampler) Ring() *Ring {
+    return this.ring
+}
+
+// 持久化存储
+func (this *Sampler) Store() Store {
+    return this.store
+}
+
+// 是否运行中
+func (this *Sampler) Running() bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.cancel != nil
+}
+
+// 开始采样
+func (this *Sampler) Start() *Sampler {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.cancel != nil {
+        return this
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    this.cancel = cancel
+    this.done = make(chan struct{})
+
+    go this.run(ctx, this.done)
+
+    return this
+}
+
+// 停止采样
+func (this *Sampler) Stop(ctx context.Context) {
+    this.mu.Lock()
+    cancel, done := this.cancel, this.done
+    this.cancel = nil
+    this.mu.Unlock()
+
+    if cancel == nil {
+        return
+    }
+
+    cancel()
+
+    select {
+        case <-done:
+        case <-ctx.Done():
+    }
+}
+
+func (this *Sampler) run(ctx context.Context, done chan struct{}) {
+    defer close(done)
+
+    // 初始化 cpu 及网络计数，第一次采样才有差值
+    cpu.Percent(0, false)
+    this.netRate(time.Now())
+
+    ticker := time.NewTicker(this.opts.Interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+            case <-ctx.Done():
+                return
+            case now := <-ticker.C:
+                this.Collect(now)
+        }
+    }
+}
+
+// 采样一次
+func (this *Sampler) Collect(now time.Time) Sample {
+    s := Sample{
+        Time: now.Unix(),
+    }
+
+    if percent, err := cpu.Percent(0, false); err == nil && len(percent) > 0 {
+        s.CPU = round(percent[0])
+    }
+
+    if avg, err := load.Avg(); err == nil {
+        s.Load1 = round(avg.Load1)
+        s.Load5 = round(avg.Load5)
+        s.Load15 = round(avg.Load15)
+    }
+
+    if vm, err := mem.VirtualMemory(); err == nil {
+        s.MemUsage = round(vm.UsedPercent)
+        s.MemUsed = float64(vm.Used)
+    }
+
+    var ms runtime.MemStats
+    runtime.ReadMemStats(&ms)
+
+    s.GoHeap = float64(ms.HeapAlloc)
+    s.Goroutines = float64(runtime.NumGoroutine())
+
+    if usage, err := disk.Usage(this.opts.DiskPath); err == nil {
+        s.DiskUsage = round(usage.UsedPercent)
+    }
+
+    s.NetSent, s.NetRecv, s.NetSentRate, s.NetRecvRate = this.netRate(now)
+
+    this.ring.Add(s)
+
+    if this.store != nil {
+        if err := this.store.Save(s); err != nil {
+            this.error(err)
+        }
+    }
+
+    this.mu.RLock()
+    handlers := this.handlers
+    this.mu.RUnlock()
+
+    for _, f := range handlers {
+        f(s)
+    }
+
+    return s
+}
+
+// 网络计数及速率
+func (this *Sampler) netRate(now time.Time) (sent, recv, sentRate, recvRate float64) {
+    this.netMu.Lock()
+    defer this.netMu.Unlock()
+
+    counters, err := net.IOCounters(false)
+    if err != nil || len(counters) == 0 {
+        return
+    }
+
+    sent = float64(counters[0].BytesSent)
+    recv = float64(counters[0].BytesRecv)
+
+    if !this.lastNetTime.IsZero() {
+        // 计数重置时跳过
+        seconds := now.Sub(this.lastNetTime).Seconds()
+        if seconds > 0 && sent >= this.lastNetSent && recv >= this.lastNetRecv {
+            sentRate = round((sent - this.lastNetSent) / seconds)
+            recvRate = round((recv - this.lastNetRecv) / seconds)
+        }
+    }
+
+    this.lastNetSent, this.lastNetRecv, this.lastNetTime = sent, recv, now
+
+    return
+}
+
+// 时间范围内的数据，内存数据不足时使用持久化数据
+func (this *Sampler) Range(start, end int64) ([]Sample, error) {
+    oldest := this.ring.Oldest()
+    if this.store == nil || (oldest > 0 && start >= oldest) {
+        return this.ring.Range(start, end), nil
+    }
+
+    return this.store.Range(start, end)
+}
+
+func (this *Sampler) error(err error) {
+    if this.onError != nil {
+        this.onError(err)
+    }
+}
+
+// 保留两位小数
+func round(v float64) float64 {
+    return float64(int64(v*100+0.5)) / 100
+}
+
+// 默认采样
+var defaultSampler *Sampler
+
+// 设置默认采样
+func SetDefault(s *Sampler) {
+    defaultSampler = s
+}
+
+// 默认采样，未开启时为 nil
+func Default() *Sampler {
+    return defaultSampler
+}
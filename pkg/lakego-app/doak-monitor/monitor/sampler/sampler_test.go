@@ -0,0 +1,30 @@
+package sampler
+
+import (
+    "sync"
+    "time"
+    "testing"
+)
+
+func Test_CollectConcurrent(t *testing.T) {
+    s := New(Options{
+        Size: 10,
+    })
+
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+
+        go func() {
+            defer wg.Done()
+
+            s.Collect(time.Now())
+        }()
+    }
+
+    wg.Wait()
+
+    if s.Ring().Len() != 4 {
+        t.Errorf("Collect got %d samples, expected 4", s.Ring().Len())
+    }
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/openapi"
+    "github.com/deatil/lakego-doak/lakego/command"
+)
+
+/**
+ * 生成 OpenAPI 文档
+ *
+ * 根据当前注册的路由生成 OpenAPI 3.1 文档并写入文件
+ *
+ * > ./main lakego-swagger:openapi [--output=runtime/openapi/openapi.json] [--server=http://127.0.0.1:8080]
+ * > main.exe lakego-swagger:openapi
+ * > go run main.go lakego-swagger:openapi
+ *
+ * @create 2024-5-21
+ * @author deatil
+ */
+var OpenAPICmd = &command.Command{
+    Use: "lakego-swagger:openapi",
+    Short: "lakego-swagger write openapi document.",
+    Example: "{execfile} lakego-swagger:openapi [--output=file] [--server=url]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+
+    },
+    Run: func(cmd *command.Command, args []string) {
+        OpenAPI()
+    },
+}
+
+// 输出文件
+var openAPIOutput string
+
+// 服务地址
+var openAPIServer string
+
+func init() {
+    pf := OpenAPICmd.Flags()
+    pf.StringVarP(&openAPIOutput, "output", "o", "", "输出文件，默认为 runtime/openapi/openapi.json")
+    pf.StringVarP(&openAPIServer, "server", "s", "", "服务地址")
+}
+
+// 生成文档
+func OpenAPI() {
+    output := openAPIOutput
+    if output == "" {
+        output = path.RuntimePath("openapi/openapi.json")
+    }
+
+    doc := openapi.Build()
+    if openAPIServer != "" {
+        doc.Servers = append(doc.Servers, openapi.Server{
+            URL: openAPIServer,
+        })
+    }
+
+    err := openapi.WriteFile(doc, output)
+    if err != nil {
+        fmt.Println("文档生成失败：", err.Error())
+        return
+    }
+
+    fmt.Printf("文档生成成功，共 %d 个路由，文件为 %s\n", len(doc.Paths), output)
+}
@@ -1,13 +1,21 @@
 package provider
 
 import (
+    "os"
+
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/openapi"
     "github.com/deatil/lakego-doak/lakego/provider"
 
     swaggerFiles "github.com/swaggo/files"
     ginSwagger "github.com/swaggo/gin-swagger"
+
+    "github.com/deatil/lakego-doak-swagger/swagger/cmd"
 )
 
+// 关闭文档的环境变量
+const disableEnv = "LAKEGO_ADMIN_SWAGGER_CLOSE"
+
 /**
  * 服务提供者
  *
@@ -20,17 +28,40 @@ type Swagger struct {
 
 // 引导
 func (this *Swagger) Boot() {
+    // 脚本
+    this.loadCommand()
+
     // 路由
     this.loadRoute()
 }
 
+/**
+ * 导入脚本
+ */
+func (this *Swagger) loadCommand() {
+    // 生成 OpenAPI 文档
+    this.AddCommand(cmd.OpenAPICmd)
+}
+
 /**
  * 导入路由
  */
 func (this *Swagger) loadRoute() {
+    // 文档路由不出现在文档中
+    openapi.Default().SkipPath("/swagger/", "/openapi.json")
+
     // 常规 gin 路由
     this.AddRoute(func(engine *router.Engine) {
-        engine.GET("/swagger/*any", ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "LAKEGO_ADMIN_SWAGGER_CLOSE"))
+        engine.GET("/swagger/*any", ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, disableEnv))
+
+        // 运行时生成的 OpenAPI 文档
+        engine.GET("/openapi.json", func(ctx *router.Context) {
+            if os.Getenv(disableEnv) != "" {
+                ctx.AbortWithStatus(404)
+                return
+            }
+
+            ctx.JSON(200, openapi.Build())
+        })
     })
 }
-
@@ -2,9 +2,13 @@ package event
 
 import (
 	"fmt"
-	"reflect"
+	"sync/atomic"
 )
 
+// 监听器编号
+// listener id counter
+var listenerID uint64
+
 // 监听器函数
 // EventHandler func
 type EventHandler = func(*Event)
@@ -13,6 +17,18 @@ type EventHandler = func(*Event)
 // Event Listener
 type EventListener struct {
 	Handler EventHandler
+
+	// 优先级，越大越先执行 / priority, larger runs first
+	Priority int
+
+	// 异步执行 / run in pool
+	Async bool
+
+	// 编号 / listener id
+	id uint64
+
+	// 原始监听 / origin handler
+	origin any
 }
 
 // 创建监听器
@@ -20,9 +36,48 @@ type EventListener struct {
 func NewEventListener(h EventHandler) *EventListener {
 	l := new(EventListener)
 	l.Handler = h
+	l.id = atomic.AddUint64(&listenerID, 1)
 	return l
 }
 
+// 监听器编号
+// Listener id
+func (this *EventListener) ID() uint64 {
+	return this.id
+}
+
+// 设置优先级
+// With Priority
+func (this *EventListener) WithPriority(priority int) *EventListener {
+	this.Priority = priority
+	return this
+}
+
+// 设置异步执行
+// With Async
+func (this *EventListener) WithAsync(async bool) *EventListener {
+	this.Async = async
+	return this
+}
+
+// 是否为同一个监听，函数监听只能使用监听器或者监听句柄比较
+// if same listener return true
+func (this *EventListener) Same(listener *EventListener) bool {
+	if this == listener {
+		return true
+	}
+
+	if listener != nil && this.id != 0 && this.id == listener.id {
+		return true
+	}
+
+	if this.origin == nil || listener == nil || listener.origin == nil {
+		return false
+	}
+
+	return sameHandler(this.origin, listener.origin)
+}
+
 // 事件调度器中存放的单元
 // Event Saver list
 type EventSaver struct {
@@ -55,6 +110,12 @@ type IEventDispatcher interface {
 	DispatchEvent(*Event)
 }
 
+// 异步执行器
+// async runner
+type IEventRunner interface {
+	Go(func())
+}
+
 // =====
 
 /**
@@ -72,8 +133,28 @@ type Event struct {
 
 	// 事件携带数据源 / event data
 	Object any
+
+	// 派发方式 / dispatch mode
+	mode dispatchMode
+
+	// 停止派发 / stop propagation
+	stopped bool
+
+	// 监听返回值 / listener result
+	result    any
+	hasResult bool
 }
 
+// 派发方式
+// dispatch mode
+type dispatchMode int
+
+const (
+	modeDispatch dispatchMode = iota
+	modeUntil
+	modeFilter
+)
+
 // 创建事件
 // New Event
 func NewEvent(eventType string, object any) *Event {
@@ -95,6 +176,31 @@ func (this *Event) Clone() *Event {
 	return e
 }
 
+// 停止派发，后面的监听不再执行
+// Stop propagation
+func (this *Event) Stop() {
+	this.stopped = true
+}
+
+// 是否已停止派发
+// if stopped return true
+func (this *Event) IsStopped() bool {
+	return this.stopped
+}
+
+// 设置返回值，Until 派发时非 nil 返回值会停止派发，Filter 派发时作为下一个监听的数据
+// Set listener result
+func (this *Event) SetResult(result any) {
+	this.result = result
+	this.hasResult = true
+}
+
+// 返回值
+// get listener result
+func (this *Event) Result() any {
+	return this.result
+}
+
 // 返回字符
 // return string
 func (this *Event) String() string {
@@ -107,6 +213,9 @@ func (this *Event) String() string {
 // Event Dispatcher
 type EventDispatcher struct {
 	savers []*EventSaver
+
+	// 异步执行器 / async runner
+	runner IEventRunner
 }
 
 // 创建事件派发器
@@ -118,12 +227,20 @@ func NewEventDispatcher() *EventDispatcher {
 	return dispatcher
 }
 
-// 事件调度器添加事件
+// 设置异步执行器
+// With async runner
+func (this *EventDispatcher) WithRunner(runner IEventRunner) *EventDispatcher {
+	this.runner = runner
+
+	return this
+}
+
+// 事件调度器添加事件，按优先级排序，相同优先级按添加顺序
 // Add Event Listener
 func (this *EventDispatcher) AddEventListener(eventType string, listener *EventListener) {
 	for _, saver := range this.savers {
 		if saver.Type == eventType {
-			saver.Listeners = append(saver.Listeners, listener)
+			saver.Listeners = insertListener(saver.Listeners, listener)
 			return
 		}
 	}
@@ -168,7 +285,7 @@ func (this *EventDispatcher) RemoveEventListener(eventType string, listener *Eve
 	for _, saver := range this.savers {
 		if saver.Type == eventType {
 			for i, l := range saver.Listeners {
-				if reflect.DeepEqual(listener, l) {
+				if l.Same(listener) {
 					saver.Listeners = append(saver.Listeners[:i], saver.Listeners[i+1:]...)
 					return true
 				}
@@ -185,7 +302,7 @@ func (this *EventDispatcher) HasEventListener(eventType string, listener *EventL
 	for _, saver := range this.savers {
 		if saver.Type == eventType {
 			for _, l := range saver.Listeners {
-				if reflect.DeepEqual(listener, l) {
+				if l.Same(listener) {
 					return true
 				}
 			}
@@ -198,15 +315,61 @@ func (this *EventDispatcher) HasEventListener(eventType string, listener *EventL
 // 事件调度器派发事件
 // Dispatch Event
 func (this *EventDispatcher) DispatchEvent(event *Event) {
+	this.dispatchListeners(event, this.matchListeners(event.Type))
+}
+
+// 派发给监听列表
+// Dispatch Event to listeners
+func (this *EventDispatcher) dispatchListeners(event *Event, listeners []*EventListener) {
+	event.Target = this
+
+	for _, listener := range listeners {
+		if listener.Async && this.runner != nil {
+			e := event.Clone()
+			e.Object = event.Object
+
+			handler := listener.Handler
+			this.runner.Go(func() {
+				handler(e)
+			})
+
+			continue
+		}
+
+		listener.Handler(event)
+
+		switch event.mode {
+		case modeUntil:
+			if event.hasResult && event.result != nil {
+				return
+			}
+		case modeFilter:
+			if event.hasResult {
+				event.Object = event.result
+				event.result, event.hasResult = nil, false
+			}
+		}
+
+		if event.stopped {
+			return
+		}
+	}
+}
+
+// 匹配的监听列表，按优先级排序，返回新的列表
+// match Listeners
+func (this *EventDispatcher) matchListeners(eventType string) []*EventListener {
+	listeners := make([]*EventListener, 0)
+
 	for _, saver := range this.savers {
-		if matchTypeName(event.Type, saver.Type) {
+		if matchTypeName(eventType, saver.Type) {
 			for _, listener := range saver.Listeners {
-				event.Target = this
-
-				listener.Handler(event)
+				listeners = insertListener(listeners, listener)
 			}
 		}
 	}
+
+	return listeners
 }
 
 // 事件类型列表
@@ -221,12 +384,12 @@ func (this *EventDispatcher) EventNames() []string {
 	return names
 }
 
-// 事件类型对应监听列表
-// list Event Listeners
+// 事件类型对应监听列表，返回副本
+// list Event Listeners, returns a copy
 func (this *EventDispatcher) EventListeners(eventType string) []*EventListener {
 	for _, saver := range this.savers {
 		if saver.Type == eventType {
-			return saver.Listeners
+			return append([]*EventListener(nil), saver.Listeners...)
 		}
 	}
 
@@ -22,36 +22,89 @@ type Events struct {
 
 	// 调度器 / dispatcher struct
 	dispatcher *EventDispatcher
+
+	// 异步执行池 / async pool
+	pool *Pool
 }
 
 // 构造函数
 // New Events
 func New() *Events {
 	event := &Events{
-		dispatcher: NewEventDispatcher(),
+		pool: NewPool(0, 100),
 	}
 
+	event.dispatcher = NewEventDispatcher().WithRunner(event.pool)
+
 	return event
 }
 
+// 设置异步执行池
+// With async Pool
+func (this *Events) WithPool(pool *Pool) *Events {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.pool = pool
+	this.dispatcher.WithRunner(pool)
+
+	return this
+}
+
+// 获取异步执行池
+// get async Pool
+func (this *Events) GetPool() *Pool {
+	return this.pool
+}
+
+// 监听，priority 越大越先执行
+// Listen event, larger priority runs first
+func (this *Events) Listen(name any, handler any, priority ...int) *Subscription {
+	return this.listen(name, handler, false, priority...)
+}
+
 // 监听
 // Listen event
-func (this *Events) Listen(name any, handler any) {
+func Listen(name any, handler any, priority ...int) *Subscription {
+	return defaultEvents.Listen(name, handler, priority...)
+}
+
+// 异步监听，在执行池中执行，不能停止派发及返回数据
+// Listen event in async Pool
+func (this *Events) ListenAsync(name any, handler any, priority ...int) *Subscription {
+	return this.listen(name, handler, true, priority...)
+}
+
+// 异步监听
+// Listen event in async Pool
+func ListenAsync(name any, handler any, priority ...int) *Subscription {
+	return defaultEvents.ListenAsync(name, handler, priority...)
+}
+
+func (this *Events) listen(name any, handler any, async bool, priority ...int) *Subscription {
 	this.mu.Lock()
 	defer this.mu.Unlock()
 
 	newName := formatName(name)
-	if newName != "" {
-		listener := this.formatEventHandler(handler)
+	if newName == "" {
+		return nil
+	}
 
-		this.dispatcher.AddEventListener(newName, listener)
+	listener := this.formatEventHandler(handler)
+	if len(priority) > 0 {
+		listener.Priority = priority[0]
+	}
+	if async {
+		listener.Async = true
 	}
-}
 
-// 监听
-// Listen event
-func Listen(name any, handler any) {
-	defaultEvents.Listen(name, handler)
+	this.dispatcher.AddEventListener(newName, listener)
+
+	return &Subscription{
+		events:   this,
+		name:     newName,
+		listener: listener,
+	}
 }
 
 // 注册事件订阅者
@@ -116,9 +169,64 @@ func Observe(observer any, prefix string) {
 // 事件调度
 // Dispatch Event
 func (this *Events) Dispatch(name any, object ...any) bool {
-	this.mu.RLock()
-	defer this.mu.RUnlock()
+	return this.dispatch(modeDispatch, name, object...) != nil
+}
+
+// 事件调度
+// Dispatch Event
+func Dispatch(name any, object ...any) bool {
+	return defaultEvents.Dispatch(name, object...)
+}
+
+// 事件调度，返回第一个非 nil 的监听返回值，并停止派发
+// Dispatch Event until first not nil result
+func (this *Events) Until(name any, object ...any) any {
+	e := this.dispatch(modeUntil, name, object...)
+	if e == nil {
+		return nil
+	}
+
+	return e.Result()
+}
 
+// 事件调度，返回第一个非 nil 的监听返回值
+// Dispatch Event until first not nil result
+func Until(name any, object ...any) any {
+	return defaultEvents.Until(name, object...)
+}
+
+// 过滤调度，监听返回值作为下一个监听的数据，返回最终数据
+// Filter value by listeners
+func (this *Events) Filter(name any, value any) any {
+	e := this.dispatch(modeFilter, name, value)
+	if e == nil {
+		return value
+	}
+
+	return e.Object
+}
+
+// 过滤调度
+// Filter value by listeners
+func Filter(name any, value any) any {
+	return defaultEvents.Filter(name, value)
+}
+
+// 等待异步监听执行完成
+// Wait async listeners
+func (this *Events) Wait() {
+	if this.pool != nil {
+		this.pool.Wait()
+	}
+}
+
+// 等待异步监听执行完成
+// Wait async listeners
+func Wait() {
+	defaultEvents.Wait()
+}
+
+func (this *Events) dispatch(mode dispatchMode, name any, object ...any) *Event {
 	var eventObject any
 	if len(object) > 0 {
 		eventObject = object[0]
@@ -139,27 +247,29 @@ func (this *Events) Dispatch(name any, object ...any) bool {
 	}
 
 	if newName == "" {
-		return false
+		return nil
 	}
 
 	newEvent := NewEvent(newName, eventObject)
+	newEvent.mode = mode
 
-	this.dispatcher.DispatchEvent(newEvent)
+	// 监听执行时不加锁，监听里可以添加及移除监听
+	// listeners run without lock so they can listen or unsubscribe
+	this.mu.RLock()
+	dispatcher := this.dispatcher
+	listeners := dispatcher.matchListeners(newName)
+	this.mu.RUnlock()
 
-	return true
-}
+	dispatcher.dispatchListeners(newEvent, listeners)
 
-// 事件调度
-// Dispatch Event
-func Dispatch(name any, object ...any) bool {
-	return defaultEvents.Dispatch(name, object...)
+	return newEvent
 }
 
 // 移除
 // Remove Event
 func (this *Events) RemoveEvent(name any) bool {
-	this.mu.RLock()
-	defer this.mu.RUnlock()
+	this.mu.Lock()
+	defer this.mu.Unlock()
 
 	newName := formatName(name)
 	if newName == "" {
@@ -195,11 +305,12 @@ func HasEvent(name any) bool {
 	return defaultEvents.HasEvent(name)
 }
 
-// 移除
-// Remove Listen
+// 移除，handler 可以为监听器、监听句柄或者结构体监听
+// 函数监听没有可比较的标识，需要使用 Listen 返回的监听句柄移除
+// Remove Listen by listener, subscription or struct handler
 func (this *Events) RemoveListen(name any, handler any) bool {
-	this.mu.RLock()
-	defer this.mu.RUnlock()
+	this.mu.Lock()
+	defer this.mu.Unlock()
 
 	newName := formatName(name)
 	if newName == "" {
@@ -277,7 +388,10 @@ func EventListeners(name any) []*EventListener {
 // 重置
 // Reset Event
 func (this *Events) Reset() *Events {
-	this.dispatcher = NewEventDispatcher()
+	this.mu.Lock()
+	defer this.mu.Unlock()
+
+	this.dispatcher = NewEventDispatcher().WithRunner(this.pool)
 
 	return this
 }
@@ -322,7 +436,7 @@ func (this *Events) subscribeListen(es EventSubscribe, e *Event) {
 	}
 
 	if len(params) == numIn {
-		fn.Call(params)
+		setCallResult(e, fn.Call(params))
 	}
 }
 
@@ -354,7 +468,7 @@ func (this *Events) funcReflectListen(fn any, e *Event) {
 	}
 
 	if fieldNum == len(newParams) {
-		fnObject.Call(newParams)
+		setCallResult(e, fnObject.Call(newParams))
 	}
 }
 
@@ -384,6 +498,9 @@ func (this *Events) formatEventHandler(handler any) *EventListener {
 	case *EventListener:
 		return fn
 
+	case *Subscription:
+		return fn.listener
+
 	// func(*Event)
 	case EventHandler:
 		newHandler = fn
@@ -403,6 +520,16 @@ func (this *Events) formatEventHandler(handler any) *EventListener {
 			fn(e.Object, e.Type)
 		}
 
+	case func(any) any:
+		newHandler = func(e *Event) {
+			e.SetResult(fn(e.Object))
+		}
+
+	case func(any, string) any:
+		newHandler = func(e *Event) {
+			e.SetResult(fn(e.Object, e.Type))
+		}
+
 	case EventSubscribe:
 		newHandler = func(e *Event) {
 			this.subscribeListen(fn, e)
@@ -424,6 +551,7 @@ func (this *Events) formatEventHandler(handler any) *EventListener {
 	}
 
 	listener := NewEventListener(newHandler)
+	listener.origin = handler
 
 	return listener
 }
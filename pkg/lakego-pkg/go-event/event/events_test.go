@@ -3,7 +3,9 @@ package event
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func assertDeepEqualT(t *testing.T) func(any, any, string) {
@@ -371,15 +373,21 @@ func Test_RemoveListen(t *testing.T) {
 		eventData = data
 	}
 
-	Listen("data22222.test", fn1)
+	sub := Listen("data22222.test", fn1)
 
-	eq(HasListen("data22222.test", fn1), false, "RemoveListen")
+	// 函数不能比较，使用监听句柄
+	eq(HasListen("data22222.test", fn1), false, "HasListen func")
+	eq(HasListen("data22222.test", sub), true, "HasListen")
 	eq(HasListen(int64(123678), fn1), false, "RemoveListen other type")
 
 	Dispatch("data22222.test", checkData)
 
 	eq(eventData, checkData, "RemoveListen")
 
+	eq(RemoveListen("data22222.test", fn1), false, "RemoveListen func")
+	eq(RemoveListen("data22222.test", sub), true, "RemoveListen sub")
+	eq(HasListen("data22222.test", sub), false, "RemoveListen sub HasListen")
+
 	// ==========
 
 	ev := NewEventDispatcher()
@@ -441,3 +449,242 @@ func Test_Reset(t *testing.T) {
 
 	eq(eventData2, nil, "Reset 2")
 }
+
+func Test_Priority(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var calls []string
+	ev.Listen("Priority.test", func(data any) {
+		calls = append(calls, "a")
+	})
+	ev.Listen("Priority.test", func(data any) {
+		calls = append(calls, "b")
+	}, 10)
+	ev.Listen("Priority.test", func(data any) {
+		calls = append(calls, "c")
+	}, -1)
+	ev.Listen("Priority.test", func(data any) {
+		calls = append(calls, "d")
+	}, 10)
+
+	ev.Dispatch("Priority.test", "data")
+
+	eq(calls, []string{"b", "d", "a", "c"}, "Priority")
+}
+
+func Test_Stop(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var calls []string
+	ev.Listen("Stop.test", func(e *Event) {
+		calls = append(calls, "a")
+		e.Stop()
+	})
+	ev.Listen("Stop.test", func(data any) {
+		calls = append(calls, "b")
+	})
+
+	eq(ev.Dispatch("Stop.test", "data"), true, "Stop Dispatch")
+	eq(calls, []string{"a"}, "Stop")
+}
+
+func Test_Until(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var calls []string
+	ev.Listen("Until.test", func(data any) any {
+		calls = append(calls, "a")
+		return nil
+	})
+	ev.Listen("Until.test", func(data string) string {
+		calls = append(calls, "b")
+		return data + "-b"
+	})
+	ev.Listen("Until.test", func(data any) {
+		calls = append(calls, "c")
+	})
+
+	eq(ev.Until("Until.test", "data"), "data-b", "Until")
+	eq(calls, []string{"a", "b"}, "Until calls")
+
+	eq(ev.Until("Until.test111", "data"), nil, "Until empty")
+}
+
+func Test_Filter(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	ev.Listen("Filter.test", func(data string) string {
+		return data + "-a"
+	})
+	ev.Listen("Filter.test", func(data string) error {
+		return nil
+	})
+	ev.Listen("Filter.test", func(e *Event) {
+		e.SetResult(e.Object.(string) + "-b")
+	})
+	ev.Listen("Filter.test", func(data string) string {
+		return data + "-first"
+	}, 10)
+
+	eq(ev.Filter("Filter.test", "data"), "data-first-a-b", "Filter")
+	eq(ev.Filter("Filter.test111", "data"), "data", "Filter empty")
+}
+
+func Test_ListenAsync(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New().WithPool(NewPool(2, 1))
+
+	var mu sync.Mutex
+	total := 0
+
+	ev.ListenAsync("Async.test", func(data int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		total += data
+	})
+
+	for i := 1; i <= 10; i++ {
+		ev.Dispatch("Async.test", i)
+	}
+
+	ev.Wait()
+
+	eq(total, 55, "ListenAsync")
+}
+
+func Test_ListenAsyncNested(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	// 队列已满时不阻塞，监听中再次触发事件不会死锁
+	ev := New().WithPool(NewPool(1, 0))
+
+	var mu sync.Mutex
+	total := 0
+
+	ev.ListenAsync("AsyncNested.test", func(data int) {
+		if data > 0 {
+			ev.Dispatch("AsyncNested.test", data-1)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		total++
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ev.Dispatch("AsyncNested.test", 5)
+		ev.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ListenAsync nested deadlock")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	eq(total, 6, "ListenAsync nested")
+}
+
+func Test_EventListenersCopy(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+	ev.Listen("ListenersCopy.test", func(data any) {})
+
+	listeners := ev.EventListeners("ListenersCopy.test")
+	listeners[0] = nil
+
+	eq(ev.EventListeners("ListenersCopy.test")[0] != nil, true, "EventListeners copy")
+}
+
+func Test_Subscription(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var calls []string
+	sub := ev.Listen("Subscription.test", func(data any) {
+		calls = append(calls, "a")
+	})
+	ev.Listen("Subscription.test", func(data any) {
+		calls = append(calls, "b")
+	})
+
+	eq(sub.Name(), "Subscription.test", "Subscription Name")
+	eq(ev.HasListen("Subscription.test", sub), true, "Subscription HasListen")
+	eq(sub.Unsubscribe(), true, "Unsubscribe")
+	eq(sub.Unsubscribe(), false, "Unsubscribe again")
+
+	ev.Dispatch("Subscription.test", "data")
+
+	eq(calls, []string{"b"}, "Subscription calls")
+}
+
+func Test_RemoveListenClosure(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var calls []int
+	subs := make([]*Subscription, 0)
+	for i := 0; i < 3; i++ {
+		n := i
+		subs = append(subs, ev.Listen("Closure.test", func(data any) {
+			calls = append(calls, n)
+		}))
+	}
+
+	// 同一个函数字面量生成的闭包只移除对应的监听
+	eq(subs[1].Unsubscribe(), true, "Unsubscribe closure")
+
+	ev.Dispatch("Closure.test", "data")
+
+	eq(calls, []int{0, 2}, "closure calls")
+}
+
+func Test_UnsubscribeInListener(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	calls := 0
+
+	var sub *Subscription
+	sub = ev.Listen("Unsubscribe.self", func(data any) {
+		calls++
+		sub.Unsubscribe()
+		ev.Listen("Unsubscribe.other", func() {})
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ev.Dispatch("Unsubscribe.self", "data")
+		ev.Dispatch("Unsubscribe.self", "data")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("dispatch deadlock")
+	}
+
+	eq(calls, 1, "unsubscribe in listener")
+	eq(ev.HasEvent("Unsubscribe.other"), true, "listen in listener")
+}
@@ -0,0 +1,86 @@
+package event
+
+import (
+	"runtime"
+	"sync"
+)
+
+/**
+ * 异步监听执行池 / async listener pool
+ *
+ * 固定数量的协程执行异步监听，队列已满时在当前协程执行，避免在监听中触发事件时死锁
+ * fixed workers run async listeners, Go runs the task inline when queue is full
+ *
+ * @create 2024-5-14
+ * @author deatil
+ */
+type Pool struct {
+	// 协程数量 / workers
+	workers int
+
+	// 任务队列 / tasks
+	tasks chan func()
+
+	// 执行中任务 / pending tasks
+	pending sync.WaitGroup
+
+	once sync.Once
+}
+
+// 构造函数
+// New Pool
+func NewPool(workers int, size int) *Pool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if size < 0 {
+		size = 0
+	}
+
+	return &Pool{
+		workers: workers,
+		tasks:   make(chan func(), size),
+	}
+}
+
+// 执行任务，队列已满时在当前协程执行
+// run task, run inline when queue is full
+func (this *Pool) Go(task func()) {
+	this.once.Do(this.start)
+
+	this.pending.Add(1)
+
+	select {
+	case this.tasks <- task:
+	default:
+		this.run(task)
+	}
+}
+
+// 等待已添加的任务执行完成
+// wait pending tasks
+func (this *Pool) Wait() {
+	this.pending.Wait()
+}
+
+func (this *Pool) start() {
+	for i := 0; i < this.workers; i++ {
+		go func() {
+			for task := range this.tasks {
+				this.run(task)
+			}
+		}()
+	}
+}
+
+func (this *Pool) run(task func()) {
+	defer this.pending.Done()
+
+	// 监听异常不影响其他任务
+	// recover listener panic
+	defer func() {
+		recover()
+	}()
+
+	task()
+}
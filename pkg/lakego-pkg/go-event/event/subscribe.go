@@ -22,3 +22,33 @@ type EventSubscribe struct {
 	// 方法
 	Method reflect.Method
 }
+
+// 监听句柄
+// Subscription handle
+type Subscription struct {
+	events   *Events
+	name     string
+	listener *EventListener
+}
+
+// 事件名称
+// Event name
+func (this *Subscription) Name() string {
+	return this.name
+}
+
+// 监听器
+// get Listener
+func (this *Subscription) Listener() *EventListener {
+	return this.listener
+}
+
+// 取消监听
+// Remove Listen
+func (this *Subscription) Unsubscribe() bool {
+	if this == nil || this.events == nil {
+		return false
+	}
+
+	return this.events.RemoveListen(this.name, this.listener)
+}
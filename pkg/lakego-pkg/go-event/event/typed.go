@@ -0,0 +1,118 @@
+package event
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// 类型事件名称前缀
+// typed event name prefix
+const typedPrefix = "typed:"
+
+// 监听错误列表
+// listener errors
+type Errors []error
+
+// 错误信息
+// Error string
+func (this Errors) Error() string {
+	msgs := make([]string, 0, len(this))
+	for _, err := range this {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// 错误列表
+// Unwrap errors
+func (this Errors) Unwrap() []error {
+	return this
+}
+
+// 类型事件数据
+// typed event payload
+type typedPayload[T any] struct {
+	ctx     context.Context
+	payload T
+	errs    Errors
+}
+
+// 类型事件名称
+// typed event name
+func TypedName[T any]() string {
+	key := getTypeKey(reflect.TypeOf((*T)(nil)).Elem())
+
+	// * 会被当作通配符
+	// * is used as wildcard
+	return typedPrefix + strings.ReplaceAll(key, "*", "ptr:")
+}
+
+// 监听类型事件，priority 越大越先执行
+// Listen typed event
+func OnEvents[T any](e *Events, fn func(context.Context, T) error, priority ...int) *Subscription {
+	listener := NewEventListener(func(ev *Event) {
+		data, ok := ev.Object.(*typedPayload[T])
+		if !ok {
+			return
+		}
+
+		// 已取消时停止派发
+		// stop when context is done
+		if err := data.ctx.Err(); err != nil {
+			data.errs = append(data.errs, err)
+			ev.Stop()
+			return
+		}
+
+		if err := fn(data.ctx, data.payload); err != nil {
+			data.errs = append(data.errs, err)
+		}
+	})
+
+	if len(priority) > 0 {
+		listener.Priority = priority[0]
+	}
+
+	return e.Listen(TypedName[T](), listener)
+}
+
+// 监听类型事件
+// Listen typed event
+func On[T any](fn func(context.Context, T) error, priority ...int) *Subscription {
+	return OnEvents(defaultEvents, fn, priority...)
+}
+
+// 派发类型事件，返回监听的错误列表
+// Emit typed event and return listener errors
+func EmitEvents[T any](e *Events, ctx context.Context, payload T) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	data := &typedPayload[T]{
+		ctx:     ctx,
+		payload: payload,
+	}
+
+	e.Dispatch(TypedName[T](), data)
+
+	if len(data.errs) == 0 {
+		return nil
+	}
+
+	return data.errs
+}
+
+// 派发类型事件
+// Emit typed event
+func Emit[T any](ctx context.Context, payload T) error {
+	return EmitEvents(defaultEvents, ctx, payload)
+}
+
+// 移除类型事件监听，使用 On 返回的监听句柄
+// Remove typed listener by subscription
+func Off(sub *Subscription) bool {
+	return sub.Unsubscribe()
+}
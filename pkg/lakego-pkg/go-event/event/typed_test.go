@@ -0,0 +1,102 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type typedUser struct {
+	Name string
+}
+
+func Test_Typed(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	var names []string
+	OnEvents(ev, func(ctx context.Context, u typedUser) error {
+		names = append(names, "a:"+u.Name)
+		return nil
+	})
+	OnEvents(ev, func(ctx context.Context, u typedUser) error {
+		names = append(names, "b:"+u.Name)
+		return errors.New("b error")
+	}, 10)
+	OnEvents(ev, func(ctx context.Context, u *typedUser) error {
+		names = append(names, "ptr:"+u.Name)
+		return nil
+	})
+
+	err := EmitEvents(ev, context.Background(), typedUser{Name: "lakego"})
+	eq(names, []string{"b:lakego", "a:lakego"}, "Emit")
+	eq(err.Error(), "b error", "Emit error")
+
+	var errs Errors
+	eq(errors.As(err, &errs), true, "Emit Errors")
+	eq(len(errs), 1, "Emit Errors len")
+
+	names = nil
+	err = EmitEvents(ev, context.Background(), &typedUser{Name: "admin"})
+	eq(names, []string{"ptr:admin"}, "Emit ptr")
+	eq(err, nil, "Emit ptr error")
+}
+
+func Test_TypedContext(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	called := false
+	OnEvents(ev, func(ctx context.Context, u typedUser) error {
+		called = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := EmitEvents(ev, ctx, typedUser{Name: "lakego"})
+	eq(called, false, "Emit canceled")
+	eq(errors.Is(err, context.Canceled), true, "Emit canceled error")
+}
+
+func Test_TypedOff(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	called := 0
+	fn := func(ctx context.Context, u typedUser) error {
+		called++
+		return nil
+	}
+
+	sub := OnEvents(ev, fn)
+	EmitEvents(ev, context.Background(), typedUser{})
+	eq(Off(sub), true, "Off")
+	EmitEvents(ev, context.Background(), typedUser{})
+
+	eq(called, 1, "Off called")
+}
+
+func Test_TypedUnsubscribeInListener(t *testing.T) {
+	eq := assertDeepEqualT(t)
+
+	ev := New()
+
+	called := 0
+
+	var sub *Subscription
+	sub = OnEvents(ev, func(ctx context.Context, u typedUser) error {
+		called++
+		sub.Unsubscribe()
+		return nil
+	})
+
+	EmitEvents(ev, context.Background(), typedUser{})
+	EmitEvents(ev, context.Background(), typedUser{})
+
+	eq(called, 1, "typed unsubscribe in listener")
+}
@@ -24,6 +24,59 @@ func matchTypeName(typeName string, current string) bool {
 	return true
 }
 
+// 按优先级插入监听，相同优先级排在后面
+// insert Listener by priority
+func insertListener(listeners []*EventListener, listener *EventListener) []*EventListener {
+	i := len(listeners)
+	for i > 0 && listeners[i-1].Priority < listener.Priority {
+		i--
+	}
+
+	listeners = append(listeners, nil)
+	copy(listeners[i+1:], listeners[i:])
+	listeners[i] = listener
+
+	return listeners
+}
+
+// 是否为同一个监听，函数不能比较，同一个函数字面量生成的闭包地址相同
+// if same handler return true, funcs are never the same
+func sameHandler(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	switch va.Kind() {
+	case reflect.Func:
+		return false
+	case reflect.Pointer:
+		return va.Pointer() == vb.Pointer()
+	}
+
+	if es, ok := a.(EventSubscribe); ok {
+		eb := b.(EventSubscribe)
+		return es.Method.Name == eb.Method.Name &&
+			sameHandler(es.Struct.Interface(), eb.Struct.Interface())
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// 错误类型
+// error type
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// 设置反射调用的返回值，返回 error 类型时忽略
+// set Event result from call returns
+func setCallResult(e *Event, out []reflect.Value) {
+	if len(out) == 0 || out[0].Type() == errorType {
+		return
+	}
+
+	e.SetResult(out[0].Interface())
+}
+
 // 获取方法名称
 // get Func Name
 func getFuncName(data any) string {
@@ -1,6 +1,6 @@
 module github.com/deatil/lakego-doak
 
-go 1.18
+go 1.21
 
 require (
 	github.com/casbin/casbin/v2 v2.60.0
@@ -19,6 +19,7 @@ require (
 	github.com/go-redis/cache/v8 v8.4.3
 	github.com/go-redis/redis/v8 v8.11.4
 	github.com/golang-jwt/jwt/v4 v4.4.3 // indirect
+	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/google/uuid v1.3.0
 	github.com/iancoleman/strcase v0.2.0
 	github.com/lestrrat/go-file-rotatelogs v0.0.0-20180223000712-d3151e2a480f
@@ -2,13 +2,10 @@ package app
 
 import (
     "os"
-    "os/signal"
     "net"
-    "net/http"
     "fmt"
     "log"
     "sync"
-    "errors"
     "context"
     "reflect"
 
@@ -73,8 +70,14 @@ type App struct {
     // 启动后
     bootedCallbacks []func()
 
+    // 关闭时
+    shutdownCallbacks []func(context.Context)
+
     // 自定义运行监听
     netListener net.Listener
+
+    // 服务状态
+    state int32
 }
 
 // App 结构体
@@ -269,6 +272,25 @@ func (this *App) CallBootedCallbacks() {
     }
 }
 
+// 设置关闭时函数
+func (this *App) WithShutdown(f func(context.Context)) {
+    this.mut.Lock()
+    defer this.mut.Unlock()
+
+    this.shutdownCallbacks = append(this.shutdownCallbacks, f)
+}
+
+// 关闭时回调，后注册的先执行
+func (this *App) CallShutdownCallbacks(ctx context.Context) {
+    this.mut.RLock()
+    callbacks := this.shutdownCallbacks
+    this.mut.RUnlock()
+
+    for i := len(callbacks) - 1; i >= 0; i-- {
+        callbacks[i](ctx)
+    }
+}
+
 // 设置根脚本
 func (this *App) WithRootCmd(cmd *command.Command) {
     this.rootCmd = cmd
@@ -381,6 +403,9 @@ func (this *App) runApp() {
         r = router.New()
     }
 
+    // 传入 *router.Context 时使用请求上下文，链路等数据在请求上下文中
+    r.ContextWithFallback = true
+
     // 日志记录方式
     logType := serverConf.GetString("log-type")
     if logType == "file" {
@@ -453,115 +478,6 @@ func (this *App) loadServiceProvider() {
     this.CallBootedCallbacks()
 }
 
-// 服务运行
-func (this *App) serverRun() {
-    conf := this.config
-
-    // 报错数据
-    var err error
-
-    // 运行方式
-    runType := conf.GetString("default")
-    switch runType {
-        case "http":
-            // 运行方式
-            servertype := conf.GetString("types.http.server-type")
-
-            // 运行端口
-            addr := conf.GetString("types.http.addr")
-
-            if servertype == "grace" {
-                // 优雅地关机
-                this.graceRun(addr)
-            } else {
-                // gin 自带运行
-                err = this.route.Run(addr)
-            }
-
-        case "tls":
-            // 运行端口
-            addr := conf.GetString("types.tls.addr")
-
-            certFile := conf.GetString("types.tls.cert-file")
-            keyFile := conf.GetString("types.tls.key-file")
-
-            // 格式化
-            certFile = this.formatPath(certFile)
-            keyFile = this.formatPath(keyFile)
-
-            err = this.route.RunTLS(addr, certFile, keyFile)
-
-        case "unix":
-            // 文件
-            file := conf.GetString("types.unix.file")
-
-            // 格式化
-            file = this.formatPath(file)
-
-            err = this.route.RunUnix(file)
-
-        case "fd":
-            // fd
-            fd := conf.GetInt("types.fd.fd")
-
-            err = this.route.RunFd(fd)
-
-        case "net-listener":
-            if this.netListener != nil {
-                err = this.route.RunListener(this.netListener)
-            } else {
-                // 监听
-                typ := conf.GetString("types.net-listener.type")
-                addr := conf.GetString("types.net-listener.addr")
-
-                netListener, _ := net.Listen(typ, addr)
-
-                err = this.route.RunListener(netListener)
-            }
-
-        default:
-            err = errors.New("服务启动错误")
-    }
-
-    if err != nil {
-        log.Fatalf("server err: %s\n", err)
-    }
-}
-
-// 优雅地关机
-func (this *App) graceRun(address string) {
-    conf := this.config
-
-    srv := &http.Server{
-        Addr:           address,
-        Handler:        this.route,
-        ReadTimeout:    conf.GetDuration("types.http.grace-read-timeout"),
-        WriteTimeout:   conf.GetDuration("types.http.grace-write-timeout"),
-        MaxHeaderBytes: 1 << 20,
-    }
-
-    go func() {
-        // 服务连接
-        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-            log.Fatalf("listen: %s\n", err)
-        }
-    }()
-
-    // 等待中断信号以优雅地关闭服务器（设置 5 秒的超时时间）
-    quit := make(chan os.Signal)
-    signal.Notify(quit, os.Interrupt)
-    <-quit
-    log.Println("Shutdown Server ...")
-
-    ctx, cancel := context.WithTimeout(context.Background(), conf.GetDuration("types.http.grace-timeout"))
-    defer cancel()
-    if err := srv.Shutdown(ctx); err != nil {
-        log.Fatal("Server Shutdown:", err)
-    }
-
-    log.Println("Server exiting")
-}
-
 /**
  * 初始化容器
  */
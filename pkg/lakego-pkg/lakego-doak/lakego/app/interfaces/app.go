@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/schedule"
     iprovider "github.com/deatil/lakego-doak/lakego/provider/interfaces"
@@ -40,6 +42,9 @@ type App interface {
     // 设置启动后函数
     WithBooted(func())
 
+    // 设置关闭时函数
+    WithShutdown(func(context.Context))
+
     // 获取脚本
     GetRootCmd() *command.Command
 
@@ -57,4 +62,10 @@ type App interface {
 
     // 是否为开发者模式
     IsDev() bool
+
+    // 服务是否就绪
+    IsReady() bool
+
+    // 服务是否正在关闭
+    IsDraining() bool
 }
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package app
+
+import (
+    "os"
+    "syscall"
+)
+
+// 平滑重启信号
+func restartSignals() []os.Signal {
+    return []os.Signal{syscall.SIGHUP, syscall.SIGUSR2}
+}
@@ -0,0 +1,10 @@
+package app
+
+import (
+    "os"
+)
+
+// windows 不支持传递监听，不开启平滑重启
+func restartSignals() []os.Signal {
+    return nil
+}
@@ -0,0 +1,319 @@
+package app
+
+import (
+    "os"
+    "os/exec"
+    "os/signal"
+    "net"
+    "net/http"
+    "log"
+    "time"
+    "errors"
+    "context"
+    "strings"
+    "strconv"
+    "syscall"
+    "sync/atomic"
+)
+
+// 服务状态
+const (
+    StateStarting int32 = iota
+    StateReady
+    StateDraining
+    StateStopped
+)
+
+// 平滑重启时传递给新进程的环境变量
+const (
+    // 继承的监听文件
+    envListenFd = "LAKEGO_LISTEN_FD"
+
+    // 旧进程 pid，新进程就绪后通知旧进程退出
+    envParentPid = "LAKEGO_PARENT_PID"
+)
+
+// 继承的监听在新进程中的 fd，ExtraFiles 从 3 开始
+const inheritedFd = 3
+
+// 是否为平滑重启启动的进程
+func Inherited() bool {
+    return os.Getenv(envListenFd) != ""
+}
+
+// 服务是否就绪
+func (this *App) IsReady() bool {
+    return atomic.LoadInt32(&this.state) == StateReady
+}
+
+// 服务是否正在关闭
+func (this *App) IsDraining() bool {
+    return atomic.LoadInt32(&this.state) == StateDraining
+}
+
+// 服务状态
+func (this *App) State() int32 {
+    return atomic.LoadInt32(&this.state)
+}
+
+// 服务运行
+func (this *App) serverRun() {
+    ln, err := this.listen()
+    if err != nil {
+        log.Fatalf("server err: %s\n", err)
+    }
+
+    srv := this.newServer()
+
+    serveErr := make(chan error, 1)
+    go func() {
+        serveErr <- this.serve(srv, ln)
+    }()
+
+    atomic.StoreInt32(&this.state, StateReady)
+
+    // 平滑重启的新进程已就绪，通知旧进程退出
+    this.notifyParent()
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+    restart := make(chan os.Signal, 1)
+    if this.config.GetBool("shutdown.restart") {
+        if sigs := restartSignals(); len(sigs) > 0 {
+            signal.Notify(restart, sigs...)
+        }
+    }
+
+    restarting := false
+
+    // 新进程退出，新进程就绪前退出时可以再次重启
+    var childExit chan error
+
+    for {
+        select {
+            case err := <-serveErr:
+                if err != nil && err != http.ErrServerClosed {
+                    log.Fatalf("server err: %s\n", err)
+                }
+
+                return
+
+            case <-restart:
+                // 等待新进程就绪，新进程就绪后会发送 SIGTERM
+                if restarting {
+                    continue
+                }
+
+                cmd, err := this.restart(ln)
+                if err != nil {
+                    log.Println("Restart Server err:", err)
+                    continue
+                }
+
+                exit := make(chan error, 1)
+                go func() {
+                    exit <- cmd.Wait()
+                }()
+
+                childExit = exit
+
+                restarting = true
+                log.Println("Restart Server ...")
+
+            case err := <-childExit:
+                childExit = nil
+                restarting = false
+
+                log.Println("Restart Server exited:", err)
+
+            case <-quit:
+                this.shutdown(srv)
+
+                return
+        }
+    }
+}
+
+// 监听
+func (this *App) listen() (net.Listener, error) {
+    // 平滑重启时使用旧进程的监听
+    if Inherited() {
+        return net.FileListener(os.NewFile(uintptr(inheritedFd), "lakego-listener"))
+    }
+
+    conf := this.config
+
+    switch conf.GetString("default") {
+        case "http":
+            return net.Listen("tcp", conf.GetString("types.http.addr"))
+
+        case "tls":
+            return net.Listen("tcp", conf.GetString("types.tls.addr"))
+
+        case "unix":
+            file := this.formatPath(conf.GetString("types.unix.file"))
+
+            // 移除旧的 socket 文件
+            os.Remove(file)
+
+            return net.Listen("unix", file)
+
+        case "fd":
+            fd := conf.GetInt("types.fd.fd")
+
+            return net.FileListener(os.NewFile(uintptr(fd), "fd@"+strconv.Itoa(fd)))
+
+        case "net-listener":
+            if this.netListener != nil {
+                return this.netListener, nil
+            }
+
+            typ := conf.GetString("types.net-listener.type")
+            addr := conf.GetString("types.net-listener.addr")
+
+            return net.Listen(typ, addr)
+    }
+
+    return nil, errors.New("服务启动错误")
+}
+
+// http 服务
+func (this *App) newServer() *http.Server {
+    conf := this.config
+
+    srv := &http.Server{
+        Handler:        this.route,
+        MaxHeaderBytes: 1 << 20,
+    }
+
+    if conf.GetString("default") == "http" &&
+        conf.GetString("types.http.server-type") == "grace" {
+        srv.ReadTimeout = conf.GetDuration("types.http.grace-read-timeout")
+        srv.WriteTimeout = conf.GetDuration("types.http.grace-write-timeout")
+    }
+
+    return srv
+}
+
+// 运行服务
+func (this *App) serve(srv *http.Server, ln net.Listener) error {
+    conf := this.config
+
+    if conf.GetString("default") == "tls" {
+        certFile := this.formatPath(conf.GetString("types.tls.cert-file"))
+        keyFile := this.formatPath(conf.GetString("types.tls.key-file"))
+
+        return srv.ServeTLS(ln, certFile, keyFile)
+    }
+
+    return srv.Serve(ln)
+}
+
+// 优雅地关闭服务
+func (this *App) shutdown(srv *http.Server) {
+    conf := this.config
+
+    // 关闭期间未就绪，负载均衡可以摘除节点
+    atomic.StoreInt32(&this.state, StateDraining)
+    srv.SetKeepAlivesEnabled(false)
+
+    log.Println("Shutdown Server ...")
+
+    if delay := conf.GetDuration("shutdown.drain-delay"); delay > 0 {
+        time.Sleep(delay)
+    }
+
+    timeout := conf.GetDuration("shutdown.timeout")
+    if timeout <= 0 {
+        timeout = conf.GetDuration("types.http.grace-timeout")
+    }
+    if timeout <= 0 {
+        timeout = 5 * time.Second
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+
+    if err := srv.Shutdown(ctx); err != nil {
+        log.Println("Server Shutdown:", err)
+    }
+
+    // 关闭时回调
+    hookCtx, hookCancel := context.WithTimeout(context.Background(), timeout)
+    defer hookCancel()
+
+    this.CallShutdownCallbacks(hookCtx)
+
+    atomic.StoreInt32(&this.state, StateStopped)
+
+    log.Println("Server exiting")
+}
+
+// 启动新进程并传递监听
+func (this *App) restart(ln net.Listener) (*exec.Cmd, error) {
+    filer, ok := ln.(interface{
+        File() (*os.File, error)
+    })
+    if !ok {
+        return nil, errors.New("listener does not support inheritance")
+    }
+
+    file, err := filer.File()
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    // 旧进程关闭时不删除 socket 文件
+    if ul, ok := ln.(*net.UnixListener); ok {
+        ul.SetUnlinkOnClose(false)
+    }
+
+    exe, err := os.Executable()
+    if err != nil {
+        return nil, err
+    }
+
+    env := make([]string, 0)
+    for _, v := range os.Environ() {
+        if strings.HasPrefix(v, envListenFd+"=") ||
+            strings.HasPrefix(v, envParentPid+"=") {
+            continue
+        }
+
+        env = append(env, v)
+    }
+
+    env = append(env,
+        envListenFd + "=" + strconv.Itoa(inheritedFd),
+        envParentPid + "=" + strconv.Itoa(os.Getpid()),
+    )
+
+    cmd := exec.Command(exe, os.Args[1:]...)
+    cmd.Env = env
+    cmd.Stdin = os.Stdin
+    cmd.Stdout = os.Stdout
+    cmd.Stderr = os.Stderr
+    cmd.ExtraFiles = []*os.File{file}
+
+    if err := cmd.Start(); err != nil {
+        return nil, err
+    }
+
+    return cmd, nil
+}
+
+// 通知旧进程退出
+func (this *App) notifyParent() {
+    pid, _ := strconv.Atoi(os.Getenv(envParentPid))
+    if pid <= 0 {
+        return
+    }
+
+    os.Unsetenv(envParentPid)
+
+    if p, err := os.FindProcess(pid); err == nil {
+        p.Signal(syscall.SIGTERM)
+    }
+}
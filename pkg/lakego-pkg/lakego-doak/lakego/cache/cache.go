@@ -3,6 +3,7 @@ package cache
 import (
     "fmt"
     "time"
+    "context"
 
     "github.com/deatil/go-goch/goch"
 
@@ -43,6 +44,9 @@ type Cache struct {
 
     // 驱动
     driver interfaces.Driver
+
+    // 上下文
+    ctx context.Context
 }
 
 // 设置驱动
@@ -81,18 +85,53 @@ func (this *Cache) GetPrefix() string {
     return this.prefix
 }
 
+// 设置上下文，返回新的缓存实例，用于传递请求链路
+func (this *Cache) WithContext(ctx context.Context) *Cache {
+    c := *this
+    c.ctx = ctx
+
+    if driver, ok := this.driver.(interfaces.ContextDriver); ok {
+        c.driver = driver.WithContext(ctx)
+    }
+
+    return &c
+}
+
+// 获取上下文
+func (this *Cache) GetContext() context.Context {
+    if this.ctx == nil {
+        return context.Background()
+    }
+
+    return this.ctx
+}
+
 // 获取
 func (this *Cache) Has(key string) bool {
     key = this.wrapperKey(key)
 
-    return this.driver.Exists(key)
+    span := this.startSpan("has", key)
+
+    exists := this.driver.Exists(key)
+    observe("has", exists)
+
+    span.SetAttribute("cache.hit", exists).End()
+
+    return exists
 }
 
 // 获取
 func (this *Cache) Get(key string) (any, error) {
     key = this.wrapperKey(key)
 
-    return this.driver.Get(key)
+    span := this.startSpan("get", key)
+
+    val, err := this.driver.Get(key)
+    observe("get", err == nil)
+
+    span.SetAttribute("cache.hit", err == nil).End()
+
+    return val, err
 }
 
 // 设置
@@ -101,14 +140,26 @@ func (this *Cache) Put(key string, value any, ttl any) error {
 
     expiration := this.formatTime(ttl)
 
-    return this.driver.Put(key, value, expiration)
+    span := this.startSpan("put", key)
+
+    err := this.driver.Put(key, value, expiration)
+
+    span.RecordError(err).End()
+
+    return err
 }
 
 // 永久设置
 func (this *Cache) Forever(key string, value any) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Forever(key, value)
+    span := this.startSpan("forever", key)
+
+    err := this.driver.Forever(key, value)
+
+    span.RecordError(err).End()
+
+    return err
 }
 
 // 获取后删除
@@ -118,7 +169,13 @@ func (this *Cache) Pull(key string) (any, error) {
 
     key = this.wrapperKey(key)
 
+    span := this.startSpan("pull", key)
+
     val, err = this.driver.Get(key)
+    observe("pull", err == nil)
+
+    span.SetAttribute("cache.hit", err == nil).End()
+
     if err != nil {
         return val, err
     }
@@ -132,26 +189,50 @@ func (this *Cache) Pull(key string) (any, error) {
 func (this *Cache) Increment(key string, value ...int64) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Increment(key, value...)
+    span := this.startSpan("increment", key)
+
+    err := this.driver.Increment(key, value...)
+
+    span.RecordError(err).End()
+
+    return err
 }
 
 // 减去一
 func (this *Cache) Decrement(key string, value ...int64) error {
     key = this.wrapperKey(key)
 
-    return this.driver.Decrement(key, value...)
+    span := this.startSpan("decrement", key)
+
+    err := this.driver.Decrement(key, value...)
+
+    span.RecordError(err).End()
+
+    return err
 }
 
 // 删除
 func (this *Cache) Forget(key string) (bool, error) {
     key = this.wrapperKey(key)
 
-    return this.driver.Forget(key)
+    span := this.startSpan("forget", key)
+
+    ok, err := this.driver.Forget(key)
+
+    span.RecordError(err).End()
+
+    return ok, err
 }
 
 // 清空
 func (this *Cache) Flush() (bool, error) {
-    return this.driver.Flush()
+    span := this.startSpan("flush", "")
+
+    ok, err := this.driver.Flush()
+
+    span.RecordError(err).End()
+
+    return ok, err
 }
 
 // 包装字段
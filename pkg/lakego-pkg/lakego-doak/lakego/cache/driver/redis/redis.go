@@ -7,6 +7,9 @@ import (
 
     "github.com/go-redis/redis/v8"
     "github.com/go-redis/redis/extra/redisotel/v8"
+
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+    lakegoRedis "github.com/deatil/lakego-doak/lakego/redis"
 )
 
 // 日志接口
@@ -75,12 +78,23 @@ func New(config Config) *Redis {
         client.AddHook(redisotel.NewTracingHook())
     }
 
+    // 链路追踪
+    client.AddHook(lakegoRedis.NewTraceHook())
+
     return &Redis{
         ctx:    context.Background(),
         client: client,
     }
 }
 
+// 设置上下文，用于传递请求链路
+func (this *Redis) WithContext(ctx context.Context) interfaces.Driver {
+    r := *this
+    r.ctx = ctx
+
+    return &r
+}
+
 // 判断是否存在
 func (this *Redis) Exists(key string) bool {
     n, err := this.client.Exists(this.ctx, key).Result()
@@ -2,6 +2,7 @@ package interfaces
 
 import (
     "time"
+    "context"
 )
 
 /**
@@ -36,3 +37,14 @@ type Driver interface {
     Flush() (bool, error)
 }
 
+
+/**
+ * 上下文驱动接口，用于传递请求链路
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type ContextDriver interface {
+    // 设置上下文
+    WithContext(context.Context) Driver
+}
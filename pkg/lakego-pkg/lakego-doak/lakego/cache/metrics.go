@@ -0,0 +1,25 @@
+package cache
+
+import (
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+// 缓存命中
+var requestsTotal = metrics.NewCounter(
+    "lakego_cache_requests_total",
+    "Total number of cache lookups by operation and result.",
+    "operation", "result",
+)
+
+func init() {
+    metrics.Register("cache_requests_total", requestsTotal)
+}
+
+// 记录命中情况
+func observe(operation string, hit bool) {
+    if hit {
+        requestsTotal.Inc(operation, "hit")
+    } else {
+        requestsTotal.Inc(operation, "miss")
+    }
+}
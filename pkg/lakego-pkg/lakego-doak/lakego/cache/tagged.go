@@ -0,0 +1,114 @@
+package cache
+
+import (
+    "sort"
+    "strings"
+    "crypto/sha1"
+    "encoding/hex"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 标签版本键名前缀
+const tagKeyPrefix = "tag:"
+
+/**
+ * 标签缓存，键名带上标签版本，清除标签时增加版本使旧数据失效
+ *
+ * cache.Default.Tags("auth-rule").Put("rules", data, 600)
+ * cache.Default.Tags("auth-rule").Flush()
+ *
+ * @create 2024-5-31
+ * @author deatil
+ */
+type TaggedCache struct {
+    // 缓存
+    cache *Cache
+
+    // 标签
+    names []string
+}
+
+// 使用标签
+func (this *Cache) Tags(names ...string) *TaggedCache {
+    list := make([]string, 0, len(names))
+    for _, name := range names {
+        if name = strings.TrimSpace(name); name != "" {
+            list = append(list, name)
+        }
+    }
+
+    sort.Strings(list)
+
+    return &TaggedCache{
+        cache: this,
+        names: list,
+    }
+}
+
+// 标签列表
+func (this *TaggedCache) GetNames() []string {
+    return this.names
+}
+
+// 判断是否存在
+func (this *TaggedCache) Has(key string) bool {
+    return this.cache.Has(this.TaggedKey(key))
+}
+
+// 获取
+func (this *TaggedCache) Get(key string) (any, error) {
+    return this.cache.Get(this.TaggedKey(key))
+}
+
+// 设置
+func (this *TaggedCache) Put(key string, value any, ttl any) error {
+    return this.cache.Put(this.TaggedKey(key), value, ttl)
+}
+
+// 永久设置
+func (this *TaggedCache) Forever(key string, value any) error {
+    return this.cache.Forever(this.TaggedKey(key), value)
+}
+
+// 删除
+func (this *TaggedCache) Forget(key string) (bool, error) {
+    return this.cache.Forget(this.TaggedKey(key))
+}
+
+// 清除标签下的全部缓存，旧数据在过期后删除
+func (this *TaggedCache) Flush() error {
+    for _, name := range this.names {
+        if err := this.cache.Increment(tagKeyPrefix + name); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 带标签版本的键名
+func (this *TaggedCache) TaggedKey(key string) string {
+    if len(this.names) == 0 {
+        return key
+    }
+
+    versions := make([]string, 0, len(this.names))
+    for _, name := range this.names {
+        versions = append(versions, name + "=" + this.version(name))
+    }
+
+    sum := sha1.Sum([]byte(strings.Join(versions, "|")))
+
+    return "tagged:" + hex.EncodeToString(sum[:8]) + ":" + key
+}
+
+// 标签版本，不存在时为 0
+func (this *TaggedCache) version(name string) string {
+    val, err := this.cache.Get(tagKeyPrefix + name)
+    if err != nil || val == nil {
+        return "0"
+    }
+
+    return goch.ToString(val)
+}
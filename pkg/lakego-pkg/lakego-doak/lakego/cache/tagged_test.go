@@ -0,0 +1,118 @@
+package cache
+
+import (
+    "time"
+    "errors"
+    "testing"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 测试用内存驱动
+type testDriver struct {
+    data map[string]any
+}
+
+func newTestDriver() *testDriver {
+    return &testDriver{
+        data: make(map[string]any),
+    }
+}
+
+func (this *testDriver) Exists(key string) bool {
+    _, ok := this.data[key]
+    return ok
+}
+
+func (this *testDriver) Get(key string) (any, error) {
+    val, ok := this.data[key]
+    if !ok {
+        return nil, errors.New("not found")
+    }
+
+    return val, nil
+}
+
+func (this *testDriver) Put(key string, value any, ttl time.Duration) error {
+    this.data[key] = value
+    return nil
+}
+
+func (this *testDriver) Forever(key string, value any) error {
+    this.data[key] = value
+    return nil
+}
+
+func (this *testDriver) Increment(key string, value ...int64) error {
+    step := int64(1)
+    if len(value) > 0 {
+        step = value[0]
+    }
+
+    this.data[key] = goch.ToInt64(this.data[key]) + step
+    return nil
+}
+
+func (this *testDriver) Decrement(key string, value ...int64) error {
+    step := int64(1)
+    if len(value) > 0 {
+        step = value[0]
+    }
+
+    return this.Increment(key, -step)
+}
+
+func (this *testDriver) Forget(key string) (bool, error) {
+    delete(this.data, key)
+    return true, nil
+}
+
+func (this *testDriver) Flush() (bool, error) {
+    this.data = make(map[string]any)
+    return true, nil
+}
+
+func Test_Tags(t *testing.T) {
+    c := New(newTestDriver())
+
+    rules := c.Tags("auth-rule")
+    both := c.Tags("auth-rule", "auth-group")
+
+    rules.Put("tree", "tree-data", 60)
+    both.Put("profile", "profile-data", 60)
+
+    if val, err := rules.Get("tree"); err != nil || val != "tree-data" {
+        t.Fatalf("Get got %v, %v", val, err)
+    }
+
+    // 标签顺序不影响键名
+    if c.Tags("auth-group", "auth-rule").TaggedKey("profile") != both.TaggedKey("profile") {
+        t.Error("tag order should not change key")
+    }
+
+    // 没有标签的缓存不受影响
+    if c.Tags().TaggedKey("k") != "k" {
+        t.Error("no tags should use raw key")
+    }
+
+    if err := c.Tags("auth-group").Flush(); err != nil {
+        t.Fatal(err)
+    }
+
+    if !rules.Has("tree") {
+        t.Error("auth-rule data should be kept")
+    }
+    if both.Has("profile") {
+        t.Error("auth-group data should be flushed")
+    }
+
+    both.Put("profile", "profile-data-2", 60)
+    if val, _ := both.Get("profile"); val != "profile-data-2" {
+        t.Errorf("Get got %v", val)
+    }
+
+    rules.Flush()
+    if _, err := rules.Get("tree"); err == nil {
+        t.Error("auth-rule data should be flushed")
+    }
+}
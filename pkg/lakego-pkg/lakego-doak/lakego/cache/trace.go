@@ -0,0 +1,24 @@
+package cache
+
+import (
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 开始缓存节点，上下文中没有链路时返回空
+func (this *Cache) startSpan(operation string, key string) *trace.Span {
+    if this.ctx == nil || !trace.Default().Enabled() {
+        return nil
+    }
+
+    if !trace.SpanContextFromContext(this.ctx).IsValid() {
+        return nil
+    }
+
+    _, span := trace.Start(this.ctx, "cache." + operation, trace.KindInternal)
+    span.SetAttributes(map[string]any{
+        "cache.operation": operation,
+        "cache.key":       key,
+    })
+
+    return span
+}
@@ -0,0 +1,144 @@
+package captcha
+
+import (
+    "sync"
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 验证码模式
+const (
+    // 总是需要验证码
+    ModeAlways = "always"
+
+    // 失败次数达到限制后需要验证码
+    ModeAdaptive = "adaptive"
+
+    // 不需要验证码
+    ModeOff = "off"
+)
+
+// 失败次数存储
+type ICounter interface {
+    Get(key string) (any, error)
+    Put(key string, value any, ttl any) error
+    Forget(key string) (bool, error)
+}
+
+// 自适应配置
+type AdaptiveConfig struct {
+    // 模式
+    Mode string
+
+    // 失败次数限制
+    MaxFailures int
+
+    // 失败次数保存时间，比如 15m
+    TTL any
+
+    // 缓存前缀
+    Prefix string
+}
+
+// 构造函数
+func NewAdaptive(counter ICounter, conf AdaptiveConfig) *Adaptive {
+    if conf.Mode == "" {
+        conf.Mode = ModeAlways
+    }
+    if conf.MaxFailures <= 0 {
+        conf.MaxFailures = 3
+    }
+    if conf.TTL == nil {
+        conf.TTL = "15m"
+    }
+    if conf.Prefix == "" {
+        conf.Prefix = "captcha-failures:"
+    }
+
+    return &Adaptive{
+        counter: counter,
+        conf:    conf,
+    }
+}
+
+/**
+ * 自适应验证码，按 ip 及账号记录失败次数，
+ * 任一失败次数达到限制后需要验证码
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type Adaptive struct {
+    mu sync.Mutex
+
+    counter ICounter
+    conf    AdaptiveConfig
+}
+
+// 模式
+func (this *Adaptive) Mode() string {
+    return this.conf.Mode
+}
+
+// 是否需要验证码
+func (this *Adaptive) Required(keys ...string) bool {
+    switch this.conf.Mode {
+        case ModeOff:
+            return false
+        case ModeAdaptive:
+            for _, key := range keys {
+                if this.Failures(key) >= this.conf.MaxFailures {
+                    return true
+                }
+            }
+
+            return false
+    }
+
+    return true
+}
+
+// 失败次数
+func (this *Adaptive) Failures(key string) int {
+    val, err := this.counter.Get(this.conf.Prefix + key)
+    if err != nil || val == nil {
+        return 0
+    }
+
+    return goch.ToInt(val)
+}
+
+// 记录失败
+func (this *Adaptive) Fail(keys ...string) {
+    if this.conf.Mode != ModeAdaptive {
+        return
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    for _, key := range keys {
+        this.counter.Put(this.conf.Prefix + key, this.Failures(key) + 1, this.conf.TTL)
+    }
+}
+
+// 清除失败记录
+func (this *Adaptive) Reset(keys ...string) {
+    for _, key := range keys {
+        this.counter.Forget(this.conf.Prefix + key)
+    }
+}
+
+// 登录失败记录键名
+func AdaptiveKeys(ip string, account string) []string {
+    keys := make([]string, 0, 2)
+    if ip != "" {
+        keys = append(keys, "ip:" + ip)
+    }
+    if account != "" {
+        keys = append(keys, "account:" + strings.ToLower(account))
+    }
+
+    return keys
+}
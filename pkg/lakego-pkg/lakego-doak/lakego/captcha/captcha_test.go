@@ -0,0 +1,158 @@
+package captcha
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "testing"
+    "context"
+    "net/http"
+    "encoding/json"
+    "net/http/httptest"
+
+    "github.com/mojocn/base64Captcha"
+)
+
+func Test_Slider(t *testing.T) {
+    store := base64Captcha.NewMemoryStore(100, 0)
+
+    m := NewManager(store).
+        Register("slider", NewSliderChallenge(SliderConfig{Tolerance: 4}))
+
+    c, err := m.Generate("slider")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if c.Type != "slider" || c.Data["background"] == nil || c.Data["piece"] == nil {
+        t.Fatalf("Generate got %+v", c)
+    }
+
+    _, answer, _ := strings.Cut(store.Get(c.Id, false), "|")
+    x, _ := strconv.Atoi(answer)
+
+    ok, err := m.Verify(context.Background(), c.Id, strconv.Itoa(x + 3), "")
+    if !ok || err != nil {
+        t.Errorf("Verify got %v, %v", ok, err)
+    }
+
+    // 只能验证一次
+    ok, err = m.Verify(context.Background(), c.Id, strconv.Itoa(x), "")
+    if ok || err != ErrExpired {
+        t.Errorf("Verify again got %v, %v", ok, err)
+    }
+
+    s := NewSliderChallenge(SliderConfig{Tolerance: 4})
+    if s.Check("100", "105") || !s.Check("100", "96.5") || s.Check("100", "abc") {
+        t.Error("Slider Check tolerance error")
+    }
+}
+
+func Test_Click(t *testing.T) {
+    c := NewClickChallenge(ClickConfig{Count: 4, Click: 2})
+
+    data, answer, err := c.Generate()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if len(data["prompt"].([]string)) != 2 {
+        t.Fatalf("prompt got %v", data["prompt"])
+    }
+
+    var points []Point
+    json.Unmarshal([]byte(answer), &points)
+
+    input, _ := json.Marshal([]Point{
+        {X: points[0].X + 3, Y: points[0].Y - 3},
+        {X: points[1].X, Y: points[1].Y},
+    })
+    if !c.Check(answer, string(input)) {
+        t.Errorf("Check got false, answer %s, input %s", answer, input)
+    }
+
+    // 顺序错误
+    input, _ = json.Marshal([]Point{points[1], points[0]})
+    if points[0] != points[1] && c.Check(answer, string(input)) {
+        t.Error("Check order should be false")
+    }
+}
+
+func Test_Provider(t *testing.T) {
+    m := NewManager(base64Captcha.NewMemoryStore(100, 0)).
+        WithProvider(NewMockProvider("pass"))
+
+    c, _ := m.Generate("")
+    if c.Type != RemoteType {
+        t.Errorf("Generate got %+v", c)
+    }
+
+    if ok, _ := m.Verify(context.Background(), "", "pass", ""); !ok {
+        t.Error("Mock should pass")
+    }
+    if ok, _ := m.Verify(context.Background(), "", "fail", ""); ok {
+        t.Error("Mock should fail")
+    }
+
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        r.ParseForm()
+        fmt.Fprintf(w, `{"success": %v}`, r.Form.Get("secret") == "s" && r.Form.Get("response") == "token")
+    }))
+    defer srv.Close()
+
+    p := NewHTTPProvider(srv.URL, "site", "s", 0)
+    if ok, err := p.Verify(context.Background(), "token", "127.0.0.1"); !ok || err != nil {
+        t.Errorf("HTTPProvider got %v, %v", ok, err)
+    }
+    if ok, _ := p.Verify(context.Background(), "bad", ""); ok {
+        t.Error("HTTPProvider should fail")
+    }
+}
+
+type mapCounter map[string]any
+
+func (this mapCounter) Get(key string) (any, error) {
+    return this[key], nil
+}
+
+func (this mapCounter) Put(key string, value any, ttl any) error {
+    this[key] = value
+    return nil
+}
+
+func (this mapCounter) Forget(key string) (bool, error) {
+    delete(this, key)
+    return true, nil
+}
+
+func Test_Adaptive(t *testing.T) {
+    a := NewAdaptive(mapCounter{}, AdaptiveConfig{
+        Mode:        ModeAdaptive,
+        MaxFailures: 2,
+    })
+
+    keys := AdaptiveKeys("127.0.0.1", "Admin")
+    if a.Required(keys...) {
+        t.Error("Required should be false")
+    }
+
+    a.Fail(keys...)
+    a.Fail(AdaptiveKeys("127.0.0.1", "other")...)
+
+    // ip 失败次数达到限制
+    if !a.Required(AdaptiveKeys("127.0.0.1", "")...) {
+        t.Error("Required by ip should be true")
+    }
+    if a.Required(AdaptiveKeys("", "admin")...) {
+        t.Error("Required by account should be false")
+    }
+
+    a.Reset(keys...)
+    if a.Required(keys...) {
+        t.Error("Required after Reset should be false")
+    }
+
+    if !NewAdaptive(mapCounter{}, AdaptiveConfig{}).Required() {
+        t.Error("always mode should be required")
+    }
+}
@@ -0,0 +1,81 @@
+package captcha
+
+import (
+    "strings"
+
+    "github.com/mojocn/base64Captcha"
+)
+
+/**
+ * 验证方式接口
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type IChallenge interface {
+    // 生成题目数据及答案
+    Generate() (data map[string]any, answer string, err error)
+
+    // 检测答案
+    Check(answer string, input string) bool
+}
+
+// 验证题目
+type Challenge struct {
+    // 题目 id
+    Id string `json:"id"`
+
+    // 验证方式
+    Type string `json:"type"`
+
+    // 题目数据
+    Data map[string]any `json:"data"`
+}
+
+// 使用 base64Captcha 驱动的验证方式
+// field 为数据字段名称，图片使用 image，音频使用 audio
+func NewDriverChallenge(driver IDriver, field string) *DriverChallenge {
+    if field == "" {
+        field = "image"
+    }
+
+    return &DriverChallenge{
+        driver: driver,
+        field:  field,
+    }
+}
+
+/**
+ * base64Captcha 驱动验证，包括字符、算术及音频等
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type DriverChallenge struct {
+    driver IDriver
+    field  string
+}
+
+// 生成
+func (this *DriverChallenge) Generate() (map[string]any, string, error) {
+    _, q, a := this.driver.GenerateIdQuestionAnswer()
+
+    item, err := this.driver.DrawCaptcha(q)
+    if err != nil {
+        return nil, "", err
+    }
+
+    return map[string]any{
+        this.field: item.EncodeB64string(),
+    }, a, nil
+}
+
+// 检测，不区分大小写
+func (this *DriverChallenge) Check(answer string, input string) bool {
+    return answer != "" && strings.EqualFold(strings.TrimSpace(input), answer)
+}
+
+// 随机 id
+func randomId() string {
+    return base64Captcha.RandomId()
+}
@@ -0,0 +1,236 @@
+package captcha
+
+import (
+    "math"
+    "strings"
+    "math/rand"
+    "image"
+    "encoding/json"
+
+    "github.com/golang/freetype"
+    "github.com/mojocn/base64Captcha"
+)
+
+// 点击位置
+type Point struct {
+    X int `json:"x"`
+    Y int `json:"y"`
+}
+
+// 点选验证配置
+type ClickConfig struct {
+    // 图片宽高
+    Width  int
+    Height int
+
+    // 候选文字
+    Source string
+
+    // 图片中文字数量
+    Count int
+
+    // 需要点击的文字数量
+    Click int
+
+    // 字体大小
+    FontSize int
+
+    // 允许误差，单位：像素
+    Tolerance int
+
+    // 字体，使用 base64Captcha 内置字体
+    Font string
+}
+
+// 点选验证
+func NewClickChallenge(conf ClickConfig) *ClickChallenge {
+    if conf.Width <= 0 {
+        conf.Width = 300
+    }
+    if conf.Height <= 0 {
+        conf.Height = 160
+    }
+    if conf.Source == "" {
+        conf.Source = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+    }
+    if conf.Count <= 0 {
+        conf.Count = 5
+    }
+    if conf.Click <= 0 || conf.Click > conf.Count {
+        conf.Click = 3
+        if conf.Click > conf.Count {
+            conf.Click = conf.Count
+        }
+    }
+    if conf.FontSize <= 0 {
+        conf.FontSize = 28
+    }
+    if conf.Tolerance <= 0 {
+        conf.Tolerance = conf.FontSize * 2 / 3
+    }
+    if conf.Font == "" {
+        conf.Font = "wqy-microhei.ttc"
+    }
+
+    return &ClickChallenge{
+        conf: conf,
+    }
+}
+
+/**
+ * 点选文字验证
+ *
+ * 图片中随机分布文字，按提示顺序点击指定文字，
+ * 答案为文字中心位置列表，服务端按误差范围检测
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type ClickChallenge struct {
+    conf ClickConfig
+}
+
+// 生成
+func (this *ClickChallenge) Generate() (map[string]any, string, error) {
+    conf := this.conf
+
+    chars := pickChars(conf.Source, conf.Count)
+    if len(chars) < conf.Count {
+        return nil, "", errSourceTooShort
+    }
+
+    cells := gridCells(conf.Width, conf.Height, conf.FontSize, conf.Count)
+    if len(cells) < conf.Count {
+        return nil, "", errTooSmall
+    }
+
+    font := base64Captcha.DefaultEmbeddedFonts.LoadFontByName("fonts/" + conf.Font)
+
+    img := randomBackground(conf.Width, conf.Height)
+
+    c := freetype.NewContext()
+    c.SetDPI(72)
+    c.SetClip(img.Bounds())
+    c.SetDst(img)
+    c.SetFont(font)
+    c.SetFontSize(float64(conf.FontSize))
+
+    points := make([]Point, 0, len(chars))
+    for i, ch := range chars {
+        cell := cells[i]
+
+        c.SetSrc(image.NewUniform(base64Captcha.RandDeepColor()))
+
+        // 基线位置，文字中心为 cell
+        pt := freetype.Pt(cell.X - conf.FontSize / 2, cell.Y + conf.FontSize / 3)
+        if _, err := c.DrawString(ch, pt); err != nil {
+            return nil, "", err
+        }
+
+        points = append(points, cell)
+    }
+
+    // 需要点击的文字，按随机顺序
+    order := rand.Perm(len(chars))[:conf.Click]
+
+    prompt := make([]string, 0, len(order))
+    answer := make([]Point, 0, len(order))
+    for _, i := range order {
+        prompt = append(prompt, chars[i])
+        answer = append(answer, points[i])
+    }
+
+    imgData, err := encodePNG(img)
+    if err != nil {
+        return nil, "", err
+    }
+
+    answerData, err := json.Marshal(answer)
+    if err != nil {
+        return nil, "", err
+    }
+
+    return map[string]any{
+        "image":  imgData,
+        "prompt": prompt,
+        "width":  conf.Width,
+        "height": conf.Height,
+    }, string(answerData), nil
+}
+
+// 检测，输入为点击位置 json，比如 [{"x":10,"y":20}]
+func (this *ClickChallenge) Check(answer string, input string) bool {
+    var want, got []Point
+    if err := json.Unmarshal([]byte(answer), &want); err != nil {
+        return false
+    }
+    if err := json.Unmarshal([]byte(input), &got); err != nil {
+        return false
+    }
+
+    if len(want) == 0 || len(want) != len(got) {
+        return false
+    }
+
+    for i := range want {
+        dx := float64(want[i].X - got[i].X)
+        dy := float64(want[i].Y - got[i].Y)
+        if math.Hypot(dx, dy) > float64(this.conf.Tolerance) {
+            return false
+        }
+    }
+
+    return true
+}
+
+// 随机选取不重复的文字
+func pickChars(source string, n int) []string {
+    seen := make(map[string]bool)
+
+    list := make([]string, 0)
+    for _, r := range strings.Split(source, "") {
+        if r != "" && r != " " && r != "," && !seen[r] {
+            seen[r] = true
+            list = append(list, r)
+        }
+    }
+
+    rand.Shuffle(len(list), func(i, j int) {
+        list[i], list[j] = list[j], list[i]
+    })
+
+    if len(list) > n {
+        list = list[:n]
+    }
+
+    return list
+}
+
+// 文字位置，按网格分布避免重叠，返回随机网格中心
+func gridCells(w int, h int, size int, n int) []Point {
+    cell := size * 3 / 2
+
+    cols, rows := w / cell, h / cell
+    if cols * rows < n {
+        return nil
+    }
+
+    cells := make([]Point, 0, n)
+    for _, i := range rand.Perm(cols * rows)[:n] {
+        col, row := i % cols, i / cols
+
+        // 网格内随机偏移
+        offset := (cell - size) / 2
+        dx, dy := 0, 0
+        if offset > 0 {
+            dx, dy = rand.Intn(offset * 2 + 1) - offset, rand.Intn(offset * 2 + 1) - offset
+        }
+
+        cells = append(cells, Point{
+            X: col * cell + cell / 2 + dx,
+            Y: row * cell + cell / 2 + dy,
+        })
+    }
+
+    return cells
+}
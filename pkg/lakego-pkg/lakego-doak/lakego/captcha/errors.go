@@ -0,0 +1,20 @@
+package captcha
+
+import (
+    "errors"
+)
+
+var (
+    // 验证方式不存在
+    ErrChallengeNotFound = errors.New("验证方式不存在")
+
+    // 验证码已过期
+    ErrExpired = errors.New("验证码已过期")
+
+    // 远程验证失败
+    ErrProvider = errors.New("远程验证失败")
+
+    errTooSmall = errors.New("验证码图片尺寸过小")
+
+    errSourceTooShort = errors.New("验证码候选文字数量不足")
+)
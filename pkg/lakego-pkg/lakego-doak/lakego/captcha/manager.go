@@ -0,0 +1,163 @@
+package captcha
+
+import (
+    "sort"
+    "sync"
+    "context"
+    "strings"
+)
+
+// 远程验证方式名称
+const RemoteType = "remote"
+
+// 构造函数
+func NewManager(store IStore) *Manager {
+    return &Manager{
+        store:      store,
+        challenges: make(map[string]IChallenge),
+    }
+}
+
+/**
+ * 验证方式管理
+ *
+ * 生成题目时答案保存到存储，验证时按题目类型检测，
+ * 设置远程验证后使用远程验证，前端提交远程验证返回的 token
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type Manager struct {
+    mu sync.RWMutex
+
+    // 存储
+    store IStore
+
+    // 验证方式
+    challenges map[string]IChallenge
+
+    // 默认验证方式
+    defaultType string
+
+    // 远程验证
+    provider IProvider
+}
+
+// 注册验证方式
+func (this *Manager) Register(name string, challenge IChallenge) *Manager {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.challenges[name] = challenge
+    if this.defaultType == "" {
+        this.defaultType = name
+    }
+
+    return this
+}
+
+// 设置默认验证方式
+func (this *Manager) WithDefault(name string) *Manager {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.defaultType = name
+
+    return this
+}
+
+// 设置远程验证，为 nil 时使用本地验证
+func (this *Manager) WithProvider(provider IProvider) *Manager {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.provider = provider
+
+    return this
+}
+
+// 获取远程验证
+func (this *Manager) GetProvider() IProvider {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.provider
+}
+
+// 已注册的验证方式
+func (this *Manager) Types() []string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    types := make([]string, 0, len(this.challenges))
+    for name := range this.challenges {
+        types = append(types, name)
+    }
+
+    sort.Strings(types)
+
+    return types
+}
+
+// 生成题目，typ 为空时使用默认验证方式
+func (this *Manager) Generate(typ string) (*Challenge, error) {
+    this.mu.RLock()
+    provider := this.provider
+    if typ == "" {
+        typ = this.defaultType
+    }
+    challenge, ok := this.challenges[typ]
+    this.mu.RUnlock()
+
+    if provider != nil {
+        return &Challenge{
+            Type: RemoteType,
+            Data: provider.Data(),
+        }, nil
+    }
+
+    if !ok {
+        return nil, ErrChallengeNotFound
+    }
+
+    data, answer, err := challenge.Generate()
+    if err != nil {
+        return nil, err
+    }
+
+    id := randomId()
+    if err := this.store.Set(id, typ + "|" + answer); err != nil {
+        return nil, err
+    }
+
+    return &Challenge{
+        Id:   id,
+        Type: typ,
+        Data: data,
+    }, nil
+}
+
+// 验证，题目只能验证一次
+// 使用远程验证时 input 为远程验证 token，id 不使用
+func (this *Manager) Verify(ctx context.Context, id string, input string, remoteIP string) (bool, error) {
+    if provider := this.GetProvider(); provider != nil {
+        return provider.Verify(ctx, input, remoteIP)
+    }
+
+    value := this.store.Get(id, true)
+    if id == "" || value == "" {
+        return false, ErrExpired
+    }
+
+    typ, answer, _ := strings.Cut(value, "|")
+
+    this.mu.RLock()
+    challenge, ok := this.challenges[typ]
+    this.mu.RUnlock()
+
+    if !ok {
+        return false, ErrChallengeNotFound
+    }
+
+    return challenge.Check(answer, input), nil
+}
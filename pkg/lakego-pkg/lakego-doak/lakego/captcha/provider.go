@@ -0,0 +1,131 @@
+package captcha
+
+import (
+    "time"
+    "context"
+    "strings"
+    "net/url"
+    "net/http"
+    "encoding/json"
+)
+
+/**
+ * 远程验证接口
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type IProvider interface {
+    // 前端需要的数据，比如 site_key
+    Data() map[string]any
+
+    // 验证前端提交的 token
+    Verify(ctx context.Context, token string, remoteIP string) (bool, error)
+}
+
+// siteverify 远程验证
+func NewHTTPProvider(verifyURL string, siteKey string, secret string, timeout time.Duration) *HTTPProvider {
+    if timeout <= 0 {
+        timeout = 5 * time.Second
+    }
+
+    return &HTTPProvider{
+        URL:     verifyURL,
+        SiteKey: siteKey,
+        Secret:  secret,
+        Client:  &http.Client{
+            Timeout: timeout,
+        },
+    }
+}
+
+/**
+ * siteverify 远程验证，兼容 reCAPTCHA、hCaptcha 及 Turnstile
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type HTTPProvider struct {
+    URL     string
+    SiteKey string
+    Secret  string
+    Client  *http.Client
+}
+
+// 前端数据
+func (this *HTTPProvider) Data() map[string]any {
+    return map[string]any{
+        "site_key": this.SiteKey,
+    }
+}
+
+// 验证
+func (this *HTTPProvider) Verify(ctx context.Context, token string, remoteIP string) (bool, error) {
+    if token == "" {
+        return false, nil
+    }
+
+    form := url.Values{}
+    form.Set("secret", this.Secret)
+    form.Set("response", token)
+    if remoteIP != "" {
+        form.Set("remoteip", remoteIP)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, "POST", this.URL, strings.NewReader(form.Encode()))
+    if err != nil {
+        return false, err
+    }
+
+    req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+    resp, err := this.Client.Do(req)
+    if err != nil {
+        return false, err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return false, ErrProvider
+    }
+
+    var result struct {
+        Success bool `json:"success"`
+    }
+    if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+        return false, err
+    }
+
+    return result.Success, nil
+}
+
+// 本地模拟远程验证
+func NewMockProvider(passToken string) *MockProvider {
+    return &MockProvider{
+        PassToken: passToken,
+    }
+}
+
+/**
+ * 本地模拟远程验证，用于开发及测试
+ * token 和 PassToken 一致时验证通过
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type MockProvider struct {
+    PassToken string
+}
+
+// 前端数据
+func (this *MockProvider) Data() map[string]any {
+    return map[string]any{
+        "site_key": "mock",
+        "token":    this.PassToken,
+    }
+}
+
+// 验证
+func (this *MockProvider) Verify(ctx context.Context, token string, remoteIP string) (bool, error) {
+    return token != "" && token == this.PassToken, nil
+}
@@ -0,0 +1,207 @@
+package captcha
+
+import (
+    "math"
+    "bytes"
+    "strconv"
+    "math/rand"
+    "image"
+    "image/png"
+    "image/draw"
+    "image/color"
+    "encoding/base64"
+)
+
+// 滑块验证配置
+type SliderConfig struct {
+    // 背景宽高
+    Width  int
+    Height int
+
+    // 滑块边长
+    PieceSize int
+
+    // 允许误差，单位：像素
+    Tolerance int
+}
+
+// 滑块验证
+func NewSliderChallenge(conf SliderConfig) *SliderChallenge {
+    if conf.Width <= 0 {
+        conf.Width = 300
+    }
+    if conf.Height <= 0 {
+        conf.Height = 160
+    }
+    if conf.PieceSize <= 0 {
+        conf.PieceSize = 50
+    }
+    if conf.Tolerance <= 0 {
+        conf.Tolerance = 5
+    }
+
+    return &SliderChallenge{
+        conf: conf,
+    }
+}
+
+/**
+ * 滑块拼图验证
+ *
+ * 背景图中挖出一块，前端拖动滑块到缺口位置，
+ * 答案为缺口横坐标，服务端按误差范围检测
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type SliderChallenge struct {
+    conf SliderConfig
+}
+
+// 生成
+func (this *SliderChallenge) Generate() (map[string]any, string, error) {
+    w, h, size := this.conf.Width, this.conf.Height, this.conf.PieceSize
+    if w < size * 3 || h < size + 10 {
+        return nil, "", errTooSmall
+    }
+
+    bg := randomBackground(w, h)
+
+    // 缺口位置，不放在最左侧滑块起始处
+    x := size + 10 + rand.Intn(w - size * 2 - 20)
+    y := 5 + rand.Intn(h - size - 10)
+
+    rect := image.Rect(x, y, x + size, y + size)
+
+    // 滑块
+    piece := image.NewNRGBA(image.Rect(0, 0, size, size))
+    draw.Draw(piece, piece.Bounds(), bg, rect.Min, draw.Src)
+    drawBorder(piece, piece.Bounds(), color.NRGBA{255, 255, 255, 230})
+
+    // 缺口变暗
+    for py := rect.Min.Y; py < rect.Max.Y; py++ {
+        for px := rect.Min.X; px < rect.Max.X; px++ {
+            c := bg.NRGBAAt(px, py)
+            bg.SetNRGBA(px, py, color.NRGBA{c.R / 3, c.G / 3, c.B / 3, 255})
+        }
+    }
+    drawBorder(bg, rect, color.NRGBA{255, 255, 255, 160})
+
+    bgData, err := encodePNG(bg)
+    if err != nil {
+        return nil, "", err
+    }
+
+    pieceData, err := encodePNG(piece)
+    if err != nil {
+        return nil, "", err
+    }
+
+    return map[string]any{
+        "background": bgData,
+        "piece":      pieceData,
+        "y":          y,
+        "width":      w,
+        "height":     h,
+        "piece_size": size,
+    }, strconv.Itoa(x), nil
+}
+
+// 检测，输入为滑块横坐标
+func (this *SliderChallenge) Check(answer string, input string) bool {
+    x, err := strconv.ParseFloat(answer, 64)
+    if err != nil {
+        return false
+    }
+
+    in, err := strconv.ParseFloat(input, 64)
+    if err != nil {
+        return false
+    }
+
+    return math.Abs(x - in) <= float64(this.conf.Tolerance)
+}
+
+// 随机背景，渐变色加随机色块
+func randomBackground(w int, h int) *image.NRGBA {
+    img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+    c1 := randColor()
+    c2 := randColor()
+    for y := 0; y < h; y++ {
+        for x := 0; x < w; x++ {
+            t := float64(x + y) / float64(w + h)
+            img.SetNRGBA(x, y, color.NRGBA{
+                R: mix(c1.R, c2.R, t),
+                G: mix(c1.G, c2.G, t),
+                B: mix(c1.B, c2.B, t),
+                A: 255,
+            })
+        }
+    }
+
+    for i := 0; i < 12; i++ {
+        c := randColor()
+        c.A = uint8(80 + rand.Intn(120))
+
+        cx, cy := rand.Intn(w), rand.Intn(h)
+        r := 8 + rand.Intn(h / 3)
+
+        for y := cy - r; y <= cy + r; y++ {
+            for x := cx - r; x <= cx + r; x++ {
+                if (x - cx) * (x - cx) + (y - cy) * (y - cy) > r * r {
+                    continue
+                }
+                if !(image.Point{x, y}).In(img.Bounds()) {
+                    continue
+                }
+
+                old := img.NRGBAAt(x, y)
+                a := float64(c.A) / 255
+                img.SetNRGBA(x, y, color.NRGBA{
+                    R: mix(old.R, c.R, a),
+                    G: mix(old.G, c.G, a),
+                    B: mix(old.B, c.B, a),
+                    A: 255,
+                })
+            }
+        }
+    }
+
+    return img
+}
+
+// 边框
+func drawBorder(img *image.NRGBA, rect image.Rectangle, c color.NRGBA) {
+    for x := rect.Min.X; x < rect.Max.X; x++ {
+        img.SetNRGBA(x, rect.Min.Y, c)
+        img.SetNRGBA(x, rect.Max.Y - 1, c)
+    }
+    for y := rect.Min.Y; y < rect.Max.Y; y++ {
+        img.SetNRGBA(rect.Min.X, y, c)
+        img.SetNRGBA(rect.Max.X - 1, y, c)
+    }
+}
+
+func randColor() color.NRGBA {
+    return color.NRGBA{
+        R: uint8(rand.Intn(256)),
+        G: uint8(rand.Intn(256)),
+        B: uint8(rand.Intn(256)),
+        A: 255,
+    }
+}
+
+func mix(a uint8, b uint8, t float64) uint8 {
+    return uint8(float64(a) * (1 - t) + float64(b) * t)
+}
+
+// 生成 png base64 数据
+func encodePNG(img image.Image) (string, error) {
+    var buf bytes.Buffer
+    if err := png.Encode(&buf, img); err != nil {
+        return "", err
+    }
+
+    return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,325 @@
+package queue
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "context"
+    "strings"
+    "syscall"
+    "os/signal"
+
+    "github.com/deatil/go-datebin/datebin"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/queue"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    facadeQueue "github.com/deatil/lakego-doak/lakego/facade/queue"
+)
+
+/**
+ * 执行队列任务
+ *
+ * > ./main lakego:queue:work
+ * > main.exe lakego:queue:work --queue=emails,default --concurrency=8
+ * > go run main.go lakego:queue:work --connection=redis --tries=5
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+var QueueWorkCmd = &command.Command{
+    Use: "lakego:queue:work",
+    Short: "执行队列任务。",
+    Example: "{execfile} lakego:queue:work [--connection=redis] [--queue=emails,default] [--concurrency=4]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+        QueueWork()
+    },
+}
+
+// 工作进程参数
+var (
+    workConnection  string
+    workQueue       string
+    workConcurrency int
+    workTries       int
+    workTimeout     time.Duration
+    workSleep       time.Duration
+    workOnce        bool
+)
+
+/**
+ * 失败任务列表
+ *
+ * > go run main.go lakego:queue:failed
+ * > go run main.go lakego:queue:failed --flush
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+var QueueFailedCmd = &command.Command{
+    Use: "lakego:queue:failed",
+    Short: "队列失败任务列表。",
+    Example: "{execfile} lakego:queue:failed [--forget=id] [--flush]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+        QueueFailed()
+    },
+}
+
+// 失败任务参数
+var (
+    failedForget string
+    failedFlush  bool
+)
+
+/**
+ * 重新执行失败任务
+ *
+ * > go run main.go lakego:queue:retry --id=xxx
+ * > go run main.go lakego:queue:retry --id=all
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+var QueueRetryCmd = &command.Command{
+    Use: "lakego:queue:retry",
+    Short: "重新推送失败任务到队列。",
+    Example: "{execfile} lakego:queue:retry --id=[id|all]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+        QueueRetry()
+    },
+}
+
+// 重试任务ID
+var retryId string
+
+func init() {
+    wf := QueueWorkCmd.Flags()
+    wf.StringVarP(&workConnection, "connection", "c", "", "队列连接，默认使用配置中的连接")
+    wf.StringVarP(&workQueue, "queue", "q", "", "监听的队列，多个使用逗号分隔，按顺序优先")
+    wf.IntVarP(&workConcurrency, "concurrency", "n", 0, "并发数量")
+    wf.IntVar(&workTries, "tries", 0, "默认最大尝试次数")
+    wf.DurationVar(&workTimeout, "timeout", 0, "默认执行超时时间")
+    wf.DurationVar(&workSleep, "sleep", 0, "没有任务时等待时间")
+    wf.BoolVar(&workOnce, "once", false, "只执行一个任务")
+
+    ff := QueueFailedCmd.Flags()
+    ff.StringVar(&failedForget, "forget", "", "删除指定失败任务")
+    ff.BoolVar(&failedFlush, "flush", false, "清空失败任务")
+
+    rf := QueueRetryCmd.Flags()
+    rf.StringVar(&retryId, "id", "", "失败任务ID，all 为全部")
+}
+
+// 执行队列任务
+func QueueWork() {
+    q := connection(workConnection)
+
+    opts := facadeQueue.WorkerOptions()
+    if workQueue != "" {
+        for _, name := range strings.Split(workQueue, ",") {
+            if name = strings.TrimSpace(name); name != "" {
+                opts.Queues = append(opts.Queues, name)
+            }
+        }
+    }
+    if workConcurrency > 0 {
+        opts.Concurrency = workConcurrency
+    }
+    if workTries > 0 {
+        opts.Tries = workTries
+    }
+    if workTimeout > 0 {
+        opts.Timeout = workTimeout
+    }
+    if workSleep > 0 {
+        opts.Sleep = workSleep
+    }
+
+    opts.OnError = func(msg *interfaces.Message, err error) {
+        if msg != nil {
+            logger.New().Errorf("[queue] %s(%s): %s", msg.Queue, msg.ID, err.Error())
+        } else {
+            logger.New().Errorf("[queue] %s", err.Error())
+        }
+    }
+
+    worker := queue.NewWorker(q, opts)
+
+    if workOnce {
+        ok, err := worker.RunOnce(context.Background())
+        if err != nil && !ok {
+            fmt.Println("获取任务失败：" + err.Error())
+        } else if err != nil {
+            fmt.Println("任务执行失败：" + err.Error())
+        } else if !ok {
+            fmt.Println("没有可执行的任务")
+        } else {
+            fmt.Println("任务执行成功")
+        }
+
+        return
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    done := make(chan struct{})
+    go func() {
+        worker.Run(ctx)
+        close(done)
+    }()
+
+    nowDate := datebin.Now().ToDatetimeString()
+
+    fmt.Print("\n")
+    color.
+        NewWithOption(
+            color.ForegroundOption("green"),
+            color.BaseOption("bold"),
+        ).
+        Print(fmt.Sprintf("[%s] 队列 [%s] 工作进程已启动，并发数量 %d ...", nowDate, q.GetConnection(), opts.Concurrency))
+    fmt.Print("\n")
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+    <-quit
+
+    fmt.Println("正在等待执行中的任务完成...")
+    cancel()
+
+    stopTimeout := config.New("queue").GetDuration("worker.stop-timeout")
+    if stopTimeout <= 0 {
+        stopTimeout = 30 * time.Second
+    }
+
+    select {
+        case <-done:
+            fmt.Println("工作进程已退出")
+        case <-time.After(stopTimeout):
+            fmt.Println("等待超时，工作进程强制退出")
+    }
+}
+
+// 失败任务列表
+func QueueFailed() {
+    failed := facadeQueue.NewFailed()
+    if failed == nil {
+        fmt.Println("没有设置失败任务存储")
+        return
+    }
+
+    if failedFlush {
+        if err := failed.Flush(); err != nil {
+            fmt.Println("清空失败任务失败：" + err.Error())
+            return
+        }
+
+        fmt.Println("清空失败任务成功")
+        return
+    }
+
+    if failedForget != "" {
+        if err := failed.Forget(failedForget); err != nil {
+            fmt.Println("删除失败任务失败：" + err.Error())
+            return
+        }
+
+        fmt.Println("删除失败任务成功")
+        return
+    }
+
+    list, err := failed.All()
+    if err != nil {
+        fmt.Println("获取失败任务失败：" + err.Error())
+        return
+    }
+
+    if len(list) == 0 {
+        fmt.Println("没有失败任务")
+        return
+    }
+
+    for _, item := range list {
+        name := ""
+        if payload, err := queue.DecodePayload(item.Payload); err == nil {
+            name = payload.Job
+        }
+
+        fmt.Printf("%s  %s  %s/%s  %s\n    %s\n",
+            item.ID,
+            datebin.FromTimestamp(item.FailedAt).ToDatetimeString(),
+            item.Connection,
+            item.Queue,
+            name,
+            item.Exception,
+        )
+    }
+
+    fmt.Printf("\n共 %d 条失败任务\n", len(list))
+}
+
+// 重新推送失败任务
+func QueueRetry() {
+    if retryId == "" {
+        fmt.Println("请设置失败任务ID")
+        return
+    }
+
+    failed := facadeQueue.NewFailed()
+    if failed == nil {
+        fmt.Println("没有设置失败任务存储")
+        return
+    }
+
+    ids := []string{retryId}
+    if retryId == "all" {
+        list, err := failed.All()
+        if err != nil {
+            fmt.Println("获取失败任务失败：" + err.Error())
+            return
+        }
+
+        ids = ids[:0]
+        for _, item := range list {
+            ids = append(ids, item.ID)
+        }
+    }
+
+    ctx := context.Background()
+
+    for _, id := range ids {
+        job, err := failed.Find(id)
+        if err != nil {
+            fmt.Println("[" + id + "] 失败任务不存在")
+            continue
+        }
+
+        if err := connection(job.Connection).Retry(ctx, id); err != nil {
+            fmt.Println("[" + id + "] 重新推送失败：" + err.Error())
+            continue
+        }
+
+        fmt.Println("[" + id + "] 已重新推送到队列")
+    }
+}
+
+// 队列连接
+func connection(name string) *queue.Queue {
+    if name == "" {
+        return facadeQueue.New()
+    }
+
+    return facadeQueue.Connection(name)
+}
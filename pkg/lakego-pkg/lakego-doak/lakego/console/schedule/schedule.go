@@ -1,13 +1,19 @@
 package schedule
 
 import (
+    "os"
     "fmt"
+    "time"
+    "context"
+    "syscall"
+    "os/signal"
 
     "github.com/deatil/go-datebin/datebin"
 
     "github.com/deatil/lakego-doak/lakego/color"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/schedule"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
 )
 
 /**
@@ -32,29 +38,318 @@ var ScheduleCmd = &command.Command{
     },
 }
 
+/**
+ * 执行计划任务，收到退出信号后等待执行中的任务完成
+ *
+ * > go run main.go lakego:schedule:work
+ * > go run main.go lakego:schedule:work --stop-timeout=1m
+ *
+ * @create 2024-5-16
+ * @author deatil
+ */
+var ScheduleWorkCmd = &command.Command{
+    Use: "lakego:schedule:work",
+    Short: "执行计划任务，支持平滑退出。",
+    Example: "{execfile} lakego:schedule:work [--stop-timeout=30s]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+// 等待执行中任务的时间
+var workStopTimeout time.Duration
+
+/**
+ * 计划任务列表
+ *
+ * > go run main.go lakego:schedule:list
+ *
+ * @create 2024-5-16
+ * @author deatil
+ */
+var ScheduleListCmd = &command.Command{
+    Use: "lakego:schedule:list",
+    Short: "计划任务列表。",
+    Example: "{execfile} lakego:schedule:list",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+/**
+ * 立即执行单个计划任务
+ *
+ * > go run main.go lakego:schedule:run --name=xxx
+ *
+ * @create 2024-5-16
+ * @author deatil
+ */
+var ScheduleRunCmd = &command.Command{
+    Use: "lakego:schedule:run",
+    Short: "立即执行单个计划任务。",
+    Example: "{execfile} lakego:schedule:run --name=xxx",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+// 任务名称
+var runName string
+
+/**
+ * 计划时间测试，输出时间范围内的执行时间，不执行任务
+ *
+ * > go run main.go lakego:schedule:test --spec="0 0/5 * * * *"
+ * > go run main.go lakego:schedule:test --name=xxx --start="2024-05-16 00:00:00" --end="2024-05-17 00:00:00"
+ *
+ * @create 2024-5-16
+ * @author deatil
+ */
+var ScheduleTestCmd = &command.Command{
+    Use: "lakego:schedule:test",
+    Short: "计划时间测试，输出执行时间。",
+    Example: "{execfile} lakego:schedule:test [--spec=\"0 */5 * * * *\"|--name=xxx] [--start=datetime] [--end=datetime] [--limit=10]",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+// 测试参数
+var (
+    testSpec  string
+    testName  string
+    testStart string
+    testEnd   string
+    testLimit int
+)
+
+func init() {
+    ScheduleWorkCmd.Flags().DurationVar(&workStopTimeout, "stop-timeout", 0, "退出时等待执行中任务的时间")
+
+    ScheduleRunCmd.Flags().StringVarP(&runName, "name", "n", "", "任务名称")
+
+    tf := ScheduleTestCmd.Flags()
+    tf.StringVarP(&testSpec, "spec", "s", "", "计划时间，格式：秒 分 时 日 月 周")
+    tf.StringVarP(&testName, "name", "n", "", "任务名称，使用任务的计划时间")
+    tf.StringVar(&testStart, "start", "", "开始时间，默认当前时间")
+    tf.StringVar(&testEnd, "end", "", "结束时间")
+    tf.IntVarP(&testLimit, "limit", "l", 10, "最多输出数量")
+}
+
 // 构造函数
 func NewScheduleCmd(s *schedule.Schedule) *command.Command {
     ScheduleCmd.Run = func(cmd *command.Command, args []string) {
-        nowDate := datebin.Now().ToDatetimeString()
+        ScheduleWork(s)
+    }
 
-        s.Start()
-        defer s.Stop()
+    return ScheduleCmd
+}
 
-        ids := s.CronIDs()
-        cronCount := fmt.Sprintf("%d", len(ids))
+// 平滑退出的计划任务
+func NewScheduleWorkCmd(s *schedule.Schedule) *command.Command {
+    ScheduleWorkCmd.Run = func(cmd *command.Command, args []string) {
+        ScheduleWork(s)
+    }
 
-        fmt.Print("\n")
-        color.
-            NewWithOption(
-                color.ForegroundOption("green"),
-                color.BaseOption("bold"),
-                color.BaseOption("blinkRapid"),
-            ).
-            Print("[" + nowDate + "] 计划任务共 " + cronCount + " 条已开始进行...")
-        fmt.Print("\n")
+    return ScheduleWorkCmd
+}
 
-        select {}
+// 计划任务列表
+func NewScheduleListCmd(s *schedule.Schedule) *command.Command {
+    ScheduleListCmd.Run = func(cmd *command.Command, args []string) {
+        ScheduleList(s)
     }
 
-    return ScheduleCmd
+    return ScheduleListCmd
+}
+
+// 立即执行计划任务
+func NewScheduleRunCmd(s *schedule.Schedule) *command.Command {
+    ScheduleRunCmd.Run = func(cmd *command.Command, args []string) {
+        ScheduleRun(s)
+    }
+
+    return ScheduleRunCmd
+}
+
+// 计划时间测试
+func NewScheduleTestCmd(s *schedule.Schedule) *command.Command {
+    ScheduleTestCmd.Run = func(cmd *command.Command, args []string) {
+        ScheduleTest(s)
+    }
+
+    return ScheduleTestCmd
+}
+
+// 执行计划任务
+func ScheduleWork(s *schedule.Schedule) {
+    nowDate := datebin.Now().ToDatetimeString()
+
+    s.Start()
+
+    cronCount := fmt.Sprintf("%d", len(s.CronIDs()))
+
+    fmt.Print("\n")
+    color.
+        NewWithOption(
+            color.ForegroundOption("green"),
+            color.BaseOption("bold"),
+            color.BaseOption("blinkRapid"),
+        ).
+        Print("[" + nowDate + "] 计划任务共 " + cronCount + " 条已开始进行...")
+    fmt.Print("\n")
+
+    quit := make(chan os.Signal, 1)
+    signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+    <-quit
+
+    fmt.Println("正在等待执行中的任务完成...")
+
+    stopTimeout := workStopTimeout
+    if stopTimeout <= 0 {
+        stopTimeout = config.New("schedule").GetDuration("stop-timeout")
+    }
+    if stopTimeout <= 0 {
+        stopTimeout = 30 * time.Second
+    }
+
+    select {
+        case <-s.Stop().Done():
+            fmt.Println("计划任务已退出")
+        case <-time.After(stopTimeout):
+            fmt.Println("等待超时，计划任务强制退出")
+    }
+}
+
+// 计划任务列表
+func ScheduleList(s *schedule.Schedule) {
+    infos := s.EntryInfos()
+    if len(infos) == 0 {
+        fmt.Println("没有计划任务")
+        return
+    }
+
+    for _, info := range infos {
+        name := info.Name
+        if name == "" {
+            name = "-"
+        }
+
+        next := "-"
+        if !info.Next.IsZero() {
+            next = datebin.FromStdTime(info.Next).ToDatetimeString()
+        }
+
+        fmt.Printf("%-30s  %-20s  %s\n", name, info.Spec, next)
+    }
+
+    fmt.Printf("\n共 %d 条计划任务\n", len(infos))
+}
+
+// 立即执行计划任务
+func ScheduleRun(s *schedule.Schedule) {
+    if runName == "" {
+        fmt.Println("请设置任务名称")
+        return
+    }
+
+    run, err := s.RunEntry(context.Background(), runName)
+    if err != nil {
+        fmt.Println("[" + runName + "] 计划任务不存在")
+        return
+    }
+
+    fmt.Printf("[%s] %s，用时 %s\n", run.Name, run.Status, run.Duration)
+
+    if run.Error != "" {
+        fmt.Println("错误信息：" + run.Error)
+    }
+    if run.Output != "" {
+        fmt.Println(run.Output)
+    }
+}
+
+// 计划时间测试
+func ScheduleTest(s *schedule.Schedule) {
+    spec := testSpec
+    if spec == "" && testName != "" {
+        entry := s.GetEntry(testName)
+        if entry.Cmd == nil {
+            fmt.Println("[" + testName + "] 计划任务不存在")
+            return
+        }
+
+        spec = entry.Spec
+    }
+
+    if spec == "" {
+        fmt.Println("请设置计划时间或者任务名称")
+        return
+    }
+
+    sched, err := schedule.ParseSpec(spec)
+    if err != nil {
+        fmt.Println("计划时间格式错误：" + err.Error())
+        return
+    }
+
+    loc := s.CronLocation()
+
+    start := time.Now().In(loc)
+    if testStart != "" {
+        date := datebin.Parse(testStart)
+        if date.IsInvalid() {
+            fmt.Println("开始时间格式错误")
+            return
+        }
+
+        start = date.ToStdTime().In(loc)
+    }
+
+    var end time.Time
+    if testEnd != "" {
+        date := datebin.Parse(testEnd)
+        if date.IsInvalid() {
+            fmt.Println("结束时间格式错误")
+            return
+        }
+
+        end = date.ToStdTime().In(loc)
+    }
+
+    limit := testLimit
+    if limit <= 0 {
+        limit = 10
+    }
+
+    fmt.Printf("计划时间：%s\n\n", spec)
+
+    count := 0
+    next := sched.Next(start.Add(-time.Second))
+    for !next.IsZero() && count < limit {
+        if !end.IsZero() && next.After(end) {
+            break
+        }
+
+        fmt.Println(datebin.FromStdTime(next).ToDatetimeString())
+
+        count++
+        next = sched.Next(next)
+    }
+
+    if count == 0 {
+        fmt.Println("时间范围内没有执行时间")
+    }
 }
@@ -86,7 +86,7 @@ func (this *Driver) CreateOpenConnection(dia gorm.Dialector) {
     logLevel := cfg.Value("log-level").ToString()
 
     // 日志
-    gormLogger := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+    gormLogger := newTraceLogger(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
         // 默认 200 * time.Millisecond
         SlowThreshold:             cfg.Value("log-slow-threshold").ToDuration(),
         LogLevel:                  getLogLevel(logLevel),
@@ -119,6 +119,11 @@ func (this *Driver) CreateOpenConnection(dia gorm.Dialector) {
         log.Printf("Error to open database connection: %v", err)
     }
 
+    // 链路追踪
+    if err := db.Use(&TracePlugin{}); err != nil {
+        log.Printf("Error to use database trace plugin: %v", err)
+    }
+
     // 连接池设置, *sql.DB (database/sql)
     sqlDB, _ := db.DB()
 
@@ -0,0 +1,196 @@
+package driver
+
+import (
+    "fmt"
+    "sort"
+    "time"
+    "errors"
+    "context"
+    "strings"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/logger"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 链路节点在 gorm 实例中的名称
+const traceSpanKey = "lakego:trace_span"
+
+/**
+ * gorm 链路追踪插件，请求上下文中有链路时记录查询节点
+ *
+ * 查询需要使用 db.WithContext(ctx) 传入请求上下文，*router.Context 时使用其请求上下文
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type TracePlugin struct {}
+
+// 名称
+func (this *TracePlugin) Name() string {
+    return "lakego:trace"
+}
+
+// 初始化
+func (this *TracePlugin) Initialize(db *gorm.DB) error {
+    cb := db.Callback()
+
+    processors := []struct {
+        name string
+        before func(string, func(*gorm.DB)) error
+        after  func(string, func(*gorm.DB)) error
+    }{
+        {"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+        {"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+        {"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+        {"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+        {"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+        {"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+    }
+
+    for _, p := range processors {
+        if err := p.before("lakego:trace_before_" + p.name, traceBefore("gorm." + p.name)); err != nil {
+            return err
+        }
+        if err := p.after("lakego:trace_after_" + p.name, traceAfter); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 开始节点
+func traceBefore(name string) func(*gorm.DB) {
+    return func(db *gorm.DB) {
+        if !trace.Default().Enabled() {
+            return
+        }
+
+        ctx := statementContext(db.Statement.Context)
+        if !trace.SpanContextFromContext(ctx).IsValid() {
+            return
+        }
+
+        _, span := trace.Start(ctx, name, trace.KindClient)
+        db.InstanceSet(traceSpanKey, span)
+    }
+}
+
+// 查询上下文，*router.Context 只有设置 ContextWithFallback 时才会读取请求上下文
+func statementContext(ctx context.Context) context.Context {
+    if c, ok := ctx.(*router.Context); ok && c.Request != nil {
+        return router.TraceContext(c)
+    }
+
+    return ctx
+}
+
+// 结束节点
+func traceAfter(db *gorm.DB) {
+    value, ok := db.InstanceGet(traceSpanKey)
+    if !ok {
+        return
+    }
+
+    span, ok := value.(*trace.Span)
+    if !ok {
+        return
+    }
+
+    span.SetAttributes(map[string]any{
+        "db.system":        db.Dialector.Name(),
+        "db.statement":     db.Statement.SQL.String(),
+        "db.sql.table":     db.Statement.Table,
+        "db.rows_affected": db.Statement.RowsAffected,
+    })
+
+    if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+        span.RecordError(db.Error)
+    }
+
+    span.End()
+}
+
+// 带链路字段的日志
+func newTraceLogger(writer logger.Writer, config logger.Config) logger.Interface {
+    return &traceLogger{
+        Interface: logger.New(writer, config),
+        writer:    writer,
+        config:    config,
+    }
+}
+
+/**
+ * gorm 日志，上下文中有请求 id 时在日志前添加请求 id 及链路 id
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type traceLogger struct {
+    logger.Interface
+
+    writer logger.Writer
+    config logger.Config
+}
+
+// 设置日志等级
+func (this *traceLogger) LogMode(level logger.LogLevel) logger.Interface {
+    config := this.config
+    config.LogLevel = level
+
+    return newTraceLogger(this.writer, config)
+}
+
+func (this *traceLogger) Info(ctx context.Context, msg string, data ...any) {
+    this.with(ctx).Info(ctx, msg, data...)
+}
+
+func (this *traceLogger) Warn(ctx context.Context, msg string, data ...any) {
+    this.with(ctx).Warn(ctx, msg, data...)
+}
+
+func (this *traceLogger) Error(ctx context.Context, msg string, data ...any) {
+    this.with(ctx).Error(ctx, msg, data...)
+}
+
+func (this *traceLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+    this.with(ctx).Trace(ctx, begin, fc, err)
+}
+
+// 上下文对应的日志
+func (this *traceLogger) with(ctx context.Context) logger.Interface {
+    fields := trace.Fields(ctx)
+    if len(fields) == 0 {
+        return this.Interface
+    }
+
+    keys := make([]string, 0, len(fields))
+    for k := range fields {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    prefix := make([]string, 0, len(keys))
+    for _, k := range keys {
+        prefix = append(prefix, fmt.Sprintf("%s=%v", k, fields[k]))
+    }
+
+    return logger.New(&prefixWriter{
+        writer: this.writer,
+        prefix: "[" + strings.Join(prefix, " ") + "] ",
+    }, this.config)
+}
+
+// 添加前缀
+type prefixWriter struct {
+    writer logger.Writer
+    prefix string
+}
+
+func (this *prefixWriter) Printf(format string, args ...any) {
+    this.writer.Printf(this.prefix + format, args...)
+}
@@ -0,0 +1,65 @@
+package driver
+
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+    "net/http/httptest"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/utils/tests"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func init() {
+    router.SetMode(router.TestMode)
+}
+
+func Test_TracePluginRouterContext(t *testing.T) {
+    buf := new(bytes.Buffer)
+    tracer := trace.NewTracer(trace.NewWriterExporter(buf, "test"), trace.Options{SampleRatio: 1})
+
+    old := trace.Default()
+    trace.SetDefault(tracer)
+    defer trace.SetDefault(old)
+
+    db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := db.Use(&TracePlugin{}); err != nil {
+        t.Fatal(err)
+    }
+
+    // 未设置 ContextWithFallback 的路由
+    r := router.New()
+    r.Use(func(ctx *router.Context) {
+        c, span := trace.Start(ctx.Request.Context(), "GET /user", trace.KindServer)
+        ctx.Request = ctx.Request.WithContext(c)
+
+        ctx.Next()
+
+        span.End()
+    })
+    r.GET("/user", func(ctx *router.Context) {
+        rows := make([]map[string]any, 0)
+        db.WithContext(ctx).Table("user").Find(&rows)
+    })
+
+    r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/user", nil))
+
+    if err := tracer.Shutdown(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+
+    lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("exported %d spans, want 2: %s", len(lines), buf.String())
+    }
+    if !strings.Contains(lines[0], `"name":"gorm.query"`) || !strings.Contains(lines[0], "SELECT * FROM `user`") {
+        t.Errorf("got %s", lines[0])
+    }
+}
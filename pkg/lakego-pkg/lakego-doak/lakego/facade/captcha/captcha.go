@@ -1,6 +1,7 @@
 package captcha
 
 import (
+    "time"
     "strings"
     "image/color"
 
@@ -17,6 +18,12 @@ import (
 // 默认
 var Default *captcha.Captcha
 
+// 验证方式管理
+var Challenges *captcha.Manager
+
+// 自适应验证码
+var Adaptive *captcha.Adaptive
+
 // 初始化
 func init() {
     // 注册默认
@@ -24,6 +31,12 @@ func init() {
 
     // 默认
     Default = New()
+
+    // 验证方式
+    Challenges = NewManager()
+
+    // 自适应
+    Adaptive = NewAdaptive()
 }
 
 /**
@@ -44,6 +57,14 @@ func New(once ...bool) *captcha.Captcha {
 
 // 验证码
 func Captcha(driverName string, storeName string, once ...bool) *captcha.Captcha {
+    store := Store(storeName, once...)
+    driver := Driver(driverName, once...)
+
+    return captcha.New(driver, store)
+}
+
+// 存储
+func Store(storeName string, once ...bool) captcha.IStore {
     // 验证码配置
     conf := config.New("captcha")
 
@@ -70,6 +91,14 @@ func Captcha(driverName string, storeName string, once ...bool) *captcha.Captcha
         panic("验证码存储驱动[" + storeType + "]没有被注册")
     }
 
+    return store.(captcha.IStore)
+}
+
+// 驱动
+func Driver(driverName string, once ...bool) captcha.IDriver {
+    // 验证码配置
+    conf := config.New("captcha")
+
     // 驱动列表
     drivers := conf.GetStringMap("Drivers")
 
@@ -93,7 +122,63 @@ func Captcha(driverName string, storeName string, once ...bool) *captcha.Captcha
         panic("验证码驱动[" + driverType + "]没有被注册")
     }
 
-    return captcha.New(driver.(captcha.IDriver), store.(captcha.IStore))
+    return driver.(captcha.IDriver)
+}
+
+// 验证方式管理，使用 challenge 配置
+func NewManager() *captcha.Manager {
+    conf := config.New("captcha")
+
+    manager := captcha.NewManager(Store(GetDefaultStore()))
+
+    // 验证方式
+    types := conf.GetStringMap("challenge.types")
+    for name, typeConfig := range types {
+        typeConf, ok := typeConfig.(map[string]any)
+        if !ok {
+            continue
+        }
+
+        typ := array.ArrayFrom(typeConf).Value("type").ToString()
+        challenge := register.
+            NewManagerWithPrefix("captcha-challenge").
+            GetRegister(typ, typeConf)
+        if challenge == nil {
+            panic("验证方式[" + typ + "]没有被注册")
+        }
+
+        manager.Register(strings.ToLower(name), challenge.(captcha.IChallenge))
+    }
+
+    manager.WithDefault(conf.GetString("challenge.default"))
+
+    // 远程验证
+    providerConf := conf.GetStringMap("provider")
+    providerType := array.ArrayFrom(providerConf).Value("type").ToString()
+    if providerType != "" {
+        provider := register.
+            NewManagerWithPrefix("captcha-provider").
+            GetRegister(providerType, providerConf)
+        if provider == nil {
+            panic("远程验证[" + providerType + "]没有被注册")
+        }
+
+        manager.WithProvider(provider.(captcha.IProvider))
+    }
+
+    return manager
+}
+
+// 自适应验证码，使用 adaptive 配置
+func NewAdaptive() *captcha.Adaptive {
+    cfg := array.ArrayFrom(config.New("captcha").GetStringMap("adaptive"))
+
+    return captcha.NewAdaptive(cache.Default, captcha.AdaptiveConfig{
+        Mode:        cfg.Value("mode").ToString(),
+        MaxFailures: cfg.Value("max-failures").ToInt(),
+        TTL:         cfg.Value("ttl").ToString(),
+        Prefix:      cfg.Value("prefix").ToString(),
+    })
 }
 
 // 默认驱动
@@ -257,5 +342,67 @@ func registerCaptcha() {
                 return driver
             },
         })
-}
 
+    // 注册验证方式
+    register.
+        NewManagerWithPrefix("captcha-challenge").
+        RegisterMany(map[string]func(map[string]any) any {
+            // base64Captcha 驱动，包括字符、算术及音频
+            "driver": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                driver := Driver(cfg.Value("driver").ToString())
+
+                return captcha.NewDriverChallenge(driver, cfg.Value("field").ToString())
+            },
+            // 滑块拼图
+            "slider": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                return captcha.NewSliderChallenge(captcha.SliderConfig{
+                    Width:     cfg.Value("width").ToInt(),
+                    Height:    cfg.Value("height").ToInt(),
+                    PieceSize: cfg.Value("piece-size").ToInt(),
+                    Tolerance: cfg.Value("tolerance").ToInt(),
+                })
+            },
+            // 点选文字
+            "click": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                return captcha.NewClickChallenge(captcha.ClickConfig{
+                    Width:     cfg.Value("width").ToInt(),
+                    Height:    cfg.Value("height").ToInt(),
+                    Source:    cfg.Value("source").ToString(),
+                    Count:     cfg.Value("count").ToInt(),
+                    Click:     cfg.Value("click").ToInt(),
+                    FontSize:  cfg.Value("font-size").ToInt(),
+                    Tolerance: cfg.Value("tolerance").ToInt(),
+                    Font:      cfg.Value("font").ToString(),
+                })
+            },
+        })
+
+    // 注册远程验证
+    register.
+        NewManagerWithPrefix("captcha-provider").
+        RegisterMany(map[string]func(map[string]any) any {
+            // siteverify 接口
+            "http": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                return captcha.NewHTTPProvider(
+                    cfg.Value("url").ToString(),
+                    cfg.Value("site-key").ToString(),
+                    cfg.Value("secret").ToString(),
+                    time.Duration(cfg.Value("timeout").ToInt64()) * time.Second,
+                )
+            },
+            // 本地模拟
+            "mock": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                return captcha.NewMockProvider(cfg.Value("pass-token").ToString())
+            },
+        })
+}
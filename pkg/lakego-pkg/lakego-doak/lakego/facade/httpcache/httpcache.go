@@ -0,0 +1,80 @@
+package httpcache
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    cacheFacade "github.com/deatil/lakego-doak/lakego/facade/cache"
+    httpCache "github.com/deatil/lakego-doak/lakego/middleware/httpcache"
+)
+
+/**
+ * 响应缓存
+ *
+ * route.Use(httpcache.RulesHandler())
+ * httpcache.Flush(ctx, "auth-rule")
+ *
+ * @create 2024-5-31
+ * @author deatil
+ */
+
+// 使用的缓存
+func Cache() *cache.Cache {
+    name := config.New("httpcache").GetString("cache")
+    if name == "" {
+        return cacheFacade.Default
+    }
+
+    return cacheFacade.Cache(name, true)
+}
+
+// 中间件配置
+func Options() httpCache.Options {
+    conf := config.New("httpcache")
+
+    return httpCache.Options{
+        Cache:       Cache(),
+        Prefix:      conf.GetString("prefix"),
+        Conditional: conf.GetBool("conditional"),
+        OnError: func(ctx *router.Context, err error) {
+            logger.New().WithContext(router.TraceContext(ctx)).Error("[httpcache]" + err.Error())
+        },
+    }
+}
+
+// 缓存规则
+func Rules() []httpCache.Rule {
+    rules := make([]httpCache.Rule, 0)
+
+    ruleList, _ := config.New("httpcache").Get("rules").([]any)
+    for _, item := range ruleList {
+        cfg := array.ArrayFrom(item)
+
+        rules = append(rules, httpCache.Rule{
+            Routes:   cfg.Value("routes").ToStringSlice(),
+            TTL:      cfg.Value("ttl").ToDuration(),
+            PerAdmin: cfg.Value("per-admin").ToBool(),
+            Tags:     cfg.Value("tags").ToStringSlice(),
+        })
+    }
+
+    return rules
+}
+
+// 使用配置的响应缓存中间件
+func RulesHandler() router.HandlerFunc {
+    return httpCache.RulesHandler(Options(), Rules())
+}
+
+// 清除标签下的响应缓存
+func Flush(ctx context.Context, tags ...string) error {
+    if len(tags) == 0 {
+        return nil
+    }
+
+    return Cache().WithContext(ctx).Tags(tags...).Flush()
+}
@@ -1,19 +1,28 @@
 package logger
 
 import (
+    "fmt"
     "log"
+    "errors"
     "strings"
 
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/logger"
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+    stackDriver "github.com/deatil/lakego-doak/lakego/logger/driver/stack"
+    slogDriver "github.com/deatil/lakego-doak/lakego/logger/driver/slog"
     logrusDriver "github.com/deatil/lakego-doak/lakego/logger/driver/logrus"
 )
 
 // 默认
 var Default *logger.Logger
 
+// 日志通道
+var Channels = logger.NewChannels(func(name string) (interfaces.Driver, error) {
+    return NewChannelDriver(name, nil)
+})
+
 // 初始化
 func init() {
     // 注册默认
@@ -64,7 +73,62 @@ func NewLogger(driverName string, once ...bool) *logger.Logger {
         log.Print("日志驱动[" + driverType + "]没有被注册")
     }
 
-    return logger.New(driver.(interfaces.Driver))
+    return logger.New(driver.(interfaces.Driver)).WithChannels(Channels)
+}
+
+// 通道日志
+// logger.Channel("auth").Info("login")
+func Channel(name string) *logger.Logger {
+    return New().Channel(name)
+}
+
+// 通道驱动，先查找 channels 配置，不存在时使用 drivers 配置
+// stack 类型通道同时写入 channels 列表中的通道
+func NewChannelDriver(name string, parents []string) (interfaces.Driver, error) {
+    name = strings.ToLower(name)
+
+    for _, parent := range parents {
+        if parent == name {
+            return nil, errors.New(fmt.Sprintf("日志通道[%s]循环引用", strings.Join(append(parents, name), " -> ")))
+        }
+    }
+
+    // 配置
+    conf := config.New("logger")
+
+    channelConf, ok := conf.GetStringMap("channels")[name].(map[string]any)
+    if !ok {
+        channelConf, ok = conf.GetStringMap("drivers")[name].(map[string]any)
+        if !ok {
+            return nil, errors.New(fmt.Sprintf("日志通道[%s]配置不存在", name))
+        }
+    }
+
+    driverType, _ := channelConf["type"].(string)
+    if driverType == "stack" {
+        list, _ := channelConf["channels"].([]any)
+
+        drivers := make([]interfaces.Driver, 0, len(list))
+        for _, item := range list {
+            driver, err := NewChannelDriver(fmt.Sprint(item), append(parents, name))
+            if err != nil {
+                return nil, err
+            }
+
+            drivers = append(drivers, driver)
+        }
+
+        return stackDriver.New(drivers...), nil
+    }
+
+    driver, ok := register.
+        NewManagerWithPrefix("logger").
+        GetRegister(driverType, channelConf).(interfaces.Driver)
+    if !ok {
+        return nil, errors.New(fmt.Sprintf("日志驱动[%s]没有被注册", driverType))
+    }
+
+    return driver, nil
 }
 
 // 自定义数据
@@ -98,5 +162,14 @@ func registerDriver() {
 
                 return driver
             },
+
+            // slog 日志
+            "slog": func(conf map[string]any) any {
+                driver := slogDriver.New()
+
+                driver.WithConfig(conf)
+
+                return driver
+            },
         })
 }
@@ -0,0 +1,154 @@
+package queue
+
+import (
+    "sync"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+    "github.com/deatil/lakego-doak/lakego/queue"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    syncDriver "github.com/deatil/lakego-doak/lakego/queue/driver/sync"
+    redisDriver "github.com/deatil/lakego-doak/lakego/queue/driver/redis"
+    databaseDriver "github.com/deatil/lakego-doak/lakego/queue/driver/database"
+)
+
+/**
+ * 队列
+ *
+ * queue.Register(&SendMail{})
+ * queue.New().Push(ctx, &SendMail{To: "lakego@admin.com"})
+ * queue.Connection("redis").Later(ctx, time.Minute, &SendMail{To: "lakego@admin.com"})
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+
+// 已创建的连接
+var connections sync.Map
+
+// 初始化
+func init() {
+    // 注册默认
+    registerDriver()
+}
+
+// 注册任务
+func Register(job queue.Job) {
+    queue.Register(job)
+}
+
+// 默认连接
+func New() *queue.Queue {
+    return Connection(GetDefaultConnection())
+}
+
+// 指定连接
+func Connection(name string) *queue.Queue {
+    name = strings.ToLower(name)
+
+    if q, ok := connections.Load(name); ok {
+        return q.(*queue.Queue)
+    }
+
+    q, _ := connections.LoadOrStore(name, newQueue(name))
+    return q.(*queue.Queue)
+}
+
+func newQueue(name string) *queue.Queue {
+    conf := config.New("queue")
+
+    // 连接列表
+    conns := conf.GetStringMap("connections")
+
+    // 获取驱动配置
+    driverConfig, ok := conns[name]
+    if !ok {
+        panic("队列连接[" + name + "]配置不存在")
+    }
+
+    // 配置
+    driverConf := driverConfig.(map[string]any)
+
+    driverType := driverConf["type"].(string)
+    driver := register.
+        NewManagerWithPrefix("queue").
+        GetRegister(driverType, driverConf)
+    if driver == nil {
+        panic("队列驱动[" + driverType + "]没有被注册")
+    }
+
+    cfg := array.ArrayFrom(driverConf)
+
+    return queue.New(driver.(interfaces.Driver)).
+        WithConnection(name).
+        WithQueue(cfg.Value("queue").ToString()).
+        WithFailed(NewFailed()).
+        WithSyncOptions(WorkerOptions())
+}
+
+// 失败任务存储
+func NewFailed() interfaces.FailedStore {
+    conf := config.New("queue")
+
+    switch conf.GetString("failed.type") {
+        case "database":
+            return databaseDriver.NewFailed(database.Default, conf.GetString("failed.table"))
+    }
+
+    return nil
+}
+
+// 工作进程设置
+func WorkerOptions() queue.WorkerOptions {
+    conf := config.New("queue")
+
+    return queue.WorkerOptions{
+        Concurrency: conf.GetInt("worker.concurrency"),
+        Sleep:       conf.GetDuration("worker.sleep"),
+        Tries:       conf.GetInt("worker.tries"),
+        Timeout:     conf.GetDuration("worker.timeout"),
+        Backoff:     conf.GetDuration("worker.backoff"),
+        MaxBackoff:  conf.GetDuration("worker.max-backoff"),
+    }
+}
+
+// 默认连接名称
+func GetDefaultConnection() string {
+    return config.New("queue").GetString("default")
+}
+
+// 注册
+func registerDriver() {
+    m := register.NewManagerWithPrefix("queue")
+
+    // 同步
+    m.Register("sync", func(conf map[string]any) any {
+        return syncDriver.New()
+    })
+
+    // 数据库
+    m.Register("database", func(conf map[string]any) any {
+        cfg := array.ArrayFrom(conf)
+
+        return databaseDriver.New(database.Default, databaseDriver.Config{
+            Table:      cfg.Value("table").ToString(),
+            RetryAfter: cfg.Value("retry-after").ToDuration(),
+        })
+    })
+
+    // redis
+    m.Register("redis", func(conf map[string]any) any {
+        cfg := array.ArrayFrom(conf)
+
+        client := redis.Connect(cfg.Value("connect").ToString()).GetClient()
+
+        return redisDriver.New(client, redisDriver.Config{
+            Prefix:     cfg.Value("prefix").ToString(),
+            RetryAfter: cfg.Value("retry-after").ToDuration(),
+        })
+    })
+}
@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+    "errors"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    httpRatelimit "github.com/deatil/lakego-doak/lakego/middleware/ratelimit"
+)
+
+// 默认
+var Default *ratelimit.Limiter
+
+// 初始化
+func init() {
+    // 注册默认
+    registerRatelimit()
+
+    // 默认
+    Default = New()
+}
+
+/**
+ * 限流
+ *
+ * res, err := ratelimit.Default.Take(ctx, key, policy)
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+func New(once ...bool) *ratelimit.Limiter {
+    conf := config.New("ratelimit")
+
+    limiter := ratelimit.New(Store(conf.GetString("store"), once...))
+    if prefix := conf.GetString("prefix"); prefix != "" {
+        limiter.WithPrefix(prefix)
+    }
+
+    return limiter
+}
+
+// 存储
+func Store(name string, once ...bool) ratelimit.IStore {
+    conf := config.New("ratelimit")
+
+    name = strings.ToLower(name)
+    if name == "" {
+        name = "memory"
+    }
+
+    store := register.
+        NewManagerWithPrefix("ratelimit-store").
+        GetRegister(name, map[string]any{
+            "redis-connect": conf.GetString("redis-connect"),
+        }, once...)
+    if store == nil {
+        panic("限流存储[" + name + "]没有被注册")
+    }
+
+    return store.(ratelimit.IStore)
+}
+
+// 限流规则，name 为 policies 配置名称或者限流规则字符
+func Policy(name string) (ratelimit.Policy, error) {
+    policies := config.New("ratelimit").GetStringMap("policies")
+
+    var (
+        policy ratelimit.Policy
+        err    error
+    )
+
+    switch data := policies[strings.ToLower(name)].(type) {
+        case string:
+            policy, err = ratelimit.ParsePolicy(data)
+        case map[string]any:
+            policy, err = policyFromMap(data)
+        case nil:
+            policy, err = ratelimit.ParsePolicy(name)
+        default:
+            err = errors.New("限流规则[" + name + "]配置错误")
+    }
+
+    if err != nil {
+        return policy, err
+    }
+
+    policy.Name = name
+
+    return policy, nil
+}
+
+// 多个限流规则
+func Policies(names ...string) ([]ratelimit.Policy, error) {
+    policies := make([]ratelimit.Policy, 0, len(names))
+    for _, name := range names {
+        name = strings.TrimSpace(name)
+        if name == "" {
+            continue
+        }
+
+        policy, err := Policy(name)
+        if err != nil {
+            return nil, err
+        }
+
+        policies = append(policies, policy)
+    }
+
+    return policies, nil
+}
+
+// 中间件配置
+func Options() httpRatelimit.Options {
+    conf := config.New("ratelimit")
+
+    return httpRatelimit.Options{
+        Limiter:   Default,
+        SkipPaths: conf.GetStringSlice("skip-paths"),
+        OnError: func(ctx *router.Context, err error) {
+            logger.New().WithContext(router.TraceContext(ctx)).Error("[ratelimit]" + err.Error())
+        },
+    }
+}
+
+// 限流中间件，多个规则使用 | 分隔，比如 "login|fixed 1000/1h by=ip"
+func Handler(spec string) router.HandlerFunc {
+    policies, err := Policies(strings.Split(spec, "|")...)
+    if err != nil {
+        panic("限流规则[" + spec + "]错误：" + err.Error())
+    }
+
+    return httpRatelimit.Handler(Options(), policies...)
+}
+
+// 使用配置的路由限流中间件
+func RulesHandler() router.HandlerFunc {
+    conf := config.New("ratelimit")
+
+    rules := make([]httpRatelimit.Rule, 0)
+
+    ruleList, _ := conf.Get("rules").([]any)
+    for _, item := range ruleList {
+        cfg := array.ArrayFrom(item)
+
+        policies, err := Policies(cfg.Value("policies").ToStringSlice()...)
+        if err != nil {
+            panic("路由限流规则错误：" + err.Error())
+        }
+
+        rules = append(rules, httpRatelimit.Rule{
+            Routes:   cfg.Value("routes").ToStringSlice(),
+            Policies: policies,
+        })
+    }
+
+    defaults, err := Policies(conf.GetString("default"))
+    if err != nil {
+        panic("默认限流规则错误：" + err.Error())
+    }
+
+    return httpRatelimit.RulesHandler(Options(), rules, defaults...)
+}
+
+// 使用配置生成限流规则
+func policyFromMap(conf map[string]any) (ratelimit.Policy, error) {
+    cfg := array.ArrayFrom(conf)
+
+    period, err := ratelimit.ParsePeriod(cfg.Value("period").ToString())
+    if err != nil {
+        return ratelimit.Policy{}, err
+    }
+
+    algorithm := strings.ToLower(cfg.Value("algorithm").ToString())
+    if algorithm == "" {
+        algorithm = ratelimit.AlgorithmFixed
+    }
+
+    policy := ratelimit.Policy{
+        Algorithm: algorithm,
+        Limit:     cfg.Value("limit").ToInt(),
+        Period:    period,
+        Burst:     cfg.Value("burst").ToInt(),
+    }
+
+    // 限流对象，支持字符及列表
+    if by, ok := conf["by"].(string); ok {
+        policy.By = ratelimit.ParseBy(by)
+    } else {
+        policy.By = ratelimit.ParseBy(strings.Join(cfg.Value("by").ToStringSlice(), ","))
+    }
+
+    return policy, policy.Validate()
+}
+
+// 注册
+func registerRatelimit() {
+    // 接口客户端请求头
+    if header := config.New("ratelimit").GetString("client-header"); header != "" {
+        httpRatelimit.RegisterKey("client", httpRatelimit.KeyByClient(header))
+    }
+
+    register.
+        NewManagerWithPrefix("ratelimit-store").
+        RegisterMany(map[string]func(map[string]any) any {
+            "memory": func(conf map[string]any) any {
+                return ratelimit.NewMemoryStore()
+            },
+            "redis": func(conf map[string]any) any {
+                connect := array.ArrayFrom(conf).Value("redis-connect").ToString()
+                if connect == "" {
+                    return ratelimit.NewRedisStore(redis.Default.GetClient())
+                }
+
+                return ratelimit.NewRedisStore(redis.New(connect).GetClient())
+            },
+        })
+}
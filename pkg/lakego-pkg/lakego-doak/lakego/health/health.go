@@ -0,0 +1,264 @@
+package health
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+    "time"
+    "context"
+)
+
+// 检测状态
+const (
+    StatusUp   = "up"
+    StatusDown = "down"
+)
+
+// 默认单个检测超时时间
+const DefaultTimeout = 3 * time.Second
+
+// 检测函数
+type CheckFunc func(ctx context.Context) error
+
+// 检测项
+type Check struct {
+    // 名称
+    Name string
+
+    // 检测函数
+    Check CheckFunc
+
+    // 超时时间，为 0 时使用默认超时时间
+    Timeout time.Duration
+}
+
+// 单项检测结果
+type Result struct {
+    // 名称
+    Name string `json:"name"`
+
+    // 状态
+    Status string `json:"status"`
+
+    // 用时
+    Duration string `json:"duration"`
+
+    // 错误信息
+    Error string `json:"error,omitempty"`
+}
+
+// 检测报告
+type Report struct {
+    // 状态
+    Status string `json:"status"`
+
+    // 检测结果
+    Checks []Result `json:"checks"`
+}
+
+// 是否正常
+func (this Report) Up() bool {
+    return this.Status == StatusUp
+}
+
+// 构造函数
+func NewRegistry() *Registry {
+    return &Registry{
+        timeout: DefaultTimeout,
+        checks:  make(map[string]Check),
+    }
+}
+
+/**
+ * 健康检测注册
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type Registry struct {
+    mu sync.RWMutex
+
+    // 默认超时时间
+    timeout time.Duration
+
+    // 检测项
+    checks map[string]Check
+}
+
+// 设置默认超时时间
+func (this *Registry) WithTimeout(timeout time.Duration) *Registry {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if timeout > 0 {
+        this.timeout = timeout
+    }
+
+    return this
+}
+
+// 注册检测，同名时覆盖
+func (this *Registry) Register(name string, check CheckFunc, timeout ...time.Duration) {
+    c := Check{
+        Name:  name,
+        Check: check,
+    }
+    if len(timeout) > 0 {
+        c.Timeout = timeout[0]
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.checks[name] = c
+}
+
+// 移除检测
+func (this *Registry) Unregister(name string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.checks, name)
+}
+
+// 检测名称列表
+func (this *Registry) Names() []string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    names := make([]string, 0, len(this.checks))
+    for name := range this.checks {
+        names = append(names, name)
+    }
+
+    sort.Strings(names)
+
+    return names
+}
+
+// 并发执行全部检测
+func (this *Registry) Run(ctx context.Context) Report {
+    this.mu.RLock()
+    checks := make([]Check, 0, len(this.checks))
+    for _, c := range this.checks {
+        checks = append(checks, c)
+    }
+    defaultTimeout := this.timeout
+    this.mu.RUnlock()
+
+    sort.Slice(checks, func(i, j int) bool {
+        return checks[i].Name < checks[j].Name
+    })
+
+    results := make([]Result, len(checks))
+
+    var wg sync.WaitGroup
+    for i, c := range checks {
+        wg.Add(1)
+
+        go func(i int, c Check) {
+            defer wg.Done()
+
+            timeout := c.Timeout
+            if timeout <= 0 {
+                timeout = defaultTimeout
+            }
+
+            results[i] = runCheck(ctx, c, timeout)
+        }(i, c)
+    }
+
+    wg.Wait()
+
+    report := Report{
+        Status: StatusUp,
+        Checks: results,
+    }
+    for _, r := range results {
+        if r.Status != StatusUp {
+            report.Status = StatusDown
+            break
+        }
+    }
+
+    return report
+}
+
+// 执行单个检测，超时后不再等待
+func runCheck(ctx context.Context, c Check, timeout time.Duration) Result {
+    ctx, cancel := context.WithTimeout(ctx, timeout)
+    defer cancel()
+
+    start := time.Now()
+
+    done := make(chan error, 1)
+    go func() {
+        defer func() {
+            if r := recover(); r != nil {
+                done <- fmt.Errorf("panic: %v", r)
+            }
+        }()
+
+        done <- c.Check(ctx)
+    }()
+
+    var err error
+    select {
+        case err = <-done:
+        case <-ctx.Done():
+            err = fmt.Errorf("timeout after %s", timeout)
+    }
+
+    result := Result{
+        Name:     c.Name,
+        Status:   StatusUp,
+        Duration: time.Since(start).String(),
+    }
+    if err != nil {
+        result.Status = StatusDown
+        result.Error = err.Error()
+    }
+
+    return result
+}
+
+// 缓存检测结果，ttl 内重复检测时返回上次结果，用于有副作用或者较慢的检测
+func Cached(check CheckFunc, ttl time.Duration) CheckFunc {
+    var (
+        mu      sync.Mutex
+        lastErr error
+        expire  time.Time
+    )
+
+    return func(ctx context.Context) error {
+        mu.Lock()
+        defer mu.Unlock()
+
+        if time.Now().Before(expire) {
+            return lastErr
+        }
+
+        lastErr = check(ctx)
+        expire = time.Now().Add(ttl)
+
+        return lastErr
+    }
+}
+
+// 默认注册
+var defaultRegistry = NewRegistry()
+
+// 默认注册
+func Default() *Registry {
+    return defaultRegistry
+}
+
+// 注册检测到默认注册
+func Register(name string, check CheckFunc, timeout ...time.Duration) {
+    defaultRegistry.Register(name, check, timeout...)
+}
+
+// 从默认注册移除检测
+func Unregister(name string) {
+    defaultRegistry.Unregister(name)
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+    "time"
+    "errors"
+    "context"
+    "testing"
+)
+
+func Test_Run(t *testing.T) {
+    r := NewRegistry()
+    r.Register("ok", func(ctx context.Context) error {
+        return nil
+    })
+
+    if report := r.Run(context.Background()); !report.Up() {
+        t.Fatalf("got %v", report)
+    }
+
+    r.Register("fail", func(ctx context.Context) error {
+        return errors.New("down")
+    })
+
+    report := r.Run(context.Background())
+    if report.Up() {
+        t.Fatal("report should be down")
+    }
+    if report.Checks[0].Name != "fail" || report.Checks[0].Error != "down" {
+        t.Errorf("got %v", report.Checks)
+    }
+}
+
+func Test_Timeout(t *testing.T) {
+    r := NewRegistry()
+    r.Register("slow", func(ctx context.Context) error {
+        time.Sleep(time.Second)
+        return nil
+    }, 20*time.Millisecond)
+
+    start := time.Now()
+    report := r.Run(context.Background())
+
+    if report.Up() {
+        t.Error("slow check should time out")
+    }
+    if time.Since(start) > 500*time.Millisecond {
+        t.Error("run did not stop at the check timeout")
+    }
+}
+
+func Test_Cached(t *testing.T) {
+    calls := 0
+    check := Cached(func(ctx context.Context) error {
+        calls++
+        if calls > 1 {
+            return errors.New("down")
+        }
+
+        return nil
+    }, 50 * time.Millisecond)
+
+    for i := 0; i < 3; i++ {
+        if err := check(context.Background()); err != nil {
+            t.Fatalf("cached got %v", err)
+        }
+    }
+    if calls != 1 {
+        t.Errorf("calls got %d", calls)
+    }
+
+    time.Sleep(60 * time.Millisecond)
+
+    if err := check(context.Background()); err == nil || calls != 2 {
+        t.Errorf("expired got %v, calls %d", err, calls)
+    }
+}
@@ -0,0 +1,79 @@
+package probe
+
+import (
+    "net/http"
+    "strings"
+    "crypto/subtle"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/health"
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+/**
+ * 健康检测，全部检测通过时返回 200，否则返回 503
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+func Healthz(registry *health.Registry) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        report := registry.Run(ctx.Request.Context())
+
+        code := http.StatusOK
+        if !report.Up() {
+            code = http.StatusServiceUnavailable
+        }
+
+        ctx.JSON(code, report)
+    }
+}
+
+/**
+ * 就绪检测，服务未就绪或者正在关闭时直接返回 503
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+func Readyz(registry *health.Registry, ready func() bool) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        if ready != nil && !ready() {
+            ctx.JSON(http.StatusServiceUnavailable, health.Report{
+                Status: health.StatusDown,
+                Checks: []health.Result{
+                    {
+                        Name:   "server",
+                        Status: health.StatusDown,
+                        Error:  "server is not ready",
+                    },
+                },
+            })
+            return
+        }
+
+        Healthz(registry)(ctx)
+    }
+}
+
+/**
+ * prometheus 指标，token 不为空时需要使用 Authorization: Bearer token 访问
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+func Metrics(registry *metrics.Registry, token string) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        if token != "" {
+            auth := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+            if subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+                ctx.AbortWithStatus(http.StatusUnauthorized)
+                return
+            }
+        }
+
+        ctx.Header("Content-Type", metrics.ContentType)
+        ctx.Status(http.StatusOK)
+
+        registry.WriteText(ctx.Writer)
+    }
+}
@@ -87,9 +87,9 @@ func (this *Response) ReturnJsonFromString(jsonStr string) {
     this.ctx.String(this.httpCode, jsonStr)
 }
 
-// 渲染模板
+// 渲染模板，会合并中间件添加的视图共享数据
 func (this *Response) View(template string, obj any) {
-    this.ctx.HTML(this.httpCode, template, obj)
+    this.ctx.HTML(this.httpCode, template, router.MergeViewData(this.ctx, obj))
 }
 
 // 下载
@@ -0,0 +1,79 @@
+package logger
+
+import (
+    "sync"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 通道驱动获取方法
+type ChannelResolver = func(name string) (interfaces.Driver, error)
+
+// 构造方法
+func NewChannels(resolver ChannelResolver) *Channels {
+    return &Channels{
+        resolver: resolver,
+        loggers:  make(map[string]*Logger),
+    }
+}
+
+/**
+ * 日志通道，每个通道使用单独的驱动及输出
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+type Channels struct {
+    mu sync.Mutex
+
+    // 驱动获取方法
+    resolver ChannelResolver
+
+    // 已生成的通道日志
+    loggers map[string]*Logger
+}
+
+// 获取通道日志，生成后会缓存
+func (this *Channels) Get(name string) (*Logger, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if log, ok := this.loggers[name]; ok {
+        return log, nil
+    }
+
+    driver, err := this.resolver(name)
+    if err != nil {
+        return nil, err
+    }
+
+    log := New(driver).WithChannels(this)
+    this.loggers[name] = log
+
+    return log, nil
+}
+
+// 设置通道日志
+func (this *Channels) Set(name string, log *Logger) *Channels {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.loggers[name] = log.WithChannels(this)
+
+    return this
+}
+
+// 清除通道缓存，配置更改后重新生成
+func (this *Channels) Forget(name ...string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if len(name) == 0 {
+        this.loggers = make(map[string]*Logger)
+        return
+    }
+
+    for _, n := range name {
+        delete(this.loggers, n)
+    }
+}
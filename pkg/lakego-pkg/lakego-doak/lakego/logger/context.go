@@ -0,0 +1,75 @@
+package logger
+
+import (
+    "sync"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 管理员 id 日志字段名称
+const FieldAdminID = "admin_id"
+
+// 上下文数据键名
+type adminIDKey struct{}
+
+// 上下文日志字段获取方法
+type ContextFieldsFunc = func(context.Context) map[string]any
+
+var (
+    contextFieldsMu sync.RWMutex
+
+    // 自定义上下文日志字段获取方法
+    contextFieldsFuncs []ContextFieldsFunc
+)
+
+// 添加上下文日志字段获取方法
+func AddContextFields(f ContextFieldsFunc) {
+    contextFieldsMu.Lock()
+    defer contextFieldsMu.Unlock()
+
+    contextFieldsFuncs = append(contextFieldsFuncs, f)
+}
+
+// 设置管理员 id
+func ContextWithAdminID(ctx context.Context, id any) context.Context {
+    return context.WithValue(ctx, adminIDKey{}, id)
+}
+
+// 获取管理员 id，兼容 gin.Context 中使用 admin_id 设置的数据
+func AdminIDFromContext(ctx context.Context) any {
+    if ctx == nil {
+        return nil
+    }
+
+    if id := ctx.Value(adminIDKey{}); id != nil {
+        return id
+    }
+
+    return ctx.Value(FieldAdminID)
+}
+
+// 上下文中的日志字段，包括请求 id、链路 id 及管理员 id
+func ContextFields(ctx context.Context) map[string]any {
+    if ctx == nil {
+        return map[string]any{}
+    }
+
+    fields := trace.Fields(ctx)
+
+    if id := AdminIDFromContext(ctx); id != nil && id != "" {
+        fields[FieldAdminID] = id
+    }
+
+    contextFieldsMu.RLock()
+    funcs := contextFieldsFuncs
+    contextFieldsMu.RUnlock()
+
+    for _, f := range funcs {
+        for k, v := range f(ctx) {
+            fields[k] = v
+        }
+    }
+
+    return fields
+}
@@ -1,20 +1,26 @@
 package logrus
 
 import (
-    "fmt"
-    "time"
-    logger "log"
+    "sync"
 
     "github.com/sirupsen/logrus"
-    "github.com/lestrrat/go-file-rotatelogs"
 
-    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/logger/sink"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
     "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter"
 )
 
 // 构造方法
 func New() *Logrus {
-    return &Logrus{}
+    return &Logrus{
+        state: &state{},
+    }
+}
+
+// 共用的日志对象，With 生成的驱动共用同一个输出
+type state struct {
+    once   sync.Once
+    logger *logrus.Logger
 }
 
 type (
@@ -37,6 +43,11 @@ type (
 type Logrus struct {
     // 配置
     Config map[string]any
+
+    // 自定义数据
+    fields Fields
+
+    state *state
 }
 
 // 设置配置
@@ -44,6 +55,23 @@ func (this *Logrus) WithConfig(config map[string]any) {
     this.Config = config
 }
 
+// 带自定义数据的驱动
+func (this *Logrus) With(fields map[string]any) interfaces.Driver {
+    data := make(Fields, len(this.fields) + len(fields))
+    for k, v := range this.fields {
+        data[k] = v
+    }
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return &Logrus{
+        Config: this.Config,
+        fields: data,
+        state:  this.state,
+    }
+}
+
 // 批量设置自定义变量
 func (this *Logrus) WithFields(fields map[string]any) any {
     data := make(Fields, len(fields))
@@ -51,95 +79,95 @@ func (this *Logrus) WithFields(fields map[string]any) any {
         data[k] = v
     }
 
-    return this.getLogger().WithFields(data)
+    return this.entry().WithFields(data)
 }
 
 // 设置自定义变量
 // *logrus.Entry
 func (this *Logrus) WithField(key string, value any) any {
-    return this.getLogger().WithField(key, value)
+    return this.entry().WithField(key, value)
 }
 
 // ========
 
 func (this *Logrus) Trace(args ...any) {
-    this.getLogger().Trace(args...)
+    this.entry().Trace(args...)
 }
 
 func (this *Logrus) Debug(args ...any) {
-    this.getLogger().Debug(args...)
+    this.entry().Debug(args...)
 }
 
 func (this *Logrus) Info(args ...any) {
-    this.getLogger().Info(args...)
+    this.entry().Info(args...)
 }
 
 func (this *Logrus) Warn(args ...any) {
-    this.getLogger().Warn(args...)
+    this.entry().Warn(args...)
 }
 
 func (this *Logrus) Warning(args ...any) {
-    this.getLogger().Warning(args...)
+    this.entry().Warning(args...)
 }
 
 func (this *Logrus) Error(args ...any) {
-    this.getLogger().Error(args...)
+    this.entry().Error(args...)
 }
 
 func (this *Logrus) Fatal(args ...any) {
-    this.getLogger().Fatal(args...)
+    this.entry().Fatal(args...)
 }
 
 func (this *Logrus) Panic(args ...any) {
-    this.getLogger().Panic(args...)
+    this.entry().Panic(args...)
 }
 
 // ========
 
 func (this *Logrus) Tracef(template string, args ...any) {
-    this.getLogger().Tracef(template, args...)
+    this.entry().Tracef(template, args...)
 }
 
 func (this *Logrus) Debugf(template string, args ...any) {
-    this.getLogger().Debugf(template, args...)
+    this.entry().Debugf(template, args...)
 }
 
 func (this *Logrus) Infof(template string, args ...any) {
-    this.getLogger().Infof(template, args...)
+    this.entry().Infof(template, args...)
 }
 
 func (this *Logrus) Warnf(template string, args ...any) {
-    this.getLogger().Warnf(template, args...)
+    this.entry().Warnf(template, args...)
 }
 
 func (this *Logrus) Warningf(template string, args ...any) {
-    this.getLogger().Warningf(template, args...)
+    this.entry().Warningf(template, args...)
 }
 
 func (this *Logrus) Errorf(template string, args ...any) {
-    this.getLogger().Errorf(template, args...)
+    this.entry().Errorf(template, args...)
 }
 
 func (this *Logrus) Fatalf(template string, args ...any) {
-    this.getLogger().Fatalf(template, args...)
+    this.entry().Fatalf(template, args...)
 }
 
 func (this *Logrus) Panicf(template string, args ...any) {
-    this.getLogger().Panicf(template, args...)
+    this.entry().Panicf(template, args...)
 }
 
 // ========
 
 func (this *Logrus) Traceln(args ...any) {
-    this.getLogger().Traceln(args...)
+    this.entry().Traceln(args...)
 }
 
 func (this *Logrus) Debugln(args ...any) {
-    this.getLogger().Debugln(args...)
+    this.entry().Debugln(args...)
 }
 
 func (this *Logrus) Infoln(args ...any) {
-    this.getLogger().Infoln(args...)
+    this.entry().Infoln(args...)
 }
 
 func (this *Logrus) Println(args ...any) {
@@ -147,23 +175,23 @@ func (this *Logrus) Println(args ...any) {
 }
 
 func (this *Logrus) Warnln(args ...any) {
-    this.getLogger().Warnln(args...)
+    this.entry().Warnln(args...)
 }
 
 func (this *Logrus) Warningln(args ...any) {
-    this.getLogger().Warningln(args...)
+    this.entry().Warningln(args...)
 }
 
 func (this *Logrus) Errorln(args ...any) {
-    this.getLogger().Errorln(args...)
+    this.entry().Errorln(args...)
 }
 
 func (this *Logrus) Fatalln(args ...any) {
-    this.getLogger().Fatalln(args...)
+    this.entry().Fatalln(args...)
 }
 
 func (this *Logrus) Panicln(args ...any) {
-    this.getLogger().Panicln(args...)
+    this.entry().Panicln(args...)
 }
 
 // ========
@@ -215,8 +243,22 @@ func (this *Logrus) GetLevel() logrus.Level {
     return this.getLogger().GetLevel()
 }
 
-// 设置
+// 带自定义数据的日志
+func (this *Logrus) entry() *Entry {
+    return this.getLogger().WithFields(this.fields)
+}
+
+// 获取日志对象
 func (this *Logrus) getLogger() *logrus.Logger {
+    this.state.once.Do(func() {
+        this.state.logger = this.newLogger()
+    })
+
+    return this.state.logger
+}
+
+// 根据配置生成日志对象
+func (this *Logrus) newLogger() *logrus.Logger {
     // 配置
     conf := this.Config
 
@@ -244,30 +286,8 @@ func (this *Logrus) getLogger() *logrus.Logger {
     // 设置输出样式
     log.SetFormatter(useFormatter)
 
-    // 日志目录
-    filepath := conf["filepath"].(string)
-
-    // 日志文件
-    // log_%Y%m%d.log
-    logPath := path.FormatPath(filepath)
-
-    maxAge := time.Duration(int64(conf["max-age"].(int)))
-    rotationTime := time.Duration(int64(conf["rotation-time"].(int)))
-
-    writer, err := rotatelogs.New(
-        logPath,
-        // rotatelogs.WithLinkName(baseLogPath), // 生成软链，指向最新日志文件
-        rotatelogs.WithMaxAge(maxAge * time.Hour), // 文件最大保存时间
-        rotatelogs.WithRotationTime(rotationTime * time.Hour), // 日志切割时间间隔
-    )
-    if err != nil {
-        logger.Print(fmt.Sprintf("日志配置错误：%v", err))
-    }
-
-    // os.Stdout || os.Stderr
-    // 设置output,默认为stderr,可以为任何io.Writer，比如文件*os.File
-    // file, _ := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
-    log.SetOutput(writer)
+    // 日志输出，默认为文件
+    log.SetOutput(sink.New(conf))
 
     // 日志等级
     level := conf["level"].(string)
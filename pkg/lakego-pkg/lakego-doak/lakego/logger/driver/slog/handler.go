@@ -0,0 +1,229 @@
+package slog
+
+import (
+    "io"
+    "fmt"
+    "sync"
+    "bytes"
+    "context"
+    "strings"
+    "log/slog"
+)
+
+// 自定义日志等级
+const (
+    LevelTrace = slog.Level(-8)
+    LevelDebug = slog.LevelDebug
+    LevelInfo  = slog.LevelInfo
+    LevelWarn  = slog.LevelWarn
+    LevelError = slog.LevelError
+    LevelFatal = slog.Level(12)
+    LevelPanic = slog.Level(16)
+)
+
+// 日志等级名称
+func LevelName(level slog.Level) string {
+    switch {
+        case level < LevelDebug:
+            return "TRACE"
+        case level < LevelInfo:
+            return "DEBUG"
+        case level < LevelWarn:
+            return "INFO"
+        case level < LevelError:
+            return "WARNING"
+        case level < LevelFatal:
+            return "ERROR"
+        case level < LevelPanic:
+            return "FATAL"
+    }
+
+    return "PANIC"
+}
+
+// 解析日志等级，和 logrus 驱动的配置一致
+func ParseLevel(level string) slog.Level {
+    switch strings.ToLower(level) {
+        case "panic":
+            return LevelPanic
+        case "fatal":
+            return LevelFatal
+        case "error":
+            return LevelError
+        case "warn", "warning":
+            return LevelWarn
+        case "info":
+            return LevelInfo
+        case "debug":
+            return LevelDebug
+    }
+
+    return LevelTrace
+}
+
+// 替换等级名称
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+    if a.Key == slog.LevelKey && len(groups) == 0 {
+        if level, ok := a.Value.Any().(slog.Level); ok {
+            a.Value = slog.StringValue(LevelName(level))
+        }
+    }
+
+    return a
+}
+
+// 生成处理器
+// formatter 可选 json, logfmt, console
+func NewHandler(w io.Writer, formatter string, opts *slog.HandlerOptions) slog.Handler {
+    if opts == nil {
+        opts = &slog.HandlerOptions{}
+    }
+
+    opts.ReplaceAttr = replaceLevel
+
+    switch formatter {
+        case "json":
+            return slog.NewJSONHandler(w, opts)
+        case "logfmt", "text":
+            return slog.NewTextHandler(w, opts)
+    }
+
+    return NewConsoleHandler(w, opts)
+}
+
+// 控制台处理器
+func NewConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *ConsoleHandler {
+    if opts == nil {
+        opts = &slog.HandlerOptions{}
+    }
+
+    return &ConsoleHandler{
+        w:    w,
+        mu:   &sync.Mutex{},
+        opts: opts,
+    }
+}
+
+/**
+ * 控制台格式
+ * 格式为：[2024-05-27 10:00:00] [INFO] 日志信息 key=value
+ *
+ * @create 2024-5-27
+ * @author deatil
+ */
+type ConsoleHandler struct {
+    w    io.Writer
+    mu   *sync.Mutex
+    opts *slog.HandlerOptions
+
+    // 已添加数据，分组已包含在键名中
+    attrs []slog.Attr
+
+    // 当前分组前缀
+    group string
+}
+
+func (this *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+    min := slog.LevelInfo
+    if this.opts.Level != nil {
+        min = this.opts.Level.Level()
+    }
+
+    return level >= min
+}
+
+func (this *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+    var buf bytes.Buffer
+
+    buf.WriteString("[" + r.Time.Format("2006-01-02 15:04:05") + "] ")
+    buf.WriteString("[" + LevelName(r.Level) + "] ")
+    buf.WriteString(r.Message)
+
+    for _, a := range this.attrs {
+        writeAttr(&buf, "", a)
+    }
+
+    r.Attrs(func(a slog.Attr) bool {
+        writeAttr(&buf, this.group, a)
+        return true
+    })
+
+    if this.opts.AddSource && r.PC != 0 {
+        source := sourceFrom(r.PC)
+        if source != nil {
+            buf.WriteString(fmt.Sprintf(" source=%s:%d", source.File, source.Line))
+        }
+    }
+
+    buf.WriteByte('\n')
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    _, err := this.w.Write(buf.Bytes())
+    return err
+}
+
+func (this *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+    h := this.clone()
+    for _, a := range attrs {
+        if this.group != "" {
+            a.Key = this.group + a.Key
+        }
+
+        h.attrs = append(h.attrs, a)
+    }
+
+    return h
+}
+
+func (this *ConsoleHandler) WithGroup(name string) slog.Handler {
+    if name == "" {
+        return this
+    }
+
+    h := this.clone()
+    h.group = this.group + name + "."
+
+    return h
+}
+
+func (this *ConsoleHandler) clone() *ConsoleHandler {
+    attrs := make([]slog.Attr, len(this.attrs))
+    copy(attrs, this.attrs)
+
+    return &ConsoleHandler{
+        w:     this.w,
+        mu:    this.mu,
+        opts:  this.opts,
+        attrs: attrs,
+        group: this.group,
+    }
+}
+
+// 写入数据，分组数据展开为 group.key=value
+func writeAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+    a.Value = a.Value.Resolve()
+    if a.Equal(slog.Attr{}) {
+        return
+    }
+
+    if a.Value.Kind() == slog.KindGroup {
+        if a.Key != "" {
+            prefix += a.Key + "."
+        }
+
+        for _, ga := range a.Value.Group() {
+            writeAttr(buf, prefix, ga)
+        }
+
+        return
+    }
+
+    value := a.Value.String()
+    if strings.ContainsAny(value, " \t\n\"=") {
+        value = fmt.Sprintf("%q", value)
+    }
+
+    buf.WriteString(" " + prefix + a.Key + "=" + value)
+}
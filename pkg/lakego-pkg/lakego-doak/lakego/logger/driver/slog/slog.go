@@ -0,0 +1,259 @@
+package slog
+
+import (
+    "os"
+    "fmt"
+    "sync"
+    "time"
+    "context"
+    "runtime"
+    "strings"
+    "log/slog"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/logger/sink"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 构造方法
+func New() *Slog {
+    return &Slog{
+        state: &state{},
+    }
+}
+
+// 共用的日志对象，With 生成的驱动共用同一个输出
+type state struct {
+    once    sync.Once
+    handler slog.Handler
+    level   slog.Level
+}
+
+/**
+ * 日志 slog 驱动
+ *
+ * @create 2024-5-27
+ * @author deatil
+ */
+type Slog struct {
+    // 配置
+    Config map[string]any
+
+    // 自定义数据
+    attrs []any
+
+    state *state
+}
+
+// 设置配置
+func (this *Slog) WithConfig(config map[string]any) {
+    this.Config = config
+}
+
+// 设置处理器，设置后不再使用配置生成
+func (this *Slog) WithHandler(handler slog.Handler, level slog.Level) {
+    this.state.once.Do(func() {
+        this.state.handler = handler
+        this.state.level = level
+    })
+}
+
+// 带自定义数据的驱动
+func (this *Slog) With(fields map[string]any) interfaces.Driver {
+    attrs := make([]any, 0, len(this.attrs) + len(fields) * 2)
+    attrs = append(attrs, this.attrs...)
+    for k, v := range fields {
+        attrs = append(attrs, k, v)
+    }
+
+    return &Slog{
+        Config: this.Config,
+        attrs:  attrs,
+        state:  this.state,
+    }
+}
+
+// 批量设置自定义变量
+// *slog.Logger
+func (this *Slog) WithFields(fields map[string]any) any {
+    attrs := make([]any, 0, len(fields) * 2)
+    for k, v := range fields {
+        attrs = append(attrs, k, v)
+    }
+
+    return this.GetLogger().With(attrs...)
+}
+
+// 设置自定义变量
+// *slog.Logger
+func (this *Slog) WithField(key string, value any) any {
+    return this.GetLogger().With(key, value)
+}
+
+// ========
+
+func (this *Slog) Trace(args ...any) {
+    this.log(LevelTrace, fmt.Sprint(args...))
+}
+
+func (this *Slog) Debug(args ...any) {
+    this.log(LevelDebug, fmt.Sprint(args...))
+}
+
+func (this *Slog) Info(args ...any) {
+    this.log(LevelInfo, fmt.Sprint(args...))
+}
+
+func (this *Slog) Warn(args ...any) {
+    this.log(LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Slog) Warning(args ...any) {
+    this.log(LevelWarn, fmt.Sprint(args...))
+}
+
+func (this *Slog) Error(args ...any) {
+    this.log(LevelError, fmt.Sprint(args...))
+}
+
+func (this *Slog) Fatal(args ...any) {
+    this.log(LevelFatal, fmt.Sprint(args...))
+    os.Exit(1)
+}
+
+func (this *Slog) Panic(args ...any) {
+    msg := fmt.Sprint(args...)
+
+    this.log(LevelPanic, msg)
+    panic(msg)
+}
+
+// ========
+
+func (this *Slog) Tracef(template string, args ...any) {
+    this.log(LevelTrace, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Debugf(template string, args ...any) {
+    this.log(LevelDebug, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Infof(template string, args ...any) {
+    this.log(LevelInfo, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Warnf(template string, args ...any) {
+    this.log(LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Warningf(template string, args ...any) {
+    this.log(LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Errorf(template string, args ...any) {
+    this.log(LevelError, fmt.Sprintf(template, args...))
+}
+
+func (this *Slog) Fatalf(template string, args ...any) {
+    this.log(LevelFatal, fmt.Sprintf(template, args...))
+    os.Exit(1)
+}
+
+func (this *Slog) Panicf(template string, args ...any) {
+    msg := fmt.Sprintf(template, args...)
+
+    this.log(LevelPanic, msg)
+    panic(msg)
+}
+
+// ========
+
+// 获取带自定义数据的 slog 日志
+func (this *Slog) GetLogger() *slog.Logger {
+    return slog.New(this.getHandler()).With(this.attrs...)
+}
+
+// 获取等级
+func (this *Slog) GetLevel() slog.Level {
+    this.getHandler()
+
+    return this.state.level
+}
+
+// 记录日志，来源为调用日志包之外的位置
+func (this *Slog) log(level slog.Level, msg string) {
+    ctx := context.Background()
+
+    handler := this.getHandler()
+    if !handler.Enabled(ctx, level) {
+        return
+    }
+
+    r := slog.NewRecord(time.Now(), level, msg, callerPC())
+    r.Add(this.attrs...)
+
+    handler.Handle(ctx, r)
+}
+
+// 获取处理器
+func (this *Slog) getHandler() slog.Handler {
+    this.state.once.Do(func() {
+        this.state.handler, this.state.level = this.newHandler()
+    })
+
+    return this.state.handler
+}
+
+// 根据配置生成处理器
+func (this *Slog) newHandler() (slog.Handler, slog.Level) {
+    conf := this.Config
+
+    level := ParseLevel(goch.ToString(conf["level"]))
+
+    opts := &slog.HandlerOptions{
+        Level:     level,
+        AddSource: goch.ToBool(conf["add-source"]),
+    }
+
+    formatter := goch.ToString(conf["formatter"])
+
+    return NewHandler(sink.New(conf), formatter, opts), level
+}
+
+// 调用日志的位置，跳过日志包内的调用
+func callerPC() uintptr {
+    var pcs [16]uintptr
+    n := runtime.Callers(3, pcs[:])
+
+    frames := runtime.CallersFrames(pcs[:n])
+    for {
+        frame, more := frames.Next()
+        if !strings.Contains(frame.Function, "lakego/logger") &&
+            !strings.Contains(frame.Function, "lakego/facade/logger") {
+            // frame.PC 为调用指令位置，slog 使用返回地址
+            return frame.PC + 1
+        }
+
+        if !more {
+            break
+        }
+    }
+
+    return pcs[0]
+}
+
+// 来源位置
+func sourceFrom(pc uintptr) *slog.Source {
+    frames := runtime.CallersFrames([]uintptr{pc})
+    frame, _ := frames.Next()
+    if frame.File == "" {
+        return nil
+    }
+
+    return &slog.Source{
+        Function: frame.Function,
+        File:     frame.File,
+        Line:     frame.Line,
+    }
+}
@@ -0,0 +1,75 @@
+package slog
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "log/slog"
+    "encoding/json"
+)
+
+func newTestSlog(formatter string, level string) (*Slog, *bytes.Buffer) {
+    buf := &bytes.Buffer{}
+
+    l := ParseLevel(level)
+
+    s := New()
+    s.WithHandler(NewHandler(buf, formatter, &slog.HandlerOptions{Level: l}), l)
+
+    return s, buf
+}
+
+func Test_JSON(t *testing.T) {
+    s, buf := newTestSlog("json", "info")
+
+    s.With(map[string]any{"request_id": "req-1"}).Infof("hello %s", "lakego")
+
+    var data map[string]any
+    if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+        t.Fatal(err)
+    }
+
+    if data["msg"] != "hello lakego" || data["level"] != "INFO" || data["request_id"] != "req-1" {
+        t.Errorf("JSON got %v", data)
+    }
+}
+
+func Test_Level(t *testing.T) {
+    s, buf := newTestSlog("logfmt", "info")
+
+    s.Debug("debug")
+    if buf.Len() != 0 {
+        t.Errorf("Debug should be skipped, got %s", buf.String())
+    }
+
+    s.Warning("warning")
+    if !strings.Contains(buf.String(), "level=WARNING") || !strings.Contains(buf.String(), "msg=warning") {
+        t.Errorf("logfmt got %s", buf.String())
+    }
+}
+
+func Test_Console(t *testing.T) {
+    s, buf := newTestSlog("console", "trace")
+
+    s.With(map[string]any{"user": "lake go"}).Trace("trace")
+
+    out := buf.String()
+    if !strings.Contains(out, "[TRACE] trace") || !strings.Contains(out, `user="lake go"`) {
+        t.Errorf("console got %s", out)
+    }
+}
+
+func Test_Panic(t *testing.T) {
+    s, buf := newTestSlog("json", "info")
+
+    defer func() {
+        if r := recover(); r != "panic test" {
+            t.Errorf("Panic got %v", r)
+        }
+        if !strings.Contains(buf.String(), `"level":"PANIC"`) {
+            t.Errorf("Panic log got %s", buf.String())
+        }
+    }()
+
+    s.Panic("panic test")
+}
@@ -0,0 +1,162 @@
+package stack
+
+import (
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 构造方法
+func New(drivers ...interfaces.Driver) *Stack {
+    return &Stack{
+        drivers: drivers,
+    }
+}
+
+/**
+ * 日志 stack 驱动，同时写入多个驱动
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+type Stack struct {
+    drivers []interfaces.Driver
+}
+
+// 获取驱动列表
+func (this *Stack) GetDrivers() []interfaces.Driver {
+    return this.drivers
+}
+
+// 带自定义数据的驱动
+func (this *Stack) With(fields map[string]any) interfaces.Driver {
+    drivers := make([]interfaces.Driver, 0, len(this.drivers))
+    for _, d := range this.drivers {
+        drivers = append(drivers, d.With(fields))
+    }
+
+    return New(drivers...)
+}
+
+// 批量设置自定义变量
+// *stack.Stack
+func (this *Stack) WithFields(fields map[string]any) any {
+    return this.With(fields)
+}
+
+// 设置自定义变量
+// *stack.Stack
+func (this *Stack) WithField(key string, value any) any {
+    return this.With(map[string]any{
+        key: value,
+    })
+}
+
+// ========
+
+func (this *Stack) Trace(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Trace(args...) })
+}
+
+func (this *Stack) Debug(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Debug(args...) })
+}
+
+func (this *Stack) Info(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Info(args...) })
+}
+
+func (this *Stack) Warn(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Warn(args...) })
+}
+
+func (this *Stack) Warning(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Warning(args...) })
+}
+
+func (this *Stack) Error(args ...any) {
+    this.each(func(d interfaces.Driver) { d.Error(args...) })
+}
+
+// 前面的驱动使用 Error 记录，最后一个驱动记录后退出
+func (this *Stack) Fatal(args ...any) {
+    this.last(
+        func(d interfaces.Driver) { d.Error(args...) },
+        func(d interfaces.Driver) { d.Fatal(args...) },
+    )
+}
+
+// 全部驱动记录后 panic
+func (this *Stack) Panic(args ...any) {
+    this.panic(fmt.Sprint(args...), func(d interfaces.Driver) { d.Panic(args...) })
+}
+
+// ========
+
+func (this *Stack) Tracef(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Tracef(template, args...) })
+}
+
+func (this *Stack) Debugf(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Debugf(template, args...) })
+}
+
+func (this *Stack) Infof(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Infof(template, args...) })
+}
+
+func (this *Stack) Warnf(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Warnf(template, args...) })
+}
+
+func (this *Stack) Warningf(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Warningf(template, args...) })
+}
+
+func (this *Stack) Errorf(template string, args ...any) {
+    this.each(func(d interfaces.Driver) { d.Errorf(template, args...) })
+}
+
+func (this *Stack) Fatalf(template string, args ...any) {
+    this.last(
+        func(d interfaces.Driver) { d.Errorf(template, args...) },
+        func(d interfaces.Driver) { d.Fatalf(template, args...) },
+    )
+}
+
+func (this *Stack) Panicf(template string, args ...any) {
+    this.panic(fmt.Sprintf(template, args...), func(d interfaces.Driver) { d.Panicf(template, args...) })
+}
+
+// ========
+
+func (this *Stack) each(f func(interfaces.Driver)) {
+    for _, d := range this.drivers {
+        f(d)
+    }
+}
+
+func (this *Stack) last(f func(interfaces.Driver), last func(interfaces.Driver)) {
+    for i, d := range this.drivers {
+        if i == len(this.drivers) - 1 {
+            last(d)
+        } else {
+            f(d)
+        }
+    }
+}
+
+// 每个驱动 panic 后继续记录，最后统一 panic
+func (this *Stack) panic(msg string, f func(interfaces.Driver)) {
+    for _, d := range this.drivers {
+        func() {
+            defer func() {
+                recover()
+            }()
+
+            f(d)
+        }()
+    }
+
+    panic(msg)
+}
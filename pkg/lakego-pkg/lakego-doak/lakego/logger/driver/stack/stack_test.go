@@ -0,0 +1,51 @@
+package stack
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "log/slog"
+
+    slogDriver "github.com/deatil/lakego-doak/lakego/logger/driver/slog"
+)
+
+func newDriver(buf *bytes.Buffer) *slogDriver.Slog {
+    d := slogDriver.New()
+    d.WithHandler(slogDriver.NewHandler(buf, "logfmt", &slog.HandlerOptions{}), slogDriver.LevelInfo)
+
+    return d
+}
+
+func Test_Stack(t *testing.T) {
+    buf1 := &bytes.Buffer{}
+    buf2 := &bytes.Buffer{}
+
+    s := New(newDriver(buf1), newDriver(buf2))
+
+    s.With(map[string]any{"channel": "auth"}).Info("login")
+
+    for _, buf := range []*bytes.Buffer{buf1, buf2} {
+        if !strings.Contains(buf.String(), "msg=login") || !strings.Contains(buf.String(), "channel=auth") {
+            t.Errorf("Stack got %q", buf.String())
+        }
+    }
+}
+
+func Test_StackPanic(t *testing.T) {
+    buf1 := &bytes.Buffer{}
+    buf2 := &bytes.Buffer{}
+
+    s := New(newDriver(buf1), newDriver(buf2))
+
+    defer func() {
+        if r := recover(); r != "panic test" {
+            t.Errorf("Panic got %v", r)
+        }
+
+        if !strings.Contains(buf2.String(), "level=PANIC") {
+            t.Errorf("Panic log got %q", buf2.String())
+        }
+    }()
+
+    s.Panic("panic test")
+}
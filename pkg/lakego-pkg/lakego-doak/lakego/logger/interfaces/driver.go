@@ -7,10 +7,13 @@ package interfaces
  * @author deatil
  */
 type Driver interface {
-    // 自定义数据
+    // 带自定义数据的驱动，不修改当前驱动
+    With(map[string]any) Driver
+
+    // 自定义数据，返回驱动使用的原始日志对象
     WithField(string, any) any
 
-    // 自定义数据
+    // 自定义数据，返回驱动使用的原始日志对象
     WithFields(map[string]any) any
 
     // ======
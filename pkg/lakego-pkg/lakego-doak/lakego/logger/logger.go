@@ -1,6 +1,10 @@
 package logger
 
 import (
+    "fmt"
+    "log"
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
 )
 
@@ -25,6 +29,9 @@ type Fields map[string]any
 type Logger struct {
     // 日志驱动
     Driver interfaces.Driver
+
+    // 日志通道
+    channels *Channels
 }
 
 // 设置驱动
@@ -39,16 +46,94 @@ func (this *Logger) GetDriver() interfaces.Driver {
     return this.Driver
 }
 
-// 批量设置自定义变量
+// 设置日志通道
+func (this *Logger) WithChannels(channels *Channels) *Logger {
+    this.channels = channels
+
+    return this
+}
+
+// 获取日志通道
+func (this *Logger) GetChannels() *Channels {
+    return this.channels
+}
+
+// 使用指定通道记录日志，通道不存在时使用当前日志
+func (this *Logger) Channel(name string) *Logger {
+    if this.channels == nil {
+        log.Print("日志通道[" + name + "]不存在")
+        return this
+    }
+
+    l, err := this.channels.Get(name)
+    if err != nil {
+        log.Print(err.Error())
+        return this
+    }
+
+    return l
+}
+
+// 带自定义数据的日志，不修改当前日志
+// 参数可以为 map[string]any、Fields 或者键值对，比如 With("system", "lakego", "id", 1)
+func (this *Logger) With(fields ...any) *Logger {
+    data := FieldsFrom(fields...)
+    if len(data) == 0 {
+        return this
+    }
+
+    return New(this.Driver.With(data)).WithChannels(this.channels)
+}
+
+// 批量设置自定义变量，返回驱动使用的原始日志对象
 func (this *Logger) WithFields(fields map[string]any) any {
     return this.Driver.WithFields(fields)
 }
 
-// 设置自定义变量
+// 设置自定义变量，返回驱动使用的原始日志对象
 func (this *Logger) WithField(key string, value any) any {
     return this.Driver.WithField(key, value)
 }
 
+// 带上下文中请求 id、链路 id 及管理员 id 的日志
+func (this *Logger) WithContext(ctx context.Context) *Logger {
+    return this.With(ContextFields(ctx))
+}
+
+// ========
+
+func (this *Logger) TraceContext(ctx context.Context, args ...any) {
+    this.WithContext(ctx).Trace(args...)
+}
+
+func (this *Logger) DebugContext(ctx context.Context, args ...any) {
+    this.WithContext(ctx).Debug(args...)
+}
+
+func (this *Logger) InfoContext(ctx context.Context, args ...any) {
+    this.WithContext(ctx).Info(args...)
+}
+
+func (this *Logger) WarnContext(ctx context.Context, args ...any) {
+    this.WithContext(ctx).Warn(args...)
+}
+
+func (this *Logger) ErrorContext(ctx context.Context, args ...any) {
+    this.WithContext(ctx).Error(args...)
+}
+
+func (this *Logger) InfofContext(ctx context.Context, template string, args ...any) {
+    this.WithContext(ctx).Infof(template, args...)
+}
+
+func (this *Logger) WarnfContext(ctx context.Context, template string, args ...any) {
+    this.WithContext(ctx).Warnf(template, args...)
+}
+
+func (this *Logger) ErrorfContext(ctx context.Context, template string, args ...any) {
+    this.WithContext(ctx).Errorf(template, args...)
+}
+
 // ========
 
 func (this *Logger) Trace(args ...any) {
@@ -116,3 +201,35 @@ func (this *Logger) Fatalf(template string, args ...any) {
 func (this *Logger) Panicf(template string, args ...any) {
     this.Driver.Panicf(template, args...)
 }
+
+// 整理自定义数据，键值对中的键不是字符串时使用 fmt 格式化
+func FieldsFrom(fields ...any) map[string]any {
+    data := make(map[string]any)
+
+    for i := 0; i < len(fields); i++ {
+        switch f := fields[i].(type) {
+            case map[string]any:
+                for k, v := range f {
+                    data[k] = v
+                }
+            case Fields:
+                for k, v := range f {
+                    data[k] = v
+                }
+            default:
+                key, ok := f.(string)
+                if !ok {
+                    key = fmt.Sprint(f)
+                }
+
+                if i + 1 < len(fields) {
+                    data[key] = fields[i + 1]
+                    i++
+                } else {
+                    data[key] = nil
+                }
+        }
+    }
+
+    return data
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+    "errors"
+    "testing"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 测试驱动
+type testDriver struct {
+    fields map[string]any
+    logs   *[]map[string]any
+}
+
+func (this *testDriver) With(fields map[string]any) interfaces.Driver {
+    data := make(map[string]any)
+    for k, v := range this.fields {
+        data[k] = v
+    }
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return &testDriver{fields: data, logs: this.logs}
+}
+
+func (this *testDriver) WithField(key string, value any) any {
+    return this.With(map[string]any{key: value})
+}
+
+func (this *testDriver) WithFields(fields map[string]any) any {
+    return this.With(fields)
+}
+
+func (this *testDriver) log(msg string) {
+    data := map[string]any{"msg": msg}
+    for k, v := range this.fields {
+        data[k] = v
+    }
+
+    *this.logs = append(*this.logs, data)
+}
+
+func (this *testDriver) Trace(args ...any)   { this.log("trace") }
+func (this *testDriver) Debug(args ...any)   { this.log("debug") }
+func (this *testDriver) Info(args ...any)    { this.log("info") }
+func (this *testDriver) Warn(args ...any)    { this.log("warn") }
+func (this *testDriver) Warning(args ...any) { this.log("warning") }
+func (this *testDriver) Error(args ...any)   { this.log("error") }
+func (this *testDriver) Fatal(args ...any)   { this.log("fatal") }
+func (this *testDriver) Panic(args ...any)   { this.log("panic") }
+
+func (this *testDriver) Tracef(string, ...any)   { this.log("trace") }
+func (this *testDriver) Debugf(string, ...any)   { this.log("debug") }
+func (this *testDriver) Infof(string, ...any)    { this.log("info") }
+func (this *testDriver) Warnf(string, ...any)    { this.log("warn") }
+func (this *testDriver) Warningf(string, ...any) { this.log("warning") }
+func (this *testDriver) Errorf(string, ...any)   { this.log("error") }
+func (this *testDriver) Fatalf(string, ...any)   { this.log("fatal") }
+func (this *testDriver) Panicf(string, ...any)   { this.log("panic") }
+
+func Test_With(t *testing.T) {
+    logs := make([]map[string]any, 0)
+    log := New(&testDriver{logs: &logs})
+
+    child := log.With("system", "lakego", Fields{"id": 1}, "odd")
+    child.Info("test")
+    log.Info("test")
+
+    if len(logs) != 2 {
+        t.Fatalf("logs got %d", len(logs))
+    }
+
+    if logs[0]["system"] != "lakego" || logs[0]["id"] != 1 {
+        t.Errorf("With fields got %v", logs[0])
+    }
+    if _, ok := logs[0]["odd"]; !ok {
+        t.Errorf("With odd key not found, got %v", logs[0])
+    }
+
+    if _, ok := logs[1]["system"]; ok {
+        t.Errorf("With changed parent logger, got %v", logs[1])
+    }
+}
+
+func Test_InfoContext(t *testing.T) {
+    logs := make([]map[string]any, 0)
+    log := New(&testDriver{logs: &logs})
+
+    ctx := trace.ContextWithRequestID(context.Background(), "req-1")
+    ctx = ContextWithAdminID(ctx, "admin-1")
+
+    log.InfoContext(ctx, "test")
+
+    if logs[0][trace.FieldRequestID] != "req-1" || logs[0][FieldAdminID] != "admin-1" {
+        t.Errorf("InfoContext got %v", logs[0])
+    }
+
+    // gin.Context 使用字符串键名
+    ctx2 := context.WithValue(context.Background(), FieldAdminID, "admin-2")
+    if AdminIDFromContext(ctx2) != "admin-2" {
+        t.Errorf("AdminIDFromContext got %v", AdminIDFromContext(ctx2))
+    }
+}
+
+func Test_Channel(t *testing.T) {
+    logs := make(map[string]*[]map[string]any)
+
+    channels := NewChannels(func(name string) (interfaces.Driver, error) {
+        if name == "none" {
+            return nil, errors.New("none")
+        }
+
+        l := make([]map[string]any, 0)
+        logs[name] = &l
+
+        return &testDriver{logs: &l}, nil
+    })
+
+    defaults := make([]map[string]any, 0)
+    log := New(&testDriver{logs: &defaults}).WithChannels(channels)
+
+    log.Channel("auth").Info("login")
+    log.With("id", 1).Channel("auth").Info("login")
+    log.Channel("none").Info("default")
+
+    if len(*logs["auth"]) != 2 {
+        t.Errorf("Channel auth got %v", *logs["auth"])
+    }
+
+    if len(defaults) != 1 {
+        t.Errorf("Channel none should use default, got %v", defaults)
+    }
+}
@@ -0,0 +1,82 @@
+package sink
+
+import (
+    "io"
+    "sync"
+)
+
+// 异步输出
+func NewAsync(w io.Writer, name string, buffer int) *Async {
+    a := &Async{
+        Fallback: NewFallback(w, name),
+        ch:       make(chan []byte, buffer),
+        done:     make(chan struct{}),
+    }
+
+    go a.run()
+
+    return a
+}
+
+/**
+ * 异步输出，缓冲已满或者写入失败时写入 stderr，不会阻塞
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+type Async struct {
+    *Fallback
+
+    ch   chan []byte
+    done chan struct{}
+
+    mu     sync.RWMutex
+    closed bool
+}
+
+func (this *Async) Write(p []byte) (int, error) {
+    // 写入方可能会复用数据
+    data := make([]byte, len(p))
+    copy(data, p)
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.closed {
+        this.fallback.Write(data)
+        return len(p), nil
+    }
+
+    select {
+        case this.ch <- data:
+        default:
+            this.fallback.Write(data)
+    }
+
+    return len(p), nil
+}
+
+// 关闭，等待缓冲写入完成
+func (this *Async) Close() error {
+    this.mu.Lock()
+    if this.closed {
+        this.mu.Unlock()
+        return nil
+    }
+
+    this.closed = true
+    close(this.ch)
+    this.mu.Unlock()
+
+    <-this.done
+
+    return this.Fallback.Close()
+}
+
+func (this *Async) run() {
+    defer close(this.done)
+
+    for p := range this.ch {
+        this.Fallback.Write(p)
+    }
+}
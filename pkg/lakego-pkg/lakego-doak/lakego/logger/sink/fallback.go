@@ -0,0 +1,71 @@
+package sink
+
+import (
+    "io"
+    "os"
+    "sync"
+    "time"
+)
+
+// 错误信息最短输出间隔
+var reportInterval = time.Minute
+
+// 降级输出
+func NewFallback(w io.Writer, name string) *Fallback {
+    return &Fallback{
+        w:        w,
+        name:     name,
+        fallback: os.Stderr,
+    }
+}
+
+/**
+ * 降级输出，写入失败时写入 stderr，不返回错误
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+type Fallback struct {
+    w        io.Writer
+    name     string
+    fallback io.Writer
+
+    mu       sync.Mutex
+    reported time.Time
+}
+
+// 设置降级输出位置
+func (this *Fallback) WithFallback(w io.Writer) *Fallback {
+    this.fallback = w
+
+    return this
+}
+
+func (this *Fallback) Write(p []byte) (int, error) {
+    if _, err := this.w.Write(p); err != nil {
+        this.fail(err, p)
+    }
+
+    return len(p), nil
+}
+
+// 写入失败
+func (this *Fallback) fail(err error, p []byte) {
+    this.mu.Lock()
+    if time.Since(this.reported) >= reportInterval {
+        this.reported = time.Now()
+        report(this.name, err)
+    }
+    this.mu.Unlock()
+
+    this.fallback.Write(p)
+}
+
+// 关闭
+func (this *Fallback) Close() error {
+    if c, ok := this.w.(io.Closer); ok {
+        return c.Close()
+    }
+
+    return nil
+}
@@ -0,0 +1,153 @@
+package sink
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "time"
+    "errors"
+    "strings"
+    "path/filepath"
+
+    "github.com/deatil/go-goch/goch"
+    "github.com/lestrrat/go-file-rotatelogs"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+)
+
+// 输出类型
+const (
+    TypeFile   = "file"
+    TypeStdout = "stdout"
+    TypeStderr = "stderr"
+    TypeSyslog = "syslog"
+    TypeSocket = "socket"
+)
+
+// 默认异步缓冲数量
+const DefaultBuffer = 1024
+
+/**
+ * 日志输出
+ *
+ * sink 可选 file, stdout, stderr, syslog, socket，
+ * 没有设置 sink 时，filepath 为 stdout 或者 stderr 时输出到对应位置，其他为文件。
+ * 输出失败时写入 stderr，syslog 及 socket 异步写入，不会阻塞请求
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+func New(conf map[string]any) io.Writer {
+    w, err := Open(conf)
+    if err != nil {
+        report("", err)
+        return os.Stderr
+    }
+
+    return w
+}
+
+// 根据配置打开输出
+func Open(conf map[string]any) (io.Writer, error) {
+    typ := strings.ToLower(goch.ToString(conf["sink"]))
+    if typ == "" {
+        switch goch.ToString(conf["filepath"]) {
+            case TypeStdout:
+                typ = TypeStdout
+            case "", TypeStderr:
+                typ = TypeStderr
+            default:
+                typ = TypeFile
+        }
+    }
+
+    switch typ {
+        case TypeStdout:
+            return os.Stdout, nil
+        case TypeStderr:
+            return os.Stderr, nil
+        case TypeFile:
+            w, err := NewFile(
+                goch.ToString(conf["filepath"]),
+                time.Duration(goch.ToInt64(conf["max-age"])) * time.Hour,
+                time.Duration(goch.ToInt64(conf["rotation-time"])) * time.Hour,
+            )
+            if err != nil {
+                return nil, err
+            }
+
+            return NewFallback(w, typ), nil
+        case TypeSyslog:
+            w, err := NewSyslog(
+                goch.ToString(conf["network"]),
+                goch.ToString(conf["address"]),
+                goch.ToString(conf["tag"]),
+                goch.ToString(conf["facility"]),
+            )
+            if err != nil {
+                return nil, err
+            }
+
+            return NewAsync(w, typ, bufferSize(conf)), nil
+        case TypeSocket:
+            w, err := NewSocket(
+                goch.ToString(conf["network"]),
+                goch.ToString(conf["address"]),
+                time.Duration(goch.ToInt64(conf["timeout"])) * time.Second,
+            )
+            if err != nil {
+                return nil, err
+            }
+
+            return NewAsync(w, typ, bufferSize(conf)), nil
+    }
+
+    return nil, errors.New(fmt.Sprintf("日志输出类型[%s]不存在", typ))
+}
+
+// 文件输出，file 可以使用 {runtime} 等路径及 %Y%m%d 等时间格式
+func NewFile(file string, maxAge time.Duration, rotationTime time.Duration) (io.Writer, error) {
+    if file == "" {
+        return nil, errors.New("日志文件路径不能为空")
+    }
+
+    // 日志文件
+    // log_%Y%m%d.log
+    logPath := path.FormatPath(file)
+
+    // 目录不包含时间格式时先创建目录
+    if dir := filepath.Dir(logPath); !strings.Contains(dir, "%") {
+        if err := os.MkdirAll(dir, 0755); err != nil {
+            return nil, err
+        }
+    }
+
+    opts := make([]rotatelogs.Option, 0)
+    if maxAge > 0 {
+        opts = append(opts, rotatelogs.WithMaxAge(maxAge)) // 文件最大保存时间
+    }
+    if rotationTime > 0 {
+        opts = append(opts, rotatelogs.WithRotationTime(rotationTime)) // 日志切割时间间隔
+    }
+
+    return rotatelogs.New(logPath, opts...)
+}
+
+// 异步缓冲数量
+func bufferSize(conf map[string]any) int {
+    size := goch.ToInt(conf["buffer"])
+    if size <= 0 {
+        size = DefaultBuffer
+    }
+
+    return size
+}
+
+// 输出错误信息到 stderr
+func report(name string, err error) {
+    if name != "" {
+        name = "[" + name + "]"
+    }
+
+    fmt.Fprintf(os.Stderr, "日志输出%s错误：%v\n", name, err)
+}
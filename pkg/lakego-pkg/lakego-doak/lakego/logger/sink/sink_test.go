@@ -0,0 +1,145 @@
+package sink
+
+import (
+    "net"
+    "time"
+    "bytes"
+    "errors"
+    "testing"
+    "path/filepath"
+)
+
+func Test_SocketUDP(t *testing.T) {
+    conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+    if err != nil {
+        t.Skip(err)
+    }
+    defer conn.Close()
+
+    w, err := Open(map[string]any{
+        "sink":    "socket",
+        "network": "udp",
+        "address": conn.LocalAddr().String(),
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer w.(*Async).Close()
+
+    w.Write([]byte("udp log\n"))
+
+    conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+
+    buf := make([]byte, 64)
+    n, _, err := conn.ReadFrom(buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if string(buf[:n]) != "udp log\n" {
+        t.Errorf("udp got %q", buf[:n])
+    }
+}
+
+func Test_SocketUnixgram(t *testing.T) {
+    addr := filepath.Join(t.TempDir(), "log.sock")
+
+    conn, err := net.ListenPacket("unixgram", addr)
+    if err != nil {
+        t.Skip(err)
+    }
+    defer conn.Close()
+
+    s, err := NewSocket("unixgram", addr, 0)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer s.Close()
+
+    if _, err := s.Write([]byte("unix log")); err != nil {
+        t.Fatal(err)
+    }
+
+    conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+
+    buf := make([]byte, 64)
+    n, _, err := conn.ReadFrom(buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if string(buf[:n]) != "unix log" {
+        t.Errorf("unixgram got %q", buf[:n])
+    }
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+    return 0, errors.New("write error")
+}
+
+// 阻塞写入
+type blockWriter struct {
+    ch chan struct{}
+}
+
+func (this blockWriter) Write(p []byte) (int, error) {
+    <-this.ch
+    return len(p), nil
+}
+
+func Test_Fallback(t *testing.T) {
+    buf := &bytes.Buffer{}
+
+    w := NewFallback(errWriter{}, "test").WithFallback(buf)
+
+    n, err := w.Write([]byte("log"))
+    if n != 3 || err != nil {
+        t.Errorf("Fallback write got %d, %v", n, err)
+    }
+
+    if buf.String() != "log" {
+        t.Errorf("Fallback got %q", buf.String())
+    }
+}
+
+func Test_AsyncNotBlock(t *testing.T) {
+    buf := &bytes.Buffer{}
+
+    block := blockWriter{ch: make(chan struct{})}
+
+    w := NewAsync(block, "test", 1)
+    w.WithFallback(buf)
+
+    done := make(chan struct{})
+    go func() {
+        for i := 0; i < 5; i++ {
+            w.Write([]byte("x"))
+        }
+        close(done)
+    }()
+
+    select {
+        case <-done:
+        case <-time.After(3 * time.Second):
+            t.Fatal("Async write blocked")
+    }
+
+    close(block.ch)
+    w.Close()
+
+    if buf.Len() < 3 {
+        t.Errorf("Async fallback got %q", buf.String())
+    }
+}
+
+func Test_Open(t *testing.T) {
+    if _, err := Open(map[string]any{"sink": "none"}); err == nil {
+        t.Error("Open should return error")
+    }
+
+    if _, err := Open(map[string]any{"sink": "socket"}); err == nil {
+        t.Error("Open socket without address should return error")
+    }
+}
@@ -0,0 +1,87 @@
+package sink
+
+import (
+    "net"
+    "sync"
+    "time"
+    "errors"
+)
+
+// 默认连接超时时间
+const DefaultTimeout = 3 * time.Second
+
+// socket 输出
+// network 可选 udp, tcp, unix, unixgram
+func NewSocket(network string, address string, timeout time.Duration) (*Socket, error) {
+    if address == "" {
+        return nil, errors.New("日志 socket 地址不能为空")
+    }
+
+    if network == "" {
+        network = "udp"
+    }
+
+    if timeout <= 0 {
+        timeout = DefaultTimeout
+    }
+
+    return &Socket{
+        network: network,
+        address: address,
+        timeout: timeout,
+    }, nil
+}
+
+/**
+ * socket 输出，每次写入一行日志，写入失败时下次写入重新连接
+ *
+ * @create 2024-5-28
+ * @author deatil
+ */
+type Socket struct {
+    network string
+    address string
+    timeout time.Duration
+
+    mu   sync.Mutex
+    conn net.Conn
+}
+
+func (this *Socket) Write(p []byte) (int, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.conn == nil {
+        conn, err := net.DialTimeout(this.network, this.address, this.timeout)
+        if err != nil {
+            return 0, err
+        }
+
+        this.conn = conn
+    }
+
+    this.conn.SetWriteDeadline(time.Now().Add(this.timeout))
+
+    n, err := this.conn.Write(p)
+    if err != nil {
+        this.conn.Close()
+        this.conn = nil
+    }
+
+    return n, err
+}
+
+// 关闭
+func (this *Socket) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.conn == nil {
+        return nil
+    }
+
+    err := this.conn.Close()
+    this.conn = nil
+
+    return err
+}
@@ -0,0 +1,41 @@
+//go:build !windows && !plan9
+
+package sink
+
+import (
+    "io"
+    "strings"
+    "log/syslog"
+)
+
+// 日志来源
+var facilities = map[string]syslog.Priority{
+    "kern":   syslog.LOG_KERN,
+    "user":   syslog.LOG_USER,
+    "mail":   syslog.LOG_MAIL,
+    "daemon": syslog.LOG_DAEMON,
+    "auth":   syslog.LOG_AUTH,
+    "syslog": syslog.LOG_SYSLOG,
+    "local0": syslog.LOG_LOCAL0,
+    "local1": syslog.LOG_LOCAL1,
+    "local2": syslog.LOG_LOCAL2,
+    "local3": syslog.LOG_LOCAL3,
+    "local4": syslog.LOG_LOCAL4,
+    "local5": syslog.LOG_LOCAL5,
+    "local6": syslog.LOG_LOCAL6,
+    "local7": syslog.LOG_LOCAL7,
+}
+
+// syslog 输出，network 及 address 为空时使用本机 syslog
+func NewSyslog(network string, address string, tag string, facility string) (io.Writer, error) {
+    priority, ok := facilities[strings.ToLower(facility)]
+    if !ok {
+        priority = syslog.LOG_USER
+    }
+
+    if tag == "" {
+        tag = "lakego"
+    }
+
+    return syslog.Dial(network, address, priority | syslog.LOG_INFO, tag)
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package sink
+
+import (
+    "io"
+    "errors"
+)
+
+// windows 及 plan9 不支持 syslog
+func NewSyslog(network string, address string, tag string, facility string) (io.Writer, error) {
+    return nil, errors.New("当前系统不支持 syslog 日志输出")
+}
@@ -0,0 +1,214 @@
+package metrics
+
+import (
+    "io"
+    "fmt"
+    "sort"
+    "sync"
+    "math"
+    "bufio"
+    "strings"
+    "strconv"
+)
+
+// 指标类型
+const (
+    TypeCounter   = "counter"
+    TypeGauge     = "gauge"
+    TypeHistogram = "histogram"
+)
+
+// 单条数据
+type Sample struct {
+    // 名称后缀，比如 histogram 的 _bucket
+    Suffix string
+
+    // 标签名称
+    LabelNames []string
+
+    // 标签值
+    LabelValues []string
+
+    // 值
+    Value float64
+}
+
+// 指标
+type Family struct {
+    // 名称
+    Name string
+
+    // 说明
+    Help string
+
+    // 类型
+    Type string
+
+    // 数据
+    Samples []Sample
+}
+
+/**
+ * 指标收集接口
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type Collector interface {
+    // 收集指标
+    Collect() []Family
+}
+
+// 函数收集
+type CollectorFunc func() []Family
+
+// 收集指标
+func (this CollectorFunc) Collect() []Family {
+    return this()
+}
+
+// 构造函数
+func NewRegistry() *Registry {
+    return &Registry{
+        collectors: make(map[string]Collector),
+    }
+}
+
+/**
+ * 指标注册
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type Registry struct {
+    mu sync.RWMutex
+
+    // 收集器
+    collectors map[string]Collector
+}
+
+// 注册收集器，同名时覆盖
+func (this *Registry) Register(name string, c Collector) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.collectors[name] = c
+}
+
+// 移除收集器
+func (this *Registry) Unregister(name string) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.collectors, name)
+}
+
+// 收集全部指标，按名称排序
+func (this *Registry) Gather() []Family {
+    this.mu.RLock()
+    collectors := make([]Collector, 0, len(this.collectors))
+    for _, c := range this.collectors {
+        collectors = append(collectors, c)
+    }
+    this.mu.RUnlock()
+
+    families := make([]Family, 0)
+    for _, c := range collectors {
+        families = append(families, c.Collect()...)
+    }
+
+    sort.SliceStable(families, func(i, j int) bool {
+        return families[i].Name < families[j].Name
+    })
+
+    return families
+}
+
+// 输出 prometheus 文本格式
+func (this *Registry) WriteText(w io.Writer) error {
+    return WriteText(w, this.Gather())
+}
+
+// prometheus 文本格式的 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// 输出 prometheus 文本格式
+func WriteText(w io.Writer, families []Family) error {
+    bw := bufio.NewWriter(w)
+
+    for _, f := range families {
+        if f.Help != "" {
+            fmt.Fprintf(bw, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+        }
+        if f.Type != "" {
+            fmt.Fprintf(bw, "# TYPE %s %s\n", f.Name, f.Type)
+        }
+
+        for _, s := range f.Samples {
+            bw.WriteString(f.Name + s.Suffix)
+
+            if len(s.LabelNames) > 0 {
+                bw.WriteByte('{')
+                for i, name := range s.LabelNames {
+                    if i > 0 {
+                        bw.WriteByte(',')
+                    }
+
+                    value := ""
+                    if i < len(s.LabelValues) {
+                        value = s.LabelValues[i]
+                    }
+
+                    bw.WriteString(name + `="` + escapeLabel(value) + `"`)
+                }
+                bw.WriteByte('}')
+            }
+
+            bw.WriteString(" " + formatFloat(s.Value) + "\n")
+        }
+    }
+
+    return bw.Flush()
+}
+
+func formatFloat(v float64) string {
+    switch {
+        case math.IsInf(v, 1):
+            return "+Inf"
+        case math.IsInf(v, -1):
+            return "-Inf"
+        case math.IsNaN(v):
+            return "NaN"
+    }
+
+    return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+    return helpReplacer.Replace(s)
+}
+
+func escapeLabel(s string) string {
+    return labelReplacer.Replace(s)
+}
+
+// 默认注册
+var defaultRegistry = NewRegistry()
+
+// 默认注册
+func Default() *Registry {
+    return defaultRegistry
+}
+
+// 注册收集器到默认注册
+func Register(name string, c Collector) {
+    defaultRegistry.Register(name, c)
+}
+
+// 从默认注册移除收集器
+func Unregister(name string) {
+    defaultRegistry.Unregister(name)
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+func Test_WriteText(t *testing.T) {
+    r := NewRegistry()
+
+    c := NewCounter("test_requests_total", "Total requests.", "route")
+    c.Inc("/a")
+    c.Add(2, "/a")
+    c.Inc(`/b"`)
+    r.Register("counter", c)
+
+    h := NewHistogram("test_duration_seconds", "Duration.", []float64{1, 0.1}, "route")
+    h.Observe(0.05, "/a")
+    h.Observe(0.5, "/a")
+    r.Register("histogram", h)
+
+    var buf bytes.Buffer
+    if err := r.WriteText(&buf); err != nil {
+        t.Fatal(err)
+    }
+
+    out := buf.String()
+
+    wants := []string{
+        "# HELP test_requests_total Total requests.\n",
+        "# TYPE test_requests_total counter\n",
+        `test_requests_total{route="/a"} 3` + "\n",
+        `test_requests_total{route="/b\""} 1` + "\n",
+        "# TYPE test_duration_seconds histogram\n",
+        `test_duration_seconds_bucket{route="/a",le="0.1"} 1` + "\n",
+        `test_duration_seconds_bucket{route="/a",le="1"} 2` + "\n",
+        `test_duration_seconds_bucket{route="/a",le="+Inf"} 2` + "\n",
+        `test_duration_seconds_sum{route="/a"} 0.55` + "\n",
+        `test_duration_seconds_count{route="/a"} 2` + "\n",
+    }
+    for _, want := range wants {
+        if !strings.Contains(out, want) {
+            t.Errorf("output missing %q, got:\n%s", want, out)
+        }
+    }
+
+    if strings.Index(out, "test_duration_seconds") > strings.Index(out, "test_requests_total") {
+        t.Error("families are not sorted by name")
+    }
+}
+
+func Test_Gauge(t *testing.T) {
+    g := NewGauge("test_in_flight", "In flight.")
+    g.Inc()
+    g.Inc()
+    g.Dec()
+
+    f := g.Collect()[0]
+    if len(f.Samples) != 1 || f.Samples[0].Value != 1 {
+        t.Errorf("got %v", f.Samples)
+    }
+}
+
+func Test_Runtime(t *testing.T) {
+    var buf bytes.Buffer
+    Default().WriteText(&buf)
+
+    if !strings.Contains(buf.String(), "go_goroutines ") {
+        t.Error("runtime metrics not registered")
+    }
+}
@@ -0,0 +1,328 @@
+package metrics
+
+import (
+    "sort"
+    "sync"
+    "math"
+    "strings"
+)
+
+// 默认 histogram 区间，单位为秒
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// 标签值分隔符
+const labelSep = "\xff"
+
+// 按标签值分组的数据
+type vec[T any] struct {
+    mu sync.RWMutex
+
+    name   string
+    help   string
+    labels []string
+
+    values map[string]*T
+    keys   map[string][]string
+
+    newValue func() *T
+}
+
+func newVec[T any](name, help string, labels []string, newValue func() *T) *vec[T] {
+    return &vec[T]{
+        name:     name,
+        help:     help,
+        labels:   labels,
+        values:   make(map[string]*T),
+        keys:     make(map[string][]string),
+        newValue: newValue,
+    }
+}
+
+// 获取标签值对应的数据，标签值数量不足时补空
+func (this *vec[T]) with(values []string) *T {
+    if len(values) != len(this.labels) {
+        fixed := make([]string, len(this.labels))
+        copy(fixed, values)
+        values = fixed
+    }
+
+    key := strings.Join(values, labelSep)
+
+    this.mu.RLock()
+    v, ok := this.values[key]
+    this.mu.RUnlock()
+    if ok {
+        return v
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if v, ok = this.values[key]; ok {
+        return v
+    }
+
+    v = this.newValue()
+    this.values[key] = v
+    this.keys[key] = append([]string(nil), values...)
+
+    return v
+}
+
+// 按标签值排序遍历
+func (this *vec[T]) each(f func(values []string, v *T)) {
+    this.mu.RLock()
+    keys := make([]string, 0, len(this.values))
+    for key := range this.values {
+        keys = append(keys, key)
+    }
+    this.mu.RUnlock()
+
+    sort.Strings(keys)
+
+    for _, key := range keys {
+        this.mu.RLock()
+        v, values := this.values[key], this.keys[key]
+        this.mu.RUnlock()
+
+        f(values, v)
+    }
+}
+
+// 清空数据
+func (this *vec[T]) Reset() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.values = make(map[string]*T)
+    this.keys = make(map[string][]string)
+}
+
+// 数值
+type value struct {
+    mu sync.Mutex
+    v  float64
+}
+
+func (this *value) add(v float64) {
+    this.mu.Lock()
+    this.v += v
+    this.mu.Unlock()
+}
+
+func (this *value) set(v float64) {
+    this.mu.Lock()
+    this.v = v
+    this.mu.Unlock()
+}
+
+func (this *value) get() float64 {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.v
+}
+
+// 计数器
+func NewCounter(name, help string, labels ...string) *CounterVec {
+    return &CounterVec{
+        vec: newVec(name, help, labels, func() *value {
+            return &value{}
+        }),
+    }
+}
+
+/**
+ * 计数器，只增不减
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type CounterVec struct {
+    *vec[value]
+}
+
+// 加 1
+func (this *CounterVec) Inc(labelValues ...string) {
+    this.Add(1, labelValues...)
+}
+
+// 增加，小于 0 时忽略
+func (this *CounterVec) Add(v float64, labelValues ...string) {
+    if v < 0 {
+        return
+    }
+
+    this.with(labelValues).add(v)
+}
+
+// 收集指标
+func (this *CounterVec) Collect() []Family {
+    f := Family{Name: this.name, Help: this.help, Type: TypeCounter}
+
+    this.each(func(values []string, v *value) {
+        f.Samples = append(f.Samples, Sample{
+            LabelNames:  this.labels,
+            LabelValues: values,
+            Value:       v.get(),
+        })
+    })
+
+    return []Family{f}
+}
+
+// 仪表
+func NewGauge(name, help string, labels ...string) *GaugeVec {
+    return &GaugeVec{
+        vec: newVec(name, help, labels, func() *value {
+            return &value{}
+        }),
+    }
+}
+
+/**
+ * 仪表，可增可减
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type GaugeVec struct {
+    *vec[value]
+}
+
+// 设置
+func (this *GaugeVec) Set(v float64, labelValues ...string) {
+    this.with(labelValues).set(v)
+}
+
+// 增加
+func (this *GaugeVec) Add(v float64, labelValues ...string) {
+    this.with(labelValues).add(v)
+}
+
+// 加 1
+func (this *GaugeVec) Inc(labelValues ...string) {
+    this.Add(1, labelValues...)
+}
+
+// 减 1
+func (this *GaugeVec) Dec(labelValues ...string) {
+    this.Add(-1, labelValues...)
+}
+
+// 收集指标
+func (this *GaugeVec) Collect() []Family {
+    f := Family{Name: this.name, Help: this.help, Type: TypeGauge}
+
+    this.each(func(values []string, v *value) {
+        f.Samples = append(f.Samples, Sample{
+            LabelNames:  this.labels,
+            LabelValues: values,
+            Value:       v.get(),
+        })
+    })
+
+    return []Family{f}
+}
+
+// 分布数据
+type histogram struct {
+    mu     sync.Mutex
+    counts []uint64
+    count  uint64
+    sum    float64
+}
+
+// 分布
+func NewHistogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+    if len(buckets) == 0 {
+        buckets = DefaultBuckets
+    }
+
+    buckets = append([]float64(nil), buckets...)
+    sort.Float64s(buckets)
+
+    return &HistogramVec{
+        buckets: buckets,
+        vec: newVec(name, help, labels, func() *histogram {
+            return &histogram{
+                counts: make([]uint64, len(buckets)),
+            }
+        }),
+    }
+}
+
+/**
+ * 分布，比如请求耗时
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+type HistogramVec struct {
+    *vec[histogram]
+
+    buckets []float64
+}
+
+// 记录
+func (this *HistogramVec) Observe(v float64, labelValues ...string) {
+    h := this.with(labelValues)
+
+    h.mu.Lock()
+    defer h.mu.Unlock()
+
+    for i, upper := range this.buckets {
+        if v <= upper {
+            h.counts[i]++
+        }
+    }
+
+    h.count++
+    h.sum += v
+}
+
+// 收集指标
+func (this *HistogramVec) Collect() []Family {
+    f := Family{Name: this.name, Help: this.help, Type: TypeHistogram}
+
+    bucketLabels := append(append([]string(nil), this.labels...), "le")
+
+    this.each(func(values []string, h *histogram) {
+        h.mu.Lock()
+        counts := append([]uint64(nil), h.counts...)
+        count, sum := h.count, h.sum
+        h.mu.Unlock()
+
+        for i, upper := range this.buckets {
+            f.Samples = append(f.Samples, Sample{
+                Suffix:      "_bucket",
+                LabelNames:  bucketLabels,
+                LabelValues: append(append([]string(nil), values...), formatFloat(upper)),
+                Value:       float64(counts[i]),
+            })
+        }
+
+        f.Samples = append(f.Samples,
+            Sample{
+                Suffix:      "_bucket",
+                LabelNames:  bucketLabels,
+                LabelValues: append(append([]string(nil), values...), formatFloat(math.Inf(1))),
+                Value:       float64(count),
+            },
+            Sample{
+                Suffix:      "_sum",
+                LabelNames:  this.labels,
+                LabelValues: values,
+                Value:       sum,
+            },
+            Sample{
+                Suffix:      "_count",
+                LabelNames:  this.labels,
+                LabelValues: values,
+                Value:       float64(count),
+            },
+        )
+    })
+
+    return []Family{f}
+}
@@ -0,0 +1,357 @@
+package httpcache
+
+import (
+    "time"
+    "strings"
+    "net/http"
+    "crypto/sha1"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 响应头
+const (
+    HeaderCache        = "X-Cache"
+    HeaderETag         = "ETag"
+    HeaderLastModified = "Last-Modified"
+    HeaderCacheControl = "Cache-Control"
+)
+
+// 默认缓存键名前缀
+const DefaultPrefix = "response-cache"
+
+// 配置
+type Options struct {
+    // 缓存
+    Cache *cache.Cache
+
+    // 缓存键名前缀
+    Prefix string
+
+    // 没有匹配规则的 json 响应也生成 ETag 及处理 304
+    Conditional bool
+
+    // 响应是否可以缓存，默认为 200 的 json 响应且 success 不为 false
+    Cacheable func(status int, contentType string, body []byte) bool
+
+    // 账号标识，默认使用 admin_id
+    Identity func(*router.Context) string
+
+    // 缓存出错时回调，出错时不使用缓存
+    OnError func(*router.Context, error)
+}
+
+// 缓存规则
+type Rule struct {
+    // 路由，支持路由名称、权限标识及 GET:/admin-api/* 格式的路径
+    Routes []string
+
+    // 缓存时间
+    TTL time.Duration
+
+    // 按账号缓存，没有账号时不缓存
+    PerAdmin bool
+
+    // 标签，清除标签时删除缓存
+    Tags []string
+}
+
+// 是否匹配
+func (this Rule) Match(ctx *router.Context) bool {
+    return router.MatchRoutes(ctx, this.Routes)
+}
+
+// 缓存数据
+type Entry struct {
+    Status       int    `json:"status"`
+    ContentType  string `json:"content_type"`
+    Body         string `json:"body"`
+    ETag         string `json:"etag"`
+    LastModified int64  `json:"last_modified"`
+}
+
+/**
+ * 响应缓存，缓存 GET 及 HEAD 请求的 json 响应
+ * 同时生成 ETag 及 Last-Modified，处理 If-None-Match 及 If-Modified-Since
+ *
+ * route.Use(httpcache.RulesHandler(opts, rules))
+ *
+ * @create 2024-5-31
+ * @author deatil
+ */
+func RulesHandler(opts Options, rules []Rule) router.HandlerFunc {
+    if opts.Prefix == "" {
+        opts.Prefix = DefaultPrefix
+    }
+    if opts.Cacheable == nil {
+        opts.Cacheable = DefaultCacheable
+    }
+    if opts.Identity == nil {
+        opts.Identity = defaultIdentity
+    }
+
+    return func(ctx *router.Context) {
+        method := ctx.Request.Method
+        if method != http.MethodGet && method != http.MethodHead {
+            ctx.Next()
+            return
+        }
+
+        var rule *Rule
+        for i := range rules {
+            if rules[i].Match(ctx) {
+                rule = &rules[i]
+                break
+            }
+        }
+
+        if rule == nil || opts.Cache == nil || rule.TTL <= 0 {
+            if opts.Conditional {
+                conditional(ctx, opts)
+            } else {
+                ctx.Next()
+            }
+
+            return
+        }
+
+        identity := ""
+        if rule.PerAdmin {
+            identity = opts.Identity(ctx)
+            if identity == "" {
+                conditional(ctx, opts)
+                return
+            }
+        }
+
+        key := opts.Prefix + ":" + CacheKey(ctx, identity)
+        tagged := opts.Cache.WithContext(ctx.Request.Context()).Tags(rule.Tags...)
+
+        if entry, ok := getEntry(tagged, key); ok {
+            ctx.Header(HeaderCache, "HIT")
+            write(ctx, entry)
+            ctx.Abort()
+            return
+        }
+
+        status, body, ok := capture(ctx)
+        if !ok {
+            return
+        }
+
+        contentType := ctx.Writer.Header().Get("Content-Type")
+        if !opts.Cacheable(status, contentType, body) {
+            ctx.Writer.WriteHeader(status)
+            ctx.Writer.Write(body)
+            return
+        }
+
+        entry := Entry{
+            Status:       status,
+            ContentType:  contentType,
+            Body:         string(body),
+            ETag:         ETag(body),
+            LastModified: time.Now().Unix(),
+        }
+
+        if err := putEntry(tagged, key, entry, rule.TTL); err != nil && opts.OnError != nil {
+            opts.OnError(ctx, err)
+        }
+
+        ctx.Header(HeaderCache, "MISS")
+        write(ctx, entry)
+    }
+}
+
+// 只生成 ETag 及处理 304
+func conditional(ctx *router.Context, opts Options) {
+    status, body, ok := capture(ctx)
+    if !ok {
+        return
+    }
+
+    contentType := ctx.Writer.Header().Get("Content-Type")
+    if !opts.Cacheable(status, contentType, body) {
+        ctx.Writer.WriteHeader(status)
+        ctx.Writer.Write(body)
+        return
+    }
+
+    write(ctx, Entry{
+        Status:      status,
+        ContentType: contentType,
+        Body:        string(body),
+        ETag:        ETag(body),
+    })
+}
+
+// 缓冲后续处理的响应
+func capture(ctx *router.Context) (int, []byte, bool) {
+    origin := ctx.Writer
+
+    writer := newBufferWriter(origin)
+    ctx.Writer = writer
+
+    ctx.Next()
+
+    ctx.Writer = origin
+
+    // 已经直接写入原响应时不处理
+    if origin.Written() {
+        return 0, nil, false
+    }
+
+    status, body := writer.Result()
+
+    return status, body, true
+}
+
+// 输出响应，请求条件匹配时返回 304
+func write(ctx *router.Context, entry Entry) {
+    header := ctx.Writer.Header()
+
+    if entry.ContentType != "" {
+        header.Set("Content-Type", entry.ContentType)
+    }
+    if entry.ETag != "" {
+        header.Set(HeaderETag, entry.ETag)
+    }
+
+    lastModified := time.Time{}
+    if entry.LastModified > 0 {
+        lastModified = time.Unix(entry.LastModified, 0).UTC()
+        header.Set(HeaderLastModified, lastModified.Format(http.TimeFormat))
+    }
+
+    // 浏览器每次都需要验证
+    if header.Get(HeaderCacheControl) == "" {
+        header.Set(HeaderCacheControl, "private, no-cache")
+    }
+
+    if NotModified(ctx.Request, entry.ETag, lastModified) {
+        header.Del("Content-Type")
+        header.Del("Content-Length")
+
+        ctx.Writer.WriteHeader(http.StatusNotModified)
+        ctx.Writer.WriteHeaderNow()
+        return
+    }
+
+    ctx.Writer.WriteHeader(entry.Status)
+    ctx.Writer.WriteString(entry.Body)
+}
+
+// 请求条件是否匹配，If-None-Match 优先
+func NotModified(req *http.Request, etag string, lastModified time.Time) bool {
+    if match := req.Header.Get("If-None-Match"); match != "" {
+        if etag == "" {
+            return false
+        }
+
+        for _, item := range strings.Split(match, ",") {
+            item = strings.TrimSpace(item)
+            if item == "*" || trimWeak(item) == trimWeak(etag) {
+                return true
+            }
+        }
+
+        return false
+    }
+
+    if since := req.Header.Get("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+        t, err := http.ParseTime(since)
+        if err != nil {
+            return false
+        }
+
+        return !lastModified.Truncate(time.Second).After(t)
+    }
+
+    return false
+}
+
+// 生成 ETag
+func ETag(body []byte) string {
+    sum := sha256.Sum256(body)
+
+    return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// 缓存键名，使用请求方式、请求路径、排序后的请求参数及账号标识
+func CacheKey(ctx *router.Context, identity string) string {
+    req := ctx.Request
+
+    data := req.Method + " " + req.URL.Path + "?" + req.URL.Query().Encode() + "|" + identity
+    sum := sha1.Sum([]byte(data))
+
+    return hex.EncodeToString(sum[:])
+}
+
+// 默认可以缓存的响应
+func DefaultCacheable(status int, contentType string, body []byte) bool {
+    if status != http.StatusOK || !strings.Contains(contentType, "json") {
+        return false
+    }
+
+    // 后台接口出错时也返回 200
+    var data struct {
+        Success *bool `json:"success"`
+    }
+    if err := json.Unmarshal(body, &data); err == nil && data.Success != nil && !*data.Success {
+        return false
+    }
+
+    return true
+}
+
+// 读取缓存
+func getEntry(tagged *cache.TaggedCache, key string) (Entry, bool) {
+    var entry Entry
+
+    val, err := tagged.Get(key)
+    if err != nil || val == nil {
+        return entry, false
+    }
+
+    if err := json.Unmarshal([]byte(goch.ToString(val)), &entry); err != nil {
+        return entry, false
+    }
+
+    return entry, entry.Status > 0
+}
+
+// 写入缓存
+func putEntry(tagged *cache.TaggedCache, key string, entry Entry, ttl time.Duration) error {
+    data, err := json.Marshal(entry)
+    if err != nil {
+        return err
+    }
+
+    seconds := int64(ttl / time.Second)
+    if seconds < 1 {
+        seconds = 1
+    }
+
+    return tagged.Put(key, string(data), seconds)
+}
+
+// 默认账号标识
+func defaultIdentity(ctx *router.Context) string {
+    id, ok := ctx.Get("admin_id")
+    if !ok || id == nil {
+        return ""
+    }
+
+    return goch.ToString(id)
+}
+
+// 去掉弱验证前缀
+func trimWeak(etag string) string {
+    return strings.TrimPrefix(etag, "W/")
+}
@@ -0,0 +1,248 @@
+package httpcache
+
+import (
+    "time"
+    "errors"
+    "testing"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func init() {
+    router.SetMode(router.TestMode)
+}
+
+// 测试用内存驱动
+type testDriver struct {
+    data map[string]any
+}
+
+func (this *testDriver) Exists(key string) bool {
+    _, ok := this.data[key]
+    return ok
+}
+
+func (this *testDriver) Get(key string) (any, error) {
+    val, ok := this.data[key]
+    if !ok {
+        return nil, errors.New("not found")
+    }
+
+    return val, nil
+}
+
+func (this *testDriver) Put(key string, value any, ttl time.Duration) error {
+    this.data[key] = value
+    return nil
+}
+
+func (this *testDriver) Forever(key string, value any) error {
+    this.data[key] = value
+    return nil
+}
+
+func (this *testDriver) Increment(key string, value ...int64) error {
+    this.data[key] = goch.ToInt64(this.data[key]) + 1
+    return nil
+}
+
+func (this *testDriver) Decrement(key string, value ...int64) error {
+    this.data[key] = goch.ToInt64(this.data[key]) - 1
+    return nil
+}
+
+func (this *testDriver) Forget(key string) (bool, error) {
+    delete(this.data, key)
+    return true, nil
+}
+
+func (this *testDriver) Flush() (bool, error) {
+    this.data = make(map[string]any)
+    return true, nil
+}
+
+func Test_RulesHandler(t *testing.T) {
+    c := cache.New(&testDriver{data: make(map[string]any)})
+
+    calls := 0
+
+    r := router.New()
+    r.Use(func(ctx *router.Context) {
+        if id := ctx.GetHeader("X-Admin"); id != "" {
+            ctx.Set("admin_id", id)
+        }
+    })
+    r.Use(RulesHandler(Options{Cache: c}, []Rule{
+        {
+            Routes:   []string{"GET:/rules"},
+            TTL:      time.Minute,
+            PerAdmin: true,
+            Tags:     []string{"auth-rule"},
+        },
+    }))
+    r.GET("/rules", func(ctx *router.Context) {
+        calls++
+        ctx.JSON(200, router.H{
+            "success": true,
+            "data":    ctx.GetString("admin_id") + ctx.Query("q"),
+        })
+    })
+
+    get := func(admin string, query string, header http.Header) *httptest.ResponseRecorder {
+        req := httptest.NewRequest("GET", "/rules" + query, nil)
+        for k, v := range header {
+            req.Header[k] = v
+        }
+        if admin != "" {
+            req.Header.Set("X-Admin", admin)
+        }
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        return w
+    }
+
+    w := get("1", "", nil)
+    if w.Code != 200 || w.Header().Get(HeaderCache) != "MISS" || w.Header().Get(HeaderETag) == "" {
+        t.Fatalf("first got %d %v", w.Code, w.Header())
+    }
+
+    body := w.Body.String()
+    etag := w.Header().Get(HeaderETag)
+
+    w = get("1", "", nil)
+    if w.Header().Get(HeaderCache) != "HIT" || w.Body.String() != body || calls != 1 {
+        t.Errorf("second got %s %s, calls %d", w.Header().Get(HeaderCache), w.Body.String(), calls)
+    }
+    if w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+        t.Errorf("Content-Type got %s", w.Header().Get("Content-Type"))
+    }
+
+    // 按账号及参数区分
+    if w = get("2", "", nil); w.Header().Get(HeaderCache) != "MISS" {
+        t.Error("other admin should miss")
+    }
+    if w = get("1", "?q=a", nil); w.Header().Get(HeaderCache) != "MISS" {
+        t.Error("other query should miss")
+    }
+
+    // 没有账号时不缓存
+    if w = get("", "", nil); w.Header().Get(HeaderCache) != "" {
+        t.Error("no admin should not cache")
+    }
+
+    w = get("1", "", http.Header{"If-None-Match": {"W/" + etag}})
+    if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+        t.Errorf("If-None-Match got %d", w.Code)
+    }
+
+    lastModified := get("1", "", nil).Header().Get(HeaderLastModified)
+    w = get("1", "", http.Header{"If-Modified-Since": {lastModified}})
+    if w.Code != http.StatusNotModified {
+        t.Errorf("If-Modified-Since got %d", w.Code)
+    }
+
+    calls = 0
+    c.Tags("auth-rule").Flush()
+
+    w = get("1", "", http.Header{"If-None-Match": {etag}})
+    if calls != 1 || w.Header().Get(HeaderCache) != "MISS" {
+        t.Errorf("flush should miss, calls %d", calls)
+    }
+    // 内容不变时仍然返回 304
+    if w.Code != http.StatusNotModified {
+        t.Errorf("same body got %d", w.Code)
+    }
+}
+
+func Test_RouteParams(t *testing.T) {
+    c := cache.New(&testDriver{data: make(map[string]any)})
+
+    r := router.New()
+    r.Use(RulesHandler(Options{Cache: c}, []Rule{
+        {
+            Routes: []string{"GET:/admin/*"},
+            TTL:    time.Minute,
+        },
+    }))
+    r.GET("/admin/:id", func(ctx *router.Context) {
+        ctx.JSON(200, router.H{
+            "success": true,
+            "data":    ctx.Param("id"),
+        })
+    })
+
+    get := func(path string) *httptest.ResponseRecorder {
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+
+        return w
+    }
+
+    get("/admin/1")
+
+    // 不同路径参数分开缓存
+    w := get("/admin/2")
+    if w.Header().Get(HeaderCache) != "MISS" || w.Body.String() != `{"data":"2","success":true}` {
+        t.Errorf("other id got %s %s", w.Header().Get(HeaderCache), w.Body.String())
+    }
+
+    w = get("/admin/1")
+    if w.Header().Get(HeaderCache) != "HIT" || w.Body.String() != `{"data":"1","success":true}` {
+        t.Errorf("same id got %s %s", w.Header().Get(HeaderCache), w.Body.String())
+    }
+}
+
+func Test_Conditional(t *testing.T) {
+    r := router.New()
+    r.Use(RulesHandler(Options{Conditional: true}, nil))
+    r.GET("/ok", func(ctx *router.Context) {
+        ctx.JSON(200, router.H{"success": true})
+    })
+    r.GET("/fail", func(ctx *router.Context) {
+        ctx.JSON(200, router.H{"success": false})
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/ok", nil))
+
+    etag := w.Header().Get(HeaderETag)
+    if etag == "" || w.Header().Get(HeaderCache) != "" {
+        t.Fatalf("headers got %v", w.Header())
+    }
+
+    req := httptest.NewRequest("GET", "/ok", nil)
+    req.Header.Set("If-None-Match", etag)
+
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, req)
+    if w.Code != http.StatusNotModified {
+        t.Errorf("got %d", w.Code)
+    }
+
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/fail", nil))
+    if w.Code != 200 || w.Header().Get(HeaderETag) != "" || w.Body.String() != `{"success":false}` {
+        t.Errorf("fail got %d %v %s", w.Code, w.Header(), w.Body.String())
+    }
+}
+
+func Test_Passthrough(t *testing.T) {
+    r := router.New()
+    r.Use(RulesHandler(Options{Conditional: true}, nil))
+    r.GET("/file", func(ctx *router.Context) {
+        ctx.Data(201, "text/plain", []byte("file-data"))
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/file", nil))
+    if w.Code != 201 || w.Body.String() != "file-data" || w.Header().Get(HeaderETag) != "" {
+        t.Errorf("got %d %v %s", w.Code, w.Header(), w.Body.String())
+    }
+}
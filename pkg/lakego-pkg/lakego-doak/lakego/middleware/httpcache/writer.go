@@ -0,0 +1,127 @@
+package httpcache
+
+import (
+    "bytes"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+/**
+ * 缓冲响应，处理完成后再决定是否写入原响应
+ * 不是 json 的响应直接写入原响应，比如文件下载
+ *
+ * @create 2024-5-31
+ * @author deatil
+ */
+type bufferWriter struct {
+    router.ResponseWriter
+
+    // 状态码
+    status int
+
+    // 响应内容
+    body bytes.Buffer
+
+    // 是否已经判断响应类型
+    checked bool
+
+    // 直接写入原响应
+    passthrough bool
+}
+
+// 缓冲响应
+func newBufferWriter(w router.ResponseWriter) *bufferWriter {
+    return &bufferWriter{
+        ResponseWriter: w,
+        status:         http.StatusOK,
+    }
+}
+
+func (this *bufferWriter) WriteHeader(code int) {
+    if this.passthrough {
+        this.ResponseWriter.WriteHeader(code)
+        return
+    }
+
+    if code > 0 {
+        this.status = code
+    }
+}
+
+func (this *bufferWriter) WriteHeaderNow() {
+    if this.passthrough {
+        this.ResponseWriter.WriteHeaderNow()
+    }
+}
+
+func (this *bufferWriter) Write(data []byte) (int, error) {
+    if this.check() {
+        return this.ResponseWriter.Write(data)
+    }
+
+    return this.body.Write(data)
+}
+
+func (this *bufferWriter) WriteString(s string) (int, error) {
+    if this.check() {
+        return this.ResponseWriter.WriteString(s)
+    }
+
+    return this.body.WriteString(s)
+}
+
+func (this *bufferWriter) Status() int {
+    if this.passthrough {
+        return this.ResponseWriter.Status()
+    }
+
+    return this.status
+}
+
+func (this *bufferWriter) Size() int {
+    if this.passthrough {
+        return this.ResponseWriter.Size()
+    }
+
+    if this.body.Len() == 0 {
+        return -1
+    }
+
+    return this.body.Len()
+}
+
+func (this *bufferWriter) Written() bool {
+    if this.passthrough {
+        return this.ResponseWriter.Written()
+    }
+
+    return this.body.Len() > 0
+}
+
+func (this *bufferWriter) Flush() {
+    if this.passthrough {
+        this.ResponseWriter.Flush()
+    }
+}
+
+// 状态码及响应内容
+func (this *bufferWriter) Result() (int, []byte) {
+    return this.status, this.body.Bytes()
+}
+
+// 第一次写入时判断响应类型，返回是否直接写入原响应
+func (this *bufferWriter) check() bool {
+    if !this.checked {
+        this.checked = true
+
+        contentType := this.Header().Get("Content-Type")
+        if !strings.Contains(contentType, "json") {
+            this.passthrough = true
+            this.ResponseWriter.WriteHeader(this.status)
+        }
+    }
+
+    return this.passthrough
+}
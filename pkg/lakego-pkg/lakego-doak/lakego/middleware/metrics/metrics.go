@@ -0,0 +1,92 @@
+package metrics
+
+import (
+    "time"
+    "strconv"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+var (
+    // 请求数量
+    requestsTotal = metrics.NewCounter(
+        "lakego_http_requests_total",
+        "Total number of HTTP requests by method, route and status.",
+        "method", "route", "status",
+    )
+
+    // 请求耗时
+    requestDuration = metrics.NewHistogram(
+        "lakego_http_request_duration_seconds",
+        "HTTP request latency in seconds by method and route.",
+        metrics.DefaultBuckets,
+        "method", "route",
+    )
+
+    // 处理中的请求
+    requestsInFlight = metrics.NewGauge(
+        "lakego_http_requests_in_flight",
+        "Number of HTTP requests currently being served.",
+    )
+)
+
+func init() {
+    metrics.Register("http_requests_total", requestsTotal)
+    metrics.Register("http_request_duration", requestDuration)
+    metrics.Register("http_requests_in_flight", requestsInFlight)
+}
+
+/**
+ * 请求指标，按路由记录请求数量及耗时
+ *
+ * @create 2024-5-17
+ * @author deatil
+ */
+func Handler(skipPaths ...string) router.HandlerFunc {
+    skip := make(map[string]bool)
+    for _, path := range skipPaths {
+        skip[path] = true
+    }
+
+    return func(ctx *router.Context) {
+        if skip[ctx.Request.URL.Path] {
+            ctx.Next()
+            return
+        }
+
+        start := time.Now()
+        requestsInFlight.Inc()
+
+        // 后续处理 panic 时也需要记录
+        defer func() {
+            requestsInFlight.Dec()
+
+            status := ctx.Writer.Status()
+
+            r := recover()
+            if r != nil {
+                status = http.StatusInternalServerError
+            }
+
+            // 使用路由规则，避免路径参数导致标签过多
+            route := ctx.FullPath()
+            if route == "" {
+                route = "unmatched"
+            }
+
+            method := ctx.Request.Method
+
+            requestsTotal.Inc(method, route, strconv.Itoa(status))
+            requestDuration.Observe(time.Since(start).Seconds(), method, route)
+
+            // 交给恢复中间件处理
+            if r != nil {
+                panic(r)
+            }
+        }()
+
+        ctx.Next()
+    }
+}
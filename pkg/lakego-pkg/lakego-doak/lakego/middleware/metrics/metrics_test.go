@@ -0,0 +1,63 @@
+package metrics
+
+import (
+    "io"
+    "testing"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+func init() {
+    router.SetMode(router.TestMode)
+}
+
+// 获取指标值
+func sampleValue(families []metrics.Family, labelValues ...string) float64 {
+    for _, family := range families {
+        for _, sample := range family.Samples {
+            if equalStrings(sample.LabelValues, labelValues) {
+                return sample.Value
+            }
+        }
+    }
+
+    return 0
+}
+
+func equalStrings(a, b []string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+
+    for i := range a {
+        if a[i] != b[i] {
+            return false
+        }
+    }
+
+    return true
+}
+
+func Test_HandlerPanic(t *testing.T) {
+    r := router.New()
+    r.Use(router.RecoveryWithWriter(io.Discard))
+    r.Use(Handler())
+    r.GET("/panic", func(ctx *router.Context) {
+        panic("handler panic")
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+    if w.Code != 500 {
+        t.Errorf("got %d", w.Code)
+    }
+
+    if v := sampleValue(requestsInFlight.Collect()); v != 0 {
+        t.Errorf("in flight got %v", v)
+    }
+    if v := sampleValue(requestsTotal.Collect(), "GET", "/panic", "500"); v != 1 {
+        t.Errorf("total got %v", v)
+    }
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+    "fmt"
+    "sync"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/logger"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 默认接口客户端请求头
+const DefaultClientHeader = "X-Api-Client"
+
+// 限流对象获取方法，返回空字符时使用请求 IP
+type KeyFunc = func(*router.Context) string
+
+var (
+    keyFuncsMu sync.RWMutex
+
+    // 限流对象列表
+    keyFuncs = map[string]KeyFunc{
+        "ip":     KeyByIP,
+        "admin":  KeyByAdmin,
+        "client": KeyByClient(DefaultClientHeader),
+        "route":  KeyByRoute,
+    }
+)
+
+// 注册限流对象
+func RegisterKey(name string, f KeyFunc) {
+    keyFuncsMu.Lock()
+    defer keyFuncsMu.Unlock()
+
+    keyFuncs[strings.ToLower(name)] = f
+}
+
+// 获取限流对象
+func GetKey(name string) (KeyFunc, bool) {
+    keyFuncsMu.RLock()
+    defer keyFuncsMu.RUnlock()
+
+    f, ok := keyFuncs[strings.ToLower(name)]
+    return f, ok
+}
+
+// 请求 IP
+func KeyByIP(ctx *router.Context) string {
+    return router.GetRequestIp(ctx)
+}
+
+// 管理员 id，需要在登录验证中间件之后使用
+func KeyByAdmin(ctx *router.Context) string {
+    if id, ok := ctx.Get("admin_id"); ok && id != nil {
+        return fmt.Sprintf("%v", id)
+    }
+
+    if id := logger.AdminIDFromContext(ctx.Request.Context()); id != nil {
+        return fmt.Sprintf("%v", id)
+    }
+
+    return ""
+}
+
+// 接口客户端，从请求头获取
+func KeyByClient(header string) KeyFunc {
+    return func(ctx *router.Context) string {
+        return strings.TrimSpace(ctx.GetHeader(header))
+    }
+}
+
+// 路由，优先使用路由名称及权限标识
+func KeyByRoute(ctx *router.Context) string {
+    fullPath := ctx.FullPath()
+    if fullPath == "" {
+        return "unmatched"
+    }
+
+    if meta, ok := router.NewMeta().GetMeta(ctx.Request.Method, fullPath); ok {
+        if meta.Name != "" {
+            return meta.Name
+        }
+        if meta.Slug != "" {
+            return meta.Slug
+        }
+    }
+
+    return ctx.Request.Method + " " + fullPath
+}
+
+// 生成限流键名，比如 ip=127.0.0.1|route=admin.index
+func identity(ctx *router.Context, by []string) string {
+    if len(by) == 0 {
+        by = []string{"ip"}
+    }
+
+    parts := make([]string, 0, len(by))
+    for _, name := range by {
+        value := ""
+        if f, ok := GetKey(name); ok {
+            value = f(ctx)
+        }
+
+        // 获取不到时使用请求 IP，避免所有请求共用额度
+        if value == "" {
+            parts = append(parts, name + "=ip:" + KeyByIP(ctx))
+            continue
+        }
+
+        parts = append(parts, name + "=" + value)
+    }
+
+    return strings.Join(parts, "|")
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+    "strconv"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/ratelimit"
+)
+
+// 响应头
+const (
+    HeaderLimit      = "RateLimit-Limit"
+    HeaderRemaining  = "RateLimit-Remaining"
+    HeaderReset      = "RateLimit-Reset"
+    HeaderPolicy     = "RateLimit-Policy"
+    HeaderRetryAfter = "Retry-After"
+)
+
+// 配置
+type Options struct {
+    // 限流
+    Limiter *ratelimit.Limiter
+
+    // 被限流时的响应，默认返回 429
+    OnLimited func(*router.Context, ratelimit.Result)
+
+    // 存储出错时回调，出错时不限制请求
+    OnError func(*router.Context, error)
+
+    // 不限流的路径
+    SkipPaths []string
+}
+
+// 限流规则，匹配的路由使用规则里的限流
+type Rule struct {
+    // 路由，支持路由名称、权限标识及 GET:/admin-api/* 格式的路径
+    Routes []string
+
+    // 限流
+    Policies []ratelimit.Policy
+}
+
+// 是否匹配
+func (this Rule) Match(ctx *router.Context) bool {
+    return router.MatchRoutes(ctx, this.Routes)
+}
+
+/**
+ * 限流中间件，全部规则都通过时才允许请求
+ *
+ * route.Use(ratelimit.Handler(opts, policy))
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+func Handler(opts Options, policies ...ratelimit.Policy) router.HandlerFunc {
+    return RulesHandler(opts, nil, policies...)
+}
+
+/**
+ * 按路由限流，没有匹配的规则时使用默认限流
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+func RulesHandler(opts Options, rules []Rule, defaults ...ratelimit.Policy) router.HandlerFunc {
+    if opts.OnLimited == nil {
+        opts.OnLimited = defaultOnLimited
+    }
+
+    skip := make(map[string]bool)
+    for _, path := range opts.SkipPaths {
+        skip[path] = true
+    }
+
+    return func(ctx *router.Context) {
+        if opts.Limiter == nil || skip[ctx.Request.URL.Path] {
+            ctx.Next()
+            return
+        }
+
+        policies := defaults
+        for _, rule := range rules {
+            if rule.Match(ctx) {
+                policies = rule.Policies
+                break
+            }
+        }
+
+        if len(policies) == 0 {
+            ctx.Next()
+            return
+        }
+
+        if res, policy, ok := take(ctx, opts, policies); ok {
+            setHeaders(ctx, res, policies)
+
+            if !res.Allowed {
+                ctx.Header(HeaderRetryAfter, strconv.FormatInt(res.RetryAfterSeconds(), 10))
+                ctx.Set("ratelimit_policy", policy.Name)
+
+                opts.OnLimited(ctx, res)
+                ctx.Abort()
+                return
+            }
+        }
+
+        ctx.Next()
+    }
+}
+
+// 消耗额度，返回被限流或者剩余额度最少的结果
+func take(ctx *router.Context, opts Options, policies []ratelimit.Policy) (ratelimit.Result, ratelimit.Policy, bool) {
+    var (
+        current ratelimit.Result
+        policy  ratelimit.Policy
+        found   bool
+    )
+
+    for _, p := range policies {
+        key := p.Name + ":" + identity(ctx, p.By)
+
+        res, err := opts.Limiter.Take(ctx.Request.Context(), key, p)
+        if err != nil {
+            if opts.OnError != nil {
+                opts.OnError(ctx, err)
+            }
+
+            continue
+        }
+
+        if !res.Allowed {
+            return res, p, true
+        }
+
+        if !found || res.Remaining < current.Remaining {
+            current, policy, found = res, p, true
+        }
+    }
+
+    return current, policy, found
+}
+
+// 设置响应头
+func setHeaders(ctx *router.Context, res ratelimit.Result, policies []ratelimit.Policy) {
+    values := make([]string, 0, len(policies))
+    for _, p := range policies {
+        values = append(values, p.HeaderValue())
+    }
+
+    ctx.Header(HeaderLimit, strconv.Itoa(res.Limit))
+    ctx.Header(HeaderRemaining, strconv.Itoa(res.Remaining))
+    ctx.Header(HeaderReset, strconv.FormatInt(res.ResetSeconds(), 10))
+    ctx.Header(HeaderPolicy, strings.Join(values, ", "))
+}
+
+// 默认被限流响应
+func defaultOnLimited(ctx *router.Context, res ratelimit.Result) {
+    ctx.JSON(http.StatusTooManyRequests, router.H{
+        "success": false,
+        "code":    http.StatusTooManyRequests,
+        "message": "请求过于频繁，请稍后再试",
+        "data":    router.H{},
+    })
+}
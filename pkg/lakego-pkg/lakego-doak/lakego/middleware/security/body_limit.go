@@ -0,0 +1,122 @@
+package security
+
+import (
+    "fmt"
+    "sort"
+    "errors"
+    "strconv"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 大小单位
+var sizeUnits = map[string]int64{
+    "":   1,
+    "B":  1,
+    "K":  1 << 10,
+    "KB": 1 << 10,
+    "M":  1 << 20,
+    "MB": 1 << 20,
+    "G":  1 << 30,
+    "GB": 1 << 30,
+}
+
+/**
+ * 请求数据大小限制，超过时返回 413
+ *
+ * @create 2024-5-30
+ * @author deatil
+ */
+func BodyLimit(limit int64) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        limitBody(ctx, limit)
+    }
+}
+
+/**
+ * 按路由分组前缀限制请求数据大小，使用最长匹配的前缀，没有匹配时使用默认大小
+ *
+ * @create 2024-5-30
+ * @author deatil
+ */
+func GroupBodyLimit(defaultLimit int64, groups map[string]int64) router.HandlerFunc {
+    prefixes := make([]string, 0, len(groups))
+    limits := make(map[string]int64, len(groups))
+    for prefix, limit := range groups {
+        prefix = "/" + strings.Trim(prefix, "/")
+
+        prefixes = append(prefixes, prefix)
+        limits[prefix] = limit
+    }
+
+    // 长的前缀优先匹配
+    sort.Slice(prefixes, func(i, j int) bool {
+        return len(prefixes[i]) > len(prefixes[j])
+    })
+
+    return func(ctx *router.Context) {
+        limit := defaultLimit
+
+        path := ctx.Request.URL.Path
+        for _, prefix := range prefixes {
+            if prefix == "/" || path == prefix || strings.HasPrefix(path, prefix + "/") {
+                limit = limits[prefix]
+                break
+            }
+        }
+
+        limitBody(ctx, limit)
+    }
+}
+
+// 解析大小，比如 10MB, 512KB, 1024
+func ParseSize(size string) (int64, error) {
+    size = strings.ToUpper(strings.TrimSpace(size))
+    if size == "" {
+        return 0, nil
+    }
+
+    i := strings.IndexFunc(size, func(r rune) bool {
+        return (r < '0' || r > '9') && r != '.'
+    })
+    if i < 0 {
+        i = len(size)
+    }
+
+    unit, ok := sizeUnits[strings.TrimSpace(size[i:])]
+    if !ok {
+        return 0, errors.New(fmt.Sprintf("大小 [%s] 单位错误", size))
+    }
+
+    num, err := strconv.ParseFloat(size[:i], 64)
+    if err != nil || num < 0 {
+        return 0, errors.New(fmt.Sprintf("大小 [%s] 格式错误", size))
+    }
+
+    return int64(num * float64(unit)), nil
+}
+
+// 限制请求数据
+func limitBody(ctx *router.Context, limit int64) {
+    if limit <= 0 || ctx.Request.Body == nil {
+        ctx.Next()
+        return
+    }
+
+    if ctx.Request.ContentLength > limit {
+        ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, router.H{
+            "success": false,
+            "code":    http.StatusRequestEntityTooLarge,
+            "message": "请求数据过大",
+            "data":    router.H{},
+        })
+        return
+    }
+
+    // 未设置长度或者长度不准确时，读取超过限制会返回错误
+    ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+
+    ctx.Next()
+}
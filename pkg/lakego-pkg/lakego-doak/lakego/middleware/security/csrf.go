@@ -0,0 +1,197 @@
+package security
+
+import (
+    "time"
+    "strings"
+    "net/http"
+    "crypto/hmac"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// csrf 令牌视图数据键名
+const CSRFTokenKey = "csrf_token"
+
+// csrf 配置
+type CSRFOptions struct {
+    // 签名密钥，为空时只比较 cookie 及请求数据
+    Secret string
+
+    // cookie 名称
+    CookieName string
+
+    // cookie 路径
+    CookiePath string
+
+    // cookie 域名
+    CookieDomain string
+
+    // cookie 有效时间，为 0 时为会话 cookie
+    CookieMaxAge time.Duration
+
+    // cookie 只在 https 中发送
+    Secure bool
+
+    // cookie SameSite
+    SameSite http.SameSite
+
+    // 请求头
+    HeaderName string
+
+    // 表单字段
+    FieldName string
+
+    // 不验证的路径，支持 POST:/example/* 格式
+    Except []string
+
+    // 验证失败时的响应，默认返回 403
+    OnError func(*router.Context)
+}
+
+/**
+ * 双重提交 cookie 方式的 csrf 验证，用于使用 cookie 的路由
+ *
+ * 表单：<input type="hidden" name="_csrf" value="{{ csrf_token }}">
+ * ajax：请求头 X-CSRF-Token 设置为 cookie 中的令牌
+ *
+ * @create 2024-5-30
+ * @author deatil
+ */
+func CSRF(opts CSRFOptions) router.HandlerFunc {
+    if opts.CookieName == "" {
+        opts.CookieName = "lakego_csrf"
+    }
+    if opts.CookiePath == "" {
+        opts.CookiePath = "/"
+    }
+    if opts.SameSite == 0 {
+        opts.SameSite = http.SameSiteLaxMode
+    }
+    if opts.HeaderName == "" {
+        opts.HeaderName = "X-CSRF-Token"
+    }
+    if opts.FieldName == "" {
+        opts.FieldName = "_csrf"
+    }
+    if opts.OnError == nil {
+        opts.OnError = defaultCSRFError
+    }
+
+    return func(ctx *router.Context) {
+        token, _ := ctx.Cookie(opts.CookieName)
+
+        issued := false
+        if !validCSRFToken(token, opts.Secret) {
+            token = newCSRFToken(opts.Secret)
+            issued = true
+
+            cookie := &http.Cookie{
+                Name:     opts.CookieName,
+                Value:    token,
+                Path:     opts.CookiePath,
+                Domain:   opts.CookieDomain,
+                Secure:   opts.Secure,
+                SameSite: opts.SameSite,
+                // 需要脚本读取后放入请求头
+                HttpOnly: false,
+            }
+            if opts.CookieMaxAge > 0 {
+                cookie.MaxAge = int(opts.CookieMaxAge / time.Second)
+            }
+
+            http.SetCookie(ctx.Writer, cookie)
+        }
+
+        ctx.Set(CSRFTokenKey, token)
+        router.WithViewData(ctx, CSRFTokenKey, token)
+
+        if safeMethod(ctx.Request.Method) || matchPaths(ctx, opts.Except) {
+            ctx.Next()
+            return
+        }
+
+        submitted := ctx.GetHeader(opts.HeaderName)
+        if submitted == "" {
+            submitted = ctx.PostForm(opts.FieldName)
+        }
+
+        // 新生成的令牌说明请求没有带上有效 cookie
+        if issued ||
+            submitted == "" ||
+            subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+            opts.OnError(ctx)
+            ctx.Abort()
+            return
+        }
+
+        ctx.Next()
+    }
+}
+
+// 当前请求的 csrf 令牌
+func CSRFToken(ctx *router.Context) string {
+    return ctx.GetString(CSRFTokenKey)
+}
+
+// 生成令牌，有密钥时带上签名
+func newCSRFToken(secret string) string {
+    buf := make([]byte, 32)
+    rand.Read(buf)
+
+    token := base64.RawURLEncoding.EncodeToString(buf)
+    if secret == "" {
+        return token
+    }
+
+    return token + "." + signCSRFToken(token, secret)
+}
+
+// 检测令牌格式及签名
+func validCSRFToken(token string, secret string) bool {
+    if token == "" {
+        return false
+    }
+
+    if secret == "" {
+        return !strings.Contains(token, ".")
+    }
+
+    value, sign, ok := strings.Cut(token, ".")
+    if !ok || value == "" {
+        return false
+    }
+
+    return hmac.Equal([]byte(sign), []byte(signCSRFToken(value, secret)))
+}
+
+// 令牌签名
+func signCSRFToken(token string, secret string) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(token))
+
+    return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// 不修改数据的请求方式
+func safeMethod(method string) bool {
+    switch method {
+        case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+            return true
+    }
+
+    return false
+}
+
+// 默认验证失败响应
+func defaultCSRFError(ctx *router.Context) {
+    ctx.JSON(http.StatusForbidden, router.H{
+        "success": false,
+        "code":    http.StatusForbidden,
+        "message": "CSRF 令牌验证失败",
+        "data":    router.H{},
+    })
+}
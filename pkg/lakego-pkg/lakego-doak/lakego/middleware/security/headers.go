@@ -0,0 +1,162 @@
+package security
+
+import (
+    "net"
+    "fmt"
+    "time"
+    "strings"
+    "crypto/rand"
+    "encoding/base64"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 内容安全策略中的 nonce 占位符
+const NoncePlaceholder = "{nonce}"
+
+// nonce 视图数据键名
+const NonceKey = "csp_nonce"
+
+// 响应头配置
+type HeadersOptions struct {
+    // HSTS 有效时间，为 0 时不设置，只在 https 请求中设置
+    HSTSMaxAge time.Duration
+
+    // HSTS 包括子域名
+    HSTSIncludeSubdomains bool
+
+    // HSTS 预加载
+    HSTSPreload bool
+
+    // 内容安全策略，使用 {nonce} 时每个请求生成 nonce
+    ContentSecurityPolicy string
+
+    // 内容安全策略只报告不拦截
+    CSPReportOnly bool
+
+    // 不设置内容安全策略的路径，支持 GET:/swagger/* 格式
+    CSPExcept []string
+
+    // X-Frame-Options
+    FrameOptions string
+
+    // Referrer-Policy
+    ReferrerPolicy string
+
+    // Permissions-Policy
+    PermissionsPolicy string
+
+    // X-Content-Type-Options: nosniff
+    ContentTypeNosniff bool
+}
+
+/**
+ * 安全响应头
+ *
+ * 模板中使用 nonce：<script nonce="{{ csp_nonce }}"></script>
+ *
+ * @create 2024-5-30
+ * @author deatil
+ */
+func Headers(opts HeadersOptions) router.HandlerFunc {
+    hsts := ""
+    if opts.HSTSMaxAge > 0 {
+        hsts = fmt.Sprintf("max-age=%d", int64(opts.HSTSMaxAge / time.Second))
+        if opts.HSTSIncludeSubdomains {
+            hsts += "; includeSubDomains"
+        }
+        if opts.HSTSPreload {
+            hsts += "; preload"
+        }
+    }
+
+    cspHeader := "Content-Security-Policy"
+    if opts.CSPReportOnly {
+        cspHeader = "Content-Security-Policy-Report-Only"
+    }
+
+    useNonce := strings.Contains(opts.ContentSecurityPolicy, NoncePlaceholder)
+
+    return func(ctx *router.Context) {
+        header := ctx.Writer.Header()
+
+        if hsts != "" && IsSecure(ctx) {
+            header.Set("Strict-Transport-Security", hsts)
+        }
+
+        if opts.ContentSecurityPolicy != "" && !matchPaths(ctx, opts.CSPExcept) {
+            policy := opts.ContentSecurityPolicy
+
+            if useNonce {
+                nonce := newNonce()
+
+                ctx.Set(NonceKey, nonce)
+                router.WithViewData(ctx, NonceKey, nonce)
+
+                policy = strings.ReplaceAll(policy, NoncePlaceholder, "'nonce-" + nonce + "'")
+            }
+
+            header.Set(cspHeader, policy)
+        }
+
+        if opts.FrameOptions != "" {
+            header.Set("X-Frame-Options", opts.FrameOptions)
+        }
+
+        if opts.ReferrerPolicy != "" {
+            header.Set("Referrer-Policy", opts.ReferrerPolicy)
+        }
+
+        if opts.PermissionsPolicy != "" {
+            header.Set("Permissions-Policy", opts.PermissionsPolicy)
+        }
+
+        if opts.ContentTypeNosniff {
+            header.Set("X-Content-Type-Options", "nosniff")
+        }
+
+        ctx.Next()
+    }
+}
+
+// 当前请求的 nonce
+func Nonce(ctx *router.Context) string {
+    return ctx.GetString(NonceKey)
+}
+
+// 是否为 https 请求，来自可信代理时使用 X-Forwarded-Proto
+func IsSecure(ctx *router.Context) bool {
+    if ctx.Request.TLS != nil {
+        return true
+    }
+
+    remote, _, err := net.SplitHostPort(ctx.Request.RemoteAddr)
+    if err != nil {
+        remote = ctx.Request.RemoteAddr
+    }
+
+    if !router.IsTrustedProxy(remote) {
+        return false
+    }
+
+    return strings.EqualFold(ctx.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// 生成 nonce
+func newNonce() string {
+    buf := make([]byte, 16)
+    rand.Read(buf)
+
+    return base64.StdEncoding.EncodeToString(buf)
+}
+
+// 匹配路径
+func matchPaths(ctx *router.Context, paths []string) bool {
+    for _, path := range paths {
+        if router.MatchPath(ctx, path, ctx.Request.URL.Path) {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,190 @@
+package security
+
+import (
+    "io"
+    "time"
+    "strings"
+    "testing"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func init() {
+    router.SetMode(router.TestMode)
+}
+
+func Test_Headers(t *testing.T) {
+    r := router.New()
+    r.Use(Headers(HeadersOptions{
+        HSTSMaxAge:            time.Hour,
+        ContentSecurityPolicy: "script-src 'self' {nonce}",
+        CSPExcept:             []string{"/swagger/*"},
+        FrameOptions:          "DENY",
+        ContentTypeNosniff:    true,
+    }))
+    r.GET("/", func(ctx *router.Context) {
+        ctx.String(200, Nonce(ctx))
+    })
+    r.GET("/swagger/index", func(ctx *router.Context) {})
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+    nonce := w.Body.String()
+    if nonce == "" {
+        t.Fatal("nonce is empty")
+    }
+    if got := w.Header().Get("Content-Security-Policy"); got != "script-src 'self' 'nonce-" + nonce + "'" {
+        t.Errorf("CSP got %s", got)
+    }
+    if w.Header().Get("X-Frame-Options") != "DENY" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+        t.Errorf("headers got %v", w.Header())
+    }
+    // http 请求不设置 hsts
+    if w.Header().Get("Strict-Transport-Security") != "" {
+        t.Error("HSTS should not be set on http")
+    }
+
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "https://example.com/", nil))
+    if got := w.Header().Get("Strict-Transport-Security"); got != "max-age=3600" {
+        t.Errorf("HSTS got %s", got)
+    }
+    if w.Body.String() == nonce {
+        t.Error("nonce should change per request")
+    }
+
+    w = httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/index", nil))
+    if w.Header().Get("Content-Security-Policy") != "" {
+        t.Error("CSP should be skipped")
+    }
+}
+
+func Test_CSRF(t *testing.T) {
+    r := router.New()
+    r.Use(CSRF(CSRFOptions{
+        Secret: "test-secret",
+    }))
+    r.GET("/form", func(ctx *router.Context) {
+        ctx.String(200, CSRFToken(ctx))
+    })
+    r.POST("/submit", func(ctx *router.Context) {
+        ctx.String(200, "ok")
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/form", nil))
+
+    cookies := w.Result().Cookies()
+    if len(cookies) != 1 || cookies[0].Name != "lakego_csrf" || cookies[0].Value != w.Body.String() {
+        t.Fatalf("cookie got %v", cookies)
+    }
+
+    token := cookies[0].Value
+
+    post := func(cookie string, header string, form string) int {
+        req := httptest.NewRequest("POST", "/submit", strings.NewReader(form))
+        req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+        if cookie != "" {
+            req.AddCookie(&http.Cookie{Name: "lakego_csrf", Value: cookie})
+        }
+        if header != "" {
+            req.Header.Set("X-CSRF-Token", header)
+        }
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        return w.Code
+    }
+
+    if code := post(token, token, ""); code != 200 {
+        t.Errorf("header token got %d", code)
+    }
+    if code := post(token, "", "_csrf=" + token); code != 200 {
+        t.Errorf("form token got %d", code)
+    }
+    if code := post(token, "", ""); code != 403 {
+        t.Errorf("missing token got %d", code)
+    }
+    if code := post(token, token + "x", ""); code != 403 {
+        t.Errorf("wrong token got %d", code)
+    }
+    if code := post("", token, ""); code != 403 {
+        t.Errorf("missing cookie got %d", code)
+    }
+
+    // 签名错误的 cookie 不能使用
+    forged := "abc.def"
+    if code := post(forged, forged, ""); code != 403 {
+        t.Errorf("forged token got %d", code)
+    }
+}
+
+func Test_BodyLimit(t *testing.T) {
+    r := router.New()
+    r.Use(GroupBodyLimit(8, map[string]int64{
+        "/upload/": 16,
+    }))
+    r.POST("/*path", func(ctx *router.Context) {
+        if _, err := io.ReadAll(ctx.Request.Body); err != nil {
+            ctx.String(http.StatusRequestEntityTooLarge, err.Error())
+            return
+        }
+
+        ctx.String(200, "ok")
+    })
+
+    post := func(path string, body string, chunked bool) int {
+        req := httptest.NewRequest("POST", path, strings.NewReader(body))
+        if chunked {
+            req.ContentLength = -1
+        }
+
+        w := httptest.NewRecorder()
+        r.ServeHTTP(w, req)
+
+        return w.Code
+    }
+
+    if code := post("/a", "12345678", false); code != 200 {
+        t.Errorf("got %d", code)
+    }
+    if code := post("/a", "123456789", false); code != 413 {
+        t.Errorf("got %d", code)
+    }
+    if code := post("/a", "123456789", true); code != 413 {
+        t.Errorf("chunked got %d", code)
+    }
+    if code := post("/upload/file", "123456789", false); code != 200 {
+        t.Errorf("group got %d", code)
+    }
+    if code := post("/uploads", "123456789", false); code != 413 {
+        t.Errorf("prefix should match path segments, got %d", code)
+    }
+}
+
+func Test_ParseSize(t *testing.T) {
+    tests := map[string]int64{
+        "":      0,
+        "1024":  1024,
+        "10KB":  10 << 10,
+        "1.5mb": 3 << 19,
+        "2 G":   2 << 30,
+    }
+    for size, want := range tests {
+        got, err := ParseSize(size)
+        if err != nil || got != want {
+            t.Errorf("ParseSize(%q) got %d, %v", size, got, err)
+        }
+    }
+
+    for _, size := range []string{"10TB", "abc", "-1MB"} {
+        if _, err := ParseSize(size); err == nil {
+            t.Errorf("ParseSize(%q) should fail", size)
+        }
+    }
+}
@@ -0,0 +1,133 @@
+package trace
+
+import (
+    "fmt"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 默认请求 id 请求头
+const DefaultHeader = "X-Request-Id"
+
+// W3C 链路请求头
+const TraceparentHeader = "traceparent"
+
+// 请求 id 最大长度
+const maxRequestIDLength = 128
+
+// 配置
+type Options struct {
+    // 请求 id 请求头
+    Header string
+
+    // 是否使用上游请求传入的 traceparent
+    TrustTraceparent bool
+
+    // 不记录节点的路径
+    SkipPaths []string
+}
+
+/**
+ * 链路追踪，生成或者沿用请求 id 及 traceparent，并记录请求节点
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+func Handler(opts Options) router.HandlerFunc {
+    if opts.Header == "" {
+        opts.Header = DefaultHeader
+    }
+
+    skip := make(map[string]bool)
+    for _, path := range opts.SkipPaths {
+        skip[path] = true
+    }
+
+    return func(ctx *router.Context) {
+        requestID := ctx.GetHeader(opts.Header)
+        if !validRequestID(requestID) {
+            requestID = uuid.ToUUIDString()
+        }
+
+        c := trace.ContextWithRequestID(ctx.Request.Context(), requestID)
+
+        if opts.TrustTraceparent {
+            if parent, err := trace.ParseTraceparent(ctx.GetHeader(TraceparentHeader)); err == nil {
+                c = trace.ContextWithSpanContext(c, parent)
+            }
+        }
+
+        c, span := trace.Start(c, ctx.Request.Method+" "+ctx.Request.URL.Path, trace.KindServer)
+        sc := span.SpanContext()
+
+        ctx.Request = ctx.Request.WithContext(c)
+
+        ctx.Set(router.RequestIDKey, requestID)
+        ctx.Set(router.TraceIDKey, sc.TraceID.String())
+
+        ctx.Header(opts.Header, requestID)
+        ctx.Header(TraceparentHeader, sc.Traceparent())
+
+        // 后续处理 panic 时也需要结束节点
+        defer func() {
+            r := recover()
+
+            if !skip[ctx.Request.URL.Path] {
+                endSpan(ctx, span, requestID, r)
+            }
+
+            // 交给恢复中间件处理
+            if r != nil {
+                panic(r)
+            }
+        }()
+
+        ctx.Next()
+    }
+}
+
+// 记录请求信息并结束节点，r 为 panic 的值
+func endSpan(ctx *router.Context, span *trace.Span, requestID string, r any) {
+    status := ctx.Writer.Status()
+    if r != nil {
+        status = http.StatusInternalServerError
+    }
+
+    // 使用路由规则作为名称，避免路径参数导致名称过多
+    if route := ctx.FullPath(); route != "" {
+        span.SetName(ctx.Request.Method + " " + route)
+        span.SetAttribute("http.route", route)
+    }
+
+    span.SetAttributes(map[string]any{
+        "http.method":      ctx.Request.Method,
+        "http.target":      ctx.Request.URL.Path,
+        "http.status_code": status,
+        "http.client_ip":   router.GetRequestIp(ctx),
+        "http.user_agent":  ctx.Request.UserAgent(),
+        "request.id":       requestID,
+    })
+
+    if r != nil {
+        span.RecordError(fmt.Errorf("panic: %v", r))
+    } else if status >= 500 {
+        span.SetStatus(trace.StatusError, ctx.Errors.String())
+    }
+
+    span.End()
+}
+
+// 请求 id 只允许可见字符
+func validRequestID(id string) bool {
+    if id == "" || len(id) > maxRequestIDLength {
+        return false
+    }
+
+    return strings.IndexFunc(id, func(r rune) bool {
+        return r < 0x21 || r > 0x7e
+    }) < 0
+}
@@ -0,0 +1,57 @@
+package trace
+
+import (
+    "io"
+    "bytes"
+    "context"
+    "testing"
+    "encoding/json"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func init() {
+    router.SetMode(router.TestMode)
+}
+
+func Test_HandlerPanic(t *testing.T) {
+    buf := new(bytes.Buffer)
+    tracer := trace.NewTracer(trace.NewWriterExporter(buf, "test"), trace.Options{SampleRatio: 1})
+
+    old := trace.Default()
+    trace.SetDefault(tracer)
+    defer trace.SetDefault(old)
+
+    r := router.New()
+    r.Use(router.RecoveryWithWriter(io.Discard))
+    r.Use(Handler(Options{}))
+    r.GET("/panic/:id", func(ctx *router.Context) {
+        panic("handler panic")
+    })
+
+    w := httptest.NewRecorder()
+    r.ServeHTTP(w, httptest.NewRequest("GET", "/panic/1", nil))
+    if w.Code != 500 {
+        t.Errorf("got %d", w.Code)
+    }
+
+    if err := tracer.Shutdown(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+
+    var span map[string]any
+    if err := json.Unmarshal(buf.Bytes(), &span); err != nil {
+        t.Fatalf("span not exported: %s", buf.String())
+    }
+
+    if span["name"] != "GET /panic/:id" || span["status"] != "error" || span["message"] != "panic: handler panic" {
+        t.Errorf("got %v", span)
+    }
+
+    attrs, _ := span["attributes"].(map[string]any)
+    if attrs["http.status_code"] != float64(500) {
+        t.Errorf("status_code got %v", attrs["http.status_code"])
+    }
+}
@@ -0,0 +1,365 @@
+package openapi
+
+import (
+    "sort"
+    "sync"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 鉴权方式获取，返回路由默认使用的鉴权方式名称
+type SecurityFunc func(method string, path string) []string
+
+// 构造函数
+func New() *Generator {
+    return &Generator{
+        info: Info{
+            Title:   "lakego-admin",
+            Version: "1.0.0",
+        },
+        schemes:   make(map[string]*SecurityScheme),
+        schemas:   make(map[string]*Schema),
+        requests:  make(map[string]*Schema),
+        responses: make(map[string]*Schema),
+    }
+}
+
+/**
+ * OpenAPI 文档生成
+ *
+ * 根据注册的路由及 router.WithMeta 设置的元数据生成文档，
+ * 请求数据结构使用 slug 对应的验证规则生成
+ *
+ * @create 2024-5-21
+ * @author deatil
+ */
+type Generator struct {
+    mu sync.RWMutex
+
+    // 文档信息
+    info    Info
+    servers []Server
+
+    // 跳过的路由前缀
+    skipPaths []string
+
+    // 鉴权
+    schemes  map[string]*SecurityScheme
+    security SecurityFunc
+
+    // 通用组件
+    schemas map[string]*Schema
+
+    // 响应外层数据及数据字段
+    envelope      *Schema
+    envelopeField string
+
+    // 请求及响应数据，键名为 slug
+    requests  map[string]*Schema
+    responses map[string]*Schema
+}
+
+// 设置文档信息
+func (this *Generator) WithInfo(info Info) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.info = info
+
+    return this
+}
+
+// 添加服务地址
+func (this *Generator) AddServer(url string, description string) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.servers = append(this.servers, Server{
+        URL:         url,
+        Description: description,
+    })
+
+    return this
+}
+
+// 跳过路由前缀
+func (this *Generator) SkipPath(prefix ...string) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.skipPaths = append(this.skipPaths, prefix...)
+
+    return this
+}
+
+// 添加鉴权方式
+func (this *Generator) AddSecurityScheme(name string, scheme *SecurityScheme) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.schemes[name] = scheme
+
+    return this
+}
+
+// 设置默认鉴权
+func (this *Generator) WithSecurity(f SecurityFunc) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.security = f
+
+    return this
+}
+
+// 添加通用组件
+func (this *Generator) AddSchema(name string, schema *Schema) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.schemas[name] = schema
+
+    return this
+}
+
+// 设置响应外层数据，field 为数据字段名称
+func (this *Generator) WithEnvelope(schema *Schema, field string) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.envelope = schema
+    this.envelopeField = field
+
+    return this
+}
+
+// 设置请求数据
+func (this *Generator) SetRequest(slug string, schema *Schema) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.requests[slug] = schema
+
+    return this
+}
+
+// 使用验证规则设置请求数据
+func (this *Generator) SetRequestRules(slug string, rules map[string]any, messages map[string]string) *Generator {
+    return this.SetRequest(slug, SchemaFromRules(rules, messages))
+}
+
+// 设置响应数据
+func (this *Generator) SetResponse(slug string, schema *Schema) *Generator {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.responses[slug] = schema
+
+    return this
+}
+
+// 使用已注册的路由生成
+func (this *Generator) Build() *Document {
+    return this.Generate(router.NewRoute().GetRoutes(), router.NewMeta().GetMetas())
+}
+
+// 生成文档
+func (this *Generator) Generate(routes router.RoutesInfo, metas []router.RouteMeta) *Document {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    metaMap := make(map[string]router.RouteMeta, len(metas))
+    for _, meta := range metas {
+        metaMap[strings.ToUpper(meta.Method) + " " + meta.Path] = meta
+    }
+
+    doc := &Document{
+        OpenAPI: Version,
+        Info:    this.info,
+        Servers: this.servers,
+        Paths:   make(map[string]PathItem),
+        Components: Components{
+            Schemas:         make(map[string]*Schema),
+            SecuritySchemes: this.schemes,
+        },
+    }
+
+    for name, schema := range this.schemas {
+        doc.Components.Schemas[name] = schema
+    }
+
+    tags := make(map[string]bool)
+
+    for _, route := range routes {
+        method := strings.ToUpper(route.Method)
+        if method == "HEAD" || this.skip(route.Path) {
+            continue
+        }
+
+        meta := metaMap[method + " " + route.Path]
+
+        path, params := ConvertPath(route.Path)
+
+        op := &Operation{
+            Summary:     meta.Title,
+            Description: meta.Description,
+            OperationID: meta.Slug,
+            Slug:        meta.Slug,
+            Parameters:  params,
+            Responses:   this.responsesFor(meta.Slug),
+        }
+
+        op.Tags = meta.Tags
+        if len(op.Tags) == 0 && meta.Parent != "" {
+            op.Tags = []string{meta.Parent}
+        }
+        for _, tag := range op.Tags {
+            tags[tag] = true
+        }
+
+        security := meta.Security
+        if security == nil && this.security != nil {
+            security = this.security(method, route.Path)
+        }
+        for _, name := range security {
+            op.Security = append(op.Security, map[string][]string{
+                name: {},
+            })
+        }
+
+        if schema, ok := this.requests[meta.Slug]; ok && meta.Slug != "" {
+            if method == "GET" || method == "DELETE" {
+                op.Parameters = append(op.Parameters, queryParameters(schema)...)
+            } else {
+                op.RequestBody = &RequestBody{
+                    Required: len(schema.Required) > 0,
+                    Content: map[string]MediaType{
+                        "application/json": {
+                            Schema: schema,
+                        },
+                    },
+                }
+            }
+        }
+
+        item, ok := doc.Paths[path]
+        if !ok {
+            item = make(PathItem)
+            doc.Paths[path] = item
+        }
+
+        item[strings.ToLower(method)] = op
+    }
+
+    for tag := range tags {
+        doc.Tags = append(doc.Tags, Tag{
+            Name: tag,
+        })
+    }
+
+    sort.Slice(doc.Tags, func(i, j int) bool {
+        return doc.Tags[i].Name < doc.Tags[j].Name
+    })
+
+    return doc
+}
+
+// 响应数据
+func (this *Generator) responsesFor(slug string) map[string]Response {
+    data := this.responses[slug]
+
+    schema := data
+    if this.envelope != nil {
+        schema = this.envelope.Clone()
+        if data != nil && this.envelopeField != "" {
+            if schema.Properties == nil {
+                schema.Properties = make(map[string]*Schema)
+            }
+
+            schema.Properties[this.envelopeField] = data
+        }
+    }
+
+    resp := Response{
+        Description: "OK",
+    }
+    if schema != nil {
+        resp.Content = map[string]MediaType{
+            "application/json": {
+                Schema: schema,
+            },
+        }
+    }
+
+    return map[string]Response{
+        "200": resp,
+    }
+}
+
+// 是否跳过
+func (this *Generator) skip(path string) bool {
+    for _, prefix := range this.skipPaths {
+        if strings.HasPrefix(path, prefix) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 转换路由格式，比如 /admin/:id 转为 /admin/{id}，并返回路径参数
+func ConvertPath(path string) (string, []Parameter) {
+    params := make([]Parameter, 0)
+
+    segments := strings.Split(path, "/")
+    for i, seg := range segments {
+        if len(seg) < 2 || (seg[0] != ':' && seg[0] != '*') {
+            continue
+        }
+
+        name := seg[1:]
+        segments[i] = "{" + name + "}"
+
+        params = append(params, Parameter{
+            Name:     name,
+            In:       "path",
+            Required: true,
+            Schema: &Schema{
+                Type: "string",
+            },
+        })
+    }
+
+    return strings.Join(segments, "/"), params
+}
+
+// 查询参数
+func queryParameters(schema *Schema) []Parameter {
+    names := make([]string, 0, len(schema.Properties))
+    for name := range schema.Properties {
+        names = append(names, name)
+    }
+
+    sort.Strings(names)
+
+    required := make(map[string]bool)
+    for _, name := range schema.Required {
+        required[name] = true
+    }
+
+    params := make([]Parameter, 0, len(names))
+    for _, name := range names {
+        prop := schema.Properties[name]
+
+        params = append(params, Parameter{
+            Name:        name,
+            In:          "query",
+            Description: prop.Title,
+            Required:    required[name],
+            Schema:      prop,
+        })
+    }
+
+    return params
+}
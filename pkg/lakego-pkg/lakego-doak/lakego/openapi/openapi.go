@@ -0,0 +1,58 @@
+package openapi
+
+import (
+    "os"
+    "encoding/json"
+    "path/filepath"
+)
+
+// 默认生成器
+var defaultGenerator = New()
+
+// 设置默认生成器
+func SetDefault(g *Generator) {
+    defaultGenerator = g
+}
+
+// 默认生成器
+func Default() *Generator {
+    return defaultGenerator
+}
+
+// 使用验证规则设置请求数据
+func SetRequestRules(slug string, rules map[string]any, messages map[string]string) *Generator {
+    return defaultGenerator.SetRequestRules(slug, rules, messages)
+}
+
+// 设置响应数据
+func SetResponse(slug string, schema *Schema) *Generator {
+    return defaultGenerator.SetResponse(slug, schema)
+}
+
+// 使用已注册的路由生成
+func Build() *Document {
+    return defaultGenerator.Build()
+}
+
+// 生成 json
+func Marshal(doc *Document, indent bool) ([]byte, error) {
+    if indent {
+        return json.MarshalIndent(doc, "", "  ")
+    }
+
+    return json.Marshal(doc)
+}
+
+// 写入文件
+func WriteFile(doc *Document, file string) error {
+    data, err := Marshal(doc, true)
+    if err != nil {
+        return err
+    }
+
+    if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+        return err
+    }
+
+    return os.WriteFile(file, data, 0644)
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+    "testing"
+    "encoding/json"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func Test_SchemaFromRules(t *testing.T) {
+    rules := map[string]any{
+        "name": "required,min=2,max=20",
+        "email": "required,email,max=100",
+        "avatar": "len=36",
+        "type": "oneof=a b",
+        "status": "required",
+    }
+    messages := map[string]string{
+        "name.required": "账号不能为空",
+        "status.required": "状态选项不能为空",
+    }
+
+    s := SchemaFromRules(rules, messages)
+
+    if len(s.Required) != 3 || s.Required[0] != "email" || s.Required[1] != "name" || s.Required[2] != "status" {
+        t.Fatalf("Required got %v", s.Required)
+    }
+
+    name := s.Properties["name"]
+    if name.Type != "string" || *name.MinLength != 2 || *name.MaxLength != 20 || name.Title != "账号" {
+        t.Errorf("name got %+v", name)
+    }
+
+    if s.Properties["email"].Format != "email" {
+        t.Errorf("email format got %s", s.Properties["email"].Format)
+    }
+
+    avatar := s.Properties["avatar"]
+    if *avatar.MinLength != 36 || *avatar.MaxLength != 36 {
+        t.Errorf("avatar got %+v", avatar)
+    }
+
+    if len(s.Properties["type"].Enum) != 2 {
+        t.Errorf("type enum got %v", s.Properties["type"].Enum)
+    }
+
+    status := s.Properties["status"]
+    if status.Type != "" || status.Title != "状态" {
+        t.Errorf("status got %+v", status)
+    }
+}
+
+func Test_SchemaOf(t *testing.T) {
+    type result struct {
+        Success bool   `json:"success"`
+        Code    int    `json:"code"`
+        Message string `json:"message,omitempty"`
+        Data    any    `json:"data"`
+        hidden  string
+    }
+
+    s := SchemaOf(result{})
+    if s.Type != "object" || len(s.Properties) != 4 {
+        t.Fatalf("SchemaOf got %+v", s)
+    }
+
+    if s.Properties["success"].Type != "boolean" || s.Properties["code"].Type != "integer" {
+        t.Errorf("SchemaOf properties got %+v", s.Properties)
+    }
+
+    if len(s.Required) != 3 {
+        t.Errorf("SchemaOf required got %v", s.Required)
+    }
+}
+
+func Test_ConvertPath(t *testing.T) {
+    path, params := ConvertPath("/admin-api/admin/:id/files/*any")
+    if path != "/admin-api/admin/{id}/files/{any}" {
+        t.Errorf("ConvertPath got %s", path)
+    }
+
+    if len(params) != 2 || params[0].Name != "id" || params[1].Name != "any" {
+        t.Errorf("ConvertPath params got %+v", params)
+    }
+}
+
+func Test_Generate(t *testing.T) {
+    g := New().
+        SkipPath("/swagger").
+        AddSecurityScheme("Bearer", BearerScheme("JWT")).
+        WithSecurity(func(method string, path string) []string {
+            return []string{"Bearer"}
+        }).
+        WithEnvelope(&Schema{
+            Type: "object",
+            Properties: map[string]*Schema{
+                "success": {Type: "boolean"},
+                "data":    {},
+            },
+        }, "data").
+        SetRequestRules("admin.create", map[string]any{
+            "name": "required,max=20",
+        }, nil).
+        SetResponse("admin.detail", &Schema{Type: "object"})
+
+    routes := gin.RoutesInfo{
+        {Method: "GET", Path: "/admin/:id"},
+        {Method: "POST", Path: "/admin"},
+        {Method: "POST", Path: "/passport/login"},
+        {Method: "GET", Path: "/swagger/*any"},
+    }
+
+    metas := []router.RouteMeta{
+        {Method: "GET", Path: "/admin/:id", Slug: "admin.detail", Title: "详情", Parent: "管理员"},
+        {Method: "POST", Path: "/admin", Slug: "admin.create", Title: "添加", Parent: "管理员"},
+        {Method: "POST", Path: "/passport/login", Slug: "passport.login", Security: []string{}},
+    }
+
+    doc := g.Generate(routes, metas)
+
+    if doc.OpenAPI != Version || len(doc.Paths) != 3 {
+        t.Fatalf("Generate paths got %v", doc.Paths)
+    }
+
+    detail := doc.Paths["/admin/{id}"]["get"]
+    if detail == nil || detail.Summary != "详情" || detail.Tags[0] != "管理员" || len(detail.Parameters) != 1 {
+        t.Fatalf("detail got %+v", detail)
+    }
+
+    if len(detail.Security) != 1 {
+        t.Errorf("detail security got %v", detail.Security)
+    }
+
+    data := detail.Responses["200"].Content["application/json"].Schema.Properties["data"]
+    if data.Type != "object" {
+        t.Errorf("detail response data got %+v", data)
+    }
+
+    create := doc.Paths["/admin"]["post"]
+    if create.RequestBody == nil || !create.RequestBody.Required {
+        t.Fatalf("create request body got %+v", create.RequestBody)
+    }
+
+    login := doc.Paths["/passport/login"]["post"]
+    if len(login.Security) != 0 {
+        t.Errorf("login security got %v", login.Security)
+    }
+
+    if _, err := json.Marshal(doc); err != nil {
+        t.Fatal(err)
+    }
+
+    if len(doc.Tags) != 1 || doc.Tags[0].Name != "管理员" {
+        t.Errorf("Generate tags got %v", doc.Tags)
+    }
+}
@@ -0,0 +1,246 @@
+package openapi
+
+import (
+    "sort"
+    "strings"
+    "reflect"
+    "strconv"
+)
+
+// 必填提示后缀，用于从提示信息中获取字段名称
+var requiredSuffixes = []string{
+    "选项不能为空",
+    "不能为空",
+    " 字段必填",
+    " is required",
+}
+
+// 验证规则对应的格式
+var ruleFormats = [][2]string{
+    {"email", "email"},
+    {"url", "uri"},
+    {"uri", "uri"},
+    {"uuid", "uuid"},
+    {"uuid4", "uuid"},
+    {"ipv4", "ipv4"},
+    {"ipv6", "ipv6"},
+    {"ip", "ip"},
+    {"datetime", "date-time"},
+    {"hostname", "hostname"},
+}
+
+/**
+ * 根据验证规则生成数据结构
+ *
+ * rules 为 validate.ValidateMapError 使用的规则，
+ * messages 中字段 required 的提示信息会去掉后缀后作为字段标题
+ *
+ * @create 2024-5-21
+ * @author deatil
+ */
+func SchemaFromRules(rules map[string]any, messages map[string]string) *Schema {
+    schema := &Schema{
+        Type:       "object",
+        Properties: make(map[string]*Schema),
+    }
+
+    names := make([]string, 0, len(rules))
+    for name := range rules {
+        names = append(names, name)
+    }
+
+    sort.Strings(names)
+
+    for _, name := range names {
+        rule, ok := rules[name].(string)
+        if !ok {
+            continue
+        }
+
+        prop, required := schemaFromRule(rule)
+        prop.Title = fieldTitle(messages[name + ".required"])
+
+        schema.Properties[name] = prop
+        if required {
+            schema.Required = append(schema.Required, name)
+        }
+    }
+
+    return schema
+}
+
+// 单个字段规则，只有必填等规则时不限制类型
+func schemaFromRule(rule string) (*Schema, bool) {
+    schema := &Schema{}
+
+    tags := make(map[string]string)
+    for _, tag := range strings.Split(rule, ",") {
+        // 或者条件无法准确描述，跳过
+        if tag == "" || strings.Contains(tag, "|") {
+            continue
+        }
+
+        name, param, _ := strings.Cut(tag, "=")
+        tags[name] = param
+    }
+
+    _, required := tags["required"]
+
+    switch {
+        case has(tags, "boolean"):
+            schema.Type = "boolean"
+        case has(tags, "number"):
+            schema.Type = "number"
+        case has(tags, "numeric"):
+            schema.Pattern = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+    }
+
+    for _, f := range ruleFormats {
+        if has(tags, f[0]) {
+            schema.Format = f[1]
+            break
+        }
+    }
+
+    if v, ok := tags["oneof"]; ok && v != "" {
+        for _, item := range strings.Fields(v) {
+            schema.Enum = append(schema.Enum, item)
+        }
+    }
+
+    if schema.Type == "" &&
+        (schema.Format != "" ||
+        schema.Pattern != "" ||
+        has(tags, "len") ||
+        has(tags, "min") ||
+        has(tags, "max") ||
+        has(tags, "gte") ||
+        has(tags, "lte")) {
+        schema.Type = "string"
+    }
+
+    if schema.Type == "string" {
+        if n, ok := intParam(tags, "len"); ok {
+            schema.MinLength, schema.MaxLength = &n, &n
+        }
+        if n, ok := intParam(tags, "min", "gte"); ok {
+            schema.MinLength = &n
+        }
+        if n, ok := intParam(tags, "max", "lte"); ok {
+            schema.MaxLength = &n
+        }
+    } else if schema.Type == "number" {
+        if n, ok := floatParam(tags, "min", "gte"); ok {
+            schema.Minimum = &n
+        }
+        if n, ok := floatParam(tags, "max", "lte"); ok {
+            schema.Maximum = &n
+        }
+    }
+
+    return schema, required
+}
+
+// 根据类型生成数据结构，结构体使用 json 标签
+func SchemaOf(v any) *Schema {
+    return schemaOfType(reflect.TypeOf(v), 0)
+}
+
+func schemaOfType(t reflect.Type, depth int) *Schema {
+    if t == nil || depth > 8 {
+        return &Schema{}
+    }
+
+    for t.Kind() == reflect.Pointer {
+        t = t.Elem()
+    }
+
+    switch t.Kind() {
+        case reflect.Bool:
+            return &Schema{Type: "boolean"}
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+            reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            return &Schema{Type: "integer"}
+        case reflect.Float32, reflect.Float64:
+            return &Schema{Type: "number"}
+        case reflect.String:
+            return &Schema{Type: "string"}
+        case reflect.Slice, reflect.Array:
+            return &Schema{
+                Type:  "array",
+                Items: schemaOfType(t.Elem(), depth + 1),
+            }
+        case reflect.Map:
+            return &Schema{Type: "object"}
+        case reflect.Struct:
+            schema := &Schema{
+                Type:       "object",
+                Properties: make(map[string]*Schema),
+            }
+
+            for i := 0; i < t.NumField(); i++ {
+                f := t.Field(i)
+                if !f.IsExported() {
+                    continue
+                }
+
+                name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+                if name == "-" {
+                    continue
+                }
+                if name == "" {
+                    name = f.Name
+                }
+
+                schema.Properties[name] = schemaOfType(f.Type, depth + 1)
+                if !strings.Contains(opts, "omitempty") {
+                    schema.Required = append(schema.Required, name)
+                }
+            }
+
+            return schema
+    }
+
+    // interface 等任意类型
+    return &Schema{}
+}
+
+// 字段标题
+func fieldTitle(message string) string {
+    for _, suffix := range requiredSuffixes {
+        if strings.HasSuffix(message, suffix) {
+            return strings.TrimSuffix(message, suffix)
+        }
+    }
+
+    return ""
+}
+
+func has(tags map[string]string, name string) bool {
+    _, ok := tags[name]
+    return ok
+}
+
+func intParam(tags map[string]string, names ...string) (int, bool) {
+    for _, name := range names {
+        if v, ok := tags[name]; ok {
+            if n, err := strconv.Atoi(v); err == nil {
+                return n, true
+            }
+        }
+    }
+
+    return 0, false
+}
+
+func floatParam(tags map[string]string, names ...string) (float64, bool) {
+    for _, name := range names {
+        if v, ok := tags[name]; ok {
+            if n, err := strconv.ParseFloat(v, 64); err == nil {
+                return n, true
+            }
+        }
+    }
+
+    return 0, false
+}
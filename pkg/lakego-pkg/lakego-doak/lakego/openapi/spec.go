@@ -0,0 +1,153 @@
+package openapi
+
+// 版本
+const Version = "3.1.0"
+
+/**
+ * OpenAPI 文档，只包含生成时用到的字段
+ *
+ * @create 2024-5-21
+ * @author deatil
+ */
+type Document struct {
+    OpenAPI    string              `json:"openapi"`
+    Info       Info                `json:"info"`
+    Servers    []Server            `json:"servers,omitempty"`
+    Tags       []Tag               `json:"tags,omitempty"`
+    Paths      map[string]PathItem `json:"paths"`
+    Components Components          `json:"components"`
+}
+
+// 文档信息
+type Info struct {
+    Title       string `json:"title"`
+    Description string `json:"description,omitempty"`
+    Version     string `json:"version"`
+}
+
+// 服务地址
+type Server struct {
+    URL         string `json:"url"`
+    Description string `json:"description,omitempty"`
+}
+
+// 标签
+type Tag struct {
+    Name        string `json:"name"`
+    Description string `json:"description,omitempty"`
+}
+
+// 路由，键名为小写请求方式
+type PathItem map[string]*Operation
+
+// 接口
+type Operation struct {
+    Tags        []string              `json:"tags,omitempty"`
+    Summary     string                `json:"summary,omitempty"`
+    Description string                `json:"description,omitempty"`
+    OperationID string                `json:"operationId,omitempty"`
+    Parameters  []Parameter           `json:"parameters,omitempty"`
+    RequestBody *RequestBody          `json:"requestBody,omitempty"`
+    Responses   map[string]Response   `json:"responses"`
+    Security    []map[string][]string `json:"security,omitempty"`
+
+    // 权限标识
+    Slug string `json:"x-lakego-slug,omitempty"`
+}
+
+// 参数
+type Parameter struct {
+    Name        string  `json:"name"`
+    In          string  `json:"in"`
+    Description string  `json:"description,omitempty"`
+    Required    bool    `json:"required,omitempty"`
+    Schema      *Schema `json:"schema,omitempty"`
+}
+
+// 请求数据
+type RequestBody struct {
+    Required bool                 `json:"required,omitempty"`
+    Content  map[string]MediaType `json:"content"`
+}
+
+// 响应
+type Response struct {
+    Description string               `json:"description"`
+    Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// 数据格式
+type MediaType struct {
+    Schema *Schema `json:"schema,omitempty"`
+}
+
+// 组件
+type Components struct {
+    Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+    SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// 鉴权方式
+type SecurityScheme struct {
+    Type         string `json:"type"`
+    Description  string `json:"description,omitempty"`
+    Name         string `json:"name,omitempty"`
+    In           string `json:"in,omitempty"`
+    Scheme       string `json:"scheme,omitempty"`
+    BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Bearer 鉴权
+func BearerScheme(format string) *SecurityScheme {
+    return &SecurityScheme{
+        Type:         "http",
+        Scheme:       "bearer",
+        BearerFormat: format,
+    }
+}
+
+// 数据结构，使用 JSON Schema 2020-12
+type Schema struct {
+    Ref         string             `json:"$ref,omitempty"`
+    Type        string             `json:"type,omitempty"`
+    Format      string             `json:"format,omitempty"`
+    Title       string             `json:"title,omitempty"`
+    Description string             `json:"description,omitempty"`
+    Properties  map[string]*Schema `json:"properties,omitempty"`
+    Required    []string           `json:"required,omitempty"`
+    Items       *Schema            `json:"items,omitempty"`
+    Enum        []any              `json:"enum,omitempty"`
+    MinLength   *int               `json:"minLength,omitempty"`
+    MaxLength   *int               `json:"maxLength,omitempty"`
+    Minimum     *float64           `json:"minimum,omitempty"`
+    Maximum     *float64           `json:"maximum,omitempty"`
+    Pattern     string             `json:"pattern,omitempty"`
+}
+
+// 复制
+func (this *Schema) Clone() *Schema {
+    if this == nil {
+        return nil
+    }
+
+    s := *this
+    if this.Properties != nil {
+        s.Properties = make(map[string]*Schema, len(this.Properties))
+        for k, v := range this.Properties {
+            s.Properties[k] = v.Clone()
+        }
+    }
+
+    s.Required = append([]string(nil), this.Required...)
+    s.Enum = append([]any(nil), this.Enum...)
+    s.Items = this.Items.Clone()
+
+    return &s
+}
+
+// 引用组件
+func Ref(name string) *Schema {
+    return &Schema{
+        Ref: "#/components/schemas/" + name,
+    }
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+    "context"
     "path/filepath"
 
     "github.com/deatil/lakego-filesystem/filesystem"
@@ -10,6 +11,8 @@ import (
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/config/adapter"
+    "github.com/deatil/lakego-doak/lakego/facade/ratelimit"
+    "github.com/deatil/lakego-doak/lakego/middleware/security"
     path_tool "github.com/deatil/lakego-doak/lakego/path"
     iapp "github.com/deatil/lakego-doak/lakego/app/interfaces"
     view_func "github.com/deatil/lakego-doak/lakego/view/funcs"
@@ -77,7 +80,16 @@ func (this *ServiceProvider) AddRoute(fn func(*router.Engine)) {
     }
 }
 
+// 添加关闭时函数
+func (this *ServiceProvider) AddShutdown(f func(context.Context)) {
+    if this.App != nil {
+        this.App.WithShutdown(f)
+    }
+}
+
 // 添加路由分组
+// ratelimit 为限流配置名称或者限流规则，多个使用 | 分隔
+// body-limit 为请求数据大小限制，比如 2MB
 func (this *ServiceProvider) AddGroup(conf map[string]string, fn func(*router.RouterGroup)) {
     // 分组前缀
     prefix, ok := conf["prefix"]
@@ -94,6 +106,21 @@ func (this *ServiceProvider) AddGroup(conf map[string]string, fn func(*router.Ro
     // 中间件
     groupMiddlewares := router.GetMiddlewares(middleware)
 
+    // 请求数据大小
+    if size, ok := conf["body-limit"]; ok && size != "" {
+        limit, err := security.ParseSize(size)
+        if err != nil {
+            panic("路由分组[" + prefix + "]请求数据大小错误：" + err.Error())
+        }
+
+        groupMiddlewares = append(groupMiddlewares, security.BodyLimit(limit))
+    }
+
+    // 限流
+    if spec, ok := conf["ratelimit"]; ok && spec != "" {
+        groupMiddlewares = append(groupMiddlewares, ratelimit.Handler(spec))
+    }
+
     // 使用中间件
     this.AddRoute(func(engine *router.Engine) {
         // 路由
@@ -0,0 +1,178 @@
+package database
+
+import (
+    "time"
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 任务表
+type Job struct {
+    ID          string `gorm:"column:id;type:char(36);not null;primaryKey;"`
+    Queue       string `gorm:"column:queue;type:varchar(100);not null;index;"`
+    Payload     string `gorm:"column:payload;type:longtext;"`
+    Attempts    int    `gorm:"column:attempts;type:int(10);not null;default:0;"`
+    ReservedAt  int64  `gorm:"column:reserved_at;type:int(10);not null;default:0;"`
+    AvailableAt int64  `gorm:"column:available_at;type:int(10);not null;"`
+    CreatedAt   int64  `gorm:"column:created_at;type:int(10);not null;"`
+}
+
+// 配置
+type Config struct {
+    // 表名
+    Table string
+
+    // 执行超时后重新放回队列的时间
+    RetryAfter time.Duration
+}
+
+/**
+ * 数据库驱动
+ *
+ * 取出任务时通过尝试次数做乐观锁，多个工作进程不会取到同一个任务
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Database struct {
+    db     *gorm.DB
+    config Config
+}
+
+// 构造函数
+func New(db *gorm.DB, config Config) *Database {
+    if config.Table == "" {
+        config.Table = "queue_job"
+    }
+    if config.RetryAfter <= 0 {
+        config.RetryAfter = 90 * time.Second
+    }
+
+    return &Database{
+        db:     db,
+        config: config,
+    }
+}
+
+// 推送
+func (this *Database) Push(ctx context.Context, queue string, payload []byte, delay time.Duration) (string, error) {
+    now := time.Now()
+
+    job := &Job{
+        ID:          uuid.ToUUIDString(),
+        Queue:       queue,
+        Payload:     string(payload),
+        AvailableAt: now.Add(delay).Unix(),
+        CreatedAt:   now.Unix(),
+    }
+
+    if err := this.table(ctx).Create(job).Error; err != nil {
+        return "", err
+    }
+
+    return job.ID, nil
+}
+
+// 取出
+func (this *Database) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    now := time.Now().Unix()
+    expired := now - int64(this.config.RetryAfter/time.Second)
+
+    // 被其他进程抢先时重试
+    for i := 0; i < 3; i++ {
+        var job Job
+        result := this.table(ctx).
+            Where("queue = ?", queue).
+            Where(
+                this.db.Where("reserved_at = 0 AND available_at <= ?", now).
+                    Or("reserved_at > 0 AND reserved_at <= ?", expired),
+            ).
+            Order("available_at ASC").
+            Limit(1).
+            Find(&job)
+        if result.Error != nil {
+            return nil, result.Error
+        }
+
+        if result.RowsAffected == 0 {
+            return nil, nil
+        }
+
+        update := this.table(ctx).
+            Where("id = ? AND attempts = ?", job.ID, job.Attempts).
+            Updates(map[string]any{
+                "reserved_at": now,
+                "attempts":    job.Attempts + 1,
+            })
+        if update.Error != nil {
+            return nil, update.Error
+        }
+
+        if update.RowsAffected == 0 {
+            continue
+        }
+
+        return &interfaces.Message{
+            ID:       job.ID,
+            Queue:    queue,
+            Payload:  []byte(job.Payload),
+            Attempts: job.Attempts + 1,
+        }, nil
+    }
+
+    return nil, nil
+}
+
+// 删除
+func (this *Database) Delete(ctx context.Context, msg *interfaces.Message) error {
+    return this.table(ctx).
+        Where("id = ?", msg.ID).
+        Delete(&Job{}).
+        Error
+}
+
+// 放回队列
+func (this *Database) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    return this.table(ctx).
+        Where("id = ?", msg.ID).
+        Updates(map[string]any{
+            "reserved_at":  0,
+            "available_at": time.Now().Add(delay).Unix(),
+        }).
+        Error
+}
+
+// 保留时间
+func (this *Database) ReserveFor() time.Duration {
+    return this.config.RetryAfter
+}
+
+// 刷新保留时间
+func (this *Database) Touch(ctx context.Context, msg *interfaces.Message) error {
+    return this.table(ctx).
+        Where("id = ? AND reserved_at > 0", msg.ID).
+        Update("reserved_at", time.Now().Unix()).
+        Error
+}
+
+// 队列长度
+func (this *Database) Size(ctx context.Context, queue string) (int64, error) {
+    var total int64
+    err := this.table(ctx).
+        Where("queue = ?", queue).
+        Count(&total).
+        Error
+
+    return total, err
+}
+
+func (this *Database) table(ctx context.Context) *gorm.DB {
+    return this.db.
+        Session(&gorm.Session{NewDB: true}).
+        WithContext(ctx).
+        Table(this.db.NamingStrategy.TableName(this.config.Table))
+}
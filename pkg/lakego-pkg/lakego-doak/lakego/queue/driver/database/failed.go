@@ -0,0 +1,132 @@
+package database
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 失败任务表
+type FailedJob struct {
+    ID         string `gorm:"column:id;type:char(36);not null;primaryKey;"`
+    Connection string `gorm:"column:connection;type:varchar(100);"`
+    Queue      string `gorm:"column:queue;type:varchar(100);"`
+    Payload    string `gorm:"column:payload;type:longtext;"`
+    Exception  string `gorm:"column:exception;type:text;"`
+    FailedAt   int64  `gorm:"column:failed_at;type:int(10);not null;"`
+}
+
+/**
+ * 数据库失败任务存储
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Failed struct {
+    db    *gorm.DB
+    table string
+}
+
+// 构造函数
+func NewFailed(db *gorm.DB, table string) *Failed {
+    if table == "" {
+        table = "queue_failed_job"
+    }
+
+    return &Failed{
+        db:    db,
+        table: table,
+    }
+}
+
+// 记录
+func (this *Failed) Log(connection string, queue string, payload []byte, err error) (string, error) {
+    exception := ""
+    if err != nil {
+        exception = err.Error()
+    }
+
+    job := &FailedJob{
+        ID:         uuid.ToUUIDString(),
+        Connection: connection,
+        Queue:      queue,
+        Payload:    string(payload),
+        Exception:  exception,
+        FailedAt:   time.Now().Unix(),
+    }
+
+    if err := this.newDB().Create(job).Error; err != nil {
+        return "", err
+    }
+
+    return job.ID, nil
+}
+
+// 列表
+func (this *Failed) All() ([]interfaces.FailedJob, error) {
+    list := make([]FailedJob, 0)
+    err := this.newDB().
+        Order("failed_at DESC").
+        Find(&list).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    jobs := make([]interfaces.FailedJob, 0, len(list))
+    for _, item := range list {
+        jobs = append(jobs, item.toFailedJob())
+    }
+
+    return jobs, nil
+}
+
+// 获取
+func (this *Failed) Find(id string) (interfaces.FailedJob, error) {
+    var job FailedJob
+    err := this.newDB().
+        Where("id = ?", id).
+        First(&job).
+        Error
+    if err != nil {
+        return interfaces.FailedJob{}, err
+    }
+
+    return job.toFailedJob(), nil
+}
+
+// 删除
+func (this *Failed) Forget(id string) error {
+    return this.newDB().
+        Where("id = ?", id).
+        Delete(&FailedJob{}).
+        Error
+}
+
+// 清空
+func (this *Failed) Flush() error {
+    return this.newDB().
+        Where("1 = 1").
+        Delete(&FailedJob{}).
+        Error
+}
+
+func (this *Failed) newDB() *gorm.DB {
+    return this.db.
+        Session(&gorm.Session{NewDB: true}).
+        Table(this.db.NamingStrategy.TableName(this.table))
+}
+
+func (this FailedJob) toFailedJob() interfaces.FailedJob {
+    return interfaces.FailedJob{
+        ID:         this.ID,
+        Connection: this.Connection,
+        Queue:      this.Queue,
+        Payload:    []byte(this.Payload),
+        Exception:  this.Exception,
+        FailedAt:   this.FailedAt,
+    }
+}
@@ -0,0 +1,232 @@
+package redis
+
+import (
+    "time"
+    "errors"
+    "context"
+    "strconv"
+    "encoding/json"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 取出任务并放入执行中列表，同时增加尝试次数
+var popScript = redis.NewScript(`
+local job = redis.call('lpop', KEYS[1])
+local reserved = false
+
+if (job ~= false) then
+    reserved = cjson.decode(job)
+    reserved['attempts'] = reserved['attempts'] + 1
+    reserved = cjson.encode(reserved)
+    redis.call('zadd', KEYS[2], ARGV[1], reserved)
+end
+
+return {job, reserved}
+`)
+
+// 将到期的任务移动到队列中
+var migrateScript = redis.NewScript(`
+local val = redis.call('zrangebyscore', KEYS[1], '-inf', ARGV[1], 'limit', 0, 100)
+
+if (next(val) ~= nil) then
+    redis.call('zremrangebyrank', KEYS[1], 0, #val - 1)
+
+    for i = 1, #val, 100 do
+        redis.call('rpush', KEYS[2], unpack(val, i, math.min(i+99, #val)))
+    end
+end
+
+return val
+`)
+
+// 重新放回延迟列表
+var releaseScript = redis.NewScript(`
+redis.call('zrem', KEYS[2], ARGV[1])
+redis.call('zadd', KEYS[1], ARGV[2], ARGV[1])
+
+return true
+`)
+
+// 配置
+type Config struct {
+    // 键前缀
+    Prefix string
+
+    // 执行超时后重新放回队列的时间
+    RetryAfter time.Duration
+}
+
+// 存储的数据
+type record struct {
+    ID       string `json:"id"`
+    Payload  string `json:"payload"`
+    Attempts int    `json:"attempts"`
+}
+
+/**
+ * redis 驱动
+ *
+ * 使用 list 保存待执行任务，sorted set 保存延迟任务及执行中任务
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Redis struct {
+    client *redis.Client
+    config Config
+}
+
+// 构造函数
+func New(client *redis.Client, config Config) *Redis {
+    if config.Prefix == "" {
+        config.Prefix = "queues"
+    }
+    if config.RetryAfter <= 0 {
+        config.RetryAfter = 90 * time.Second
+    }
+
+    return &Redis{
+        client: client,
+        config: config,
+    }
+}
+
+// 推送
+func (this *Redis) Push(ctx context.Context, queue string, payload []byte, delay time.Duration) (string, error) {
+    id := uuid.ToUUIDString()
+
+    data, err := json.Marshal(record{
+        ID:      id,
+        Payload: string(payload),
+    })
+    if err != nil {
+        return "", err
+    }
+
+    if delay > 0 {
+        err = this.client.ZAdd(ctx, this.key(queue, "delayed"), &redis.Z{
+            Score:  float64(time.Now().Add(delay).Unix()),
+            Member: string(data),
+        }).Err()
+    } else {
+        err = this.client.RPush(ctx, this.key(queue), string(data)).Err()
+    }
+
+    if err != nil {
+        return "", err
+    }
+
+    return id, nil
+}
+
+// 取出
+func (this *Redis) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    now := time.Now()
+
+    // 到期的延迟任务及超时的执行中任务
+    for _, from := range []string{"delayed", "reserved"} {
+        err := migrateScript.Run(ctx, this.client,
+            []string{this.key(queue, from), this.key(queue)},
+            now.Unix(),
+        ).Err()
+        if err != nil && err != redis.Nil {
+            return nil, err
+        }
+    }
+
+    result, err := popScript.Run(ctx, this.client,
+        []string{this.key(queue), this.key(queue, "reserved")},
+        now.Add(this.config.RetryAfter).Unix(),
+    ).Slice()
+    if err != nil {
+        if err == redis.Nil {
+            return nil, nil
+        }
+
+        return nil, err
+    }
+
+    if len(result) < 2 || result[0] == nil {
+        return nil, nil
+    }
+
+    reserved, ok := result[1].(string)
+    if !ok {
+        return nil, errors.New("queue-redis: reserved job is invalid")
+    }
+
+    var data record
+    if err := json.Unmarshal([]byte(reserved), &data); err != nil {
+        return nil, err
+    }
+
+    return &interfaces.Message{
+        ID:       data.ID,
+        Queue:    queue,
+        Payload:  []byte(data.Payload),
+        Attempts: data.Attempts,
+        Raw:      reserved,
+    }, nil
+}
+
+// 删除
+func (this *Redis) Delete(ctx context.Context, msg *interfaces.Message) error {
+    reserved, _ := msg.Raw.(string)
+
+    return this.client.ZRem(ctx, this.key(msg.Queue, "reserved"), reserved).Err()
+}
+
+// 放回队列
+func (this *Redis) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    reserved, _ := msg.Raw.(string)
+
+    return releaseScript.Run(ctx, this.client,
+        []string{this.key(msg.Queue, "delayed"), this.key(msg.Queue, "reserved")},
+        reserved,
+        strconv.FormatInt(time.Now().Add(delay).Unix(), 10),
+    ).Err()
+}
+
+// 保留时间
+func (this *Redis) ReserveFor() time.Duration {
+    return this.config.RetryAfter
+}
+
+// 刷新保留时间，任务已被移出执行中列表时不处理
+func (this *Redis) Touch(ctx context.Context, msg *interfaces.Message) error {
+    reserved, _ := msg.Raw.(string)
+
+    return this.client.ZAddXX(ctx, this.key(msg.Queue, "reserved"), &redis.Z{
+        Score:  float64(time.Now().Add(this.config.RetryAfter).Unix()),
+        Member: reserved,
+    }).Err()
+}
+
+// 队列长度，包括延迟及执行中的任务
+func (this *Redis) Size(ctx context.Context, queue string) (int64, error) {
+    pipe := this.client.TxPipeline()
+
+    llen := pipe.LLen(ctx, this.key(queue))
+    delayed := pipe.ZCard(ctx, this.key(queue, "delayed"))
+    reserved := pipe.ZCard(ctx, this.key(queue, "reserved"))
+
+    if _, err := pipe.Exec(ctx); err != nil {
+        return 0, err
+    }
+
+    return llen.Val() + delayed.Val() + reserved.Val(), nil
+}
+
+// 键名
+func (this *Redis) key(queue string, suffix ...string) string {
+    key := this.config.Prefix + ":" + queue
+    if len(suffix) > 0 {
+        key += ":" + suffix[0]
+    }
+
+    return key
+}
@@ -0,0 +1,94 @@
+package sync
+
+import (
+    "time"
+    "context"
+    gosync "sync"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+/**
+ * 同步驱动
+ *
+ * 推送后在当前协程立即执行，延迟及重试等待时间会被忽略，一般用于测试
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Sync struct {
+    mu gosync.Mutex
+
+    // 队列数据
+    queues map[string][]*interfaces.Message
+}
+
+// 构造函数
+func New() *Sync {
+    return &Sync{
+        queues: make(map[string][]*interfaces.Message),
+    }
+}
+
+// 同步执行
+func (this *Sync) Sync() bool {
+    return true
+}
+
+// 推送
+func (this *Sync) Push(ctx context.Context, queue string, payload []byte, delay time.Duration) (string, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    id := uuid.ToUUIDString()
+
+    this.queues[queue] = append(this.queues[queue], &interfaces.Message{
+        ID:      id,
+        Queue:   queue,
+        Payload: payload,
+    })
+
+    return id, nil
+}
+
+// 取出
+func (this *Sync) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    list := this.queues[queue]
+    if len(list) == 0 {
+        return nil, nil
+    }
+
+    msg := list[0]
+    this.queues[queue] = list[1:]
+
+    msg.Attempts++
+
+    return msg, nil
+}
+
+// 删除
+func (this *Sync) Delete(ctx context.Context, msg *interfaces.Message) error {
+    return nil
+}
+
+// 放回队列
+func (this *Sync) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.queues[msg.Queue] = append(this.queues[msg.Queue], msg)
+
+    return nil
+}
+
+// 队列长度
+func (this *Sync) Size(ctx context.Context, queue string) (int64, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return int64(len(this.queues[queue])), nil
+}
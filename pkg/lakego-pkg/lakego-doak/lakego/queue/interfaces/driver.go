@@ -0,0 +1,94 @@
+package interfaces
+
+import (
+    "time"
+    "context"
+)
+
+// 队列中的消息
+type Message struct {
+    // 消息ID
+    ID string
+
+    // 队列名称
+    Queue string
+
+    // 任务数据
+    Payload []byte
+
+    // 已尝试次数，包括本次
+    Attempts int
+
+    // 驱动原始数据
+    Raw any
+}
+
+/**
+ * 队列驱动接口
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Driver interface {
+    // 推送消息，delay 大于 0 时为延迟消息
+    Push(ctx context.Context, queue string, payload []byte, delay time.Duration) (string, error)
+
+    // 取出一条消息，没有消息时返回 nil
+    Pop(ctx context.Context, queue string) (*Message, error)
+
+    // 执行成功后删除消息
+    Delete(ctx context.Context, msg *Message) error
+
+    // 重新放回队列，delay 后可再次取出
+    Release(ctx context.Context, msg *Message, delay time.Duration) error
+
+    // 队列长度
+    Size(ctx context.Context, queue string) (int64, error)
+}
+
+// 同步驱动，推送后立即执行
+type SyncDriver interface {
+    Sync() bool
+}
+
+// 保留驱动，执行中的任务超过保留时间后会被重新取出
+type ReserveDriver interface {
+    // 保留时间
+    ReserveFor() time.Duration
+
+    // 刷新执行中任务的保留时间
+    Touch(ctx context.Context, msg *Message) error
+}
+
+// 失败任务
+type FailedJob struct {
+    ID         string
+    Connection string
+    Queue      string
+    Payload    []byte
+    Exception  string
+    FailedAt   int64
+}
+
+/**
+ * 失败任务存储接口
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type FailedStore interface {
+    // 记录失败任务
+    Log(connection string, queue string, payload []byte, err error) (string, error)
+
+    // 失败任务列表
+    All() ([]FailedJob, error)
+
+    // 获取失败任务
+    Find(id string) (FailedJob, error)
+
+    // 删除失败任务
+    Forget(id string) error
+
+    // 清空失败任务
+    Flush() error
+}
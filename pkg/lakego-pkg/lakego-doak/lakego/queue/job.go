@@ -0,0 +1,171 @@
+package queue
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "context"
+    "reflect"
+    "encoding/json"
+)
+
+/**
+ * 队列任务
+ *
+ * 任务需要使用 queue.Register 注册后才能被工作进程执行，
+ * 任务数据使用 json 序列化，需要保存的字段需要导出
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Job interface {
+    // 任务名称，需要唯一
+    Name() string
+
+    // 执行任务
+    Handle(ctx context.Context) error
+}
+
+// 自定义最大尝试次数
+type JobTries interface {
+    Tries() int
+}
+
+// 自定义重试等待时间，attempts 为已尝试次数
+type JobBackoff interface {
+    Backoff(attempts int) time.Duration
+}
+
+// 自定义执行超时时间
+type JobTimeout interface {
+    Timeout() time.Duration
+}
+
+// 最终失败时回调
+type JobFailed interface {
+    Failed(ctx context.Context, err error)
+}
+
+// 任务数据
+type Payload struct {
+    // 任务ID
+    ID string `json:"id"`
+
+    // 任务名称
+    Job string `json:"job"`
+
+    // 任务数据
+    Data json.RawMessage `json:"data"`
+
+    // 最大尝试次数，为 0 时使用工作进程设置
+    Tries int `json:"tries,omitempty"`
+
+    // 执行超时时间，为 0 时使用工作进程设置
+    Timeout time.Duration `json:"timeout,omitempty"`
+
+    // 推送时间
+    PushedAt int64 `json:"pushed_at"`
+}
+
+// 任务未注册
+var ErrJobNotRegistered = errors.New("queue: job is not registered")
+
+// 任务注册表
+var jobs = struct {
+    sync.RWMutex
+    types map[string]reflect.Type
+}{
+    types: make(map[string]reflect.Type),
+}
+
+// 注册任务
+func Register(job Job) {
+    jobs.Lock()
+    defer jobs.Unlock()
+
+    jobs.types[job.Name()] = reflect.TypeOf(job)
+}
+
+// 移除任务
+func Remove(name string) {
+    jobs.Lock()
+    defer jobs.Unlock()
+
+    delete(jobs.types, name)
+}
+
+// 是否已注册
+func Registered(name string) bool {
+    jobs.RLock()
+    defer jobs.RUnlock()
+
+    _, ok := jobs.types[name]
+    return ok
+}
+
+// 编码任务
+func EncodeJob(job Job, id string, pushedAt int64) ([]byte, error) {
+    data, err := json.Marshal(job)
+    if err != nil {
+        return nil, err
+    }
+
+    payload := Payload{
+        ID:       id,
+        Job:      job.Name(),
+        Data:     data,
+        PushedAt: pushedAt,
+    }
+
+    if j, ok := job.(JobTries); ok {
+        payload.Tries = j.Tries()
+    }
+    if j, ok := job.(JobTimeout); ok {
+        payload.Timeout = j.Timeout()
+    }
+
+    return json.Marshal(payload)
+}
+
+// 解析任务数据
+func DecodePayload(data []byte) (Payload, error) {
+    var payload Payload
+    err := json.Unmarshal(data, &payload)
+
+    return payload, err
+}
+
+// 解析任务
+func DecodeJob(payload Payload) (Job, error) {
+    jobs.RLock()
+    typ, ok := jobs.types[payload.Job]
+    jobs.RUnlock()
+
+    if !ok {
+        return nil, errors.New("queue: job [" + payload.Job + "] is not registered")
+    }
+
+    var value reflect.Value
+    if typ.Kind() == reflect.Ptr {
+        value = reflect.New(typ.Elem())
+    } else {
+        value = reflect.New(typ)
+    }
+
+    if len(payload.Data) > 0 {
+        if err := json.Unmarshal(payload.Data, value.Interface()); err != nil {
+            return nil, err
+        }
+    }
+
+    if typ.Kind() != reflect.Ptr {
+        value = value.Elem()
+    }
+
+    job, ok := value.Interface().(Job)
+    if !ok {
+        return nil, ErrJobNotRegistered
+    }
+
+    return job, nil
+}
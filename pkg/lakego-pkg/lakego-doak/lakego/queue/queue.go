@@ -0,0 +1,189 @@
+package queue
+
+import (
+    "time"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 默认队列名称
+const DefaultQueue = "default"
+
+// 创建
+func New(driver interfaces.Driver) *Queue {
+    return &Queue{
+        driver: driver,
+        queue:  DefaultQueue,
+    }
+}
+
+/**
+ * 队列
+ *
+ * q.Push(ctx, &SendMail{To: "..."})
+ * q.Later(ctx, 5*time.Minute, &SendMail{To: "..."})
+ * q.PushOn(ctx, "emails", &SendMail{To: "..."})
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Queue struct {
+    // 连接名称
+    connection string
+
+    // 默认队列
+    queue string
+
+    // 驱动
+    driver interfaces.Driver
+
+    // 失败任务存储
+    failed interfaces.FailedStore
+
+    // 同步执行时的设置
+    syncOptions WorkerOptions
+}
+
+// 设置连接名称
+func (this *Queue) WithConnection(connection string) *Queue {
+    this.connection = connection
+
+    return this
+}
+
+// 获取连接名称
+func (this *Queue) GetConnection() string {
+    return this.connection
+}
+
+// 设置默认队列
+func (this *Queue) WithQueue(queue string) *Queue {
+    if queue != "" {
+        this.queue = queue
+    }
+
+    return this
+}
+
+// 获取默认队列
+func (this *Queue) GetQueue() string {
+    return this.queue
+}
+
+// 设置驱动
+func (this *Queue) WithDriver(driver interfaces.Driver) *Queue {
+    this.driver = driver
+
+    return this
+}
+
+// 获取驱动
+func (this *Queue) GetDriver() interfaces.Driver {
+    return this.driver
+}
+
+// 设置失败任务存储
+func (this *Queue) WithFailed(failed interfaces.FailedStore) *Queue {
+    this.failed = failed
+
+    return this
+}
+
+// 获取失败任务存储
+func (this *Queue) GetFailed() interfaces.FailedStore {
+    return this.failed
+}
+
+// 设置同步执行时的设置
+func (this *Queue) WithSyncOptions(opts WorkerOptions) *Queue {
+    this.syncOptions = opts
+
+    return this
+}
+
+// 推送到默认队列
+func (this *Queue) Push(ctx context.Context, job Job) (string, error) {
+    return this.LaterOn(ctx, this.queue, 0, job)
+}
+
+// 推送到指定队列
+func (this *Queue) PushOn(ctx context.Context, queue string, job Job) (string, error) {
+    return this.LaterOn(ctx, queue, 0, job)
+}
+
+// 延迟推送到默认队列
+func (this *Queue) Later(ctx context.Context, delay time.Duration, job Job) (string, error) {
+    return this.LaterOn(ctx, this.queue, delay, job)
+}
+
+// 延迟推送到指定队列
+func (this *Queue) LaterOn(ctx context.Context, queue string, delay time.Duration, job Job) (string, error) {
+    payload, err := EncodeJob(job, uuid.ToUUIDString(), time.Now().Unix())
+    if err != nil {
+        return "", err
+    }
+
+    return this.PushRaw(ctx, queue, payload, delay)
+}
+
+// 推送任务数据
+func (this *Queue) PushRaw(ctx context.Context, queue string, payload []byte, delay time.Duration) (string, error) {
+    if queue == "" {
+        queue = this.queue
+    }
+
+    id, err := this.driver.Push(ctx, queue, payload, delay)
+    if err != nil {
+        return "", err
+    }
+
+    // 同步驱动直接执行，返回最后一次执行的错误
+    if d, ok := this.driver.(interfaces.SyncDriver); ok && d.Sync() {
+        worker := NewWorker(this, this.syncOptions)
+
+        var jobErr error
+        for {
+            msg, err := this.driver.Pop(ctx, queue)
+            if err != nil {
+                return id, err
+            }
+            if msg == nil {
+                return id, jobErr
+            }
+
+            jobErr = worker.Process(ctx, msg)
+        }
+    }
+
+    return id, nil
+}
+
+// 队列长度
+func (this *Queue) Size(ctx context.Context, queue ...string) (int64, error) {
+    name := this.queue
+    if len(queue) > 0 && queue[0] != "" {
+        name = queue[0]
+    }
+
+    return this.driver.Size(ctx, name)
+}
+
+// 重新推送失败任务
+func (this *Queue) Retry(ctx context.Context, id string) error {
+    if this.failed == nil {
+        return ErrNoFailedStore
+    }
+
+    job, err := this.failed.Find(id)
+    if err != nil {
+        return err
+    }
+
+    if _, err = this.driver.Push(ctx, job.Queue, job.Payload, 0); err != nil {
+        return err
+    }
+
+    return this.failed.Forget(id)
+}
@@ -0,0 +1,216 @@
+package queue
+
+import (
+    "time"
+    "errors"
+    "context"
+    "reflect"
+    "testing"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    syncDriver "github.com/deatil/lakego-doak/lakego/queue/driver/sync"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+var handled []string
+
+type testJob struct {
+    Value string `json:"value"`
+    Fails int    `json:"fails"`
+}
+
+func (this *testJob) Name() string {
+    return "test-job"
+}
+
+func (this *testJob) Handle(ctx context.Context) error {
+    handled = append(handled, this.Value)
+
+    if len(handled) <= this.Fails {
+        return errors.New("job error")
+    }
+
+    return nil
+}
+
+type testFailed struct {
+    jobs []interfaces.FailedJob
+}
+
+func (this *testFailed) Log(connection string, queue string, payload []byte, err error) (string, error) {
+    this.jobs = append(this.jobs, interfaces.FailedJob{
+        ID:         "1",
+        Connection: connection,
+        Queue:      queue,
+        Payload:    payload,
+        Exception:  err.Error(),
+    })
+
+    return "1", nil
+}
+
+func (this *testFailed) All() ([]interfaces.FailedJob, error) {
+    return this.jobs, nil
+}
+
+func (this *testFailed) Find(id string) (interfaces.FailedJob, error) {
+    for _, job := range this.jobs {
+        if job.ID == id {
+            return job, nil
+        }
+    }
+
+    return interfaces.FailedJob{}, errors.New("not found")
+}
+
+func (this *testFailed) Forget(id string) error {
+    this.jobs = nil
+    return nil
+}
+
+func (this *testFailed) Flush() error {
+    this.jobs = nil
+    return nil
+}
+
+func Test_EncodeDecodeJob(t *testing.T) {
+    assert := assertT(t)
+
+    Register(&testJob{})
+
+    data, err := EncodeJob(&testJob{Value: "abc"}, "id", 1)
+    assert(err, nil, "EncodeJob")
+
+    payload, err := DecodePayload(data)
+    assert(err, nil, "DecodePayload")
+    assert(payload.Job, "test-job", "DecodePayload Job")
+
+    job, err := DecodeJob(payload)
+    assert(err, nil, "DecodeJob")
+    assert(job, Job(&testJob{Value: "abc"}), "DecodeJob job")
+}
+
+func Test_SyncRetry(t *testing.T) {
+    assert := assertT(t)
+
+    Register(&testJob{})
+
+    handled = nil
+
+    failed := &testFailed{}
+    q := New(syncDriver.New()).
+        WithConnection("sync").
+        WithFailed(failed).
+        WithSyncOptions(WorkerOptions{Tries: 3})
+
+    _, err := q.Push(context.Background(), &testJob{Value: "a", Fails: 2})
+    assert(err, nil, "Push")
+    assert(handled, []string{"a", "a", "a"}, "Push handled")
+    assert(len(failed.jobs), 0, "Push failed")
+}
+
+func Test_SyncFailed(t *testing.T) {
+    assert := assertT(t)
+
+    Register(&testJob{})
+
+    handled = nil
+
+    failed := &testFailed{}
+    q := New(syncDriver.New()).
+        WithConnection("sync").
+        WithFailed(failed).
+        WithSyncOptions(WorkerOptions{Tries: 2})
+
+    _, err := q.PushOn(context.Background(), "emails", &testJob{Value: "b", Fails: 5})
+    assert(err != nil, true, "PushOn error")
+    assert(handled, []string{"b", "b"}, "PushOn handled")
+    assert(len(failed.jobs), 1, "PushOn failed")
+    assert(failed.jobs[0].Queue, "emails", "PushOn failed queue")
+
+    handled = nil
+
+    err = q.Retry(context.Background(), "1")
+    assert(err, nil, "Retry")
+    assert(len(failed.jobs), 0, "Retry forget")
+
+    size, _ := q.Size(context.Background(), "emails")
+    assert(size, int64(1), "Retry size")
+}
+
+var slowRunning int32
+
+type slowJob struct {
+    Sleep time.Duration `json:"sleep"`
+}
+
+func (this *slowJob) Name() string {
+    return "slow-job"
+}
+
+// 不响应 ctx 取消
+func (this *slowJob) Handle(ctx context.Context) error {
+    atomic.AddInt32(&slowRunning, 1)
+    defer atomic.AddInt32(&slowRunning, -1)
+
+    time.Sleep(this.Sleep)
+
+    return ctx.Err()
+}
+
+type reserveDriver struct {
+    *syncDriver.Sync
+
+    touched int32
+
+    // 放回时仍在执行的任务数量
+    runningOnRelease int32
+}
+
+func (this *reserveDriver) ReserveFor() time.Duration {
+    return 20 * time.Millisecond
+}
+
+func (this *reserveDriver) Touch(ctx context.Context, msg *interfaces.Message) error {
+    atomic.AddInt32(&this.touched, 1)
+    return nil
+}
+
+func (this *reserveDriver) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    atomic.StoreInt32(&this.runningOnRelease, atomic.LoadInt32(&slowRunning))
+    return this.Sync.Release(ctx, msg, delay)
+}
+
+func Test_WorkerTimeoutWaitsJob(t *testing.T) {
+    assert := assertT(t)
+
+    Register(&slowJob{})
+
+    driver := &reserveDriver{Sync: syncDriver.New()}
+    worker := NewWorker(New(driver), WorkerOptions{
+        Tries:   2,
+        Timeout: 10 * time.Millisecond,
+    })
+
+    data, err := EncodeJob(&slowJob{Sleep: 100 * time.Millisecond}, "id", time.Now().Unix())
+    assert(err, nil, "EncodeJob")
+
+    err = worker.Process(context.Background(), &interfaces.Message{
+        ID:       "id",
+        Queue:    "default",
+        Payload:  data,
+        Attempts: 1,
+    })
+    assert(err, ErrJobTimeout, "Process timeout")
+    assert(atomic.LoadInt32(&slowRunning), int32(0), "Process returned while job running")
+    assert(atomic.LoadInt32(&driver.runningOnRelease), int32(0), "Release while job running")
+    assert(atomic.LoadInt32(&driver.touched) > 0, true, "Touch reservation")
+}
@@ -0,0 +1,313 @@
+package queue
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 没有设置失败任务存储
+var ErrNoFailedStore = errors.New("queue: failed job store is not set")
+
+// 执行超时
+var ErrJobTimeout = errors.New("queue: job timed out")
+
+// 工作进程设置
+type WorkerOptions struct {
+    // 监听的队列，按顺序优先
+    Queues []string
+
+    // 并发数量
+    Concurrency int
+
+    // 没有任务时等待时间
+    Sleep time.Duration
+
+    // 默认最大尝试次数
+    Tries int
+
+    // 默认执行超时时间
+    Timeout time.Duration
+
+    // 重试等待基础时间，按尝试次数指数增长
+    Backoff time.Duration
+
+    // 重试最大等待时间
+    MaxBackoff time.Duration
+
+    // 错误回调
+    OnError func(msg *interfaces.Message, err error)
+}
+
+func (opts WorkerOptions) withDefaults() WorkerOptions {
+    if opts.Concurrency <= 0 {
+        opts.Concurrency = 1
+    }
+    if opts.Sleep <= 0 {
+        opts.Sleep = 3 * time.Second
+    }
+    if opts.Tries <= 0 {
+        opts.Tries = 1
+    }
+    if opts.MaxBackoff <= 0 {
+        opts.MaxBackoff = 10 * time.Minute
+    }
+
+    return opts
+}
+
+/**
+ * 工作进程
+ *
+ * @create 2024-5-13
+ * @author deatil
+ */
+type Worker struct {
+    queue *Queue
+    opts  WorkerOptions
+}
+
+// 创建工作进程
+func NewWorker(queue *Queue, opts WorkerOptions) *Worker {
+    opts = opts.withDefaults()
+    if len(opts.Queues) == 0 {
+        opts.Queues = []string{queue.GetQueue()}
+    }
+
+    return &Worker{
+        queue: queue,
+        opts:  opts,
+    }
+}
+
+// 运行，ctx 取消后等待执行中的任务完成后返回
+func (this *Worker) Run(ctx context.Context) {
+    var wg sync.WaitGroup
+
+    for i := 0; i < this.opts.Concurrency; i++ {
+        wg.Add(1)
+
+        go func() {
+            defer wg.Done()
+            this.loop(ctx)
+        }()
+    }
+
+    wg.Wait()
+}
+
+// 执行一次，返回是否取到了任务
+func (this *Worker) RunOnce(ctx context.Context) (bool, error) {
+    msg, err := this.next(ctx)
+    if err != nil || msg == nil {
+        return false, err
+    }
+
+    return true, this.Process(ctx, msg)
+}
+
+func (this *Worker) loop(ctx context.Context) {
+    for {
+        if ctx.Err() != nil {
+            return
+        }
+
+        // 执行中的任务不随 ctx 取消
+        ok, err := this.RunOnce(context.Background())
+        if err != nil {
+            this.error(nil, err)
+        }
+
+        if ok {
+            continue
+        }
+
+        select {
+            case <-ctx.Done():
+                return
+            case <-time.After(this.opts.Sleep):
+        }
+    }
+}
+
+// 按顺序获取任务
+func (this *Worker) next(ctx context.Context) (*interfaces.Message, error) {
+    driver := this.queue.GetDriver()
+
+    for _, name := range this.opts.Queues {
+        msg, err := driver.Pop(ctx, name)
+        if err != nil {
+            return nil, err
+        }
+
+        if msg != nil {
+            return msg, nil
+        }
+    }
+
+    return nil, nil
+}
+
+// 执行任务，返回任务执行错误
+func (this *Worker) Process(ctx context.Context, msg *interfaces.Message) error {
+    driver := this.queue.GetDriver()
+
+    payload, err := DecodePayload(msg.Payload)
+    if err != nil {
+        // 数据无法解析时直接记录为失败
+        return this.fail(ctx, msg, nil, err)
+    }
+
+    job, err := DecodeJob(payload)
+    if err != nil {
+        return this.fail(ctx, msg, nil, err)
+    }
+
+    tries := this.opts.Tries
+    if payload.Tries > 0 {
+        tries = payload.Tries
+    }
+
+    timeout := this.opts.Timeout
+    if payload.Timeout > 0 {
+        timeout = payload.Timeout
+    }
+
+    // 执行期间刷新保留时间，避免任务被其他进程重复取出
+    stop := this.keepalive(ctx, msg)
+    err = this.handle(ctx, job, timeout)
+    stop()
+
+    if err == nil {
+        if delErr := driver.Delete(ctx, msg); delErr != nil {
+            this.error(msg, delErr)
+        }
+
+        return nil
+    }
+
+    this.error(msg, err)
+
+    if msg.Attempts >= tries {
+        this.fail(ctx, msg, job, err)
+        return err
+    }
+
+    if relErr := driver.Release(ctx, msg, this.backoff(job, msg.Attempts)); relErr != nil {
+        this.error(msg, relErr)
+    }
+
+    return err
+}
+
+// 执行任务，超时后取消 ctx 并等待任务返回，避免放回后与仍在执行的任务重复执行
+func (this *Worker) handle(ctx context.Context, job Job, timeout time.Duration) (err error) {
+    if timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, timeout)
+        defer cancel()
+    }
+
+    done := make(chan error, 1)
+
+    go func() {
+        defer func() {
+            if r := recover(); r != nil {
+                done <- fmt.Errorf("queue: job panic: %v", r)
+            }
+        }()
+
+        done <- job.Handle(ctx)
+    }()
+
+    err = <-done
+    if err != nil && ctx.Err() == context.DeadlineExceeded {
+        return ErrJobTimeout
+    }
+
+    return err
+}
+
+// 定时刷新任务保留时间，返回停止函数
+func (this *Worker) keepalive(ctx context.Context, msg *interfaces.Message) func() {
+    driver, ok := this.queue.GetDriver().(interfaces.ReserveDriver)
+    if !ok || driver.ReserveFor() <= 0 {
+        return func() {}
+    }
+
+    stop := make(chan struct{})
+    done := make(chan struct{})
+
+    go func() {
+        defer close(done)
+
+        ticker := time.NewTicker(driver.ReserveFor() / 2)
+        defer ticker.Stop()
+
+        for {
+            select {
+                case <-stop:
+                    return
+                case <-ticker.C:
+                    if err := driver.Touch(ctx, msg); err != nil {
+                        this.error(msg, err)
+                    }
+            }
+        }
+    }()
+
+    return func() {
+        close(stop)
+        <-done
+    }
+}
+
+// 重试等待时间
+func (this *Worker) backoff(job Job, attempts int) time.Duration {
+    if j, ok := job.(JobBackoff); ok {
+        return j.Backoff(attempts)
+    }
+
+    if this.opts.Backoff <= 0 {
+        return 0
+    }
+
+    delay := this.opts.Backoff
+    for i := 1; i < attempts; i++ {
+        delay *= 2
+        if delay >= this.opts.MaxBackoff {
+            return this.opts.MaxBackoff
+        }
+    }
+
+    return delay
+}
+
+// 记录失败任务
+func (this *Worker) fail(ctx context.Context, msg *interfaces.Message, job Job, err error) error {
+    if delErr := this.queue.GetDriver().Delete(ctx, msg); delErr != nil {
+        this.error(msg, delErr)
+    }
+
+    if failed := this.queue.GetFailed(); failed != nil {
+        if _, logErr := failed.Log(this.queue.GetConnection(), msg.Queue, msg.Payload, err); logErr != nil {
+            this.error(msg, logErr)
+        }
+    }
+
+    if j, ok := job.(JobFailed); ok {
+        j.Failed(ctx, err)
+    }
+
+    return err
+}
+
+func (this *Worker) error(msg *interfaces.Message, err error) {
+    if this.opts.OnError != nil {
+        this.opts.OnError(msg, err)
+    }
+}
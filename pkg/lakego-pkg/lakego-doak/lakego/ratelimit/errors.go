@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+    "errors"
+)
+
+var (
+    // 限流规则错误
+    ErrInvalidPolicy = errors.New("限流规则格式错误")
+
+    // 限流算法不存在
+    ErrUnknownAlgorithm = errors.New("限流算法不存在")
+
+    // 存储返回数据错误
+    ErrInvalidResult = errors.New("限流存储返回数据错误")
+)
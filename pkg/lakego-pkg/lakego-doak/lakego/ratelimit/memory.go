@@ -0,0 +1,218 @@
+package ratelimit
+
+import (
+    "math"
+    "sync"
+    "time"
+    "context"
+)
+
+// 过期数据清理间隔
+const memorySweepInterval = time.Minute
+
+// 内存数据
+type memoryItem struct {
+    // 窗口开始时间
+    window time.Time
+
+    // 当前窗口数量
+    count int
+
+    // 上个窗口数量
+    prev int
+
+    // 令牌数量
+    tokens float64
+
+    // 令牌更新时间
+    last time.Time
+
+    // 过期时间
+    expires time.Time
+}
+
+/**
+ * 内存存储，只在单个进程内有效
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type MemoryStore struct {
+    // 锁定
+    mu sync.Mutex
+
+    // 数据
+    items map[string]*memoryItem
+
+    // 当前时间
+    now func() time.Time
+
+    // 上次清理时间
+    lastSweep time.Time
+}
+
+// 构造函数
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{
+        items: make(map[string]*memoryItem),
+        now:   time.Now,
+    }
+}
+
+// 设置时间方法
+func (this *MemoryStore) WithClock(now func() time.Time) *MemoryStore {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.now = now
+
+    return this
+}
+
+// 数据数量
+func (this *MemoryStore) Len() int {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return len(this.items)
+}
+
+// 消耗一次额度
+func (this *MemoryStore) Take(_ context.Context, key string, policy Policy) (Result, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := this.now()
+    this.sweep(now)
+
+    item, ok := this.items[key]
+    if !ok {
+        item = &memoryItem{}
+        this.items[key] = item
+    }
+
+    switch policy.Algorithm {
+        case AlgorithmFixed:
+            return this.fixed(item, policy, now), nil
+        case AlgorithmSliding:
+            return this.sliding(item, policy, now), nil
+        case AlgorithmToken:
+            return this.token(item, policy, now), nil
+    }
+
+    return Result{Allowed: true}, ErrUnknownAlgorithm
+}
+
+// 固定窗口
+func (this *MemoryStore) fixed(item *memoryItem, policy Policy, now time.Time) Result {
+    start := now.Truncate(policy.Period)
+    if !item.window.Equal(start) {
+        item.window = start
+        item.count = 0
+    }
+
+    item.expires = start.Add(policy.Period)
+
+    res := Result{
+        Limit: policy.Limit,
+        Reset: item.expires.Sub(now),
+    }
+
+    if item.count >= policy.Limit {
+        res.RetryAfter = res.Reset
+        return res
+    }
+
+    item.count++
+
+    res.Allowed = true
+    res.Remaining = policy.Limit - item.count
+
+    return res
+}
+
+// 滑动窗口，使用上个窗口数量按比例估算
+func (this *MemoryStore) sliding(item *memoryItem, policy Policy, now time.Time) Result {
+    start := now.Truncate(policy.Period)
+    if !item.window.Equal(start) {
+        if item.window.Add(policy.Period).Equal(start) {
+            item.prev = item.count
+        } else {
+            item.prev = 0
+        }
+
+        item.window = start
+        item.count = 0
+    }
+
+    item.expires = start.Add(2 * policy.Period)
+
+    elapsed := now.Sub(start)
+    estimated := slidingEstimate(item.prev, item.count, elapsed, policy.Period)
+
+    res := Result{
+        Limit: policy.Limit,
+        Reset: policy.Period - elapsed,
+    }
+
+    if estimated + 1 > float64(policy.Limit) {
+        res.RetryAfter = slidingRetryAfter(item.prev, item.count, policy.Limit, elapsed, policy.Period)
+        return res
+    }
+
+    item.count++
+
+    res.Allowed = true
+    res.Remaining = int(math.Floor(float64(policy.Limit) - estimated - 1))
+
+    return res
+}
+
+// 令牌桶
+func (this *MemoryStore) token(item *memoryItem, policy Policy, now time.Time) Result {
+    capacity := float64(policy.Capacity())
+
+    // 每纳秒生成的令牌
+    rate := float64(policy.Limit) / float64(policy.Period)
+
+    if item.last.IsZero() {
+        item.tokens = capacity
+    } else if elapsed := now.Sub(item.last); elapsed > 0 {
+        item.tokens = math.Min(capacity, item.tokens + float64(elapsed) * rate)
+    }
+
+    item.last = now
+
+    res := Result{
+        Limit: policy.Capacity(),
+    }
+
+    if item.tokens >= 1 {
+        item.tokens--
+        res.Allowed = true
+    } else {
+        res.RetryAfter = time.Duration(math.Ceil((1 - item.tokens) / rate))
+    }
+
+    res.Remaining = int(math.Floor(item.tokens))
+    res.Reset = time.Duration(math.Ceil((capacity - item.tokens) / rate))
+
+    item.expires = now.Add(res.Reset)
+
+    return res
+}
+
+// 清理过期数据
+func (this *MemoryStore) sweep(now time.Time) {
+    if now.Sub(this.lastSweep) < memorySweepInterval {
+        return
+    }
+
+    this.lastSweep = now
+
+    for key, item := range this.items {
+        if !item.expires.After(now) {
+            delete(this.items, key)
+        }
+    }
+}
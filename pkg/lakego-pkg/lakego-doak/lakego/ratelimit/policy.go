@@ -0,0 +1,205 @@
+package ratelimit
+
+import (
+    "fmt"
+    "time"
+    "errors"
+    "strconv"
+    "strings"
+)
+
+// 限流算法
+const (
+    // 固定窗口
+    AlgorithmFixed = "fixed"
+
+    // 滑动窗口
+    AlgorithmSliding = "sliding"
+
+    // 令牌桶
+    AlgorithmToken = "token"
+)
+
+/**
+ * 限流规则
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type Policy struct {
+    // 名称，用于区分不同规则的计数
+    Name string
+
+    // 算法，默认为固定窗口
+    Algorithm string
+
+    // 周期内请求数量
+    Limit int
+
+    // 周期
+    Period time.Duration
+
+    // 令牌桶容量，为 0 时使用 Limit
+    Burst int
+
+    // 限流对象，比如 ip, admin, client, route
+    By []string
+}
+
+// 检测规则
+func (this Policy) Validate() error {
+    switch this.Algorithm {
+        case AlgorithmFixed, AlgorithmSliding, AlgorithmToken:
+        default:
+            return ErrUnknownAlgorithm
+    }
+
+    if this.Limit <= 0 || this.Period <= 0 || this.Burst < 0 {
+        return ErrInvalidPolicy
+    }
+
+    return nil
+}
+
+// 容量，令牌桶使用 Burst
+func (this Policy) Capacity() int {
+    if this.Algorithm == AlgorithmToken && this.Burst > 0 {
+        return this.Burst
+    }
+
+    return this.Limit
+}
+
+// RateLimit-Policy 响应头数据，比如 100;w=60
+func (this Policy) HeaderValue() string {
+    value := fmt.Sprintf("%d;w=%d", this.Limit, ceilSeconds(this.Period))
+    if this.Algorithm == AlgorithmToken && this.Burst > 0 {
+        value += fmt.Sprintf(";burst=%d", this.Burst)
+    }
+
+    return value
+}
+
+// 规则描述，格式和 ParsePolicy 一致
+func (this Policy) String() string {
+    spec := fmt.Sprintf("%s %d/%s", this.Algorithm, this.Limit, this.Period)
+
+    if len(this.By) > 0 {
+        spec += " by=" + strings.Join(this.By, ",")
+    }
+    if this.Burst > 0 {
+        spec += fmt.Sprintf(" burst=%d", this.Burst)
+    }
+
+    return spec
+}
+
+/**
+ * 解析规则字符
+ *
+ * 格式为：[算法] 数量/周期 [by=ip,route] [burst=数量]
+ * 比如：60/1m, sliding 100/h by=admin, token 10/s burst=20 by=ip
+ */
+func ParsePolicy(spec string) (Policy, error) {
+    policy := Policy{
+        Algorithm: AlgorithmFixed,
+    }
+
+    fields := strings.Fields(spec)
+    if len(fields) == 0 {
+        return policy, ErrInvalidPolicy
+    }
+
+    // 算法
+    switch strings.ToLower(fields[0]) {
+        case AlgorithmFixed, AlgorithmSliding, AlgorithmToken:
+            policy.Algorithm = strings.ToLower(fields[0])
+            fields = fields[1:]
+    }
+
+    if len(fields) == 0 {
+        return policy, ErrInvalidPolicy
+    }
+
+    // 数量及周期
+    limit, period, ok := strings.Cut(fields[0], "/")
+    if !ok {
+        return policy, errors.New(fmt.Sprintf("限流规则 [%s] 缺少周期", spec))
+    }
+
+    var err error
+
+    policy.Limit, err = strconv.Atoi(limit)
+    if err != nil {
+        return policy, errors.New(fmt.Sprintf("限流规则 [%s] 数量错误", spec))
+    }
+
+    policy.Period, err = ParsePeriod(period)
+    if err != nil {
+        return policy, errors.New(fmt.Sprintf("限流规则 [%s] 周期错误", spec))
+    }
+
+    // 可选项
+    for _, field := range fields[1:] {
+        key, value, _ := strings.Cut(field, "=")
+
+        switch strings.ToLower(key) {
+            case "by":
+                policy.By = ParseBy(value)
+            case "burst":
+                policy.Burst, err = strconv.Atoi(value)
+                if err != nil {
+                    return policy, errors.New(fmt.Sprintf("限流规则 [%s] burst 错误", spec))
+                }
+            default:
+                return policy, errors.New(fmt.Sprintf("限流规则 [%s] 不支持 [%s]", spec, key))
+        }
+    }
+
+    return policy, policy.Validate()
+}
+
+// 解析周期，支持 1m, 30s, 1h 及省略数量的 s, m, h, d
+func ParsePeriod(period string) (time.Duration, error) {
+    period = strings.TrimSpace(period)
+
+    switch period {
+        case "s", "m", "h":
+            period = "1" + period
+        case "d":
+            return 24 * time.Hour, nil
+    }
+
+    if strings.HasSuffix(period, "d") {
+        days, err := strconv.Atoi(strings.TrimSuffix(period, "d"))
+        if err != nil {
+            return 0, err
+        }
+
+        return time.Duration(days) * 24 * time.Hour, nil
+    }
+
+    return time.ParseDuration(period)
+}
+
+// 解析限流对象，使用逗号分隔
+func ParseBy(by string) []string {
+    list := make([]string, 0)
+    for _, item := range strings.Split(by, ",") {
+        item = strings.TrimSpace(item)
+        if item != "" {
+            list = append(list, strings.ToLower(item))
+        }
+    }
+
+    return list
+}
+
+// 向上取整的秒数
+func ceilSeconds(d time.Duration) int64 {
+    if d <= 0 {
+        return 0
+    }
+
+    return int64((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+    "math"
+    "time"
+    "context"
+)
+
+// 默认键名前缀
+const DefaultPrefix = "ratelimit:"
+
+// 限流结果
+type Result struct {
+    // 是否允许请求
+    Allowed bool
+
+    // 周期内请求数量，令牌桶为容量
+    Limit int
+
+    // 剩余请求数量
+    Remaining int
+
+    // 额度完全恢复的时间
+    Reset time.Duration
+
+    // 被限流时，可再次请求的时间
+    RetryAfter time.Duration
+}
+
+// 重置时间秒数，用于响应头
+func (this Result) ResetSeconds() int64 {
+    return ceilSeconds(this.Reset)
+}
+
+// 重试时间秒数，用于响应头，最小为 1 秒
+func (this Result) RetryAfterSeconds() int64 {
+    seconds := ceilSeconds(this.RetryAfter)
+    if seconds < 1 {
+        seconds = 1
+    }
+
+    return seconds
+}
+
+// 存储
+type IStore interface {
+    // 消耗一次额度
+    Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+/**
+ * 限流
+ *
+ * limiter := ratelimit.New(ratelimit.NewMemoryStore())
+ * res, err := limiter.Take(ctx, "ip:127.0.0.1", policy)
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type Limiter struct {
+    // 存储
+    store IStore
+
+    // 键名前缀
+    prefix string
+}
+
+// 构造函数
+func New(store IStore) *Limiter {
+    return &Limiter{
+        store:  store,
+        prefix: DefaultPrefix,
+    }
+}
+
+// 设置键名前缀
+func (this *Limiter) WithPrefix(prefix string) *Limiter {
+    this.prefix = prefix
+    return this
+}
+
+// 获取存储
+func (this *Limiter) GetStore() IStore {
+    return this.store
+}
+
+// 消耗一次额度，键名会带上算法，避免不同算法的数据冲突
+func (this *Limiter) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+    if err := policy.Validate(); err != nil {
+        return Result{Allowed: true}, err
+    }
+
+    return this.store.Take(ctx, this.prefix + policy.Algorithm + ":" + key, policy)
+}
+
+// 滑动窗口估算数量，上个窗口的数量按剩余时间比例计算
+func slidingEstimate(prev, curr int, elapsed, period time.Duration) float64 {
+    weight := float64(period - elapsed) / float64(period)
+
+    return float64(prev) * weight + float64(curr)
+}
+
+// 滑动窗口可再次请求的时间
+func slidingRetryAfter(prev, curr, limit int, elapsed, period time.Duration) time.Duration {
+    rest := period - elapsed
+
+    // 当前窗口已满或者没有上个窗口数据时需要等到下个窗口
+    if curr >= limit || prev <= 0 {
+        return rest
+    }
+
+    // 上个窗口的权重降到可用数量的时间
+    wait := float64(rest) - float64(limit - 1 - curr) * float64(period) / float64(prev)
+    if wait <= 0 {
+        return time.Millisecond
+    }
+
+    return time.Duration(math.Ceil(wait))
+}
@@ -0,0 +1,215 @@
+package ratelimit
+
+import (
+    "time"
+    "context"
+    "testing"
+)
+
+type testClock struct {
+    now time.Time
+}
+
+func (this *testClock) Now() time.Time {
+    return this.now
+}
+
+func (this *testClock) Add(d time.Duration) {
+    this.now = this.now.Add(d)
+}
+
+func newTestLimiter() (*Limiter, *testClock) {
+    clock := &testClock{
+        now: time.Date(2024, 5, 29, 10, 0, 0, 0, time.UTC),
+    }
+
+    return New(NewMemoryStore().WithClock(clock.Now)), clock
+}
+
+func Test_ParsePolicy(t *testing.T) {
+    policy, err := ParsePolicy("token 10/s burst=20 by=IP,route")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if policy.Algorithm != AlgorithmToken || policy.Limit != 10 || policy.Period != time.Second || policy.Burst != 20 {
+        t.Errorf("got %+v", policy)
+    }
+    if len(policy.By) != 2 || policy.By[0] != "ip" || policy.By[1] != "route" {
+        t.Errorf("By got %v", policy.By)
+    }
+    if policy.HeaderValue() != "10;w=1;burst=20" {
+        t.Errorf("HeaderValue got %s", policy.HeaderValue())
+    }
+
+    policy, err = ParsePolicy("60/2d")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if policy.Algorithm != AlgorithmFixed || policy.Period != 48 * time.Hour {
+        t.Errorf("got %+v", policy)
+    }
+
+    again, err := ParsePolicy(policy.String())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if again.Limit != policy.Limit || again.Period != policy.Period {
+        t.Errorf("String got %s", policy.String())
+    }
+
+    for _, spec := range []string{"", "fixed", "10", "a/1m", "10/x", "0/1m", "10/1m foo=bar", "token 10/s burst=x"} {
+        if _, err := ParsePolicy(spec); err == nil {
+            t.Errorf("spec %q should fail", spec)
+        }
+    }
+}
+
+func Test_Fixed(t *testing.T) {
+    limiter, clock := newTestLimiter()
+    policy := Policy{Algorithm: AlgorithmFixed, Limit: 3, Period: time.Minute}
+
+    ctx := context.Background()
+
+    for i := 0; i < 3; i++ {
+        res, err := limiter.Take(ctx, "k", policy)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if !res.Allowed || res.Remaining != 2 - i {
+            t.Errorf("take %d got %+v", i, res)
+        }
+    }
+
+    clock.Add(20 * time.Second)
+
+    res, _ := limiter.Take(ctx, "k", policy)
+    if res.Allowed {
+        t.Fatal("should be limited")
+    }
+    if res.RetryAfter != 40 * time.Second || res.RetryAfterSeconds() != 40 {
+        t.Errorf("RetryAfter got %s", res.RetryAfter)
+    }
+
+    // 其他键名不受影响
+    if res, _ := limiter.Take(ctx, "other", policy); !res.Allowed {
+        t.Error("other key should be allowed")
+    }
+
+    clock.Add(40 * time.Second)
+
+    if res, _ := limiter.Take(ctx, "k", policy); !res.Allowed || res.Remaining != 2 {
+        t.Errorf("next window got %+v", res)
+    }
+}
+
+func Test_Sliding(t *testing.T) {
+    limiter, clock := newTestLimiter()
+    policy := Policy{Algorithm: AlgorithmSliding, Limit: 10, Period: time.Minute}
+
+    ctx := context.Background()
+
+    for i := 0; i < 10; i++ {
+        if res, _ := limiter.Take(ctx, "k", policy); !res.Allowed {
+            t.Fatalf("take %d should be allowed", i)
+        }
+    }
+
+    // 下个窗口开始时，上个窗口的数量仍然有效
+    clock.Add(time.Minute)
+
+    res, _ := limiter.Take(ctx, "k", policy)
+    if res.Allowed {
+        t.Fatal("should be limited at the start of the next window")
+    }
+    if res.RetryAfter != 6 * time.Second {
+        t.Errorf("RetryAfter got %s", res.RetryAfter)
+    }
+
+    // 过去一半时间，上个窗口按一半计算
+    clock.Add(30 * time.Second)
+
+    for i := 0; i < 5; i++ {
+        res, _ := limiter.Take(ctx, "k", policy)
+        if !res.Allowed || res.Remaining != 4 - i {
+            t.Errorf("take %d got %+v", i, res)
+        }
+    }
+
+    if res, _ := limiter.Take(ctx, "k", policy); res.Allowed {
+        t.Error("should be limited")
+    }
+
+    // 两个窗口后清空
+    clock.Add(2 * time.Minute)
+
+    if res, _ := limiter.Take(ctx, "k", policy); !res.Allowed || res.Remaining != 9 {
+        t.Errorf("got %+v", res)
+    }
+}
+
+func Test_Token(t *testing.T) {
+    limiter, clock := newTestLimiter()
+    policy := Policy{Algorithm: AlgorithmToken, Limit: 1, Period: time.Second, Burst: 3}
+
+    ctx := context.Background()
+
+    for i := 0; i < 3; i++ {
+        res, _ := limiter.Take(ctx, "k", policy)
+        if !res.Allowed || res.Limit != 3 || res.Remaining != 2 - i {
+            t.Errorf("take %d got %+v", i, res)
+        }
+    }
+
+    res, _ := limiter.Take(ctx, "k", policy)
+    if res.Allowed {
+        t.Fatal("should be limited")
+    }
+    if res.RetryAfter != time.Second || res.Reset != 3 * time.Second {
+        t.Errorf("got %+v", res)
+    }
+
+    clock.Add(1500 * time.Millisecond)
+
+    if res, _ := limiter.Take(ctx, "k", policy); !res.Allowed || res.Remaining != 0 {
+        t.Errorf("got %+v", res)
+    }
+
+    clock.Add(time.Hour)
+
+    if res, _ := limiter.Take(ctx, "k", policy); !res.Allowed || res.Remaining != 2 {
+        t.Errorf("refill got %+v", res)
+    }
+}
+
+func Test_InvalidPolicy(t *testing.T) {
+    limiter, _ := newTestLimiter()
+
+    res, err := limiter.Take(context.Background(), "k", Policy{Algorithm: "leaky", Limit: 1, Period: time.Second})
+    if err != ErrUnknownAlgorithm {
+        t.Errorf("got err %v", err)
+    }
+    if !res.Allowed {
+        t.Error("invalid policy should not limit")
+    }
+}
+
+func Test_MemorySweep(t *testing.T) {
+    clock := &testClock{
+        now: time.Date(2024, 5, 29, 10, 0, 0, 0, time.UTC),
+    }
+    store := NewMemoryStore().WithClock(clock.Now)
+    limiter := New(store)
+
+    policy := Policy{Algorithm: AlgorithmFixed, Limit: 1, Period: time.Second}
+
+    limiter.Take(context.Background(), "a", policy)
+    limiter.Take(context.Background(), "b", policy)
+
+    clock.Add(2 * time.Minute)
+    limiter.Take(context.Background(), "c", policy)
+
+    if store.Len() != 1 {
+        t.Errorf("Len got %d", store.Len())
+    }
+}
@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+    "time"
+    "context"
+    "strconv"
+
+    "github.com/go-redis/redis/v8"
+)
+
+// 脚本返回：是否允许, 剩余数量, 重置毫秒数, 重试毫秒数
+// 时间使用 redis 服务器时间，多个服务共用时不受本地时间影响
+
+// 固定窗口
+var fixedScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local window = now - (now % period)
+local key = KEYS[1] .. ':' .. window
+local reset = window + period - now
+
+local count = tonumber(redis.call('GET', key) or '0')
+if count >= limit then
+    return {0, 0, reset, reset}
+end
+
+count = redis.call('INCR', key)
+if count == 1 then
+    redis.call('PEXPIRE', key, period)
+end
+
+return {1, limit - count, reset, 0}
+`)
+
+// 滑动窗口
+var slidingScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local window = now - (now % period)
+local currKey = KEYS[1] .. ':' .. window
+local prevKey = KEYS[1] .. ':' .. (window - period)
+
+local curr = tonumber(redis.call('GET', currKey) or '0')
+local prev = tonumber(redis.call('GET', prevKey) or '0')
+
+local elapsed = now - window
+local reset = period - elapsed
+local estimated = prev * (period - elapsed) / period + curr
+
+if estimated + 1 > limit then
+    local retry = reset
+    if curr < limit and prev > 0 then
+        retry = math.ceil(reset - (limit - 1 - curr) * period / prev)
+        if retry < 1 then
+            retry = 1
+        end
+    end
+
+    return {0, 0, reset, retry}
+end
+
+curr = redis.call('INCR', currKey)
+if curr == 1 then
+    redis.call('PEXPIRE', currKey, period * 2)
+end
+
+return {1, math.floor(limit - estimated - 1), reset, 0}
+`)
+
+// 令牌桶
+var tokenScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+
+if tokens == nil or ts == nil then
+    tokens = capacity
+elseif now > ts then
+    tokens = math.min(capacity, tokens + (now - ts) * rate)
+end
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+else
+    retry = math.ceil((1 - tokens) / rate)
+end
+
+local reset = math.ceil((capacity - tokens) / rate)
+
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.max(reset, 1000))
+
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+/**
+ * redis 存储，使用 lua 脚本保证原子性
+ *
+ * @create 2024-5-29
+ * @author deatil
+ */
+type RedisStore struct {
+    // 连接
+    client redis.Scripter
+}
+
+// 构造函数
+func NewRedisStore(client redis.Scripter) *RedisStore {
+    return &RedisStore{
+        client: client,
+    }
+}
+
+// 消耗一次额度
+func (this *RedisStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+    var (
+        script *redis.Script
+        args   []any
+    )
+
+    period := policy.Period.Milliseconds()
+    if period < 1 {
+        period = 1
+    }
+
+    switch policy.Algorithm {
+        case AlgorithmFixed:
+            script = fixedScript
+            args = []any{policy.Limit, period}
+        case AlgorithmSliding:
+            script = slidingScript
+            args = []any{policy.Limit, period}
+        case AlgorithmToken:
+            // 每毫秒生成的令牌
+            rate := float64(policy.Limit) / float64(period)
+
+            script = tokenScript
+            args = []any{policy.Capacity(), strconv.FormatFloat(rate, 'f', -1, 64)}
+        default:
+            return Result{Allowed: true}, ErrUnknownAlgorithm
+    }
+
+    values, err := script.Run(ctx, this.client, []string{key}, args...).Int64Slice()
+    if err != nil {
+        return Result{Allowed: true}, err
+    }
+
+    if len(values) != 4 {
+        return Result{Allowed: true}, ErrInvalidResult
+    }
+
+    remaining := int(values[1])
+    if remaining < 0 {
+        remaining = 0
+    }
+
+    return Result{
+        Allowed:    values[0] == 1,
+        Limit:      policy.Capacity(),
+        Remaining:  remaining,
+        Reset:      time.Duration(values[2]) * time.Millisecond,
+        RetryAfter: time.Duration(values[3]) * time.Millisecond,
+    }, nil
+}
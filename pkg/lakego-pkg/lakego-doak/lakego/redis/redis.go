@@ -87,6 +87,9 @@ func New(config Config) Redis {
         client.AddHook(redisotel.NewTracingHook())
     }
 
+    // 链路追踪
+    client.AddHook(NewTraceHook())
+
     return Redis{
         ctx:    context.TODO(),
         prefix: config.KeyPrefix,
@@ -98,6 +101,13 @@ func New(config Config) Redis {
     }
 }
 
+// 设置上下文，用于传递请求链路
+func (this Redis) WithContext(ctx context.Context) Redis {
+    this.ctx = ctx
+
+    return this
+}
+
 // 设置
 func (this Redis) Set(key string, value any, expiration any) error {
     ttl := goch.ToDuration(expiration)
@@ -0,0 +1,81 @@
+package redis
+
+import (
+    "context"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 链路追踪 hook
+func NewTraceHook() redis.Hook {
+    return traceHook{}
+}
+
+type traceSpanKey struct{}
+
+/**
+ * redis 链路追踪，请求上下文中有链路时记录命令节点
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type traceHook struct {}
+
+func (this traceHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+    return this.start(ctx, "redis." + cmd.FullName(), 1), nil
+}
+
+func (this traceHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+    this.end(ctx, cmd.Err())
+
+    return nil
+}
+
+func (this traceHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+    return this.start(ctx, "redis.pipeline", len(cmds)), nil
+}
+
+func (this traceHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+    var err error
+    for _, cmd := range cmds {
+        if cmd.Err() != nil && cmd.Err() != redis.Nil {
+            err = cmd.Err()
+            break
+        }
+    }
+
+    this.end(ctx, err)
+
+    return nil
+}
+
+// 开始节点
+func (this traceHook) start(ctx context.Context, name string, num int) context.Context {
+    if !trace.Default().Enabled() || !trace.SpanContextFromContext(ctx).IsValid() {
+        return ctx
+    }
+
+    ctx, span := trace.Start(ctx, name, trace.KindClient)
+    span.SetAttributes(map[string]any{
+        "db.system":        "redis",
+        "db.redis.num_cmd": num,
+    })
+
+    return context.WithValue(ctx, traceSpanKey{}, span)
+}
+
+// 结束节点
+func (this traceHook) end(ctx context.Context, err error) {
+    span, ok := ctx.Value(traceSpanKey{}).(*trace.Span)
+    if !ok {
+        return
+    }
+
+    if err != nil && err != redis.Nil {
+        span.RecordError(err)
+    }
+
+    span.End()
+}
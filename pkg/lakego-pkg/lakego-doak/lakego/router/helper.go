@@ -38,46 +38,26 @@ func GetRequestIp(ctx *Context) string {
     return ip
 }
 
-// 获取真实IP
+// 获取真实IP，只有请求来自可信代理时才使用转发请求头
 func GetRealIP(ctx *Context) (ip string) {
-    var header = ctx.Request.Header
-    var index int
+    remoteAddr := strings.TrimSpace(ctx.Request.RemoteAddr)
 
-    if ip = header.Get("X-Forwarded-For"); ip != "" {
-        index = strings.IndexByte(ip, ',')
-        if index < 0 {
-            return ip
-        }
-
-        if ip = ip[:index]; ip != "" {
-            return ip
-        }
+    remote, _, err := net.SplitHostPort(remoteAddr)
+    if err != nil {
+        remote = remoteAddr
     }
 
-    if ip = header.Get("X-Real-Ip"); ip != "" {
-        index = strings.IndexByte(ip, ',')
-        if index < 0 {
-            return ip
-        }
-
-        if ip = ip[:index]; ip != "" {
-            return ip
-        }
+    if !IsTrustedProxy(remote) {
+        return remote
     }
 
-    if ip = header.Get("Proxy-Forwarded-For"); ip != "" {
-        index = strings.IndexByte(ip, ',')
-        if index < 0 {
-            return ip
-        }
-
-        if ip = ip[:index]; ip != "" {
+    for _, name := range ForwardedHeaders {
+        if ip = forwardedIP(ctx.Request.Header.Get(name)); ip != "" {
             return ip
         }
     }
 
-    ip, _, _ = net.SplitHostPort(ctx.Request.RemoteAddr)
-    return ip
+    return remote
 }
 
 // 获取本地IP
@@ -167,3 +147,33 @@ func MatchPath(ctx *Context, path string, current string) bool {
     return true
 }
 
+
+// 匹配路由，包含 / 时按链接匹配，比如 GET:/admin/*，否则匹配路由名称或者权限标识
+func MatchRoute(ctx *Context, route string) bool {
+    if strings.Contains(route, "/") {
+        return MatchPath(ctx, route, ctx.Request.URL.Path)
+    }
+
+    fullPath := ctx.FullPath()
+    if fullPath == "" {
+        return false
+    }
+
+    meta, ok := NewMeta().GetMeta(ctx.Request.Method, fullPath)
+    if !ok {
+        return false
+    }
+
+    return route == meta.Name || route == meta.Slug
+}
+
+// 匹配任意一个路由
+func MatchRoutes(ctx *Context, routes []string) bool {
+    for _, route := range routes {
+        if MatchRoute(ctx, route) {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+    "testing"
+    "net/http/httptest"
+
+    "github.com/gin-gonic/gin"
+)
+
+func Test_MatchRoute(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+
+    NewMeta().SetMeta(RouteMeta{
+        Method: "GET",
+        Path:   "/test-match/:id",
+        Name:   "test-match.detail",
+        Slug:   "lakego-admin.test-match.detail",
+    })
+
+    tests := []struct {
+        route string
+        want  bool
+    }{
+        {"/test-match/12", true},
+        {"GET:/test-match/*", true},
+        {"POST:/test-match/*", false},
+        {"/test-match/13", false},
+        {"test-match.detail", true},
+        {"lakego-admin.test-match.detail", true},
+        {"test-match.index", false},
+    }
+
+    r := gin.New()
+    r.GET("/test-match/:id", func(ctx *gin.Context) {
+        for _, tt := range tests {
+            if got := MatchRoute(ctx, tt.route); got != tt.want {
+                t.Errorf("MatchRoute(%q) = %v, want %v", tt.route, got, tt.want)
+            }
+        }
+
+        if !MatchRoutes(ctx, []string{"none", "test-match.detail"}) || MatchRoutes(ctx, nil) {
+            t.Error("MatchRoutes error")
+        }
+    })
+
+    r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test-match/12", nil))
+}
@@ -0,0 +1,355 @@
+package router
+
+import (
+    "sync"
+    "path"
+    "strings"
+)
+
+var instanceMeta *RouteMetas
+var onceMeta sync.Once
+
+// 路由元数据
+type RouteMeta struct {
+    // 请求方式
+    Method string
+
+    // 完整路由
+    Path string
+
+    // 别名
+    Name string
+
+    // 权限标识
+    Slug string
+
+    // 标题
+    Title string
+
+    // 父级，父级路由的 slug 或者父级菜单标题
+    Parent string
+
+    // 描述
+    Description string
+
+    // 排序
+    Sort int
+
+    // 文档标签，为空时使用父级
+    Tags []string
+
+    // 文档鉴权方式，为 nil 时使用默认设置，空列表表示不需要鉴权
+    Security []string
+}
+
+/**
+ * 路由元数据
+ *
+ * @create 2024-5-6
+ * @author deatil
+ */
+type RouteMetas struct {
+    // 锁定
+    mu sync.RWMutex
+
+    // 列表
+    metas map[string]*RouteMeta
+
+    // 注册顺序
+    keys []string
+}
+
+// 单例
+func NewMeta() *RouteMetas {
+    onceMeta.Do(func() {
+        instanceMeta = &RouteMetas{
+            metas: make(map[string]*RouteMeta),
+            keys:  make([]string, 0),
+        }
+    })
+
+    return instanceMeta
+}
+
+// 设置
+func (this *RouteMetas) SetMeta(meta RouteMeta) *RouteMetas {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    meta.Method = strings.ToUpper(meta.Method)
+
+    key := metaKey(meta.Method, meta.Path)
+    if _, ok := this.metas[key]; !ok {
+        this.keys = append(this.keys, key)
+    }
+
+    this.metas[key] = &meta
+
+    return this
+}
+
+// 更新
+func (this *RouteMetas) UpdateMeta(method string, path string, f func(*RouteMeta)) *RouteMetas {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if meta, ok := this.metas[metaKey(method, path)]; ok {
+        f(meta)
+    }
+
+    return this
+}
+
+// 判断是否存在
+func (this *RouteMetas) HasMeta(method string, path string) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    _, ok := this.metas[metaKey(method, path)]
+
+    return ok
+}
+
+// 获取单个
+func (this *RouteMetas) GetMeta(method string, path string) (RouteMeta, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if meta, ok := this.metas[metaKey(method, path)]; ok {
+        return *meta, true
+    }
+
+    return RouteMeta{}, false
+}
+
+// 根据 slug 获取
+func (this *RouteMetas) GetMetaBySlug(slug string) (RouteMeta, bool) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    for _, key := range this.keys {
+        if meta := this.metas[key]; meta.Slug == slug {
+            return *meta, true
+        }
+    }
+
+    return RouteMeta{}, false
+}
+
+// 获取全部，按注册顺序排列
+func (this *RouteMetas) GetMetas() []RouteMeta {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    metas := make([]RouteMeta, 0, len(this.keys))
+    for _, key := range this.keys {
+        metas = append(metas, *this.metas[key])
+    }
+
+    return metas
+}
+
+// 索引
+func metaKey(method string, path string) string {
+    return strings.ToUpper(method) + " " + path
+}
+
+// 拼接路由
+func joinPaths(absolutePath, relativePath string) string {
+    if relativePath == "" {
+        return absolutePath
+    }
+
+    finalPath := path.Join(absolutePath, relativePath)
+    if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+        return finalPath + "/"
+    }
+
+    return finalPath
+}
+
+// ===========
+
+/**
+ * 单个路由元数据设置
+ *
+ * @create 2024-5-6
+ * @author deatil
+ */
+type MetaItem struct {
+    method string
+    path   string
+}
+
+// 设置别名
+func (this *MetaItem) Name(name string) *MetaItem {
+    this.update(func(meta *RouteMeta) {
+        meta.Name = name
+    })
+
+    NewName().SetRouteName(name, RouterInfo{
+        RouteInfo{
+            Method: this.method,
+            Path:   this.path,
+        },
+        name,
+    })
+
+    return this
+}
+
+// 设置权限标识
+func (this *MetaItem) Slug(slug string) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Slug = slug
+    })
+}
+
+// 设置标题
+func (this *MetaItem) Title(title string) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Title = title
+    })
+}
+
+// 设置父级
+func (this *MetaItem) Parent(parent string) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Parent = parent
+    })
+}
+
+// 设置描述
+func (this *MetaItem) Description(description string) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Description = description
+    })
+}
+
+// 设置排序
+func (this *MetaItem) Sort(sort int) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Sort = sort
+    })
+}
+
+// 设置文档标签
+func (this *MetaItem) Tags(tags ...string) *MetaItem {
+    return this.update(func(meta *RouteMeta) {
+        meta.Tags = tags
+    })
+}
+
+// 设置文档鉴权方式，不传参数时表示不需要鉴权
+func (this *MetaItem) Security(schemes ...string) *MetaItem {
+    if schemes == nil {
+        schemes = []string{}
+    }
+
+    return this.update(func(meta *RouteMeta) {
+        meta.Security = schemes
+    })
+}
+
+// 获取数据
+func (this *MetaItem) Meta() RouteMeta {
+    meta, _ := NewMeta().GetMeta(this.method, this.path)
+
+    return meta
+}
+
+func (this *MetaItem) update(f func(*RouteMeta)) *MetaItem {
+    NewMeta().UpdateMeta(this.method, this.path, f)
+
+    return this
+}
+
+// ===========
+
+/**
+ * 带元数据的路由注册
+ *
+ * meta := router.WithMeta(engine)
+ * meta.GET("/admin", adminController.Index).
+ *     Slug("lakego-admin.admin.index").
+ *     Title("账号列表").
+ *     Parent("管理员")
+ *
+ * @create 2024-5-6
+ * @author deatil
+ */
+type MetaRouter struct {
+    router IRouter
+}
+
+// 包装路由
+func WithMeta(router IRouter) *MetaRouter {
+    return &MetaRouter{
+        router: router,
+    }
+}
+
+// 路由分组
+func (this *MetaRouter) Group(relativePath string, handlers ...HandlerFunc) *MetaRouter {
+    return WithMeta(this.router.Group(relativePath, handlers...))
+}
+
+// 原始路由
+func (this *MetaRouter) Router() IRouter {
+    return this.router
+}
+
+// 注册路由
+func (this *MetaRouter) Handle(method string, relativePath string, handlers ...HandlerFunc) *MetaItem {
+    method = strings.ToUpper(method)
+
+    this.router.Handle(method, relativePath, handlers...)
+
+    fullPath := joinPaths(this.basePath(), relativePath)
+
+    NewMeta().SetMeta(RouteMeta{
+        Method: method,
+        Path:   fullPath,
+    })
+
+    return &MetaItem{
+        method: method,
+        path:   fullPath,
+    }
+}
+
+func (this *MetaRouter) GET(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("GET", relativePath, handlers...)
+}
+
+func (this *MetaRouter) POST(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("POST", relativePath, handlers...)
+}
+
+func (this *MetaRouter) PUT(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("PUT", relativePath, handlers...)
+}
+
+func (this *MetaRouter) PATCH(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("PATCH", relativePath, handlers...)
+}
+
+func (this *MetaRouter) DELETE(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("DELETE", relativePath, handlers...)
+}
+
+func (this *MetaRouter) OPTIONS(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("OPTIONS", relativePath, handlers...)
+}
+
+func (this *MetaRouter) HEAD(relativePath string, handlers ...HandlerFunc) *MetaItem {
+    return this.Handle("HEAD", relativePath, handlers...)
+}
+
+func (this *MetaRouter) basePath() string {
+    if r, ok := this.router.(interface{ BasePath() string }); ok {
+        return r.BasePath()
+    }
+
+    return "/"
+}
@@ -0,0 +1,115 @@
+package router
+
+import (
+    "net"
+    "fmt"
+    "sync"
+    "errors"
+    "strings"
+)
+
+// 代理转发请求头，按顺序获取
+var ForwardedHeaders = []string{
+    "X-Forwarded-For",
+    "X-Real-Ip",
+    "Proxy-Forwarded-For",
+}
+
+var (
+    trustedProxiesMu sync.RWMutex
+
+    // 可信代理，为空时不信任转发请求头
+    trustedProxies []*net.IPNet
+)
+
+// 设置可信代理，支持 IP 及 CIDR，比如 127.0.0.1, 10.0.0.0/8
+func SetTrustedProxies(proxies []string) error {
+    nets := make([]*net.IPNet, 0, len(proxies))
+
+    for _, proxy := range proxies {
+        proxy = strings.TrimSpace(proxy)
+        if proxy == "" {
+            continue
+        }
+
+        ipNet, err := parseProxy(proxy)
+        if err != nil {
+            return err
+        }
+
+        nets = append(nets, ipNet)
+    }
+
+    trustedProxiesMu.Lock()
+    defer trustedProxiesMu.Unlock()
+
+    trustedProxies = nets
+
+    return nil
+}
+
+// 是否为可信代理
+func IsTrustedProxy(ip string) bool {
+    parsed := net.ParseIP(strings.TrimSpace(ip))
+    if parsed == nil {
+        return false
+    }
+
+    trustedProxiesMu.RLock()
+    defer trustedProxiesMu.RUnlock()
+
+    for _, ipNet := range trustedProxies {
+        if ipNet.Contains(parsed) {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 解析代理地址
+func parseProxy(proxy string) (*net.IPNet, error) {
+    if strings.Contains(proxy, "/") {
+        _, ipNet, err := net.ParseCIDR(proxy)
+        if err != nil {
+            return nil, errors.New(fmt.Sprintf("可信代理 [%s] 格式错误", proxy))
+        }
+
+        return ipNet, nil
+    }
+
+    ip := net.ParseIP(proxy)
+    if ip == nil {
+        return nil, errors.New(fmt.Sprintf("可信代理 [%s] 格式错误", proxy))
+    }
+
+    bits := 32
+    if ip.To4() == nil {
+        bits = 128
+    } else {
+        ip = ip.To4()
+    }
+
+    return &net.IPNet{
+        IP:   ip,
+        Mask: net.CIDRMask(bits, bits),
+    }, nil
+}
+
+// 从转发请求头获取客户端 IP，从右往左跳过可信代理
+func forwardedIP(value string) string {
+    items := strings.Split(value, ",")
+
+    for i := len(items) - 1; i >= 0; i-- {
+        ip := strings.TrimSpace(items[i])
+        if net.ParseIP(ip) == nil {
+            return ""
+        }
+
+        if i == 0 || !IsTrustedProxy(ip) {
+            return ip
+        }
+    }
+
+    return ""
+}
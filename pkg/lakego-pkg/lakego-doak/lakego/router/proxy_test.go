@@ -0,0 +1,51 @@
+package router
+
+import (
+    "testing"
+    "net/http/httptest"
+
+    "github.com/gin-gonic/gin"
+)
+
+func Test_GetRealIP(t *testing.T) {
+    defer SetTrustedProxies(nil)
+
+    if err := SetTrustedProxies([]string{"10.0.0.0/8", "127.0.0.1"}); err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        remote  string
+        headers map[string]string
+        want    string
+    }{
+        // 不是可信代理时不使用转发请求头
+        {"8.8.8.8:1234", map[string]string{"X-Forwarded-For": "1.1.1.1"}, "8.8.8.8"},
+        {"127.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.1.1.1"}, "1.1.1.1"},
+        // 从右往左跳过可信代理，伪造的左侧数据不生效
+        {"10.0.0.2:1234", map[string]string{"X-Forwarded-For": "6.6.6.6, 2.2.2.2, 10.0.0.3"}, "2.2.2.2"},
+        {"10.0.0.2:1234", map[string]string{"X-Real-Ip": "3.3.3.3"}, "3.3.3.3"},
+        {"10.0.0.2:1234", map[string]string{"X-Forwarded-For": "bad"}, "10.0.0.2"},
+        {"10.0.0.2:1234", nil, "10.0.0.2"},
+        {"[::1]:1234", map[string]string{"X-Forwarded-For": "1.1.1.1"}, "::1"},
+    }
+
+    for _, test := range tests {
+        req := httptest.NewRequest("GET", "/", nil)
+        req.RemoteAddr = test.remote
+        for k, v := range test.headers {
+            req.Header.Set(k, v)
+        }
+
+        ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+        ctx.Request = req
+
+        if got := GetRealIP(ctx); got != test.want {
+            t.Errorf("remote %s, headers %v got %s, want %s", test.remote, test.headers, got, test.want)
+        }
+    }
+
+    if err := SetTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+        t.Error("invalid CIDR should fail")
+    }
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/trace"
+)
+
+// 链路数据在 Context 中的名称
+const (
+    RequestIDKey = "request_id"
+    TraceIDKey   = "trace_id"
+)
+
+// 请求 id，没有时为空
+func GetRequestID(ctx *Context) string {
+    return ctx.GetString(RequestIDKey)
+}
+
+// 链路 id，没有时为空
+func GetTraceID(ctx *Context) string {
+    return ctx.GetString(TraceIDKey)
+}
+
+// 带链路数据的上下文，用于数据库、缓存及日志等调用
+func TraceContext(ctx *Context) context.Context {
+    return ctx.Request.Context()
+}
+
+// 链路日志字段
+func TraceFields(ctx *Context) map[string]any {
+    return trace.Fields(ctx.Request.Context())
+}
@@ -0,0 +1,52 @@
+package router
+
+// 视图共享数据键名
+const viewDataKey = "lakego.view-data"
+
+// 添加视图共享数据，比如中间件生成的 csp_nonce 及 csrf_token
+func WithViewData(ctx *Context, key string, value any) {
+    data := GetViewData(ctx)
+    data[key] = value
+
+    ctx.Set(viewDataKey, data)
+}
+
+// 获取视图共享数据
+func GetViewData(ctx *Context) map[string]any {
+    if data, ok := ctx.Get(viewDataKey); ok {
+        if viewData, ok := data.(map[string]any); ok {
+            return viewData
+        }
+    }
+
+    return make(map[string]any)
+}
+
+// 合并视图共享数据，已存在的数据不覆盖
+func MergeViewData(ctx *Context, obj any) any {
+    shared := GetViewData(ctx)
+    if len(shared) == 0 {
+        return obj
+    }
+
+    data := make(map[string]any, len(shared))
+    for k, v := range shared {
+        data[k] = v
+    }
+
+    switch obj := obj.(type) {
+        case nil:
+        case map[string]any:
+            for k, v := range obj {
+                data[k] = v
+            }
+        case H:
+            for k, v := range obj {
+                data[k] = v
+            }
+        default:
+            return obj
+    }
+
+    return data
+}
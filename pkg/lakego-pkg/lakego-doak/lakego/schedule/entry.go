@@ -1,7 +1,10 @@
 package schedule
 
 import (
+    "time"
+    "context"
     "strings"
+    "sync/atomic"
 )
 
 // 构造函数
@@ -29,6 +32,21 @@ type Entry struct {
 
     // 当前任务名称
     Name string
+
+    // 上次执行未完成时跳过
+    withoutOverlapping bool
+
+    // 超时时间
+    timeout time.Duration
+
+    // 执行成功回调
+    onSuccess []func(Run)
+
+    // 执行失败回调
+    onFailure []func(Run)
+
+    // 执行中数量
+    running int32
 }
 
 // 设置计划时间
@@ -64,6 +82,11 @@ func (this *Entry) AddFunc(cmd func()) *Entry {
     return this.WithCmd(cmd)
 }
 
+// 带 context 的任务，超时时 context 会被取消，可使用 schedule.Output(ctx) 记录输出
+func (this *Entry) AddTask(cmd func(context.Context) error) *Entry {
+    return this.WithCmd(cmd)
+}
+
 // Job 接口类
 func (this *Entry) AddJob(cmd IJob) *Entry {
     return this.WithCmd(cmd)
@@ -76,6 +99,63 @@ func (this *Entry) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
     return this.WithCmd(cmd)
 }
 
+// 上次执行未完成时跳过本次执行
+func (this *Entry) WithoutOverlapping() *Entry {
+    this.withoutOverlapping = true
+
+    return this
+}
+
+// 设置超时时间，超时后记录为失败
+func (this *Entry) Timeout(d time.Duration) *Entry {
+    this.timeout = d
+
+    return this
+}
+
+// 执行成功回调
+func (this *Entry) OnSuccess(f func(Run)) *Entry {
+    this.onSuccess = append(this.onSuccess, f)
+
+    return this
+}
+
+// 执行失败及超时回调
+func (this *Entry) OnFailure(f func(Run)) *Entry {
+    this.onFailure = append(this.onFailure, f)
+
+    return this
+}
+
+// 是否跳过重复执行
+func (this *Entry) IsWithoutOverlapping() bool {
+    return this.withoutOverlapping
+}
+
+// 超时时间
+func (this *Entry) GetTimeout() time.Duration {
+    return this.timeout
+}
+
+// 是否执行中
+func (this *Entry) IsRunning() bool {
+    return atomic.LoadInt32(&this.running) > 0
+}
+
+// 执行脚本
+func (this *Entry) call(ctx context.Context) error {
+    switch cmd := this.Cmd.(type) {
+        case func():
+            cmd()
+        case func(context.Context) error:
+            return cmd(ctx)
+        case IJob:
+            cmd.Run()
+    }
+
+    return nil
+}
+
 // Yearly
 func (this *Entry) CronYearly() *Entry {
     this.Spec = "@yearly"
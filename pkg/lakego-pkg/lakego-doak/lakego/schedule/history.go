@@ -0,0 +1,138 @@
+package schedule
+
+import (
+    "sync"
+    "time"
+)
+
+// 执行状态
+const (
+    StatusSuccess  = "success"
+    StatusFailure  = "failure"
+    StatusTimeout  = "timeout"
+    StatusSkipped  = "skipped"
+    StatusCanceled = "canceled"
+)
+
+// 触发方式
+const (
+    TriggerCron   = "cron"
+    TriggerManual = "manual"
+)
+
+// 执行记录
+type Run struct {
+    // 记录ID
+    ID string `json:"id"`
+
+    // 任务名称
+    Name string `json:"name"`
+
+    // 计划时间
+    Spec string `json:"spec"`
+
+    // 触发方式
+    Trigger string `json:"trigger"`
+
+    // 执行状态
+    Status string `json:"status"`
+
+    // 开始时间
+    StartAt time.Time `json:"start_at"`
+
+    // 结束时间
+    EndAt time.Time `json:"end_at"`
+
+    // 执行时长
+    Duration time.Duration `json:"duration"`
+
+    // 错误信息
+    Error string `json:"error"`
+
+    // 输出内容
+    Output string `json:"output"`
+}
+
+/**
+ * 执行记录存储接口
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type HistoryStore interface {
+    // 保存记录
+    Save(run Run) error
+
+    // 记录列表，name 为空时返回全部任务的记录，按开始时间倒序
+    List(name string, offset int, limit int) ([]Run, int64, error)
+}
+
+// 内存存储
+func NewMemoryHistory(size int) *MemoryHistory {
+    if size <= 0 {
+        size = 100
+    }
+
+    return &MemoryHistory{
+        size: size,
+        runs: make([]Run, 0),
+    }
+}
+
+/**
+ * 内存执行记录，只保留最近的 size 条记录
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type MemoryHistory struct {
+    mu sync.RWMutex
+
+    // 保留数量
+    size int
+
+    // 记录
+    runs []Run
+}
+
+// 保存记录
+func (this *MemoryHistory) Save(run Run) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.runs = append(this.runs, run)
+    if len(this.runs) > this.size {
+        this.runs = this.runs[len(this.runs)-this.size:]
+    }
+
+    return nil
+}
+
+// 记录列表
+func (this *MemoryHistory) List(name string, offset int, limit int) ([]Run, int64, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    matched := make([]Run, 0)
+    for i := len(this.runs) - 1; i >= 0; i-- {
+        if name == "" || this.runs[i].Name == name {
+            matched = append(matched, this.runs[i])
+        }
+    }
+
+    total := int64(len(matched))
+
+    if offset < 0 {
+        offset = 0
+    }
+    if offset > len(matched) {
+        offset = len(matched)
+    }
+
+    matched = matched[offset:]
+    if limit > 0 && limit < len(matched) {
+        matched = matched[:limit]
+    }
+
+    return matched, total, nil
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// 执行记录表
+type ScheduleRun struct {
+    ID       string `gorm:"column:id;type:char(36);not null;primaryKey;"`
+    Name     string `gorm:"column:name;type:varchar(200);not null;index;"`
+    Spec     string `gorm:"column:spec;type:varchar(100);"`
+    Trigger  string `gorm:"column:trigger_type;type:varchar(10);"`
+    Status   string `gorm:"column:status;type:varchar(10);"`
+    StartAt  int64  `gorm:"column:start_at;type:bigint(20);"`
+    EndAt    int64  `gorm:"column:end_at;type:bigint(20);"`
+    Duration int64  `gorm:"column:duration;type:bigint(20);"`
+    Error    string `gorm:"column:error;type:text;"`
+    Output   string `gorm:"column:output;type:text;"`
+}
+
+// 数据库存储
+func NewDatabaseHistory(db *gorm.DB, table string) *DatabaseHistory {
+    if table == "" {
+        table = "schedule_run"
+    }
+
+    return &DatabaseHistory{
+        db:    db,
+        table: table,
+    }
+}
+
+/**
+ * 数据库执行记录
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type DatabaseHistory struct {
+    db    *gorm.DB
+    table string
+}
+
+// 保存记录
+func (this *DatabaseHistory) Save(run Run) error {
+    return this.newDB().Create(&ScheduleRun{
+        ID:       run.ID,
+        Name:     run.Name,
+        Spec:     run.Spec,
+        Trigger:  run.Trigger,
+        Status:   run.Status,
+        StartAt:  run.StartAt.UnixMilli(),
+        EndAt:    run.EndAt.UnixMilli(),
+        Duration: run.Duration.Milliseconds(),
+        Error:    run.Error,
+        Output:   run.Output,
+    }).Error
+}
+
+// 记录列表
+func (this *DatabaseHistory) List(name string, offset int, limit int) ([]Run, int64, error) {
+    tx := this.newDB()
+    if name != "" {
+        tx = tx.Where("name = ?", name)
+    }
+
+    var total int64
+    if err := tx.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+
+    if limit <= 0 {
+        limit = -1
+    }
+
+    list := make([]ScheduleRun, 0)
+    err := tx.Order("start_at DESC").
+        Offset(offset).
+        Limit(limit).
+        Find(&list).
+        Error
+    if err != nil {
+        return nil, 0, err
+    }
+
+    runs := make([]Run, 0, len(list))
+    for _, item := range list {
+        runs = append(runs, Run{
+            ID:       item.ID,
+            Name:     item.Name,
+            Spec:     item.Spec,
+            Trigger:  item.Trigger,
+            Status:   item.Status,
+            StartAt:  time.UnixMilli(item.StartAt),
+            EndAt:    time.UnixMilli(item.EndAt),
+            Duration: time.Duration(item.Duration) * time.Millisecond,
+            Error:    item.Error,
+            Output:   item.Output,
+        })
+    }
+
+    return runs, total, nil
+}
+
+func (this *DatabaseHistory) newDB() *gorm.DB {
+    return this.db.
+        Session(&gorm.Session{NewDB: true}).
+        Table(this.db.NamingStrategy.TableName(this.table))
+}
@@ -0,0 +1,36 @@
+package schedule
+
+import (
+    "github.com/deatil/lakego-doak/lakego/metrics"
+)
+
+var (
+    // 执行结果
+    runsTotal = metrics.NewCounter(
+        "lakego_schedule_runs_total",
+        "Total number of schedule runs by entry name and status.",
+        "name", "status",
+    )
+
+    // 执行耗时
+    runDuration = metrics.NewHistogram(
+        "lakego_schedule_run_duration_seconds",
+        "Schedule run duration in seconds by entry name.",
+        []float64{.1, .5, 1, 5, 10, 30, 60, 300, 900},
+        "name",
+    )
+)
+
+func init() {
+    metrics.Register("schedule_runs_total", runsTotal)
+    metrics.Register("schedule_run_duration", runDuration)
+}
+
+// 记录执行结果
+func observeRun(run Run) {
+    runsTotal.Inc(run.Name, run.Status)
+
+    if run.Status != StatusSkipped {
+        runDuration.Observe(run.Duration.Seconds(), run.Name)
+    }
+}
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+    "sync"
+)
+
+/**
+ * 暂停状态存储接口
+ * 管理后台和执行计划任务的进程不同时，需要使用共享的存储
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type PauseStore interface {
+    // 暂停任务
+    Pause(name string) error
+
+    // 恢复任务
+    Resume(name string) error
+
+    // 任务是否已暂停
+    IsPaused(name string) (bool, error)
+}
+
+// 内存存储
+func NewMemoryPause() *MemoryPause {
+    return &MemoryPause{
+        names: make(map[string]struct{}),
+    }
+}
+
+/**
+ * 内存暂停状态，只对当前进程有效
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type MemoryPause struct {
+    mu sync.RWMutex
+
+    // 已暂停的任务
+    names map[string]struct{}
+}
+
+// 暂停任务
+func (this *MemoryPause) Pause(name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.names[name] = struct{}{}
+
+    return nil
+}
+
+// 恢复任务
+func (this *MemoryPause) Resume(name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.names, name)
+
+    return nil
+}
+
+// 任务是否已暂停
+func (this *MemoryPause) IsPaused(name string) (bool, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    _, ok := this.names[name]
+
+    return ok, nil
+}
@@ -0,0 +1,77 @@
+package schedule
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// 暂停状态表
+type SchedulePause struct {
+    Name      string `gorm:"column:name;type:varchar(200);not null;primaryKey;"`
+    PauseTime int64  `gorm:"column:pause_time;type:bigint(20);"`
+}
+
+// 数据库存储
+func NewDatabasePause(db *gorm.DB, table string) *DatabasePause {
+    if table == "" {
+        table = "schedule_pause"
+    }
+
+    return &DatabasePause{
+        db:    db,
+        table: table,
+    }
+}
+
+/**
+ * 数据库暂停状态，多个进程共享
+ *
+ * @create 2024-5-15
+ * @author deatil
+ */
+type DatabasePause struct {
+    db    *gorm.DB
+    table string
+}
+
+// 暂停任务
+func (this *DatabasePause) Pause(name string) error {
+    paused, err := this.IsPaused(name)
+    if err != nil || paused {
+        return err
+    }
+
+    return this.newDB().Create(&SchedulePause{
+        Name:      name,
+        PauseTime: time.Now().Unix(),
+    }).Error
+}
+
+// 恢复任务
+func (this *DatabasePause) Resume(name string) error {
+    return this.newDB().
+        Where("name = ?", name).
+        Delete(&SchedulePause{}).
+        Error
+}
+
+// 任务是否已暂停
+func (this *DatabasePause) IsPaused(name string) (bool, error) {
+    var total int64
+    err := this.newDB().
+        Where("name = ?", name).
+        Count(&total).
+        Error
+    if err != nil {
+        return false, err
+    }
+
+    return total > 0, nil
+}
+
+func (this *DatabasePause) newDB() *gorm.DB {
+    return this.db.
+        Session(&gorm.Session{NewDB: true}).
+        Table(this.db.NamingStrategy.TableName(this.table))
+}
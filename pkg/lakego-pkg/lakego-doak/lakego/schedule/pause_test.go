@@ -0,0 +1,54 @@
+package schedule
+
+import (
+    "context"
+    "testing"
+)
+
+func Test_Pause(t *testing.T) {
+    s := New()
+
+    calls := 0
+    entry := s.AddTask(func(ctx context.Context) error {
+        calls++
+        return nil
+    }).WithName("job").Cron("@every 1h")
+
+    if err := s.Pause("none"); err != ErrEntryNotFound {
+        t.Errorf("Pause none got %v", err)
+    }
+
+    // 另一个进程共享同一个存储
+    store := NewMemoryPause()
+    s.WithPauseStore(store)
+
+    worker := New().WithPauseStore(store)
+    worker.WithEntry(entry)
+
+    if err := s.Pause("job"); err != nil {
+        t.Fatal(err)
+    }
+    if !worker.IsPaused("job") {
+        t.Error("worker should be paused")
+    }
+
+    job := entryJob{worker, entry}
+    job.Run()
+    if calls != 0 {
+        t.Errorf("paused job run, calls %d", calls)
+    }
+
+    // 手动执行不受暂停影响
+    if _, err := worker.RunEntry(context.Background(), "job"); err != nil || calls != 1 {
+        t.Errorf("manual run got %v, calls %d", err, calls)
+    }
+
+    if err := s.Resume("job"); err != nil {
+        t.Fatal(err)
+    }
+
+    job.Run()
+    if worker.IsPaused("job") || calls != 2 {
+        t.Errorf("resumed job calls %d", calls)
+    }
+}
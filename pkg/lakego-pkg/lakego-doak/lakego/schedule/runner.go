@@ -0,0 +1,186 @@
+package schedule
+
+import (
+    "io"
+    "fmt"
+    "sync"
+    "time"
+    "bytes"
+    "errors"
+    "context"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+)
+
+// 输出内容最大长度
+const maxOutputSize = 64 * 1024
+
+var (
+    // 任务不存在
+    ErrEntryNotFound = errors.New("schedule: entry not found")
+
+    // 执行超时
+    ErrTimeout = errors.New("schedule: entry timed out")
+
+    // 执行被取消
+    ErrCanceled = errors.New("schedule: entry canceled")
+)
+
+// 任务输出
+type outputKey struct{}
+
+// 获取任务输出，只有 AddTask 添加的任务可以使用
+func Output(ctx context.Context) io.Writer {
+    if w, ok := ctx.Value(outputKey{}).(io.Writer); ok {
+        return w
+    }
+
+    return io.Discard
+}
+
+// 并发安全的输出，超过最大长度后丢弃
+type output struct {
+    mu  sync.Mutex
+    buf bytes.Buffer
+}
+
+func (this *output) Write(p []byte) (int, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if left := maxOutputSize - this.buf.Len(); left > 0 {
+        if len(p) > left {
+            this.buf.Write(p[:left])
+        } else {
+            this.buf.Write(p)
+        }
+    }
+
+    return len(p), nil
+}
+
+func (this *output) String() string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return this.buf.String()
+}
+
+// 计划任务执行的 job
+type entryJob struct {
+    schedule *Schedule
+    entry    *Entry
+}
+
+func (this entryJob) Run() {
+    // 已暂停时跳过，手动执行不受影响
+    if this.schedule.IsPaused(this.entry.Name) {
+        return
+    }
+
+    this.schedule.runEntry(context.Background(), this.entry, TriggerCron)
+}
+
+// 执行任务并记录
+func (this *Schedule) runEntry(ctx context.Context, entry *Entry, trigger string) Run {
+    run := Run{
+        ID:      uuid.ToUUIDString(),
+        Name:    entry.Name,
+        Spec:    entry.Spec,
+        Trigger: trigger,
+        StartAt: time.Now(),
+    }
+
+    // 上次执行未完成时跳过
+    if entry.withoutOverlapping {
+        if !atomic.CompareAndSwapInt32(&entry.running, 0, 1) {
+            run.Status = StatusSkipped
+            this.finishRun(entry, &run, nil)
+
+            return run
+        }
+    } else {
+        atomic.AddInt32(&entry.running, 1)
+    }
+
+    // 调用方的 ctx，用于区分超时及取消
+    parent := ctx
+
+    out := &output{}
+    ctx = context.WithValue(ctx, outputKey{}, io.Writer(out))
+
+    if entry.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, entry.timeout)
+        defer cancel()
+    }
+
+    done := make(chan error, 1)
+
+    go func() {
+        // 执行完成后才释放，超时后任务仍在执行时不会重复执行
+        defer atomic.AddInt32(&entry.running, -1)
+
+        defer func() {
+            if r := recover(); r != nil {
+                done <- fmt.Errorf("schedule: panic: %v", r)
+            }
+        }()
+
+        done <- entry.call(ctx)
+    }()
+
+    var err error
+    select {
+        case err = <-done:
+            run.Status = StatusSuccess
+            if err != nil {
+                run.Status = StatusFailure
+            }
+        case <-ctx.Done():
+            if parent.Err() != nil {
+                err = ErrCanceled
+                run.Status = StatusCanceled
+            } else {
+                err = ErrTimeout
+                run.Status = StatusTimeout
+            }
+    }
+
+    run.Output = out.String()
+    this.finishRun(entry, &run, err)
+
+    return run
+}
+
+// 记录执行结果，设置结束时间、时长及错误信息
+func (this *Schedule) finishRun(entry *Entry, run *Run, err error) {
+    run.EndAt = time.Now()
+    run.Duration = run.EndAt.Sub(run.StartAt)
+
+    if err != nil {
+        run.Error = err.Error()
+    }
+
+    observeRun(*run)
+
+    switch run.Status {
+        case StatusSuccess:
+            for _, f := range entry.onSuccess {
+                f(*run)
+            }
+        case StatusFailure, StatusTimeout, StatusCanceled:
+            for _, f := range entry.onFailure {
+                f(*run)
+            }
+
+            this.logger().Printf("[%s] %s: %s", run.Name, run.Status, run.Error)
+    }
+
+    if history := this.GetHistory(); history != nil {
+        if saveErr := history.Save(*run); saveErr != nil {
+            this.logger().Printf("[%s] save history: %s", run.Name, saveErr.Error())
+        }
+    }
+}
@@ -0,0 +1,105 @@
+package schedule
+
+import (
+    "time"
+    "errors"
+    "context"
+    "testing"
+)
+
+func Test_RunEntry(t *testing.T) {
+    s := New()
+
+    s.AddTask(func(ctx context.Context) error {
+        time.Sleep(5 * time.Millisecond)
+        return nil
+    }).WithName("ok").Cron("@every 1h")
+
+    s.AddTask(func(ctx context.Context) error {
+        return errors.New("task failed")
+    }).WithName("fail").Cron("@every 1h")
+
+    run, err := s.RunEntry(context.Background(), "ok")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if run.Status != StatusSuccess || run.Error != "" {
+        t.Errorf("ok got %s %q", run.Status, run.Error)
+    }
+    if run.Duration <= 0 || run.EndAt.IsZero() {
+        t.Errorf("ok Duration got %s, EndAt %v", run.Duration, run.EndAt)
+    }
+
+    run, _ = s.RunEntry(context.Background(), "fail")
+    if run.Status != StatusFailure || run.Error != "task failed" {
+        t.Errorf("fail got %s %q", run.Status, run.Error)
+    }
+    if run.Duration <= 0 {
+        t.Errorf("fail Duration got %s", run.Duration)
+    }
+
+    // 执行记录和返回的记录一致
+    runs, total, _ := s.GetHistory().List("fail", 0, 10)
+    if total != 1 || runs[0].Error != "task failed" || runs[0].Duration != run.Duration {
+        t.Errorf("history got %d %v", total, runs)
+    }
+
+    if _, err := s.RunEntry(context.Background(), "none"); err != ErrEntryNotFound {
+        t.Errorf("not found got %v", err)
+    }
+}
+
+func Test_RunEntryTimeout(t *testing.T) {
+    s := New()
+
+    s.AddTask(func(ctx context.Context) error {
+        <-ctx.Done()
+        return ctx.Err()
+    }).WithName("slow").Cron("@every 1h").Timeout(10 * time.Millisecond)
+
+    run, _ := s.RunEntry(context.Background(), "slow")
+    if run.Status != StatusTimeout || run.Error != ErrTimeout.Error() {
+        t.Errorf("timeout got %s %q", run.Status, run.Error)
+    }
+    if run.Duration < 10 * time.Millisecond {
+        t.Errorf("timeout Duration got %s", run.Duration)
+    }
+}
+
+func Test_RunEntryCanceled(t *testing.T) {
+    s := New()
+
+    s.AddTask(func(ctx context.Context) error {
+        time.Sleep(50 * time.Millisecond)
+        return nil
+    }).WithName("slow").Cron("@every 1h").Timeout(time.Second)
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+
+    run, _ := s.RunEntry(ctx, "slow")
+    if run.Status != StatusCanceled || run.Error != ErrCanceled.Error() {
+        t.Errorf("canceled got %s %q", run.Status, run.Error)
+    }
+}
+
+func Test_EntryDefaultName(t *testing.T) {
+    s := New()
+
+    s.AddFunc(func() {}).Cron("@every 1h")
+    s.AddFunc(func() {}).WithName("named").Cron("@every 1h")
+    s.AddFunc(func() {}).Cron("@every 1h")
+
+    names := []string{}
+    for _, entry := range s.Entries() {
+        names = append(names, entry.Name)
+    }
+
+    if len(names) != 3 || names[0] != "cron_run_1" || names[1] != "named" || names[2] != "cron_run_3" {
+        t.Errorf("names got %v", names)
+    }
+
+    if s.HasEntry("") || s.Trigger("") != ErrEntryNotFound || s.Pause("") != ErrEntryNotFound {
+        t.Error("empty name should not match an entry")
+    }
+}
@@ -5,6 +5,8 @@ import (
     "time"
     "sync"
     "context"
+
+    "github.com/robfig/cron/v3"
 )
 
 // 常量
@@ -18,6 +20,14 @@ const (
     SATURDAY  = "6";
 )
 
+// 解析计划时间，和 New 中的 WithSeconds 格式一致
+func ParseSpec(spec string) (ISchedule, error) {
+    return NewParser(
+        cron.Second | cron.Minute | cron.Hour |
+        cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+    ).Parse(spec)
+}
+
 // 构造函数
 func New() *Schedule {
     logger := PrintfLogger(NewLogger())
@@ -32,6 +42,8 @@ func New() *Schedule {
         entries: make([]*Entry, 0),
         cronIDs: make(map[string]CronEntryID),
         stoped:  make(map[string]CronEntry),
+        history: NewMemoryHistory(100),
+        pause:   NewMemoryPause(),
     }
 
     return schedule
@@ -58,6 +70,39 @@ type Schedule struct {
 
     // 已停止的计划任务
     stoped map[string]CronEntry
+
+    // 执行记录
+    history HistoryStore
+
+    // 暂停状态
+    pause PauseStore
+}
+
+// 任务信息
+type EntryInfo struct {
+    // 任务名称
+    Name string `json:"name"`
+
+    // 计划时间
+    Spec string `json:"spec"`
+
+    // 下次执行时间
+    Next time.Time `json:"next"`
+
+    // 上次执行时间
+    Prev time.Time `json:"prev"`
+
+    // 是否已暂停
+    Paused bool `json:"paused"`
+
+    // 是否执行中
+    Running bool `json:"running"`
+
+    // 是否跳过重复执行
+    WithoutOverlapping bool `json:"without_overlapping"`
+
+    // 超时时间
+    Timeout time.Duration `json:"timeout"`
 }
 
 // 添加计划任务
@@ -69,11 +114,37 @@ func (this *Schedule) WithCron(c *Cron) *Schedule {
 
 // 添加数据
 func (this *Schedule) WithEntry(entry *Entry) *Schedule {
-    this.entries = append(this.entries, entry)
+    this.appendEntry(entry)
 
     return this
 }
 
+// 设置执行记录存储
+func (this *Schedule) WithHistory(history HistoryStore) *Schedule {
+    this.history = history
+
+    return this
+}
+
+// 执行记录存储
+func (this *Schedule) GetHistory() HistoryStore {
+    return this.history
+}
+
+// 设置暂停状态存储
+func (this *Schedule) WithPauseStore(pause PauseStore) *Schedule {
+    if pause != nil {
+        this.pause = pause
+    }
+
+    return this
+}
+
+// 暂停状态存储
+func (this *Schedule) GetPauseStore() PauseStore {
+    return this.pause
+}
+
 // 清空数据
 func (this *Schedule) ClearEntries() *Schedule {
     this.entries = make([]*Entry, 0)
@@ -99,15 +170,20 @@ func (this *Schedule) SetLocation(loc string) *Schedule {
 
 // 获取数据
 func (this *Schedule) GetEntry(name string) *Entry {
-    for _, entry := range this.entries {
-        if entry.Name == name {
-            return entry
-        }
+    if entry := this.findEntry(name); entry != nil {
+        return entry
     }
 
     return &Entry{}
 }
 
+// 任务是否存在
+func (this *Schedule) HasEntry(name string) bool {
+    entry := this.findEntry(name)
+
+    return entry != nil && entry.Cmd != nil
+}
+
 // 移除数据
 func (this *Schedule) RemoveEntry(name string) {
     var entries []*Entry
@@ -143,7 +219,16 @@ func (this *Schedule) SetShowLogInfo(logInfo bool) *Schedule {
 func (this *Schedule) AddFunc(cmd func()) *Entry {
     entry := NewEntry().AddFunc(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.appendEntry(entry)
+
+    return entry
+}
+
+// AddTask
+func (this *Schedule) AddTask(cmd func(context.Context) error) *Entry {
+    entry := NewEntry().AddTask(cmd)
+
+    this.appendEntry(entry)
 
     return entry
 }
@@ -152,7 +237,7 @@ func (this *Schedule) AddFunc(cmd func()) *Entry {
 func (this *Schedule) AddJob(cmd IJob) *Entry {
     entry := NewEntry().AddJob(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.appendEntry(entry)
 
     return entry
 }
@@ -161,11 +246,20 @@ func (this *Schedule) AddJob(cmd IJob) *Entry {
 func (this *Schedule) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
     entry := NewEntry().AddSchedule(schedule, cmd)
 
-    this.entries = append(this.entries, entry)
+    this.appendEntry(entry)
 
     return entry
 }
 
+// 添加任务，没有名称时按添加顺序设置默认名称，各进程注册顺序一致时名称相同
+func (this *Schedule) appendEntry(entry *Entry) {
+    if entry.Name == "" {
+        entry.Name = fmt.Sprintf("cron_run_%d", len(this.entries)+1)
+    }
+
+    this.entries = append(this.entries, entry)
+}
+
 // 开启
 func (this *Schedule) Start() {
     this.addEntries()
@@ -194,40 +288,162 @@ func (this *Schedule) addEntry(entry *Entry) {
         return
     }
 
+    // 统一包装，记录执行结果
+    job := entryJob{this, entry}
+
     var entryID CronEntryID
     var err error
 
-    switch cmd := entry.Cmd.(type) {
-        // 方法
-        case func():
-            entryID, err = this.Cron.AddFunc(entry.Spec, cmd)
-
-        // job 结构体
-        case IJob:
-            if entry.Spec != "" {
-                // 字符
-                entryID, err = this.Cron.AddJob(entry.Spec, cmd)
-            } else if entry.Schedule != nil {
-                // Schedule 结构体
-                entryID = this.Cron.Schedule(entry.Schedule, cmd)
-            }
+    if entry.Schedule != nil {
+        // Schedule 结构体
+        entryID = this.Cron.Schedule(entry.Schedule, job)
+    } else {
+        // 字符
+        entryID, err = this.Cron.AddJob(entry.Spec, job)
     }
 
-    if err == nil {
-        this.mu.Lock()
+    if err != nil {
+        this.logger().Printf("[%s] %s", entry.Name, err.Error())
+        return
+    }
 
-        if entry.Name != "" {
-            this.cronIDs[entry.Name] = entryID
-        } else {
-            this.cronIDs[fmt.Sprintf("cron_run_%d", entryID)] = entryID
+    this.mu.Lock()
+
+    if entry.Name == "" {
+        entry.Name = fmt.Sprintf("cron_run_%d", entryID)
+    }
+
+    this.cronIDs[entry.Name] = entryID
+
+    this.mu.Unlock()
+}
+
+// 查找任务，名称为空时不查找
+func (this *Schedule) findEntry(name string) *Entry {
+    if name == "" {
+        return nil
+    }
+
+    for _, entry := range this.entries {
+        if entry.Name == name {
+            return entry
+        }
+    }
+
+    return nil
+}
+
+// 立即执行任务，等待执行完成并返回执行记录
+func (this *Schedule) RunEntry(ctx context.Context, name string) (Run, error) {
+    if !this.HasEntry(name) {
+        return Run{}, ErrEntryNotFound
+    }
+
+    entry := this.findEntry(name)
+
+    return this.runEntry(ctx, entry, TriggerManual), nil
+}
+
+// 立即触发任务，不等待执行完成
+func (this *Schedule) Trigger(name string) error {
+    if !this.HasEntry(name) {
+        return ErrEntryNotFound
+    }
+
+    entry := this.findEntry(name)
+
+    go this.runEntry(context.Background(), entry, TriggerManual)
+
+    return nil
+}
+
+// 暂停任务，执行计划任务的进程在每次执行前检查暂停状态
+func (this *Schedule) Pause(name string) error {
+    if this.findEntry(name) == nil {
+        return ErrEntryNotFound
+    }
+
+    return this.pause.Pause(name)
+}
+
+// 恢复任务
+func (this *Schedule) Resume(name string) error {
+    if this.findEntry(name) == nil {
+        return ErrEntryNotFound
+    }
+
+    // 当前进程中停止的任务一起恢复
+    this.CronStart(name)
+
+    return this.pause.Resume(name)
+}
+
+// 任务是否已暂停
+func (this *Schedule) IsPaused(name string) bool {
+    this.mu.RLock()
+    _, ok := this.stoped[name]
+    this.mu.RUnlock()
+
+    if ok {
+        return true
+    }
+
+    paused, err := this.pause.IsPaused(name)
+    if err != nil {
+        this.logger().Printf("[%s] pause state: %s", name, err.Error())
+        return false
+    }
+
+    return paused
+}
+
+// 任务信息列表，计划任务未启动时根据计划时间计算下次执行时间
+func (this *Schedule) EntryInfos() []EntryInfo {
+    now := time.Now().In(this.CronLocation())
+
+    infos := make([]EntryInfo, 0, len(this.entries))
+    for _, entry := range this.entries {
+        info := EntryInfo{
+            Name:               entry.Name,
+            Spec:               entry.Spec,
+            Paused:             this.IsPaused(entry.Name),
+            Running:            entry.IsRunning(),
+            WithoutOverlapping: entry.withoutOverlapping,
+            Timeout:            entry.timeout,
+        }
+
+        if cronEntry := this.CronEntry(entry.Name); cronEntry.Valid() {
+            info.Next = cronEntry.Next
+            info.Prev = cronEntry.Prev
+        } else if !info.Paused {
+            if sched := this.entrySchedule(entry); sched != nil {
+                info.Next = sched.Next(now)
+            }
         }
 
-        this.mu.Unlock()
+        infos = append(infos, info)
     }
 
+    return infos
+}
+
+// 任务计划
+func (this *Schedule) entrySchedule(entry *Entry) ISchedule {
+    if entry.Schedule != nil {
+        return entry.Schedule
+    }
+
+    sched, err := ParseSpec(entry.Spec)
     if err != nil {
-        fmt.Println(err.Error())
+        return nil
     }
+
+    return sched
+}
+
+// 日志
+func (this *Schedule) logger() Logger {
+    return NewLogger()
 }
 
 // 停止
@@ -0,0 +1,23 @@
+package service_provider
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/httpcache"
+)
+
+// 响应缓存中间件别名
+const ResponseCacheMiddleware = "lakego.response-cache"
+
+/**
+ * 响应缓存，注册为 lakego.response-cache 中间件
+ * 按管理员缓存时需要添加到登录验证之后的中间件分组
+ */
+func (this *Lakego) loadHttpCache() {
+    conf := config.New("httpcache")
+    if !conf.GetBool("enable") {
+        return
+    }
+
+    router.InstanceMiddleware().AliasMiddleware(ResponseCacheMiddleware, httpcache.RulesHandler())
+}
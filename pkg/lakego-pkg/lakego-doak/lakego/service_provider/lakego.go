@@ -1,10 +1,17 @@
 package service_provider
 
 import (
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
 
     // 脚本
+    queueCmd "github.com/deatil/lakego-doak/lakego/console/queue"
     publishCmd "github.com/deatil/lakego-doak/lakego/console/publish"
     storageCmd "github.com/deatil/lakego-doak/lakego/console/storage"
     scheduleCmd "github.com/deatil/lakego-doak/lakego/console/schedule"
@@ -28,6 +35,24 @@ func NewLakego() *Lakego {
     return &Lakego{}
 }
 
+// 注册
+func (this *Lakego) Register() {
+    // 链路追踪
+    this.loadTrace()
+
+    // 请求指标
+    this.loadMetricsMiddleware()
+
+    // 安全设置
+    this.loadSecurity()
+
+    // 请求限流
+    this.loadRatelimit()
+
+    // 响应缓存
+    this.loadHttpCache()
+}
+
 // 引导
 func (this *Lakego) Boot() {
     // 脚本
@@ -35,6 +60,12 @@ func (this *Lakego) Boot() {
 
     // 模板渲染
     this.loadHtmlRender()
+
+    // 关闭时回调
+    this.loadShutdown()
+
+    // 健康检测及指标
+    this.loadProbe()
 }
 
 /**
@@ -46,12 +77,91 @@ func (this *Lakego) loadCommand() {
 
     // 创建软连接
     this.AddCommand(storageCmd.StorageLinkCmd)
+
+    // 队列
+    this.AddCommand(queueCmd.QueueWorkCmd)
+    this.AddCommand(queueCmd.QueueFailedCmd)
+    this.AddCommand(queueCmd.QueueRetryCmd)
 }
 
 // 计划任务
 func (this *Lakego) Schedule(s *schedule.Schedule) {
+    // 日志
+    schedule.SetLogPrintf(logger.Default.Errorf)
+
+    // 执行记录
+    s.WithHistory(this.newScheduleHistory())
+
+    // 暂停状态
+    s.WithPauseStore(this.newSchedulePause())
+
     // 计划任务命令
     this.AddCommand(scheduleCmd.NewScheduleCmd(s))
+    this.AddCommand(scheduleCmd.NewScheduleWorkCmd(s))
+    this.AddCommand(scheduleCmd.NewScheduleListCmd(s))
+    this.AddCommand(scheduleCmd.NewScheduleRunCmd(s))
+    this.AddCommand(scheduleCmd.NewScheduleTestCmd(s))
+}
+
+// 计划任务执行记录
+func (this *Lakego) newScheduleHistory() schedule.HistoryStore {
+    conf := config.New("schedule")
+
+    switch conf.GetString("history.type") {
+        case "database":
+            return schedule.NewDatabaseHistory(database.Default, conf.GetString("history.table"))
+        case "memory":
+            return schedule.NewMemoryHistory(conf.GetInt("history.size"))
+    }
+
+    return nil
+}
+
+// 计划任务暂停状态
+func (this *Lakego) newSchedulePause() schedule.PauseStore {
+    conf := config.New("schedule")
+
+    switch conf.GetString("pause.type") {
+        case "database":
+            return schedule.NewDatabasePause(database.Default, conf.GetString("pause.table"))
+        case "memory":
+            return schedule.NewMemoryPause()
+    }
+
+    return nil
+}
+
+/**
+ * 关闭时回调，最先注册的最后执行
+ */
+func (this *Lakego) loadShutdown() {
+    // 关闭数据库及 redis 连接
+    this.AddShutdown(func(ctx context.Context) {
+        if sqlDB, err := database.Default.DB(); err == nil {
+            if err := sqlDB.Close(); err != nil {
+                logger.New().Error("[shutdown] close database: " + err.Error())
+            }
+        }
+
+        if redis.Default.GetClient() != nil {
+            if err := redis.Default.Close(); err != nil {
+                logger.New().Error("[shutdown] close redis: " + err.Error())
+            }
+        }
+    })
+
+    // 停止计划任务，等待执行中的任务完成
+    this.AddShutdown(func(ctx context.Context) {
+        s := this.GetApp().GetSchedule()
+        if s == nil {
+            return
+        }
+
+        select {
+            case <-s.Stop().Done():
+            case <-ctx.Done():
+        }
+    })
 }
 
 /**
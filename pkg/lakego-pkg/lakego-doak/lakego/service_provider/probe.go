@@ -0,0 +1,141 @@
+package service_provider
+
+import (
+    "time"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/health"
+    "github.com/deatil/lakego-doak/lakego/metrics"
+    "github.com/deatil/lakego-doak/lakego/http/probe"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/storage"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+    httpMetrics "github.com/deatil/lakego-doak/lakego/middleware/metrics"
+)
+
+/**
+ * 请求指标中间件，需要在路由注册前添加
+ */
+func (this *Lakego) loadMetricsMiddleware() {
+    conf := config.New("health")
+    if !conf.GetBool("metrics.enable") {
+        return
+    }
+
+    // 不记录检测路由
+    skipPaths := []string{
+        conf.GetString("health.healthz-path"),
+        conf.GetString("health.readyz-path"),
+        conf.GetString("metrics.path"),
+    }
+
+    if route := this.GetRoute(); route != nil {
+        route.Use(httpMetrics.Handler(skipPaths...))
+    }
+}
+
+/**
+ * 健康检测及指标
+ */
+func (this *Lakego) loadProbe() {
+    conf := config.New("health")
+
+    // 内置检测
+    health.Default().WithTimeout(conf.GetDuration("health.timeout"))
+
+    if conf.GetBool("health.checks.database") {
+        health.Register("database", func(ctx context.Context) error {
+            sqlDB, err := database.Default.DB()
+            if err != nil {
+                return err
+            }
+
+            return sqlDB.PingContext(ctx)
+        })
+    }
+
+    if conf.GetBool("health.checks.redis") {
+        health.Register("redis", func(ctx context.Context) error {
+            return redis.Default.GetClient().Ping(ctx).Err()
+        })
+    }
+
+    if conf.GetBool("health.checks.storage") {
+        disk := conf.GetString("health.checks.storage-disk")
+
+        // 检测会写入及删除文件，缓存检测结果
+        ttl := conf.GetDuration("health.checks.storage-cache")
+        if ttl <= 0 {
+            ttl = 30 * time.Second
+        }
+
+        health.Register("storage", health.Cached(func(ctx context.Context) error {
+            s := storage.New()
+            if disk != "" {
+                s = storage.NewWithDisk(disk)
+            }
+
+            name := ".health-" + uuid.ToUUIDString()
+            if _, err := s.Put(name, "ok"); err != nil {
+                return err
+            }
+
+            _, err := s.Delete(name)
+            return err
+        }, ttl))
+    }
+
+    // 数据库连接池指标
+    metrics.Register("database_pool", metrics.CollectorFunc(collectDatabasePool))
+
+    this.AddRoute(func(engine *router.Engine) {
+        if conf.GetBool("health.enable") {
+            engine.GET(conf.GetString("health.healthz-path"), probe.Healthz(health.Default()))
+            engine.GET(conf.GetString("health.readyz-path"), probe.Readyz(health.Default(), this.GetApp().IsReady))
+        }
+
+        if conf.GetBool("metrics.enable") {
+            // 指标包含内部信息，没有设置 token 时不开放
+            token := conf.GetString("metrics.token")
+            if token == "" {
+                logger.Default.Warn("[metrics] metrics.token is empty, metrics route disabled")
+            } else {
+                engine.GET(conf.GetString("metrics.path"), probe.Metrics(metrics.Default(), token))
+            }
+        }
+    })
+}
+
+// 数据库连接池指标
+func collectDatabasePool() []metrics.Family {
+    sqlDB, err := database.Default.DB()
+    if err != nil {
+        return nil
+    }
+
+    stats := sqlDB.Stats()
+
+    family := func(name, help, typ string, v float64) metrics.Family {
+        return metrics.Family{
+            Name:    name,
+            Help:    help,
+            Type:    typ,
+            Samples: []metrics.Sample{{Value: v}},
+        }
+    }
+
+    return []metrics.Family{
+        family("lakego_db_max_open_connections", "Maximum number of open connections to the database.", metrics.TypeGauge, float64(stats.MaxOpenConnections)),
+        family("lakego_db_open_connections", "The number of established connections both in use and idle.", metrics.TypeGauge, float64(stats.OpenConnections)),
+        family("lakego_db_in_use_connections", "The number of connections currently in use.", metrics.TypeGauge, float64(stats.InUse)),
+        family("lakego_db_idle_connections", "The number of idle connections.", metrics.TypeGauge, float64(stats.Idle)),
+        family("lakego_db_wait_count_total", "The total number of connections waited for.", metrics.TypeCounter, float64(stats.WaitCount)),
+        family("lakego_db_wait_duration_seconds_total", "The total time blocked waiting for a new connection.", metrics.TypeCounter, stats.WaitDuration.Seconds()),
+        family("lakego_db_max_idle_closed_total", "The total number of connections closed due to SetMaxIdleConns.", metrics.TypeCounter, float64(stats.MaxIdleClosed)),
+        family("lakego_db_max_lifetime_closed_total", "The total number of connections closed due to SetConnMaxLifetime.", metrics.TypeCounter, float64(stats.MaxLifetimeClosed)),
+    }
+}
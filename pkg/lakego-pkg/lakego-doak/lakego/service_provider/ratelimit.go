@@ -0,0 +1,33 @@
+package service_provider
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/ratelimit"
+)
+
+// 限流中间件别名
+const RatelimitMiddleware = "lakego.ratelimit"
+
+/**
+ * 请求限流，全局使用时需要在路由注册前添加
+ * 非全局时注册为 lakego.ratelimit 中间件，可添加到登录验证之后的中间件分组
+ */
+func (this *Lakego) loadRatelimit() {
+    conf := config.New("ratelimit")
+    if !conf.GetBool("enable") {
+        return
+    }
+
+    handler := ratelimit.RulesHandler()
+
+    if conf.GetBool("global") {
+        if route := this.GetRoute(); route != nil {
+            route.Use(handler)
+        }
+
+        return
+    }
+
+    router.InstanceMiddleware().AliasMiddleware(RatelimitMiddleware, handler)
+}
@@ -0,0 +1,112 @@
+package service_provider
+
+import (
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/middleware/security"
+)
+
+// csrf 中间件别名
+const CSRFMiddleware = "lakego.csrf"
+
+/**
+ * 安全设置，全局中间件需要在路由注册前添加
+ */
+func (this *Lakego) loadSecurity() {
+    conf := config.New("security")
+
+    route := this.GetRoute()
+
+    // 可信代理
+    proxies := conf.GetStringSlice("trusted-proxies")
+    if err := router.SetTrustedProxies(proxies); err != nil {
+        logger.New().Error("[security]" + err.Error())
+    }
+
+    if route != nil {
+        if len(proxies) == 0 {
+            proxies = nil
+        }
+
+        if err := route.SetTrustedProxies(proxies); err != nil {
+            logger.New().Error("[security]" + err.Error())
+        }
+    }
+
+    // 安全响应头
+    if route != nil && conf.GetBool("headers.enable") {
+        opts := security.HeadersOptions{
+            FrameOptions:       conf.GetString("headers.frame-options"),
+            ReferrerPolicy:     conf.GetString("headers.referrer-policy"),
+            PermissionsPolicy:  conf.GetString("headers.permissions-policy"),
+            ContentTypeNosniff: conf.GetBool("headers.content-type-nosniff"),
+        }
+
+        if conf.GetBool("headers.hsts.enable") {
+            opts.HSTSMaxAge = conf.GetDuration("headers.hsts.max-age")
+            opts.HSTSIncludeSubdomains = conf.GetBool("headers.hsts.include-subdomains")
+            opts.HSTSPreload = conf.GetBool("headers.hsts.preload")
+        }
+
+        if conf.GetBool("headers.csp.enable") {
+            opts.ContentSecurityPolicy = conf.GetString("headers.csp.policy")
+            opts.CSPReportOnly = conf.GetBool("headers.csp.report-only")
+            opts.CSPExcept = conf.GetStringSlice("headers.csp.except")
+        }
+
+        route.Use(security.Headers(opts))
+    }
+
+    // 请求数据大小
+    if route != nil && conf.GetBool("body-limit.enable") {
+        defaultLimit, err := security.ParseSize(conf.GetString("body-limit.default"))
+        if err != nil {
+            logger.New().Error("[security]" + err.Error())
+        }
+
+        groups := make(map[string]int64)
+        for prefix, size := range conf.GetStringMapString("body-limit.groups") {
+            limit, err := security.ParseSize(size)
+            if err != nil {
+                logger.New().Error("[security]" + err.Error())
+                continue
+            }
+
+            groups[prefix] = limit
+        }
+
+        route.Use(security.GroupBodyLimit(defaultLimit, groups))
+    }
+
+    // csrf 验证
+    if conf.GetBool("csrf.enable") {
+        router.InstanceMiddleware().AliasMiddleware(CSRFMiddleware, security.CSRF(security.CSRFOptions{
+            Secret:       conf.GetString("csrf.secret"),
+            CookieName:   conf.GetString("csrf.cookie-name"),
+            CookiePath:   conf.GetString("csrf.cookie-path"),
+            CookieDomain: conf.GetString("csrf.cookie-domain"),
+            CookieMaxAge: conf.GetDuration("csrf.cookie-max-age"),
+            Secure:       conf.GetBool("csrf.secure"),
+            SameSite:     parseSameSite(conf.GetString("csrf.same-site")),
+            HeaderName:   conf.GetString("csrf.header-name"),
+            FieldName:    conf.GetString("csrf.field-name"),
+            Except:       conf.GetStringSlice("csrf.except"),
+        }))
+    }
+}
+
+// cookie SameSite
+func parseSameSite(sameSite string) http.SameSite {
+    switch strings.ToLower(sameSite) {
+        case "strict":
+            return http.SameSiteStrictMode
+        case "none":
+            return http.SameSiteNoneMode
+    }
+
+    return http.SameSiteLaxMode
+}
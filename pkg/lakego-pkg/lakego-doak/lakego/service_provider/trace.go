@@ -0,0 +1,88 @@
+package service_provider
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/trace"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    httpTrace "github.com/deatil/lakego-doak/lakego/middleware/trace"
+)
+
+/**
+ * 链路追踪，中间件需要在路由注册前添加
+ */
+func (this *Lakego) loadTrace() {
+    conf := config.New("trace")
+    if !conf.GetBool("enable") {
+        return
+    }
+
+    exporter, err := newTraceExporter()
+    if err != nil {
+        logger.New().Error("[trace]" + err.Error())
+    }
+
+    if exporter != nil {
+        tracer := trace.NewTracer(exporter, trace.Options{
+            SampleRatio:   conf.GetFloat64("sample-ratio"),
+            BatchSize:     conf.GetInt("batch-size"),
+            FlushInterval: conf.GetDuration("flush-interval"),
+            QueueSize:     conf.GetInt("queue-size"),
+        })
+        tracer.OnError(func(err error) {
+            logger.New().Error("[trace]" + err.Error())
+        })
+
+        trace.SetDefault(tracer)
+
+        // 关闭时导出剩余节点
+        this.AddShutdown(func(ctx context.Context) {
+            if err := tracer.Shutdown(ctx); err != nil {
+                logger.New().Error("[trace]" + err.Error())
+            }
+        })
+    }
+
+    // 不记录检测路由
+    health := config.New("health")
+    skipPaths := []string{
+        health.GetString("health.healthz-path"),
+        health.GetString("health.readyz-path"),
+        health.GetString("metrics.path"),
+    }
+
+    if route := this.GetRoute(); route != nil {
+        route.Use(httpTrace.Handler(httpTrace.Options{
+            Header:           conf.GetString("request-id-header"),
+            TrustTraceparent: conf.GetBool("trust-traceparent"),
+            SkipPaths:        skipPaths,
+        }))
+    }
+}
+
+// 节点导出
+func newTraceExporter() (trace.Exporter, error) {
+    conf := config.New("trace")
+
+    serviceName := conf.GetString("service-name")
+
+    switch conf.GetString("exporter") {
+        case "stdout":
+            return trace.NewStdoutExporter(serviceName), nil
+        case "file":
+            file := path.FormatPath(conf.GetString("exporters.file.path"))
+
+            return trace.NewFileExporter(file, serviceName)
+        case "otlp":
+            return trace.NewOTLPExporter(trace.OTLPOptions{
+                Endpoint:    conf.GetString("exporters.otlp.endpoint"),
+                Headers:     conf.GetStringMapString("exporters.otlp.headers"),
+                Timeout:     conf.GetDuration("exporters.otlp.timeout"),
+                ServiceName: serviceName,
+            }), nil
+    }
+
+    return nil, nil
+}
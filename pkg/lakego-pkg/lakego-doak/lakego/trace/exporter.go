@@ -0,0 +1,128 @@
+package trace
+
+import (
+    "io"
+    "os"
+    "sync"
+    "time"
+    "context"
+    "path/filepath"
+    "encoding/json"
+)
+
+/**
+ * 节点导出接口
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type Exporter interface {
+    // 导出节点
+    Export(ctx context.Context, spans []SpanData) error
+
+    // 关闭
+    Shutdown(ctx context.Context) error
+}
+
+// 构造函数
+func NewWriterExporter(w io.Writer, serviceName string) *WriterExporter {
+    return &WriterExporter{
+        w:           w,
+        serviceName: serviceName,
+    }
+}
+
+// 输出到标准输出
+func NewStdoutExporter(serviceName string) *WriterExporter {
+    return NewWriterExporter(os.Stdout, serviceName)
+}
+
+// 输出到文件，追加写入
+func NewFileExporter(path string, serviceName string) (*WriterExporter, error) {
+    if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+        return nil, err
+    }
+
+    f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+    if err != nil {
+        return nil, err
+    }
+
+    e := NewWriterExporter(f, serviceName)
+    e.closer = f
+
+    return e, nil
+}
+
+/**
+ * 按行输出 json 格式节点，本地调试使用
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type WriterExporter struct {
+    mu sync.Mutex
+
+    w      io.Writer
+    closer io.Closer
+
+    serviceName string
+}
+
+// 单行数据
+type writerSpan struct {
+    Service      string         `json:"service,omitempty"`
+    Name         string         `json:"name"`
+    Kind         string         `json:"kind"`
+    TraceID      string         `json:"trace_id"`
+    SpanID       string         `json:"span_id"`
+    ParentSpanID string         `json:"parent_span_id,omitempty"`
+    Start        string         `json:"start"`
+    Duration     string         `json:"duration"`
+    Status       string         `json:"status"`
+    Message      string         `json:"message,omitempty"`
+    Attributes   map[string]any `json:"attributes,omitempty"`
+}
+
+// 导出节点
+func (this *WriterExporter) Export(ctx context.Context, spans []SpanData) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    enc := json.NewEncoder(this.w)
+    for _, s := range spans {
+        data := writerSpan{
+            Service:    this.serviceName,
+            Name:       s.Name,
+            Kind:       s.Kind.String(),
+            TraceID:    s.SpanContext.TraceID.String(),
+            SpanID:     s.SpanContext.SpanID.String(),
+            Start:      s.StartTime.Format(time.RFC3339Nano),
+            Duration:   s.EndTime.Sub(s.StartTime).String(),
+            Status:     s.Status.String(),
+            Message:    s.StatusMessage,
+            Attributes: s.Attributes,
+        }
+        if s.ParentSpanID.IsValid() {
+            data.ParentSpanID = s.ParentSpanID.String()
+        }
+
+        if err := enc.Encode(data); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 关闭
+func (this *WriterExporter) Shutdown(ctx context.Context) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.closer != nil {
+        return this.closer.Close()
+    }
+
+    return nil
+}
@@ -0,0 +1,229 @@
+package trace
+
+import (
+    "fmt"
+    "sort"
+    "time"
+    "bytes"
+    "context"
+    "strconv"
+    "net/http"
+    "encoding/json"
+)
+
+// OTLP 配置
+type OTLPOptions struct {
+    // 地址，比如 http://127.0.0.1:4318/v1/traces
+    Endpoint string
+
+    // 请求头，比如鉴权信息
+    Headers map[string]string
+
+    // 超时时间
+    Timeout time.Duration
+
+    // 服务名称
+    ServiceName string
+}
+
+// 构造函数
+func NewOTLPExporter(opts OTLPOptions) *OTLPExporter {
+    if opts.Timeout <= 0 {
+        opts.Timeout = 10 * time.Second
+    }
+
+    return &OTLPExporter{
+        opts: opts,
+        client: &http.Client{
+            Timeout: opts.Timeout,
+        },
+    }
+}
+
+/**
+ * OTLP 导出，使用 http 及 json 编码
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type OTLPExporter struct {
+    opts   OTLPOptions
+    client *http.Client
+}
+
+// 导出节点
+func (this *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+    if len(spans) == 0 {
+        return nil
+    }
+
+    body, err := json.Marshal(EncodeOTLP(this.opts.ServiceName, spans))
+    if err != nil {
+        return err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.opts.Endpoint, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+    for k, v := range this.opts.Headers {
+        req.Header.Set(k, v)
+    }
+
+    resp, err := this.client.Do(req)
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("trace: otlp export failed with status %d", resp.StatusCode)
+    }
+
+    return nil
+}
+
+// 关闭
+func (this *OTLPExporter) Shutdown(ctx context.Context) error {
+    this.client.CloseIdleConnections()
+
+    return nil
+}
+
+// OTLP json 数据
+type (
+    otlpRequest struct {
+        ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+    }
+
+    otlpResourceSpans struct {
+        Resource   otlpResource     `json:"resource"`
+        ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+    }
+
+    otlpResource struct {
+        Attributes []otlpKeyValue `json:"attributes"`
+    }
+
+    otlpScopeSpans struct {
+        Scope otlpScope  `json:"scope"`
+        Spans []otlpSpan `json:"spans"`
+    }
+
+    otlpScope struct {
+        Name string `json:"name"`
+    }
+
+    otlpSpan struct {
+        TraceID           string         `json:"traceId"`
+        SpanID            string         `json:"spanId"`
+        ParentSpanID      string         `json:"parentSpanId,omitempty"`
+        Name              string         `json:"name"`
+        Kind              int            `json:"kind"`
+        StartTimeUnixNano string         `json:"startTimeUnixNano"`
+        EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+        Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+        Status            otlpStatus     `json:"status"`
+    }
+
+    otlpStatus struct {
+        Code    int    `json:"code"`
+        Message string `json:"message,omitempty"`
+    }
+
+    otlpKeyValue struct {
+        Key   string         `json:"key"`
+        Value map[string]any `json:"value"`
+    }
+)
+
+// 转换为 OTLP json 格式
+func EncodeOTLP(serviceName string, spans []SpanData) any {
+    list := make([]otlpSpan, 0, len(spans))
+    for _, s := range spans {
+        span := otlpSpan{
+            TraceID:           s.SpanContext.TraceID.String(),
+            SpanID:            s.SpanContext.SpanID.String(),
+            Name:              s.Name,
+            Kind:              int(s.Kind),
+            StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+            EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+            Attributes:        otlpAttributes(s.Attributes),
+            Status: otlpStatus{
+                Code:    int(s.Status),
+                Message: s.StatusMessage,
+            },
+        }
+        if s.ParentSpanID.IsValid() {
+            span.ParentSpanID = s.ParentSpanID.String()
+        }
+
+        list = append(list, span)
+    }
+
+    return otlpRequest{
+        ResourceSpans: []otlpResourceSpans{
+            {
+                Resource: otlpResource{
+                    Attributes: otlpAttributes(map[string]any{
+                        "service.name": serviceName,
+                    }),
+                },
+                ScopeSpans: []otlpScopeSpans{
+                    {
+                        Scope: otlpScope{Name: "lakego"},
+                        Spans: list,
+                    },
+                },
+            },
+        },
+    }
+}
+
+// 属性转换
+func otlpAttributes(attrs map[string]any) []otlpKeyValue {
+    keys := make([]string, 0, len(attrs))
+    for k := range attrs {
+        keys = append(keys, k)
+    }
+
+    sort.Strings(keys)
+
+    list := make([]otlpKeyValue, 0, len(attrs))
+    for _, k := range keys {
+        list = append(list, otlpKeyValue{
+            Key:   k,
+            Value: otlpValue(attrs[k]),
+        })
+    }
+
+    return list
+}
+
+// 属性值，int64 按规范使用字符
+func otlpValue(v any) map[string]any {
+    switch val := v.(type) {
+        case string:
+            return map[string]any{"stringValue": val}
+        case bool:
+            return map[string]any{"boolValue": val}
+        case int:
+            return map[string]any{"intValue": strconv.FormatInt(int64(val), 10)}
+        case int32:
+            return map[string]any{"intValue": strconv.FormatInt(int64(val), 10)}
+        case int64:
+            return map[string]any{"intValue": strconv.FormatInt(val, 10)}
+        case uint:
+            return map[string]any{"intValue": strconv.FormatUint(uint64(val), 10)}
+        case uint64:
+            return map[string]any{"intValue": strconv.FormatUint(val, 10)}
+        case float32:
+            return map[string]any{"doubleValue": float64(val)}
+        case float64:
+            return map[string]any{"doubleValue": val}
+    }
+
+    return map[string]any{"stringValue": fmt.Sprint(v)}
+}
@@ -0,0 +1,199 @@
+package trace
+
+import (
+    "sync"
+    "time"
+)
+
+// 节点类型，与 OpenTelemetry 一致
+type Kind int
+
+const (
+    KindInternal Kind = 1
+    KindServer   Kind = 2
+    KindClient   Kind = 3
+    KindProducer Kind = 4
+    KindConsumer Kind = 5
+)
+
+// 名称
+func (this Kind) String() string {
+    switch this {
+        case KindServer:
+            return "server"
+        case KindClient:
+            return "client"
+        case KindProducer:
+            return "producer"
+        case KindConsumer:
+            return "consumer"
+    }
+
+    return "internal"
+}
+
+// 节点状态，与 OpenTelemetry 一致
+type StatusCode int
+
+const (
+    StatusUnset StatusCode = 0
+    StatusOK    StatusCode = 1
+    StatusError StatusCode = 2
+)
+
+// 名称
+func (this StatusCode) String() string {
+    switch this {
+        case StatusOK:
+            return "ok"
+        case StatusError:
+            return "error"
+    }
+
+    return "unset"
+}
+
+// 导出的节点数据
+type SpanData struct {
+    // 名称
+    Name string
+
+    // 类型
+    Kind Kind
+
+    // 链路上下文
+    SpanContext SpanContext
+
+    // 父级节点 id
+    ParentSpanID SpanID
+
+    // 开始及结束时间
+    StartTime time.Time
+    EndTime   time.Time
+
+    // 属性
+    Attributes map[string]any
+
+    // 状态
+    Status        StatusCode
+    StatusMessage string
+}
+
+/**
+ * 链路节点
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type Span struct {
+    mu sync.Mutex
+
+    tracer *Tracer
+    data   SpanData
+    ended  bool
+}
+
+// 链路上下文
+func (this *Span) SpanContext() SpanContext {
+    if this == nil {
+        return SpanContext{}
+    }
+
+    return this.data.SpanContext
+}
+
+// 是否记录，采样且需要导出时记录
+func (this *Span) IsRecording() bool {
+    if this == nil || this.tracer == nil {
+        return false
+    }
+
+    return this.data.SpanContext.IsSampled() && this.tracer.Enabled()
+}
+
+// 设置名称
+func (this *Span) SetName(name string) *Span {
+    if !this.IsRecording() {
+        return this
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.data.Name = name
+
+    return this
+}
+
+// 设置属性
+func (this *Span) SetAttribute(key string, value any) *Span {
+    if !this.IsRecording() {
+        return this
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.data.Attributes == nil {
+        this.data.Attributes = make(map[string]any)
+    }
+
+    this.data.Attributes[key] = value
+
+    return this
+}
+
+// 批量设置属性
+func (this *Span) SetAttributes(attrs map[string]any) *Span {
+    for k, v := range attrs {
+        this.SetAttribute(k, v)
+    }
+
+    return this
+}
+
+// 设置状态
+func (this *Span) SetStatus(code StatusCode, message string) *Span {
+    if !this.IsRecording() {
+        return this
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.data.Status = code
+    this.data.StatusMessage = message
+
+    return this
+}
+
+// 记录错误，err 为空时忽略
+func (this *Span) RecordError(err error) *Span {
+    if err == nil {
+        return this
+    }
+
+    return this.SetStatus(StatusError, err.Error())
+}
+
+// 结束节点，重复调用时忽略
+func (this *Span) End() {
+    if this == nil {
+        return
+    }
+
+    this.mu.Lock()
+    if this.ended {
+        this.mu.Unlock()
+        return
+    }
+
+    this.ended = true
+    this.data.EndTime = time.Now()
+    data := this.data
+    this.mu.Unlock()
+
+    if this.IsRecording() {
+        this.tracer.export(data)
+    }
+}
@@ -0,0 +1,215 @@
+package trace
+
+import (
+    "fmt"
+    "context"
+    "strings"
+    "crypto/rand"
+    "encoding/hex"
+)
+
+// 链路 id
+type TraceID [16]byte
+
+// 是否有效
+func (this TraceID) IsValid() bool {
+    return this != TraceID{}
+}
+
+// 十六进制字符
+func (this TraceID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+// 节点 id
+type SpanID [8]byte
+
+// 是否有效
+func (this SpanID) IsValid() bool {
+    return this != SpanID{}
+}
+
+// 十六进制字符
+func (this SpanID) String() string {
+    return hex.EncodeToString(this[:])
+}
+
+// 生成链路 id
+func NewTraceID() TraceID {
+    var id TraceID
+    for !id.IsValid() {
+        rand.Read(id[:])
+    }
+
+    return id
+}
+
+// 生成节点 id
+func NewSpanID() SpanID {
+    var id SpanID
+    for !id.IsValid() {
+        rand.Read(id[:])
+    }
+
+    return id
+}
+
+// 采样标记
+const FlagSampled byte = 0x01
+
+// 链路上下文
+type SpanContext struct {
+    // 链路 id
+    TraceID TraceID
+
+    // 节点 id
+    SpanID SpanID
+
+    // 标记
+    Flags byte
+
+    // 是否来自上游请求
+    Remote bool
+}
+
+// 是否有效
+func (this SpanContext) IsValid() bool {
+    return this.TraceID.IsValid() && this.SpanID.IsValid()
+}
+
+// 是否采样
+func (this SpanContext) IsSampled() bool {
+    return this.Flags&FlagSampled == FlagSampled
+}
+
+// W3C traceparent 格式，比如 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (this SpanContext) Traceparent() string {
+    return fmt.Sprintf("00-%s-%s-%02x", this.TraceID, this.SpanID, this.Flags)
+}
+
+// 解析 W3C traceparent
+func ParseTraceparent(s string) (SpanContext, error) {
+    s = strings.TrimSpace(s)
+
+    parts := strings.Split(s, "-")
+    if len(parts) < 4 {
+        return SpanContext{}, fmt.Errorf("trace: invalid traceparent %q", s)
+    }
+
+    version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+
+    // 版本 00 只有 4 段，ff 为无效版本
+    if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+        return SpanContext{}, fmt.Errorf("trace: invalid traceparent version %q", version)
+    }
+    if _, err := hex.DecodeString(version); err != nil {
+        return SpanContext{}, fmt.Errorf("trace: invalid traceparent version %q", version)
+    }
+
+    var sc SpanContext
+
+    if len(traceID) != 32 || traceID != strings.ToLower(traceID) {
+        return SpanContext{}, fmt.Errorf("trace: invalid trace id %q", traceID)
+    }
+    if _, err := hex.Decode(sc.TraceID[:], []byte(traceID)); err != nil || !sc.TraceID.IsValid() {
+        return SpanContext{}, fmt.Errorf("trace: invalid trace id %q", traceID)
+    }
+
+    if len(spanID) != 16 || spanID != strings.ToLower(spanID) {
+        return SpanContext{}, fmt.Errorf("trace: invalid span id %q", spanID)
+    }
+    if _, err := hex.Decode(sc.SpanID[:], []byte(spanID)); err != nil || !sc.SpanID.IsValid() {
+        return SpanContext{}, fmt.Errorf("trace: invalid span id %q", spanID)
+    }
+
+    flag, err := hex.DecodeString(flags)
+    if err != nil || len(flag) != 1 {
+        return SpanContext{}, fmt.Errorf("trace: invalid trace flags %q", flags)
+    }
+
+    sc.Flags = flag[0]
+    sc.Remote = true
+
+    return sc, nil
+}
+
+type (
+    spanContextKey struct{}
+    spanKey        struct{}
+    requestIDKey   struct{}
+)
+
+// 设置链路上下文
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+    return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// 获取链路上下文，当前节点优先
+func SpanContextFromContext(ctx context.Context) SpanContext {
+    if ctx == nil {
+        return SpanContext{}
+    }
+
+    if span := SpanFromContext(ctx); span != nil {
+        return span.SpanContext()
+    }
+
+    if sc, ok := ctx.Value(spanContextKey{}).(SpanContext); ok {
+        return sc
+    }
+
+    return SpanContext{}
+}
+
+// 设置当前节点
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+    return context.WithValue(ctx, spanKey{}, span)
+}
+
+// 获取当前节点
+func SpanFromContext(ctx context.Context) *Span {
+    if ctx == nil {
+        return nil
+    }
+
+    span, _ := ctx.Value(spanKey{}).(*Span)
+    return span
+}
+
+// 设置请求 id
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+    return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// 获取请求 id
+func RequestIDFromContext(ctx context.Context) string {
+    if ctx == nil {
+        return ""
+    }
+
+    id, _ := ctx.Value(requestIDKey{}).(string)
+    return id
+}
+
+// 日志字段名称
+const (
+    FieldRequestID = "request_id"
+    FieldTraceID   = "trace_id"
+    FieldSpanID    = "span_id"
+)
+
+// 上下文中的日志字段，没有数据时返回空
+func Fields(ctx context.Context) map[string]any {
+    fields := make(map[string]any)
+
+    if id := RequestIDFromContext(ctx); id != "" {
+        fields[FieldRequestID] = id
+    }
+
+    if sc := SpanContextFromContext(ctx); sc.IsValid() {
+        fields[FieldTraceID] = sc.TraceID.String()
+        fields[FieldSpanID] = sc.SpanID.String()
+    }
+
+    return fields
+}
@@ -0,0 +1,121 @@
+package trace
+
+import (
+    "bytes"
+    "context"
+    "strings"
+    "testing"
+    "encoding/json"
+)
+
+func Test_ParseTraceparent(t *testing.T) {
+    s := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+    sc, err := ParseTraceparent(s)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if !sc.IsSampled() || !sc.Remote {
+        t.Errorf("got %+v", sc)
+    }
+    if got := sc.Traceparent(); got != s {
+        t.Errorf("Traceparent() = %s, want %s", got, s)
+    }
+
+    invalids := []string{
+        "",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+        "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+        "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+        "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+        "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+    }
+    for _, v := range invalids {
+        if _, err := ParseTraceparent(v); err == nil {
+            t.Errorf("ParseTraceparent(%q) want error", v)
+        }
+    }
+}
+
+func Test_Tracer(t *testing.T) {
+    buf := new(bytes.Buffer)
+    tracer := NewTracer(NewWriterExporter(buf, "test"), Options{SampleRatio: 1})
+
+    ctx := ContextWithRequestID(context.Background(), "req-1")
+
+    ctx, root := tracer.Start(ctx, "GET /", KindServer)
+    _, child := tracer.Start(ctx, "db.query", KindClient)
+
+    if child.SpanContext().TraceID != root.SpanContext().TraceID {
+        t.Error("child span should share trace id")
+    }
+
+    child.SetAttribute("db.statement", "SELECT 1")
+    child.End()
+    root.End()
+
+    fields := Fields(ctx)
+    if fields[FieldRequestID] != "req-1" || fields[FieldTraceID] != root.SpanContext().TraceID.String() {
+        t.Errorf("Fields() = %v", fields)
+    }
+
+    if err := tracer.Shutdown(context.Background()); err != nil {
+        t.Fatal(err)
+    }
+
+    lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+    if len(lines) != 2 {
+        t.Fatalf("exported %d spans, want 2", len(lines))
+    }
+
+    var span map[string]any
+    json.Unmarshal([]byte(lines[0]), &span)
+    if span["parent_span_id"] != root.SpanContext().SpanID.String() {
+        t.Errorf("parent_span_id = %v", span["parent_span_id"])
+    }
+}
+
+func Test_TracerNotSampled(t *testing.T) {
+    buf := new(bytes.Buffer)
+    tracer := NewTracer(NewWriterExporter(buf, "test"), Options{SampleRatio: 0})
+
+    _, span := tracer.Start(context.Background(), "GET /", KindServer)
+    if span.IsRecording() {
+        t.Error("span should not be recording")
+    }
+
+    span.End()
+    tracer.Shutdown(context.Background())
+
+    if buf.Len() != 0 {
+        t.Errorf("unexpected export: %s", buf.String())
+    }
+}
+
+func Test_EncodeOTLP(t *testing.T) {
+    tracer := NewTracer(nil, Options{})
+    _, span := tracer.Start(context.Background(), "GET /", KindServer)
+
+    data := span.data
+    data.Attributes = map[string]any{
+        "http.status_code": 200,
+        "http.method":      "GET",
+    }
+
+    body, _ := json.Marshal(EncodeOTLP("lakego", []SpanData{data}))
+
+    checks := []string{
+        `"traceId":"` + data.SpanContext.TraceID.String() + `"`,
+        `"kind":2`,
+        `{"key":"http.method","value":{"stringValue":"GET"}}`,
+        `{"key":"http.status_code","value":{"intValue":"200"}}`,
+        `{"key":"service.name","value":{"stringValue":"lakego"}}`,
+    }
+    for _, c := range checks {
+        if !bytes.Contains(body, []byte(c)) {
+            t.Errorf("missing %s in %s", c, body)
+        }
+    }
+}
@@ -0,0 +1,260 @@
+package trace
+
+import (
+    "sync"
+    "time"
+    "context"
+    "math/rand"
+)
+
+// 配置
+type Options struct {
+    // 无上游链路时的采样率，0 - 1
+    SampleRatio float64
+
+    // 批量导出数量
+    BatchSize int
+
+    // 导出间隔
+    FlushInterval time.Duration
+
+    // 队列大小，队列满时丢弃节点
+    QueueSize int
+}
+
+// 构造函数，exporter 为空时只生成 id 不导出节点
+func NewTracer(exporter Exporter, opts Options) *Tracer {
+    if opts.BatchSize <= 0 {
+        opts.BatchSize = 100
+    }
+    if opts.FlushInterval <= 0 {
+        opts.FlushInterval = 5 * time.Second
+    }
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = 2048
+    }
+
+    t := &Tracer{
+        opts:     opts,
+        exporter: exporter,
+    }
+
+    if exporter != nil {
+        t.queue = make(chan SpanData, opts.QueueSize)
+        t.flush = make(chan chan struct{})
+        t.done = make(chan struct{})
+
+        go t.run()
+    }
+
+    return t
+}
+
+/**
+ * 链路追踪
+ *
+ * @create 2024-5-19
+ * @author deatil
+ */
+type Tracer struct {
+    // 配置
+    opts Options
+
+    // 导出
+    exporter Exporter
+
+    // 错误回调
+    onError func(error)
+
+    queue chan SpanData
+    flush chan chan struct{}
+    done  chan struct{}
+
+    closeOnce sync.Once
+}
+
+// 错误回调
+func (this *Tracer) OnError(f func(error)) *Tracer {
+    this.onError = f
+
+    return this
+}
+
+// 是否导出节点
+func (this *Tracer) Enabled() bool {
+    return this.exporter != nil
+}
+
+// 开始节点，上下文中有节点时作为子节点
+func (this *Tracer) Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    parent := SpanContextFromContext(ctx)
+
+    sc := SpanContext{
+        SpanID: NewSpanID(),
+    }
+
+    data := SpanData{
+        Name:      name,
+        Kind:      kind,
+        StartTime: time.Now(),
+    }
+
+    if parent.IsValid() {
+        sc.TraceID = parent.TraceID
+        sc.Flags = parent.Flags
+        data.ParentSpanID = parent.SpanID
+    } else {
+        sc.TraceID = NewTraceID()
+        if this.sample() {
+            sc.Flags |= FlagSampled
+        }
+    }
+
+    data.SpanContext = sc
+
+    span := &Span{
+        tracer: this,
+        data:   data,
+    }
+
+    return ContextWithSpan(ctx, span), span
+}
+
+// 是否采样
+func (this *Tracer) sample() bool {
+    ratio := this.opts.SampleRatio
+    switch {
+        case ratio >= 1:
+            return true
+        case ratio <= 0:
+            return false
+    }
+
+    return rand.Float64() < ratio
+}
+
+// 加入导出队列
+func (this *Tracer) export(data SpanData) {
+    if this.queue == nil {
+        return
+    }
+
+    select {
+        case <-this.done:
+        case this.queue <- data:
+        default:
+            // 队列已满，丢弃
+    }
+}
+
+func (this *Tracer) run() {
+    ticker := time.NewTicker(this.opts.FlushInterval)
+    defer ticker.Stop()
+
+    batch := make([]SpanData, 0, this.opts.BatchSize)
+
+    send := func() {
+        if len(batch) == 0 {
+            return
+        }
+
+        ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+        defer cancel()
+
+        if err := this.exporter.Export(ctx, batch); err != nil && this.onError != nil {
+            this.onError(err)
+        }
+
+        batch = make([]SpanData, 0, this.opts.BatchSize)
+    }
+
+    for {
+        select {
+            case data := <-this.queue:
+                batch = append(batch, data)
+                if len(batch) >= this.opts.BatchSize {
+                    send()
+                }
+            case <-ticker.C:
+                send()
+            case ch := <-this.flush:
+                // 取出队列中剩余数据
+                for n := len(this.queue); n > 0; n-- {
+                    batch = append(batch, <-this.queue)
+                }
+
+                send()
+                close(ch)
+            case <-this.done:
+                for n := len(this.queue); n > 0; n-- {
+                    batch = append(batch, <-this.queue)
+                }
+
+                send()
+                return
+        }
+    }
+}
+
+// 立即导出队列中的节点
+func (this *Tracer) Flush(ctx context.Context) error {
+    if this.queue == nil {
+        return nil
+    }
+
+    ch := make(chan struct{})
+
+    select {
+        case this.flush <- ch:
+        case <-this.done:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+
+    select {
+        case <-ch:
+            return nil
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 关闭，导出剩余节点
+func (this *Tracer) Shutdown(ctx context.Context) error {
+    if this.queue == nil {
+        return nil
+    }
+
+    if err := this.Flush(ctx); err != nil {
+        return err
+    }
+
+    this.closeOnce.Do(func() {
+        close(this.done)
+    })
+
+    return this.exporter.Shutdown(ctx)
+}
+
+// 默认链路追踪，不导出节点
+var defaultTracer = NewTracer(nil, Options{})
+
+// 设置默认链路追踪
+func SetDefault(t *Tracer) {
+    defaultTracer = t
+}
+
+// 默认链路追踪
+func Default() *Tracer {
+    return defaultTracer
+}
+
+// 使用默认链路追踪开始节点
+func Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+    return defaultTracer.Start(ctx, name, kind)
+}
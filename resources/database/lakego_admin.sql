@@ -5,11 +5,15 @@ CREATE TABLE `pre__action_log` (
   `url` text COLLATE utf8mb4_unicode_ci NOT NULL,
   `method` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求类型',
   `info` text COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '内容信息',
+  `header` text COLLATE utf8mb4_unicode_ci COMMENT '请求头',
   `useragent` text COLLATE utf8mb4_unicode_ci NOT NULL COMMENT 'user-agent',
   `time` int(10) DEFAULT NULL COMMENT '记录时间',
   `ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '0',
   `status` char(3) COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '输出状态',
-  PRIMARY KEY (`id`)
+  `request_id` varchar(128) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求id',
+  PRIMARY KEY (`id`),
+  KEY `time` (`time`),
+  KEY `request_id` (`request_id`)
 ) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='操作日志';
 
 DROP TABLE IF EXISTS `pre__admin`;
@@ -56,6 +60,24 @@ CREATE TABLE `pre__attachment` (
   PRIMARY KEY (`id`)
 ) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='附件表';
 
+DROP TABLE IF EXISTS `pre__audit_log`;
+CREATE TABLE `pre__audit_log` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '日志id',
+  `model` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '数据表',
+  `record_id` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '数据ID',
+  `action` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '操作类型',
+  `old_values` mediumtext COLLATE utf8mb4_unicode_ci COMMENT '修改前数据',
+  `new_values` mediumtext COLLATE utf8mb4_unicode_ci COMMENT '修改后数据',
+  `admin_id` varchar(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '操作账号ID',
+  `request_id` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  `ip` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '请求IP',
+  `time` int(10) DEFAULT NULL COMMENT '记录时间',
+  PRIMARY KEY (`id`),
+  KEY `model_record` (`model`,`record_id`),
+  KEY `admin_id` (`admin_id`),
+  KEY `time` (`time`)
+) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='数据审计日志';
+
 DROP TABLE IF EXISTS `pre__auth_group`;
 CREATE TABLE `pre__auth_group` (
   `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
@@ -95,14 +117,14 @@ CREATE TABLE `pre__auth_rule` (
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
   PRIMARY KEY (`id`),
   KEY `module` (`status`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='规则表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='规则表';
 
 DROP TABLE IF EXISTS `pre__auth_rule_access`;
 CREATE TABLE `pre__auth_rule_access` (
   `group_id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '0',
   `rule_id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '0',
   PRIMARY KEY (`rule_id`,`group_id`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='用户组与权限关联表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='用户组与权限关联表';
 
 DROP TABLE IF EXISTS `pre__rules`;
 CREATE TABLE `pre__rules` (
@@ -140,7 +162,91 @@ CREATE TABLE `pre__extension` (
   `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
   PRIMARY KEY (`id`),
   KEY `name` (`name`)
-) ENGINE=MyISAM DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='已安装模块列表';
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='已安装模块列表';
+
+DROP TABLE IF EXISTS `pre__extension_setting`;
+CREATE TABLE `pre__extension_setting` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `name` varchar(160) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '扩展包名',
+  `key` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '设置字段',
+  `value` text COLLATE utf8mb4_unicode_ci COMMENT '设置值，json 格式',
+  `update_time` int(10) DEFAULT '0' COMMENT '更新时间',
+  `update_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '更新IP',
+  `add_time` int(10) DEFAULT '0' COMMENT '添加时间',
+  `add_ip` varchar(50) COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '添加ip',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `name_key` (`name`,`key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=COMPACT COMMENT='扩展设置';
+
+DROP TABLE IF EXISTS `pre__queue_job`;
+CREATE TABLE `pre__queue_job` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '任务id',
+  `queue` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '队列名称',
+  `payload` longtext COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '任务数据',
+  `attempts` int(10) NOT NULL DEFAULT '0' COMMENT '尝试次数',
+  `reserved_at` int(10) NOT NULL DEFAULT '0' COMMENT '取出时间',
+  `available_at` int(10) NOT NULL DEFAULT '0' COMMENT '可执行时间',
+  `created_at` int(10) NOT NULL DEFAULT '0' COMMENT '添加时间',
+  PRIMARY KEY (`id`),
+  KEY `queue` (`queue`,`reserved_at`,`available_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='队列任务';
+
+DROP TABLE IF EXISTS `pre__queue_failed_job`;
+CREATE TABLE `pre__queue_failed_job` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '任务id',
+  `connection` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '队列连接',
+  `queue` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '队列名称',
+  `payload` longtext COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '任务数据',
+  `exception` text COLLATE utf8mb4_unicode_ci COMMENT '错误信息',
+  `failed_at` int(10) NOT NULL DEFAULT '0' COMMENT '失败时间',
+  PRIMARY KEY (`id`),
+  KEY `failed_at` (`failed_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='队列失败任务';
+
+DROP TABLE IF EXISTS `pre__schedule_run`;
+CREATE TABLE `pre__schedule_run` (
+  `id` char(36) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '记录id',
+  `name` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '任务名称',
+  `spec` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '计划时间',
+  `trigger_type` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '触发方式',
+  `status` varchar(10) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '执行状态',
+  `start_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '开始时间，毫秒',
+  `end_at` bigint(20) NOT NULL DEFAULT '0' COMMENT '结束时间，毫秒',
+  `duration` bigint(20) NOT NULL DEFAULT '0' COMMENT '执行时长，毫秒',
+  `error` text COLLATE utf8mb4_unicode_ci COMMENT '错误信息',
+  `output` text COLLATE utf8mb4_unicode_ci COMMENT '输出内容',
+  PRIMARY KEY (`id`),
+  KEY `name` (`name`),
+  KEY `start_at` (`start_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='计划任务执行记录';
+
+DROP TABLE IF EXISTS `pre__schedule_pause`;
+CREATE TABLE `pre__schedule_pause` (
+  `name` varchar(200) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '' COMMENT '任务名称',
+  `pause_time` bigint(20) NOT NULL DEFAULT '0' COMMENT '暂停时间',
+  PRIMARY KEY (`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='计划任务暂停状态';
+
+DROP TABLE IF EXISTS `pre__monitor_sample`;
+CREATE TABLE `pre__monitor_sample` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `time` int(10) NOT NULL DEFAULT '0' COMMENT '采样时间',
+  `cpu` decimal(6,2) NOT NULL DEFAULT '0.00' COMMENT 'CPU 使用率',
+  `load1` decimal(8,2) NOT NULL DEFAULT '0.00' COMMENT '1 分钟负载',
+  `load5` decimal(8,2) NOT NULL DEFAULT '0.00' COMMENT '5 分钟负载',
+  `load15` decimal(8,2) NOT NULL DEFAULT '0.00' COMMENT '15 分钟负载',
+  `mem_usage` decimal(6,2) NOT NULL DEFAULT '0.00' COMMENT '内存使用率',
+  `mem_used` bigint(20) NOT NULL DEFAULT '0' COMMENT '已用内存',
+  `go_heap` bigint(20) NOT NULL DEFAULT '0' COMMENT 'go 堆内存',
+  `goroutines` int(10) NOT NULL DEFAULT '0' COMMENT '协程数量',
+  `disk_usage` decimal(6,2) NOT NULL DEFAULT '0.00' COMMENT '磁盘使用率',
+  `net_sent` bigint(20) NOT NULL DEFAULT '0' COMMENT '网络累计发送字节',
+  `net_recv` bigint(20) NOT NULL DEFAULT '0' COMMENT '网络累计接收字节',
+  `net_sent_rate` decimal(16,2) NOT NULL DEFAULT '0.00' COMMENT '网络发送速率',
+  `net_recv_rate` decimal(16,2) NOT NULL DEFAULT '0.00' COMMENT '网络接收速率',
+  PRIMARY KEY (`id`),
+  KEY `time` (`time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci ROW_FORMAT=DYNAMIC COMMENT='系统监控采样数据';
 
 
 INSERT INTO `pre__admin` VALUES ('01cabd82-060d-405f-ba47-4d79fc47efcf','lakego','8966aff5289184448a004af81373c8f9','gazqzd','lakego','lakego@admin.com','5acfcd19-3a4c-4a28-8386-ae877952fd11','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',0,1,0,'',1652759635,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1'),('642eb7b3-91ea-4808-bba6-f5f10938929a','admin','2a9b6b430ebe2f4257639e62ff9321bb','chNI7n','管理员','lakego-admin@admin.com','1f3cd4fb-f7e4-4b41-8663-167ca23ea5ab','lakego-admin 是基于 gin、jwt 和 rbac 的 go 后台管理系统',1,1,0,'',1675937003,'127.0.0.1',1652587697,'127.0.0.1',1652545221,'127.0.0.1');
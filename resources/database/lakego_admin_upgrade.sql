@@ -0,0 +1,9 @@
+-- 已安装系统的升级语句，可重复执行
+
+-- 扩展安装及权限规则需要事务
+ALTER TABLE `pre__extension` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_rule` ENGINE=InnoDB;
+ALTER TABLE `pre__auth_rule_access` ENGINE=InnoDB;
+
+-- 扩展设置保存需要事务
+ALTER TABLE `pre__extension_setting` ENGINE=InnoDB;
@@ -0,0 +1,140 @@
+package test
+
+import (
+    "reflect"
+    "testing"
+
+    "github.com/deatil/lakego-doak-extension/extension/extension"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func newTestGraph(requires map[string][]string) *extension.Graph {
+    g := extension.NewGraph(nil)
+    for name, deps := range requires {
+        require := make(map[string]string, len(deps))
+        for _, dep := range deps {
+            require[dep] = "*"
+        }
+
+        g.Add(name, require)
+    }
+
+    return g
+}
+
+func Test_GraphSort(t *testing.T) {
+    assert := assertT(t)
+
+    // d -> b, c; b -> a; c -> a
+    g := newTestGraph(map[string][]string{
+        "a": nil,
+        "b": {"a"},
+        "c": {"a"},
+        "d": {"b", "c"},
+    })
+
+    sorted, err := g.Sort([]string{"d", "c", "b", "a"})
+    assert(err, nil, "Sort error")
+    assert(sorted, []string{"a", "b", "c", "d"}, "Sort")
+
+    // 只处理传入扩展之间的依赖
+    sorted, err = g.Sort([]string{"d", "b"})
+    assert(err, nil, "Sort part error")
+    assert(sorted, []string{"b", "d"}, "Sort part")
+
+    assert(g.DetectCycle(), nil, "DetectCycle")
+}
+
+func Test_GraphCycle(t *testing.T) {
+    assert := assertT(t)
+
+    g := newTestGraph(map[string][]string{
+        "a": {"c"},
+        "b": {"a"},
+        "c": {"b"},
+        "d": nil,
+    })
+
+    sorted, err := g.Sort([]string{"a", "b", "c", "d"})
+    assert(sorted, []string{"d"}, "Sort sortable part")
+
+    cycleErr, ok := err.(*extension.CycleError)
+    assert(ok, true, "Sort CycleError")
+    if ok {
+        assert(cycleErr.Path, []string{"a", "c", "b", "a"}, "CycleError Path")
+    }
+
+    _, ok = g.DetectCycle().(*extension.CycleError)
+    assert(ok, true, "DetectCycle")
+
+    // 依赖自身
+    self := newTestGraph(map[string][]string{
+        "a": {"a"},
+    })
+
+    _, err = self.Sort([]string{"a"})
+    cycleErr, ok = err.(*extension.CycleError)
+    assert(ok, true, "Sort self CycleError")
+    if ok {
+        assert(cycleErr.Path, []string{"a", "a"}, "CycleError self Path")
+    }
+}
+
+func Test_GraphDependents(t *testing.T) {
+    assert := assertT(t)
+
+    g := newTestGraph(map[string][]string{
+        "a": nil,
+        "b": {"a"},
+        "c": {"b"},
+        "d": {"a", "c"},
+        "e": nil,
+    })
+
+    assert(g.Requires("d"), []string{"a", "c"}, "Requires")
+    assert(g.Dependents("a"), []string{"b", "d"}, "Dependents")
+    assert(g.Dependents("e"), []string{}, "Dependents empty")
+
+    // 依赖方在前
+    all, err := g.AllDependents("a")
+    assert(err, nil, "AllDependents error")
+    assert(all, []string{"d", "c", "b"}, "AllDependents")
+
+    all, err = g.AllDependents("e")
+    assert(err, nil, "AllDependents empty error")
+    assert(all, []string{}, "AllDependents empty")
+}
+
+func Test_GraphDependentsCycle(t *testing.T) {
+    assert := assertT(t)
+
+    // 依赖方之间循环
+    g := newTestGraph(map[string][]string{
+        "a": nil,
+        "b": {"a", "c"},
+        "c": {"b"},
+    })
+
+    all, err := g.AllDependents("a")
+    _, ok := err.(*extension.CycleError)
+    assert(ok, true, "AllDependents CycleError")
+    assert(len(all), 0, "AllDependents cycle result")
+
+    // 经过当前扩展的循环
+    g = newTestGraph(map[string][]string{
+        "a": {"c"},
+        "b": {"a"},
+        "c": {"b"},
+    })
+
+    _, err = g.AllDependents("a")
+    _, ok = err.(*extension.CycleError)
+    assert(ok, true, "AllDependents self CycleError")
+}
@@ -0,0 +1,222 @@
+package test
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+    "archive/zip"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak-extension/extension/pack"
+)
+
+// 扩展包数据
+type testPack struct {
+    manifest *pack.Manifest
+    files    map[string][]byte
+
+    // 签名的清单数据及签名
+    manifestData []byte
+    sig          *pack.Signature
+
+    // 写入包内但不在清单中的文件
+    extra map[string][]byte
+}
+
+func newTestPack(t *testing.T, signer *pack.Signer) *testPack {
+    p := &testPack{
+        manifest: &pack.Manifest{
+            Name:    "lakego.pack-test",
+            Version: "1.0.0",
+        },
+        files: map[string][]byte{
+            "assets/app.js":   []byte("console.log('pack')"),
+            "sql/install.sql": []byte("SELECT 1;"),
+        },
+        extra: map[string][]byte{},
+    }
+
+    for name, data := range p.files {
+        p.manifest.AddFile(name, data)
+    }
+
+    data, err := p.manifest.ToJSON()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    p.manifestData = data
+
+    p.sig, err = signer.Sign(data)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return p
+}
+
+func (this *testPack) zip(t *testing.T) []byte {
+    sigData, _ := json.Marshal(this.sig)
+
+    buf := new(bytes.Buffer)
+    zw := zip.NewWriter(buf)
+
+    write := func(name string, data []byte) {
+        w, err := zw.Create(name)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        w.Write(data)
+    }
+
+    write(pack.ManifestFile, this.manifestData)
+    write(pack.SignatureFile, sigData)
+
+    for name, data := range this.files {
+        write(name, data)
+    }
+    for name, data := range this.extra {
+        write(name, data)
+    }
+
+    if err := zw.Close(); err != nil {
+        t.Fatal(err)
+    }
+
+    return buf.Bytes()
+}
+
+func newTestSigner(t *testing.T, keyName string) (*pack.Signer, pack.TrustedKey) {
+    priv, pub, err := pack.GenerateKey(pack.AlgEd25519)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    signer, err := pack.NewSigner(pack.AlgEd25519, keyName, priv, "")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    return signer, pack.TrustedKey{
+        Name: keyName,
+        Alg:  pack.AlgEd25519,
+        Key:  pub,
+    }
+}
+
+func verifyTestPack(t *testing.T, data []byte, keyring pack.Keyring) error {
+    pkg, err := pack.Read(data, 0)
+    if err != nil {
+        return err
+    }
+
+    err = pkg.Verify(keyring)
+    if err == nil && !pkg.Verified() {
+        t.Error("Verified should be true")
+    }
+
+    return err
+}
+
+func assertErrorContains(t *testing.T, err error, contains string, msg string) {
+    if err == nil || !strings.Contains(err.Error(), contains) {
+        t.Errorf("Failed %s: error %v, expected contains %q", msg, err, contains)
+    }
+}
+
+func Test_PackVerify(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    p := newTestPack(t, signer)
+    if err := verifyTestPack(t, p.zip(t), pack.Keyring{key}); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func Test_PackTamperedManifest(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    p := newTestPack(t, signer)
+    p.manifestData = bytes.Replace(p.manifestData, []byte(`"1.0.0"`), []byte(`"9.9.9"`), 1)
+
+    err := verifyTestPack(t, p.zip(t), pack.Keyring{key})
+    if err != pack.ErrUntrusted {
+        t.Errorf("tampered manifest got %v", err)
+    }
+}
+
+func Test_PackTamperedFile(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    p := newTestPack(t, signer)
+    p.files["sql/install.sql"] = []byte("DROP TABLE pre__admin;")
+
+    err := verifyTestPack(t, p.zip(t), pack.Keyring{key})
+    assertErrorContains(t, err, "摘要错误", "tampered file")
+}
+
+func Test_PackFileNotInManifest(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    p := newTestPack(t, signer)
+    p.extra["config/evil.yml"] = []byte("evil: true")
+
+    err := verifyTestPack(t, p.zip(t), pack.Keyring{key})
+    assertErrorContains(t, err, "不在清单中", "file not in manifest")
+}
+
+func Test_PackManifestFileMissing(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    p := newTestPack(t, signer)
+    delete(p.files, "assets/app.js")
+
+    err := verifyTestPack(t, p.zip(t), pack.Keyring{key})
+    assertErrorContains(t, err, "不存在", "manifest file missing")
+}
+
+func Test_PackWrongKey(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+    other, otherKey := newTestSigner(t, "other")
+
+    // 签名公钥名称不在信任列表中
+    p := newTestPack(t, other)
+    if err := verifyTestPack(t, p.zip(t), pack.Keyring{key}); err != pack.ErrUntrusted {
+        t.Errorf("untrusted key name got %v", err)
+    }
+
+    // 签名公钥名称和信任的公钥不一致
+    p = newTestPack(t, signer)
+    p.sig.KeyName = "other"
+    if err := verifyTestPack(t, p.zip(t), pack.Keyring{key, otherKey}); err != pack.ErrUntrusted {
+        t.Errorf("wrong key name got %v", err)
+    }
+
+    // 使用其他私钥冒用公钥名称
+    fake, _ := newTestSigner(t, "main")
+    p = newTestPack(t, fake)
+    if err := verifyTestPack(t, p.zip(t), pack.Keyring{key}); err != pack.ErrUntrusted {
+        t.Errorf("forged key got %v", err)
+    }
+}
+
+func Test_PackZipSlip(t *testing.T) {
+    signer, key := newTestSigner(t, "main")
+
+    names := []string{
+        "../evil.sh",
+        "assets/../../evil.sh",
+        "/etc/passwd",
+        `assets\..\evil.sh`,
+        "C:/evil.sh",
+    }
+
+    for _, name := range names {
+        p := newTestPack(t, signer)
+        p.extra[name] = []byte("evil")
+
+        err := verifyTestPack(t, p.zip(t), pack.Keyring{key})
+        assertErrorContains(t, err, "路径错误", "zip slip " + name)
+    }
+}